@@ -0,0 +1,81 @@
+package jsonlogic
+
+import (
+	"sort"
+)
+
+// aggregated returns the list an aggregation operator works on. Both
+// {"sum": {"map": ...}} and {"sum": [{"map": ...}]} aggregate the mapped list,
+// which listArgument keeps whole even when its elements are lists.
+func aggregated(values interface{}) []interface{} {
+	if values == nil {
+		return []interface{}{}
+	}
+
+	parsed := toSlice(values)
+	if len(parsed) == 1 && isSlice(parsed[0]) {
+		return parsed[0].([]interface{})
+	}
+
+	return parsed
+}
+
+// aggregatedNumbers returns the numbers of the aggregated list, values
+// that aren't numbers or numeric strings are ignored
+func aggregatedNumbers(values interface{}) []float64 {
+	numbers := make([]float64, 0)
+
+	for _, value := range aggregated(values) {
+		if !isNumber(value) && !isString(value) {
+			continue
+		}
+
+		numbers = append(numbers, toNumber(value))
+	}
+
+	return numbers
+}
+
+func aggregateSum(values interface{}) interface{} {
+	var total float64
+
+	for _, n := range aggregatedNumbers(values) {
+		total += n
+	}
+
+	return total
+}
+
+func average(values interface{}) interface{} {
+	numbers := aggregatedNumbers(values)
+	if len(numbers) == 0 {
+		return nil
+	}
+
+	var total float64
+	for _, n := range numbers {
+		total += n
+	}
+
+	return total / float64(len(numbers))
+}
+
+func count(values interface{}) interface{} {
+	return float64(len(aggregated(values)))
+}
+
+func median(values interface{}) interface{} {
+	numbers := aggregatedNumbers(values)
+	if len(numbers) == 0 {
+		return nil
+	}
+
+	sort.Float64s(numbers)
+
+	middle := len(numbers) / 2
+	if len(numbers)%2 == 1 {
+		return numbers[middle]
+	}
+
+	return (numbers[middle-1] + numbers[middle]) / 2
+}
@@ -0,0 +1,101 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAggregationOperators(t *testing.T) {
+	data := `{
+		"items": [
+			{"name": "pen", "price": 1.5},
+			{"name": "book", "price": 12},
+			{"name": "lamp", "price": 30},
+			{"name": "mug", "price": 6.5}
+		],
+		"pairs": [[1, 2]]
+	}`
+
+	scenarios := map[string]struct {
+		Rule     string
+		Expected string
+	}{
+		"sum of mapped values": {
+			Rule:     `{"sum": {"map": [{"var": "items"}, {"var": ".price"}]}}`,
+			Expected: `50`,
+		},
+		"sum of a wrapped list": {
+			Rule:     `{"sum": [{"map": [{"var": "items"}, {"var": ".price"}]}]}`,
+			Expected: `50`,
+		},
+		"sum of literals": {
+			Rule:     `{"sum": [1, 2, "3"]}`,
+			Expected: `6`,
+		},
+		"sum of nothing": {
+			Rule:     `{"sum": {"var": "missing"}}`,
+			Expected: `0`,
+		},
+		"average": {
+			Rule:     `{"avg": {"map": [{"var": "items"}, {"var": ".price"}]}}`,
+			Expected: `12.5`,
+		},
+		"average of nothing": {
+			Rule:     `{"avg": [[]]}`,
+			Expected: `null`,
+		},
+		"count": {
+			Rule:     `{"count": {"var": "items"}}`,
+			Expected: `4`,
+		},
+		"count filtered values": {
+			Rule:     `{"count": {"filter": [{"var": "items"}, {">": [{"var": ".price"}, 10]}]}}`,
+			Expected: `2`,
+		},
+		"median of an even number of values": {
+			Rule:     `{"median": {"map": [{"var": "items"}, {"var": ".price"}]}}`,
+			Expected: `9.25`,
+		},
+		"median of an odd number of values": {
+			Rule:     `{"median": [3, 1, 2]}`,
+			Expected: `2`,
+		},
+		"count a list of lists": {
+			Rule:     `{"count": {"var": "pairs"}}`,
+			Expected: `1`,
+		},
+		"count a wrapped list": {
+			Rule:     `{"count": [[1, 2]]}`,
+			Expected: `2`,
+		},
+		"sum of a list of lists": {
+			Rule:     `{"sum": {"var": "pairs"}}`,
+			Expected: `0`,
+		},
+		"average of a list of lists": {
+			Rule:     `{"avg": {"var": "pairs"}}`,
+			Expected: `null`,
+		},
+		"median of a list of lists": {
+			Rule:     `{"median": {"var": "pairs"}}`,
+			Expected: `null`,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var result bytes.Buffer
+
+			err := Apply(strings.NewReader(scenario.Rule), strings.NewReader(data), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, result.String())
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package jsonlogic
+
+import (
+	"sync"
+)
+
+// arenaChunk is the number of values arenas allocate at once
+const arenaChunk = 256
+
+// maxPooledArena bounds the chunks kept by the arenas given back to the
+// pool, like maxPooledBuffer, so a few big evaluations don't pin their
+// memory
+const maxPooledArena = 16
+
+// WithArena makes Apply, ApplyRaw, ApplyBool, ApplyStream and the other
+// methods encoding their results allocate the temporary values of their
+// evaluations, like the arguments of operators and the parts of var paths,
+// from an arena reused from one evaluation to the next instead of from the
+// heap, which lowers the work of the garbage collector of servers
+// evaluating many small rules. Results are encoded before their arena is
+// reused, so they are never affected. ApplyInterface and the other
+// methods returning values, and ApplyStream when it streams a list, whose
+// memory would otherwise grow with it, allocate as usual.
+//
+// The arguments given to middlewares are allocated from the arena too:
+// middlewares must copy the lists they keep after the evaluation.
+func WithArena() Option {
+	return func(e *Engine) error {
+		e.arena = true
+
+		return nil
+	}
+}
+
+// maxBoxed is the length of the longest lists arenas keep in interfaces
+const maxBoxed = 8
+
+// arena hands out lists from chunks which are only reused once the
+// evaluation allocating them ends, so values can alias them freely while
+// it runs, and lists already in interfaces from boxes, indexed by their
+// length, which are reused the same way; parts is the buffer paths are
+// split into, which is reused right away since they aren't kept
+type arena struct {
+	chunks [][]interface{}
+	chunk  int
+	used   int
+	boxes  [maxBoxed + 1]boxes
+	parts  []string
+}
+
+// boxes are the lists of a length an arena keeps in interfaces, the used
+// first
+type boxes struct {
+	lists []interface{}
+	used  int
+}
+
+var arenas = sync.Pool{
+	New: func() interface{} {
+		return new(arena)
+	},
+}
+
+// newArena returns an arena from the pool, to be given back with release
+// once the results it was used for are encoded, or nil when the engine
+// doesn't allocate from arenas
+func (e *Engine) newArena() *arena {
+	if !e.arena {
+		return nil
+	}
+
+	return arenas.Get().(*arena)
+}
+
+// list returns an empty list with room for n values, allocated from the
+// arena when there is one
+func (a *arena) list(n int) []interface{} {
+	if a == nil || n > arenaChunk {
+		return make([]interface{}, 0, n)
+	}
+
+	for {
+		if a.chunk == len(a.chunks) {
+			a.chunks = append(a.chunks, make([]interface{}, arenaChunk))
+		}
+
+		if chunk := a.chunks[a.chunk]; a.used+n <= len(chunk) {
+			// appending past n copies the list instead of overwriting the
+			// next one
+			list := chunk[a.used : a.used : a.used+n]
+			a.used += n
+
+			return list
+		}
+
+		a.chunk++
+		a.used = 0
+	}
+}
+
+// boxed returns a list of n values in an interface, to be given as the
+// arguments of an operator, and the list, to set them. Putting a list in
+// an interface allocates, so arenas keep the interfaces they give and
+// their lists.
+func (a *arena) boxed(n int) (interface{}, []interface{}) {
+	if a == nil || n > maxBoxed {
+		list := make([]interface{}, n)
+
+		return list, list
+	}
+
+	b := &a.boxes[n]
+	if b.used == len(b.lists) {
+		b.lists = append(b.lists, make([]interface{}, n))
+	}
+
+	box := b.lists[b.used]
+	b.used++
+
+	return box, box.([]interface{})
+}
+
+// path returns the buffer paths are split into, nil when there is no
+// arena
+func (a *arena) path() []string {
+	if a == nil {
+		return nil
+	}
+
+	if a.parts == nil {
+		a.parts = make([]string, 0, 8)
+	}
+
+	return a.parts[:0]
+}
+
+// release resets the arena and gives it back to the pool
+func (a *arena) release() {
+	if a == nil {
+		return
+	}
+
+	a.reset()
+	arenas.Put(a)
+}
+
+// reset clears the values of the arena, so they can be collected, making
+// its lists available again
+func (a *arena) reset() {
+	for i := 0; i <= a.chunk && i < len(a.chunks); i++ {
+		chunk := a.chunks[i]
+		if i == a.chunk {
+			chunk = chunk[:a.used]
+		}
+
+		for j := range chunk {
+			chunk[j] = nil
+		}
+	}
+
+	if len(a.chunks) > maxPooledArena {
+		a.chunks = a.chunks[:maxPooledArena]
+	}
+
+	for n := range a.boxes {
+		b := &a.boxes[n]
+		for _, box := range b.lists[:b.used] {
+			list := box.([]interface{})
+			for i := range list {
+				list[i] = nil
+			}
+		}
+
+		if len(b.lists) > maxPooledArena*arenaChunk/maxBoxed {
+			b.lists = b.lists[:maxPooledArena*arenaChunk/maxBoxed]
+		}
+
+		b.used = 0
+	}
+
+	a.chunk, a.used = 0, 0
+}
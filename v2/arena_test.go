@@ -0,0 +1,134 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArena(t *testing.T) {
+	data := `{"user": {"age": 30, "tags": ["a", "b"]}, "items": [{"price": 5, "qty": 2}, {"price": 12, "qty": 1}], "x/y": {"~z": 1}}`
+
+	scenarios := map[string]struct {
+		Options []Option
+		Rule    string
+	}{
+		"vars": {
+			Rule: `{"cat": [{"var": "user.age"}, {"var": ["user.name", "none"]}, {"var": "user.tags.-1"}]}`,
+		},
+		"iterations": {
+			Rule: `{"map": [{"filter": [{"var": "items"}, {">": [{"var": ".price"}, 1]}]}, {"*": [{"var": ".price"}, {"var": ".qty"}, {"var": "$index"}]}]}`,
+		},
+		"lists given back": {
+			Rule: `{"merge": [{"var": "user.tags"}, ["c", {"var": "user.age"}], {"sort": [[3, 1, 2]]}]}`,
+		},
+		"lists of elements": {
+			Rule: `{"map": [{"var": "items"}, {"merge": [{"var": ".qty"}, {"var": ".price"}, {"var": "$index"}]}]}`,
+		},
+		"let": {
+			Rule: `{"let": [{"total": {"+": [1, 2]}}, {"cat": [{"var": "total"}, {"var": "user.age"}]}]}`,
+		},
+		"pointers": {
+			Options: []Option{WithPathSyntax(PointerPaths)},
+			Rule:    `{"+": [{"var": "/x~1y/~0z"}, {"var": "/items/1/qty"}]}`,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			engine, err := NewEngine(scenario.Options...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			arenaEngine, err := NewEngine(append(scenario.Options, WithArena())...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var expected bytes.Buffer
+
+			err = engine.Apply(strings.NewReader(scenario.Rule), strings.NewReader(data), &expected)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// the arenas of the first evaluations are reused by the next
+			for i := 0; i < 3; i++ {
+				var result bytes.Buffer
+
+				err = arenaEngine.Apply(strings.NewReader(scenario.Rule), strings.NewReader(data), &result)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				assert.Equal(t, expected.String(), result.String())
+			}
+		})
+	}
+}
+
+func TestArenaAllocations(t *testing.T) {
+	rule, err := Compile(strings.NewReader(`{"and": [{">=": [{"var": "user.age"}, 18]}, {"in": [{"var": "user.country"}, ["PT", "ES"]]}, {"some": [{"var": "items"}, {"==": [{"var": ".sku"}, "A1"]}]}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := []byte(`{"user": {"age": 30, "country": "PT"}, "items": [{"sku": "B2"}, {"sku": "A1"}]}`)
+
+	allocs := func(options ...Option) float64 {
+		engine, err := NewEngine(options...)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return testing.AllocsPerRun(100, func() {
+			result, err := engine.ApplyBool(rule, data)
+			if err != nil || !result {
+				t.Fatal(result, err)
+			}
+		})
+	}
+
+	assert.True(t, allocs(WithArena()) < allocs())
+}
+
+func TestArenaReset(t *testing.T) {
+	a := new(arena)
+
+	first := append(a.list(2), "a", "b")
+	second := append(a.list(arenaChunk), "c")
+
+	// appending past the room asked for doesn't overwrite the next list
+	first = append(first, "d")
+	assert.Equal(t, []interface{}{"a", "b", "d"}, first)
+	assert.Len(t, a.chunks, 2)
+
+	a.reset()
+
+	assert.Equal(t, []interface{}{nil, nil}, a.chunks[0][:2])
+	assert.Nil(t, second[:1][0])
+	assert.Equal(t, 0, a.chunk)
+	assert.Equal(t, 0, a.used)
+
+	box, list := a.boxed(2)
+	list[0] = "e"
+	assert.Equal(t, []interface{}{"e", nil}, box)
+
+	a.reset()
+
+	// boxes are reused once released, emptied
+	again, _ := a.boxed(2)
+	assert.Equal(t, []interface{}{nil, nil}, again)
+	assert.Len(t, a.boxes[2].lists, 1)
+
+	var none *arena
+	assert.Equal(t, 0, cap(none.list(0)))
+	assert.Nil(t, none.path())
+	_, list = none.boxed(1)
+	assert.Len(t, list, 1)
+	none.release()
+}
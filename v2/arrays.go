@@ -1,6 +1,27 @@
 package jsonlogic
 
-func filter(values, data interface{}) interface{} {
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+func (ev *evaluator) filter(values, data interface{}) interface{} {
+	result := make([]interface{}, 0)
+
+	ev.filtered(values, data, func(value interface{}) {
+		ev.grow("filter", len(result)+1)
+		result = append(result, value)
+	})
+
+	return result
+}
+
+// filtered gives emit the elements of the list of a filter passing its
+// rule, in order
+func (ev *evaluator) filtered(values, data interface{}, emit func(value interface{})) {
 	parsed := values.([]interface{})
 
 	var subject interface{}
@@ -8,29 +29,40 @@ func filter(values, data interface{}) interface{} {
 	if isSlice(parsed[0]) {
 		subject = parsed[0]
 	} else {
-		subject = apply(parsed[0], data)
+		subject = ev.apply(parsed[0], data)
 	}
 
-	result := make([]interface{}, 0)
-
 	if subject == nil {
-		return result
+		return
 	}
 
-	logic := solveVars(parsed[1], data)
+	logic := ev.solveVars(parsed[1], data)
 
-	for _, value := range subject.([]interface{}) {
-		v := parseValues(logic, value)
+	defer ev.iteration(data)()
 
-		if isTrue(v) {
-			result = append(result, value)
+	ev.each(logic, source(subject), false, func(value, v interface{}) bool {
+		if ev.truthy(v) {
+			emit(value)
 		}
-	}
+
+		return true
+	})
+}
+
+func (ev *evaluator) _map(values, data interface{}) interface{} {
+	result := make([]interface{}, 0)
+
+	ev.mapped(values, data, func(value interface{}) {
+		ev.grow("map", len(result)+1)
+		result = append(result, value)
+	})
 
 	return result
 }
 
-func _map(values, data interface{}) interface{} {
+// mapped gives emit the results of the rule of a map for the elements of
+// its list, in order
+func (ev *evaluator) mapped(values, data interface{}, emit func(value interface{})) {
 	parsed := values.([]interface{})
 
 	var subject interface{}
@@ -38,31 +70,78 @@ func _map(values, data interface{}) interface{} {
 	if isSlice(parsed[0]) {
 		subject = parsed[0]
 	} else {
-		subject = apply(parsed[0], data)
+		subject = ev.apply(parsed[0], data)
 	}
 
-	result := make([]interface{}, 0)
-
 	if subject == nil {
-		return result
+		return
 	}
 
-	logic := solveVars(parsed[1], data)
+	logic := ev.solveVars(parsed[1], data)
 
-	for _, value := range subject.([]interface{}) {
-		v := parseValues(logic, value)
+	defer ev.iteration(data)()
 
-		if isTrue(v) || isNumber(v) {
-			result = append(result, v)
+	ev.each(logic, source(subject), false, func(_, v interface{}) bool {
+		// json-logic-js keeps every result
+		if ev.engine.coercion == CompatJS || isTrue(v) || isNumber(v) {
+			emit(v)
 		}
+
+		return true
+	})
+}
+
+// groupBy partitions a list by the key a rule gives for its elements:
+// {"groupby": [{"var": "attempts"}, {"var": ".user"}]} is an object with
+// the list of attempts of every user, in their order. Numbers and booleans
+// are converted to strings to be keys; elements with other keys, like
+// null, are left out.
+func (ev *evaluator) groupBy(values, data interface{}) interface{} {
+	parsed := toSlice(values)
+	result := make(map[string]interface{})
+
+	if len(parsed) < 2 {
+		return result
 	}
 
+	subject := parsed[0]
+	if isMap(subject) {
+		subject = ev.apply(subject, data)
+	}
+
+	switch subject.(type) {
+	case []interface{}, DataSource:
+	default:
+		return result
+	}
+
+	logic := ev.solveVars(parsed[1], data)
+
+	defer ev.iteration(data)()
+
+	ev.each(logic, source(subject), false, func(value, v interface{}) bool {
+		key, ok := castToString(v)
+		if !ok {
+			return true
+		}
+
+		group, _ := result[key.(string)].([]interface{})
+		result[key.(string)] = append(group, value)
+
+		return true
+	})
+
 	return result
 }
 
-func reduce(values, data interface{}) interface{} {
+func (ev *evaluator) reduce(values, data interface{}) interface{} {
 	parsed := values.([]interface{})
-	subject := apply(parsed[0], data)
+
+	if ev.engine.coercion == CompatJS {
+		return ev.compatReduce(parsed, data)
+	}
+
+	subject := ev.apply(parsed[0], data)
 
 	if subject == nil {
 		return float64(0)
@@ -70,20 +149,257 @@ func reduce(values, data interface{}) interface{} {
 
 	context := map[string]interface{}{
 		"current":     float64(0),
-		"accumulator": toNumber(parsed[2]),
+		"accumulator": ev.number(parsed[2]),
 	}
 
-	for _, value := range subject.([]interface{}) {
+	defer ev.iteration(data)()
+
+	list := source(subject)
+
+	for i := 0; i < list.Len(); i++ {
+		value := element(list, i)
+
+		ev.at(i, value, false)
 		context["current"] = value
 
-		v := apply(parsed[1], context)
+		v := ev.apply(parsed[1], context)
 
 		if v == nil {
 			continue
 		}
 
-		context["accumulator"] = toNumber(v)
+		context["accumulator"] = ev.number(v)
 	}
 
 	return context["accumulator"]
 }
+
+// compatReduce reduces a list like json-logic-js, whose initial value is
+// evaluated and null by default, and whose accumulator is never converted
+func (ev *evaluator) compatReduce(parsed []interface{}, data interface{}) interface{} {
+	subject := ev.parseValues(parsed[0], data)
+
+	var accumulator interface{}
+	if len(parsed) > 2 {
+		accumulator = ev.parseValues(parsed[2], data)
+	}
+
+	list, ok := subject.([]interface{})
+	if !ok {
+		return accumulator
+	}
+
+	defer ev.iteration(data)()
+
+	for i, value := range list {
+		ev.at(i, value, false)
+		accumulator = ev.apply(parsed[1], map[string]interface{}{
+			"current":     value,
+			"accumulator": accumulator,
+		})
+	}
+
+	return accumulator
+}
+
+// listOperators are the operators working on a list, given either as the
+// first of their arguments, {"op": [list, args...]}, or as an expression
+// giving the list, {"op": {"var": "x"}}
+var listOperators = map[string]bool{
+	"sort":    true,
+	"unique":  true,
+	"reverse": true,
+	"slice":   true,
+	"flatten": true,
+	"count":   true,
+	"sum":     true,
+	"avg":     true,
+	"median":  true,
+}
+
+// listArgument wraps the list an expression gives to a list operator, so
+// that it is read as the list and not as the list of arguments:
+// {"flatten": {"var": "x"}} flattens x like {"flatten": [{"var": "x"}]}
+// does, even when the first element of x is a list
+func listArgument(operator string, value interface{}) interface{} {
+	if listOperators[operator] && isSlice(value) {
+		return []interface{}{value}
+	}
+
+	return value
+}
+
+// ErrInvalidArguments is returned, wrapped, by the evaluations giving an
+// operator arguments it can't work on
+var ErrInvalidArguments = errors.New("invalid arguments")
+
+// listArgs splits the arguments of an array operator into the list it
+// works on and the remaining arguments: {"op": [list, args...]}. null, like
+// a missing var gives, is an empty list, and anything else but a list, or
+// more arguments than the operator takes, fails the evaluation with
+// ErrInvalidArguments: {"unique": [[1], [1]]} isn't the list [[1], [1]].
+func (ev *evaluator) listArgs(operator string, values interface{}) ([]interface{}, []interface{}) {
+	parsed := toSlice(values)
+	if len(parsed) == 0 || parsed[0] == nil {
+		return []interface{}{}, nil
+	}
+
+	if !isSlice(parsed[0]) {
+		ev.fail(fmt.Errorf("%w: %s of %s %v, which isn't a list", ErrInvalidArguments, operator, typeOf(parsed[0]), parsed[0]))
+	}
+
+	if max := operatorInfos[operator].MaxArgs; max >= 0 && len(parsed) > max {
+		ev.fail(fmt.Errorf("%w: %s takes at most %d arguments, got %d", ErrInvalidArguments, operator, max, len(parsed)))
+	}
+
+	return parsed[0].([]interface{}), parsed[1:]
+}
+
+// sortValues returns a sorted copy of a list, in ascending order unless
+// "desc" is given: {"sort": [list, "desc"]}. See compare for how values
+// of different types are ordered.
+func sortValues(list, args []interface{}) interface{} {
+	descending := len(args) > 0 && args[0] == "desc"
+
+	sorted := make([]interface{}, len(list))
+	copy(sorted, list)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if descending {
+			return compare(sorted[j], sorted[i]) < 0
+		}
+
+		return compare(sorted[i], sorted[j]) < 0
+	})
+
+	return sorted
+}
+
+// unique removes repeated values from a list, keeping the first ones
+func (ev *evaluator) unique(values interface{}) interface{} {
+	list, _ := ev.listArgs("unique", values)
+
+	result := make([]interface{}, 0, len(list))
+	for _, value := range list {
+		if indexOf(result, value) < 0 {
+			result = append(result, value)
+		}
+	}
+
+	return result
+}
+
+func (ev *evaluator) reverse(values interface{}) interface{} {
+	list, _ := ev.listArgs("reverse", values)
+
+	result := make([]interface{}, len(list))
+	for i, value := range list {
+		result[len(list)-1-i] = value
+	}
+
+	return result
+}
+
+// slice returns a portion of a list like Array.prototype.slice does:
+// {"slice": [list, start, end]}, where negative positions count from the
+// end of the list and end is optional
+func (ev *evaluator) slice(values interface{}) interface{} {
+	list, args := ev.listArgs("slice", values)
+
+	position := func(i int, fallback int) int {
+		if len(args) <= i || (!isNumber(args[i]) && !isString(args[i])) {
+			return fallback
+		}
+
+		n := int(toNumber(args[i]))
+		if n < 0 {
+			n += len(list)
+		}
+
+		if n < 0 {
+			return 0
+		}
+
+		if n > len(list) {
+			return len(list)
+		}
+
+		return n
+	}
+
+	start := position(0, 0)
+	end := position(1, len(list))
+
+	result := make([]interface{}, 0)
+	if start < end {
+		result = append(result, list[start:end]...)
+	}
+
+	return result
+}
+
+// flatten concatenates nested lists up to the given depth (one by
+// default): {"flatten": [[1, [2, [3]]], 2]} is [1, 2, 3]
+func (ev *evaluator) flatten(values interface{}) interface{} {
+	list, args := ev.listArgs("flatten", values)
+
+	depth := 1
+	if len(args) > 0 && isNumber(args[0]) {
+		depth = int(toNumber(args[0]))
+	}
+
+	return ev.flattenList(make([]interface{}, 0, len(list)), list, depth)
+}
+
+// flattenList appends the elements of list to result, flattening the
+// nested lists up to depth
+func (ev *evaluator) flattenList(result, list []interface{}, depth int) []interface{} {
+	for _, value := range list {
+		if isSlice(value) && depth > 0 {
+			result = ev.flattenList(result, value.([]interface{}), depth-1)
+
+			continue
+		}
+
+		ev.grow("flatten", len(result)+1)
+		result = append(result, value)
+	}
+
+	return result
+}
+
+// index returns the position of a value in a list, or of a substring in
+// a string, and -1 when it isn't found: {"index_of": [list, value]}
+func index(values interface{}) interface{} {
+	parsed := toSlice(values)
+	if len(parsed) != 2 {
+		return float64(-1)
+	}
+
+	if isString(parsed[0]) {
+		text := parsed[0].(string)
+
+		i := strings.Index(text, toString(parsed[1]))
+		if i < 0 {
+			return float64(-1)
+		}
+
+		return float64(utf8.RuneCountInString(text[:i]))
+	}
+
+	if !isSlice(parsed[0]) {
+		return float64(-1)
+	}
+
+	return float64(indexOf(parsed[0].([]interface{}), parsed[1]))
+}
+
+func indexOf(list []interface{}, value interface{}) int {
+	for i, element := range list {
+		if deepEquals(element, value) {
+			return i
+		}
+	}
+
+	return -1
+}
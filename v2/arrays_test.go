@@ -0,0 +1,145 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArrayOperators(t *testing.T) {
+	data := `{
+		"tags": ["b", "a", "c", "a"],
+		"scores": [3, 1, 2],
+		"nested": [[1, 2], [3, [4]]],
+		"lists": [[3], [1], [2]],
+		"users": [{"id": 1}, {"id": 2}, {"id": 1}]
+	}`
+
+	scenarios := map[string]struct {
+		Rule     string
+		Expected string
+	}{
+		"sort a bare list":                  {`{"sort": {"var": "scores"}}`, `[1, 2, 3]`},
+		"sort descending":                   {`{"sort": [{"var": "tags"}, "desc"]}`, `["c", "b", "a", "a"]`},
+		"sort mixed types":                  {`{"sort": [[true, "1", 2, null, 1]]}`, `[null, true, 1, 2, "1"]`},
+		"sort does not change the data":     {`{"cat": [{"index_of": [{"sort": {"var": "tags"}}, "b"]}, {"index_of": [{"var": "tags"}, "b"]}]}`, `"20"`},
+		"unique":                            {`{"unique": {"var": "tags"}}`, `["b", "a", "c"]`},
+		"unique objects":                    {`{"unique": [{"var": "users"}]}`, `[{"id": 1}, {"id": 2}]`},
+		"unique does not coerce":            {`{"unique": [[1, "1", 1]]}`, `[1, "1"]`},
+		"dedupe and compare":                {`{"==": [{"cat": {"sort": {"unique": {"var": "tags"}}}}, "abc"]}`, `true`},
+		"reverse":                           {`{"reverse": {"var": "scores"}}`, `[2, 1, 3]`},
+		"slice":                             {`{"slice": [{"var": "tags"}, 1, 3]}`, `["a", "c"]`},
+		"slice from the end":                {`{"slice": [{"var": "tags"}, -2]}`, `["c", "a"]`},
+		"slice out of range":                {`{"slice": [{"var": "tags"}, 10]}`, `[]`},
+		"flatten one level":                 {`{"flatten": [{"var": "nested"}]}`, `[1, 2, 3, [4]]`},
+		"flatten deeper":                    {`{"flatten": [{"var": "nested"}, 2]}`, `[1, 2, 3, 4]`},
+		"index of a value":                  {`{"index_of": [{"var": "tags"}, "c"]}`, `2`},
+		"index of an object":                {`{"index_of": [{"var": "users"}, {"var": "users.1"}]}`, `1`},
+		"index of a missing value":          {`{"index_of": [{"var": "tags"}, "z"]}`, `-1`},
+		"index of a substring":              {`{"index_of": ["São Paulo", "Paulo"]}`, `4`},
+		"index of something in null":        {`{"index_of": [{"var": "missing"}, 1]}`, `-1`},
+		"array operators over missing data": {`{"reverse": {"var": "missing"}}`, `[]`},
+		"flatten a list of lists":           {`{"flatten": {"var": "lists"}}`, `[3, 1, 2]`},
+		"reverse a list of lists":           {`{"reverse": {"var": "lists"}}`, `[[2], [1], [3]]`},
+		"sort a list of lists":              {`{"sort": {"var": "lists"}}`, `[[1], [2], [3]]`},
+		"unique in a list of lists":         {`{"unique": {"merge": [{"var": "lists"}, [[1]]]}}`, `[[3], [1], [2]]`},
+		"slice a list of lists":             {`{"slice": [{"var": "lists"}, 1]}`, `[[1], [2]]`},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var result bytes.Buffer
+
+			err := Apply(strings.NewReader(scenario.Rule), strings.NewReader(data), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, result.String())
+		})
+	}
+}
+
+func TestArrayOperatorsArguments(t *testing.T) {
+	rules := map[string]string{
+		"unique of two lists":     `{"unique": [[1], [1]]}`,
+		"slice of a string":       `{"slice": ["abcdef", 1, 3]}`,
+		"reverse a string":        `{"reverse": "abc"}`,
+		"sort a bare list":        `{"sort": [3, 1, 2]}`,
+		"flatten a number":        `{"flatten": [1, 2]}`,
+		"sort with too many":      `{"sort": [[3, 1], "desc", "asc"]}`,
+		"reverse of a var string": `{"reverse": {"var": "name"}}`,
+		"slice with an object":    `{"slice": [{"var": ""}, 1]}`,
+	}
+
+	for name, rule := range rules {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var result bytes.Buffer
+
+			err := Apply(strings.NewReader(rule), strings.NewReader(`{"name": "abc"}`), &result)
+			assert.True(t, errors.Is(err, ErrInvalidArguments), "%v", err)
+		})
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	data := `{
+		"attempts": [
+			{"user": "ana", "status": "failed"},
+			{"user": "rui", "status": "ok"},
+			{"user": "ana", "status": "failed"},
+			{"user": "rui", "status": "failed"},
+			{"user": "ana", "status": "failed"},
+			{"user": "ana", "status": "failed"},
+			{"status": "failed"}
+		],
+		"scores": [1, 2, 3, 4, 5]
+	}`
+
+	scenarios := map[string]struct {
+		Rule     string
+		Expected string
+	}{
+		"by property": {
+			`{"groupby": [{"var": "attempts"}, {"var": ".status"}]}`,
+			`{"failed": [{"user": "ana", "status": "failed"}, {"user": "ana", "status": "failed"}, {"user": "rui", "status": "failed"}, {"user": "ana", "status": "failed"}, {"user": "ana", "status": "failed"}, {"status": "failed"}], "ok": [{"user": "rui", "status": "ok"}]}`,
+		},
+		"by number": {
+			`{"groupby": [{"var": "scores"}, {"%": [{"var": ""}, 2]}]}`,
+			`{"0": [2, 4], "1": [1, 3, 5]}`,
+		},
+		"by boolean": {
+			`{"groupby": [{"var": "scores"}, {">": [{"var": ""}, 3]}]}`,
+			`{"false": [1, 2, 3], "true": [4, 5]}`,
+		},
+		"null keys are left out": {
+			`{"map_obj": [{"groupby": [{"var": "attempts"}, {"var": ".user"}]}, {"count": {"var": ".value"}}]}`,
+			`{"ana": 4, "rui": 2}`,
+		},
+		"any group with more than 3 failed attempts": {
+			`{"some": [{"groupby": [{"filter": [{"var": "attempts"}, {"==": [{"var": ".status"}, "failed"]}]}, {"var": ".user"}]}, {">": [{"count": {"var": ".value"}}, 3]}]}`,
+			`true`,
+		},
+		"literal list": {`{"groupby": [["a", "b", "a"], {"var": ""}]}`, `{"a": ["a", "a"], "b": ["b"]}`},
+		"missing list": {`{"groupby": [{"var": "missing"}, {"var": ""}]}`, `{}`},
+		"without rule": {`{"groupby": [{"var": "scores"}]}`, `{}`},
+		"empty list":   {`{"groupby": [[], {"var": ""}]}`, `{}`},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var result bytes.Buffer
+
+			err := Apply(strings.NewReader(scenario.Rule), strings.NewReader(data), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, result.String())
+		})
+	}
+}
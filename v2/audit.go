@@ -0,0 +1,183 @@
+package jsonlogic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// AuditRecord explains an evaluation, for compliance pipelines that must
+// account for automated decisions. Its JSON encoding is stable: fields are
+// only ever added.
+type AuditRecord struct {
+	// RuleHash is the Hash of the rule, and DataHash the digest of the
+	// canonical form of the data, key order and number notation aside
+	RuleHash string `json:"rule_hash"`
+	DataHash string `json:"data_hash"`
+
+	// Result is the result of the evaluation in canonical form, or Error
+	// the error it failed with
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+
+	// EngineVersion is the version of this module the program was built
+	// with, "(devel)" when it is unknown, and OperatorSet the operator set
+	// the engine is pinned to, if any
+	EngineVersion string `json:"engine_version"`
+	OperatorSet   string `json:"operator_set,omitempty"`
+
+	// Timestamp is when the evaluation started, as a RFC3339 UTC time from
+	// the clock of the engine, which is the time now gives
+	Timestamp string `json:"timestamp"`
+
+	// TraceDigest is the digest of the trace of the evaluation, as
+	// ApplyWithTrace returns it, which is the same for evaluations going
+	// through the same steps
+	TraceDigest string `json:"trace_digest"`
+}
+
+// WithAuditLog makes Apply, ApplyRaw and ApplyYAML write an AuditRecord
+// of their evaluations to w, as a line of JSON, whether they succeed or
+// not. Evaluations are traced to be audited, and don't use the cache of
+// the engine. Failing to write the record fails the evaluation.
+func WithAuditLog(w io.Writer) Option {
+	return func(e *Engine) error {
+		if w == nil {
+			return fmt.Errorf("audit log must not be nil")
+		}
+
+		e.audit = &auditLog{w: w}
+
+		return nil
+	}
+}
+
+// auditLog serializes the records written by concurrent evaluations
+type auditLog struct {
+	w  io.Writer
+	mu sync.Mutex
+}
+
+func (l *auditLog) write(record *AuditRecord) error {
+	encoded, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	_, err = l.w.Write(append(encoded, '\n'))
+
+	return err
+}
+
+// modulePath is the path of this module in the build information
+const modulePath = "github.com/bewica/jsonlogic/v2"
+
+// engineVersion is the version of this module the program was built with
+var engineVersion = moduleVersion()
+
+func moduleVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "(devel)"
+	}
+
+	module := &info.Main
+	for _, dependency := range info.Deps {
+		if dependency.Path == modulePath {
+			module = dependency
+		}
+	}
+
+	if module.Replace != nil {
+		module = module.Replace
+	}
+
+	if module.Path != modulePath || module.Version == "" {
+		return "(devel)"
+	}
+
+	return module.Version
+}
+
+// audited applies a decoded rule to decoded data in ctx, writing the audit
+// record of the evaluation
+func (e *Engine) audited(ctx context.Context, rule, data interface{}) (interface{}, error) {
+	ev := e.evaluator()
+	ev.ctx = ctx
+	ev.tracing = true
+	ev.started = e.clock()
+
+	record := AuditRecord{
+		EngineVersion: engineVersion,
+		OperatorSet:   e.operatorSet,
+		Timestamp:     ev.started.UTC().Format(time.RFC3339Nano),
+	}
+
+	var err error
+
+	// the rule is hashed from a copy, since expand modifies it
+	record.RuleHash, err = digest(expand(canonical(rule)))
+	if err != nil {
+		return nil, err
+	}
+
+	record.DataHash, err = digest(data)
+	if err != nil {
+		return nil, err
+	}
+
+	output, failure := ev.run(rule, data)
+
+	record.TraceDigest, err = digest(canonicalTrace(ev.root))
+	if err != nil {
+		return nil, err
+	}
+
+	if failure != nil {
+		record.Error = failure.Error()
+	} else if record.Result, err = encodeCanonical(output, ""); err != nil {
+		return nil, err
+	}
+
+	if err := e.audit.write(&record); err != nil {
+		return nil, fmt.Errorf("error writing audit record: %w", err)
+	}
+
+	return output, failure
+}
+
+// canonicalTrace returns a copy of a trace whose arguments and values are
+// written in full, so it is the same for rules with the same Hash
+func canonicalTrace(trace *Trace) *Trace {
+	if trace == nil {
+		return nil
+	}
+
+	node := *trace
+	node.Arguments = expandArguments(trace.Operator, trace.Arguments)
+	node.Values = expandArguments(trace.Operator, trace.Values)
+
+	node.Children = nil
+	for _, child := range trace.Children {
+		node.Children = append(node.Children, canonicalTrace(child))
+	}
+
+	return &node
+}
+
+func expandArguments(operator string, arguments interface{}) interface{} {
+	if arguments == nil {
+		return nil
+	}
+
+	expression := expand(canonical(map[string]interface{}{operator: arguments}))
+
+	return expression.(map[string]interface{})[operator]
+}
@@ -0,0 +1,114 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithAuditLog(t *testing.T) {
+	var log bytes.Buffer
+
+	clock := func() time.Time {
+		return time.Date(2024, 3, 1, 12, 30, 0, 0, time.FixedZone("CET", 3600))
+	}
+
+	engine, err := NewEngine(WithAuditLog(&log), WithClock(clock), WithOperatorSet("ext-2024"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var result bytes.Buffer
+
+	err = engine.Apply(strings.NewReader(`{"if": [{">": [{"var": "age"}, 18]}, "adult", "minor"]}`), strings.NewReader(`{"age": 20, "name": "Ann"}`), &result)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.JSONEq(t, `"adult"`, result.String())
+
+	_, err = engine.ApplyRaw(json.RawMessage(`{"if":[{">":[{"var":["age"]},18.0]},"adult","minor"]}`), json.RawMessage(`{"name":"Ann","age":2e1}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = engine.ApplyRaw(json.RawMessage(`{"if":[{">":[{"var":["age"]},18.0]},"adult","minor"]}`), json.RawMessage(`{"age": 10}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(log.String()), "\n")
+	assert.Len(t, lines, 3)
+
+	records := make([]AuditRecord, len(lines))
+	for i, line := range lines {
+		if err := json.Unmarshal([]byte(line), &records[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	hash, err := Hash(strings.NewReader(`{"if": [{">": [{"var": "age"}, 18]}, "adult", "minor"]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, hash, records[0].RuleHash)
+	assert.Equal(t, `"adult"`, string(records[0].Result))
+	assert.Equal(t, "2024-03-01T11:30:00Z", records[0].Timestamp)
+	assert.Equal(t, "ext-2024", records[0].OperatorSet)
+	assert.Equal(t, engineVersion, records[0].EngineVersion)
+	assert.NotEmpty(t, records[0].EngineVersion)
+	assert.Empty(t, records[0].Error)
+
+	// the same rule and data written differently are recorded alike
+	assert.Equal(t, records[0], records[1])
+
+	assert.Equal(t, records[0].RuleHash, records[2].RuleHash)
+	assert.NotEqual(t, records[0].DataHash, records[2].DataHash)
+	assert.NotEqual(t, records[0].TraceDigest, records[2].TraceDigest)
+	assert.Equal(t, `"minor"`, string(records[2].Result))
+}
+
+func TestWithAuditLogFailures(t *testing.T) {
+	var log bytes.Buffer
+
+	engine, err := NewEngine(WithAuditLog(&log), WithDivisionPolicy(DivideToError))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = engine.ApplyRaw(json.RawMessage(`{"/": [1, 0]}`), json.RawMessage(`{}`))
+	assert.True(t, errors.Is(err, ErrDivisionByZero))
+
+	var record AuditRecord
+	if err := json.Unmarshal(log.Bytes(), &record); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, err.Error(), record.Error)
+	assert.Nil(t, record.Result)
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestWithAuditLogWriteError(t *testing.T) {
+	engine, err := NewEngine(WithAuditLog(failingWriter{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = engine.ApplyRaw(json.RawMessage(`{"==": [1, 1]}`), json.RawMessage(`{}`))
+	assert.EqualError(t, err, "error writing audit record: disk full")
+
+	_, err = NewEngine(WithAuditLog(nil))
+	assert.Error(t, err)
+}
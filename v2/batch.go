@@ -0,0 +1,266 @@
+package jsonlogic
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// expressionKey identifies an expression by a digest of its canonical
+// form, the same for identical expressions of different rules
+type expressionKey [sha256.Size]byte
+
+// ApplyAll applies compiled rules to the same JSON data. See
+// Engine.ApplyAll.
+func ApplyAll(rules map[string]*Rule, data []byte) (map[string]interface{}, error) {
+	return defaultEngine.ApplyAll(rules, data)
+}
+
+// ApplyAll applies compiled rules to the same JSON data, returning their
+// results by name. The data is decoded once for all of them, only the
+// values they read when their var paths are known, and the expressions
+// rules have in common are evaluated once: a check shared by many rules
+// costs as much as in a single one, and the rules having nothing in
+// common run the instructions they were compiled to, like with ApplyBool.
+// Results may then share values, which must not be modified. Rules are
+// applied in the order of their names, and the first one failing stops the
+// evaluation.
+func (e *Engine) ApplyAll(rules map[string]*Rule, data []byte) (map[string]interface{}, error) {
+	names := make([]string, 0, len(rules))
+	for name, rule := range rules {
+		if rule == nil {
+			return nil, fmt.Errorf("rule %q is nil", name)
+		}
+
+		if err := e.checkRule(rule); err != nil {
+			return nil, fmt.Errorf("rule %q: %w", name, err)
+		}
+
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	ordered := make([]*Rule, 0, len(names))
+	for _, name := range names {
+		ordered = append(ordered, rules[name])
+	}
+
+	_data, err := e.decodeShared(ordered, data)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing data %w", err)
+	}
+
+	results := make(map[string]interface{}, len(rules))
+
+	failed, err := e.applyEach(ordered, _data, func(i int, result interface{}) bool {
+		results[names[i]] = result
+
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error applying rule %q: %w", names[failed], err)
+	}
+
+	for _, name := range names {
+		if results[name], err = e.transform(results[name]); err != nil {
+			return nil, fmt.Errorf("error applying rule %q: %w", name, err)
+		}
+	}
+
+	return results, nil
+}
+
+// applyEach applies rules in order to the same data, sharing the results
+// of their common expressions, and gives their results to yield until it
+// returns false. It returns the index of the rule failing, if any.
+func (e *Engine) applyEach(rules []*Rule, data interface{}, yield func(i int, result interface{}) bool) (int, error) {
+	if e.cache != nil {
+		for i, rule := range rules {
+			result, err := e.cached(context.Background(), rule.tree, data)
+			if err != nil {
+				return i, err
+			}
+
+			if !yield(i, result) {
+				break
+			}
+		}
+
+		return 0, nil
+	}
+
+	ev := e.acquire()
+	defer release(ev)
+
+	ev.shared = make(map[expressionKey]interface{})
+
+	sharing := shared(rules)
+
+	for i, rule := range rules {
+		// the rules sharing nothing run their programs
+		ev.keys = nil
+		if sharing[i] {
+			ev.keys = rule.sharedKeys()
+		}
+
+		result, err := ev.runRule(rule, data)
+		if err != nil {
+			return i, err
+		}
+
+		if !yield(i, result) {
+			break
+		}
+	}
+
+	return 0, nil
+}
+
+// decodeShared decodes the parts of data read by the rules, or all of it
+// when they aren't known
+func (e *Engine) decodeShared(rules []*Rule, data []byte) (interface{}, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	paths := make([]string, 0)
+	complete := true
+
+	for _, rule := range rules {
+		complete = complete && rule.complete
+		paths = append(paths, rule.read...)
+	}
+
+	if complete && json.Valid(data) {
+		if extracted, ok := e.extract(data, newPathTree(paths)); ok {
+			return extracted, nil
+		}
+	}
+
+	var _data interface{}
+
+	err := e.unmarshal(nil, data, &_data)
+
+	return _data, err
+}
+
+// share evaluates rule once for all the rules of ApplyAll having it
+func (ev *evaluator) share(rule, data interface{}) interface{} {
+	key, ok := ev.keys[reflect.ValueOf(rule).Pointer()]
+	if !ok {
+		return ev.applyRule(rule, data)
+	}
+
+	if result, ok := ev.shared[key]; ok {
+		return result
+	}
+
+	result := ev.applyRule(rule, data)
+	ev.shared[key] = result
+
+	return result
+}
+
+// shared tells which rules have expressions whose results can be shared,
+// with other rules or within themselves. Expressions are counted by the
+// first bytes of their keys, which rarely makes rules sharing nothing
+// look like they do, and costs less than counting whole keys.
+func shared(rules []*Rule) []bool {
+	size := 0
+	for _, rule := range rules {
+		size += len(rule.sharedKeys())
+	}
+
+	counts := make(map[uint64]int32, size)
+	for _, rule := range rules {
+		for _, key := range rule.sharedKeys() {
+			counts[binary.LittleEndian.Uint64(key[:])]++
+		}
+	}
+
+	sharing := make([]bool, len(rules))
+	for i, rule := range rules {
+		for _, key := range rule.sharedKeys() {
+			if counts[binary.LittleEndian.Uint64(key[:])] > 1 {
+				sharing[i] = true
+
+				break
+			}
+		}
+	}
+
+	return sharing
+}
+
+// sharedKeys returns the keys of the expressions of the rule which can be
+// shared, computing them the first time
+func (r *Rule) sharedKeys() map[uintptr]expressionKey {
+	r.keysOnce.Do(func() {
+		r.keys = make(map[uintptr]expressionKey)
+		collectKeys(r.tree, r.keys)
+	})
+
+	return r.keys
+}
+
+// collectKeys computes the keys of the pure expressions evaluated against
+// the data itself, the ones whose result only depends on it: the
+// predicates of iterations are left out, as well as var, which costs no
+// more than looking its result up.
+func collectKeys(rule interface{}, keys map[uintptr]expressionKey) {
+	if isSlice(rule) {
+		for _, value := range rule.([]interface{}) {
+			collectKeys(value, keys)
+		}
+
+		return
+	}
+
+	if !isMap(rule) {
+		return
+	}
+
+	for operator, values := range rule.(map[string]interface{}) {
+		if operator == "var" {
+			return
+		}
+
+		if isPure(rule) {
+			if encoded, err := encodeCanonical(rule, ""); err == nil {
+				keys[reflect.ValueOf(rule).Pointer()] = sha256.Sum256(encoded)
+			}
+		}
+
+		parsed := toSlice(values)
+		if iteratorOperators[operator] && len(parsed) > 1 {
+			collectKeys(parsed[0], keys)
+			collectKeys(parsed[2:], keys)
+
+			return
+		}
+
+		if operator == "let" {
+			// the results of the body depend on the values bound, like
+			// those of predicates on the elements
+			if bindings, _, ok := letArgs(values); ok {
+				for _, value := range bindings {
+					collectKeys(value, keys)
+				}
+			}
+
+			return
+		}
+
+		if operator == "def" {
+			// and those of calls on the functions defined
+			return
+		}
+
+		collectKeys(values, keys)
+	}
+}
@@ -0,0 +1,237 @@
+package jsonlogic
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func compileAll(t testing.TB, rules map[string]string) map[string]*Rule {
+	compiled := make(map[string]*Rule, len(rules))
+	for name, rule := range rules {
+		r, err := Compile(strings.NewReader(rule))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		compiled[name] = r
+	}
+
+	return compiled
+}
+
+func TestApplyAll(t *testing.T) {
+	scenarios := map[string]struct {
+		rules    map[string]string
+		data     string
+		expected map[string]interface{}
+	}{
+		"known paths": {
+			rules: map[string]string{
+				"adult":   `{">=": [{"var": "user.age"}, 18]}`,
+				"country": `{"var": "user.country"}`,
+				"tags":    `{"map": [{"var": "tags"}, {"cat": ["#", {"var": ""}]}]}`,
+			},
+			data: `{"user": {"age": 20, "country": "PT", "name": "Ana"}, "tags": ["a", "b"], "ignored": [1, 2, 3]}`,
+			expected: map[string]interface{}{
+				"adult":   true,
+				"country": "PT",
+				"tags":    []interface{}{"#a", "#b"},
+			},
+		},
+		"unknown paths": {
+			rules: map[string]string{
+				"computed": `{"var": {"cat": ["user.", "name"]}}`,
+				"adult":    `{">=": [{"var": "user.age"}, 18]}`,
+			},
+			data: `{"user": {"age": 10, "name": "Ana"}}`,
+			expected: map[string]interface{}{
+				"computed": "Ana",
+				"adult":    false,
+			},
+		},
+		"shared expressions": {
+			rules: map[string]string{
+				"a": `{"and": [{">": [{"var": "total"}, 100]}, {"==": [{"var": "plan"}, "gold"]}]}`,
+				"b": `{"or": [{">": [{"var": "total"}, 100]}, {"==": [{"var": "plan"}, "silver"]}]}`,
+				"c": `{"if": [{">": [{"var": "total"}, 100]}, "big", "small"]}`,
+			},
+			data: `{"total": 150, "plan": "silver"}`,
+			expected: map[string]interface{}{
+				"a": false,
+				"b": true,
+				"c": "big",
+			},
+		},
+		"literal rules": {
+			rules:    map[string]string{"always": `true`},
+			data:     `{}`,
+			expected: map[string]interface{}{"always": true},
+		},
+		"no data": {
+			rules:    map[string]string{"missing": `{"missing": ["a"]}`},
+			expected: map[string]interface{}{"missing": []interface{}{"a"}},
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			results, err := ApplyAll(compileAll(t, scenario.rules), []byte(scenario.data))
+			assert.NoError(t, err)
+			assert.Equal(t, scenario.expected, results)
+		})
+	}
+}
+
+type operatorCounter struct {
+	counts map[string]*int64
+}
+
+func (c operatorCounter) ApplyStarted(ctx context.Context) (context.Context, func(time.Duration, error)) {
+	return ctx, func(time.Duration, error) {}
+}
+
+func (c operatorCounter) OperatorEvaluated(_ context.Context, operator string) {
+	if count, ok := c.counts[operator]; ok {
+		atomic.AddInt64(count, 1)
+	}
+}
+
+func TestApplyAllSharesExpressions(t *testing.T) {
+	var gt, eq int64
+
+	engine, err := NewEngine(WithInstrumentation(operatorCounter{counts: map[string]*int64{">": &gt, "==": &eq}}))
+	assert.NoError(t, err)
+
+	rules := compileAll(t, map[string]string{
+		"a": `{"and": [{">": [{"var": "total"}, 100]}, {"==": [{"var": "plan"}, "gold"]}]}`,
+		"b": `{"or": [{">": [{"var": "total"}, 100]}, {"==": [{"var": "plan"}, "silver"]}]}`,
+		"c": `{"if": [{">": [{"var": "total"}, 100]}, "big", "small"]}`,
+		"d": `{"some": [{"var": "items"}, {">": [{"var": "total"}, 100]}]}`,
+	})
+
+	_, err = engine.ApplyAll(rules, []byte(`{"total": 150, "plan": "silver", "items": [1, 2]}`))
+	assert.NoError(t, err)
+
+	// once for the rules, and once inside the iteration, where the
+	// expressions are memoized instead
+	assert.Equal(t, int64(2), gt)
+	assert.Equal(t, int64(2), eq)
+}
+
+func TestApplyAllSharing(t *testing.T) {
+	rules := compileAll(t, map[string]string{
+		"a": `{"and": [{">": [{"var": "total"}, 100]}, {"==": [{"var": "plan"}, "gold"]}]}`,
+		"b": `{"if": [{">": [{"var": "total"}, 100]}, "big", "small"]}`,
+		"c": `{"==": [{"var": "plan"}, "silver"]}`,
+		"d": `{"+": [{"*": [{"var": "total"}, 2]}, {"*": [{"var": "total"}, 2]}]}`,
+		"e": `{"var": "plan"}`,
+	})
+
+	// c and e share nothing, and run their programs
+	sharing := shared([]*Rule{rules["a"], rules["b"], rules["c"], rules["d"], rules["e"]})
+	assert.Equal(t, []bool{true, true, false, true, false}, sharing)
+
+	results, err := ApplyAll(rules, []byte(`{"total": 150, "plan": "silver"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"a": false, "b": "big", "c": true, "d": float64(600), "e": "silver"}, results)
+}
+
+func TestApplyAllErrors(t *testing.T) {
+	engine, err := NewEngine(WithStrictCasts())
+	assert.NoError(t, err)
+
+	rules := compileAll(t, map[string]string{
+		"ok":     `{"var": "a"}`,
+		"broken": `{"to_number": "abc"}`,
+	})
+
+	_, err = engine.ApplyAll(rules, []byte(`{"a": 1}`))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `"broken"`)
+
+	_, err = ApplyAll(rules, []byte(`{"a": `))
+	assert.Error(t, err)
+
+	_, err = ApplyAll(map[string]*Rule{"nil": nil}, []byte(`{}`))
+	assert.Error(t, err)
+}
+
+func TestApplyAllMatchesApplyBool(t *testing.T) {
+	rules := compileAll(t, map[string]string{
+		"a": `{"and": [{"<": [1, {"var": "n"}, 10]}, {"!": [{"missing": ["m"]}]}]}`,
+		"b": `{"<": [1, {"var": "n"}, 10]}`,
+		"c": `{"all": [{"var": "l"}, {"<": [1, {"var": ""}, 10]}]}`,
+	})
+
+	for _, data := range []string{`{"n": 5, "m": 1, "l": [2, 3]}`, `{"n": 50, "l": [0]}`, `{}`} {
+		results, err := ApplyAll(rules, []byte(data))
+		assert.NoError(t, err)
+
+		for name, rule := range rules {
+			expected, err := ApplyBool(rule, []byte(data))
+			assert.NoError(t, err)
+			assert.Equal(t, expected, isTrue(results[name]), "%s on %s", name, data)
+		}
+	}
+}
+
+func BenchmarkApplyAll(b *testing.B) {
+	sources := make(map[string]string)
+	for i := 0; i < 300; i++ {
+		sources[fmt.Sprintf("rule%d", i)] = fmt.Sprintf(
+			`{"and": [{">=": [{"var": "user.age"}, 18]}, {"in": [{"var": "user.country"}, ["PT", "BR"]]}, {">": [{"var": "total"}, %d]}]}`, i)
+	}
+
+	rules := compileAll(b, sources)
+	data := []byte(`{"user": {"age": 34, "country": "PT"}, "total": 150, "items": [1, 2, 3]}`)
+
+	b.Run("ApplyAll", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for n := 0; n < b.N; n++ {
+			if _, err := ApplyAll(rules, data); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("loop", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for n := 0; n < b.N; n++ {
+			for _, rule := range rules {
+				if _, err := ApplyBool(rule, data); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+}
+
+// BenchmarkApplyAllDistinct applies rules which have no expressions in
+// common, which run their programs
+func BenchmarkApplyAllDistinct(b *testing.B) {
+	sources := make(map[string]string)
+	for i := 0; i < 300; i++ {
+		sources[fmt.Sprintf("rule%d", i)] = fmt.Sprintf(
+			`{"and": [{">=": [{"var": "user.age"}, %d]}, {"in": [{"var": "user.country"}, ["PT", "BR", "%d"]]}, {">": [{"var": "total"}, %d]}]}`, i, i, i)
+	}
+
+	rules := compileAll(b, sources)
+	data := []byte(`{"user": {"age": 34, "country": "PT"}, "total": 150, "items": [1, 2, 3]}`)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		if _, err := ApplyAll(rules, data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package benchmarks
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/bewica/jsonlogic/v2"
+)
+
+func TestWorkloads(t *testing.T) {
+	for _, w := range Workloads() {
+		t.Run(w.Name, func(t *testing.T) {
+			if !jsonlogic.IsValid(bytes.NewReader(w.Rule)) {
+				t.Fatalf("invalid rule %s", w.Rule)
+			}
+
+			err := jsonlogic.Apply(bytes.NewReader(w.Rule), bytes.NewReader(w.Data), ioutil.Discard)
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+// BenchmarkApply measures the whole path: decoding, evaluation, encoding
+func BenchmarkApply(b *testing.B) {
+	for _, w := range Workloads() {
+		b.Run(w.Name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(w.Data)))
+
+			for n := 0; n < b.N; n++ {
+				err := jsonlogic.Apply(bytes.NewReader(w.Rule), bytes.NewReader(w.Data), ioutil.Discard)
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkApplyInterface measures the evaluation alone
+func BenchmarkApplyInterface(b *testing.B) {
+	for _, w := range Workloads() {
+		var rule, data interface{}
+		if err := json.Unmarshal(w.Rule, &rule); err != nil {
+			b.Fatal(err)
+		}
+
+		if err := json.Unmarshal(w.Data, &data); err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(w.Name, func(b *testing.B) {
+			b.ReportAllocs()
+
+			for n := 0; n < b.N; n++ {
+				if _, err := jsonlogic.ApplyInterface(rule, data); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkApplyBool measures compiled rules applied to raw data
+func BenchmarkApplyBool(b *testing.B) {
+	for _, w := range Workloads() {
+		rule, err := jsonlogic.Compile(bytes.NewReader(w.Rule))
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(w.Name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(w.Data)))
+
+			for n := 0; n < b.N; n++ {
+				if _, err := jsonlogic.ApplyBool(rule, w.Data); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+#!/bin/sh
+# Compares the benchmarks of a git revision, main by default, with the ones
+# of the working tree:
+#
+#   ./benchmarks/compare.sh [revision] [benchmark regexp]
+#
+# Requires benchstat: go install golang.org/x/perf/cmd/benchstat@latest
+set -eu
+
+revision=${1:-main}
+pattern=${2:-.}
+count=${COUNT:-10}
+
+module=$(cd "$(dirname "$0")/.." && pwd)
+root=$(git -C "$module" rev-parse --show-toplevel)
+prefix=$(git -C "$module" rev-parse --show-prefix)
+
+work=$(mktemp -d)
+trap 'git -C "$root" worktree remove --force "$work/base" >/dev/null 2>&1; rm -rf "$work"' EXIT
+
+git -C "$root" worktree add --detach "$work/base" "$revision" >/dev/null
+
+# the revision may predate the workloads, so they are always the current ones
+mkdir -p "$work/base/${prefix}benchmarks"
+cp "$module"/benchmarks/*.go "$work/base/${prefix}benchmarks/"
+
+(cd "$work/base/$prefix" && go test -run '^$' -bench "$pattern" -count "$count" ./benchmarks) > "$work/old.txt"
+(cd "$module" && go test -run '^$' -bench "$pattern" -count "$count" ./benchmarks) > "$work/new.txt"
+
+benchstat "$work/old.txt" "$work/new.txt"
@@ -0,0 +1,102 @@
+// Package benchmarks holds representative workloads to measure the
+// performance of the evaluation, so changes can be compared with
+// benchstat:
+//
+//	go test -run '^$' -bench . -count 10 ./benchmarks > new.txt
+//	benchstat old.txt new.txt
+//
+// compare.sh runs them on a git revision and on the working tree, and
+// compares the results.
+package benchmarks
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Workload is a rule with the data it is applied to
+type Workload struct {
+	Name string
+	Rule []byte
+	Data []byte
+}
+
+// Workloads returns the workloads measured by the benchmarks
+func Workloads() []Workload {
+	return []Workload{
+		{
+			Name: "small",
+			Rule: []byte(`{"and": [{">=": [{"var": "user.age"}, 18]}, {"in": [{"var": "user.country"}, ["PT", "BR"]]}]}`),
+			Data: []byte(`{"user": {"age": 34, "country": "PT", "name": "Ana"}}`),
+		},
+		{
+			Name: "deep_nesting",
+			Rule: deepRule(50),
+			Data: []byte(`{"level": 49}`),
+		},
+		{
+			Name: "big_array",
+			Rule: []byte(`{"reduce": [
+				{"filter": [{"var": "items"}, {">": [{"var": ".price"}, 50]}]},
+				{"+": [{"var": "accumulator"}, {"var": "current.price"}]},
+				0
+			]}`),
+			Data: items(10000),
+		},
+		{
+			Name: "big_array_predicate",
+			Rule: []byte(`{"some": [{"var": "items"}, {"==": [{"var": ".id"}, {"var": "wanted"}]}]}`),
+			Data: itemsWith(10000, `"wanted": 9999`),
+		},
+		{
+			Name: "string_heavy",
+			Rule: []byte(`{"map": [{"var": "words"}, {"cat": [
+				{"substr": [{"var": ""}, 0, 3]},
+				"-",
+				{"if": [{"in": ["a", {"var": ""}]}, "with-a", "without-a"]}
+			]}]}`),
+			Data: words(2000),
+		},
+		{
+			Name: "big_document",
+			Rule: []byte(`{"==": [{"var": "meta.owner"}, "ana"]}`),
+			Data: itemsWith(5000, `"meta": {"owner": "ana"}`),
+		},
+	}
+}
+
+// deepRule nests depth conditions, the last one being the true one
+func deepRule(depth int) []byte {
+	rule := `"none"`
+	for i := 0; i < depth; i++ {
+		rule = fmt.Sprintf(`{"if": [{"==": [{"var": "level"}, %d]}, "level %d", %s]}`, i, i, rule)
+	}
+
+	return []byte(rule)
+}
+
+func items(n int) []byte {
+	return itemsWith(n, "")
+}
+
+func itemsWith(n int, extra string) []byte {
+	list := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		list = append(list, fmt.Sprintf(`{"id": %d, "name": "item %d", "price": %d, "tags": ["a", "b"]}`, i, i, i%100))
+	}
+
+	if extra != "" {
+		extra = ", " + extra
+	}
+
+	return []byte(fmt.Sprintf(`{"items": [%s]%s}`, strings.Join(list, ","), extra))
+}
+
+func words(n int) []byte {
+	list := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		list = append(list, fmt.Sprintf(`"word%dlorem%cipsum"`, i, 'a'+rune(i%26)))
+	}
+
+	return []byte(fmt.Sprintf(`{"words": [%s]}`, strings.Join(list, ",")))
+}
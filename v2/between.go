@@ -0,0 +1,67 @@
+package jsonlogic
+
+// ordered compares two values with <, <=, > or >=
+func (ev *evaluator) ordered(operator string, a, b interface{}) bool {
+	if ev.engine.coercion != SpecCoercion {
+		return ev.compareCoerced(operator, a, b)
+	}
+
+	ev.compared(operator, a, b)
+
+	switch operator {
+	case "<":
+		return ev.less(a, b)
+	case ">":
+		return ev.less(b, a)
+	case "<=":
+		return ev.less(a, b) || ev.equals(a, b)
+	case ">=":
+		return ev.less(b, a) || ev.equals(a, b)
+	}
+
+	return false
+}
+
+// chained compares three values with the same operator, the middle one
+// with each of the others: {"<": [1, {"var": "x"}, 10]} is 1 < x < 10, and
+// {">=": [10, {"var": "x"}, 1]} is 10 >= x >= 1
+func (ev *evaluator) chained(operator string, values []interface{}) interface{} {
+	return ev.ordered(operator, values[0], values[1]) && ev.ordered(operator, values[1], values[2])
+}
+
+// intervals maps the notations of the bounds of between to the operators
+// comparing the value with the low and the high bound
+var intervals = map[string][2]string{
+	"[]": {">=", "<="},
+	"()": {">", "<"},
+	"[)": {">=", "<"},
+	"(]": {">", "<="},
+}
+
+// between tells if a value is between two bounds, which are inclusive
+// unless told otherwise with the notation of intervals: {"between":
+// [{"var": "age"}, 18, 65, "[)"]} is 18 <= age < 65. Missing bounds or an
+// unknown notation give null.
+func (ev *evaluator) between(values interface{}) interface{} {
+	parsed := toSlice(values)
+	if len(parsed) < 3 || len(parsed) > 4 {
+		return nil
+	}
+
+	bounds := "[]"
+	if len(parsed) == 4 {
+		notation, ok := parsed[3].(string)
+		if !ok {
+			return nil
+		}
+
+		bounds = notation
+	}
+
+	operators, ok := intervals[bounds]
+	if !ok {
+		return nil
+	}
+
+	return ev.ordered(operators[0], parsed[0], parsed[1]) && ev.ordered(operators[1], parsed[0], parsed[2])
+}
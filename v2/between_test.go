@@ -0,0 +1,92 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChainedComparisons(t *testing.T) {
+	scenarios := map[string]struct {
+		Rule     string
+		Expected string
+	}{
+		"less":                  {`{"<": [1, {"var": "x"}, 10]}`, `true`},
+		"less at the bound":     {`{"<": [1, {"var": "low"}, 10]}`, `false`},
+		"less or equal":         {`{"<=": [1, {"var": "low"}, 10]}`, `true`},
+		"greater":               {`{">": [10, {"var": "x"}, 1]}`, `true`},
+		"greater at the bound":  {`{">": [10, {"var": "high"}, 1]}`, `false`},
+		"greater or equal":      {`{">=": [10, {"var": "high"}, 1]}`, `true`},
+		"greater out of bounds": {`{">=": [10, {"var": "big"}, 1]}`, `false`},
+		"strings":               {`{">": ["c", "b", "a"]}`, `true`},
+		"equality":              {`{"==": [1, 1, 1]}`, `false`},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var result bytes.Buffer
+
+			err := Apply(strings.NewReader(scenario.Rule), strings.NewReader(`{"x": 5, "low": 1, "high": 10, "big": 11}`), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, result.String())
+		})
+	}
+}
+
+func TestBetween(t *testing.T) {
+	scenarios := map[string]struct {
+		Rule     string
+		Expected string
+	}{
+		"inclusive by default":  {`{"between": [{"var": "age"}, 18, 65]}`, `true`},
+		"at the low bound":      {`{"between": [18, 18, 65]}`, `true`},
+		"at the high bound":     {`{"between": [65, 18, 65]}`, `true`},
+		"inclusive":             {`{"between": [65, 18, 65, "[]"]}`, `true`},
+		"exclusive":             {`{"between": [18, 18, 65, "()"]}`, `false`},
+		"exclusive inside":      {`{"between": [{"var": "age"}, 18, 65, "()"]}`, `true`},
+		"half open":             {`{"between": [65, 18, 65, "[)"]}`, `false`},
+		"half open low":         {`{"between": [18, 18, 65, "[)"]}`, `true`},
+		"half open on the left": {`{"between": [18, 18, 65, "(]"]}`, `false`},
+		"below":                 {`{"between": [17, 18, 65]}`, `false`},
+		"above":                 {`{"between": [66, 18, 65]}`, `false`},
+		"dates":                 {`{"between": ["2024-05-01", "2024-01-01", "2024-12-31"]}`, `true`},
+		"computed bounds":       {`{"between": [{"var": "age"}, {"-": [{"var": "age"}, 1]}, {"+": [{"var": "age"}, 1]}, "()"]}`, `true`},
+		"unknown notation":      {`{"between": [1, 0, 2, "[["]}`, `null`},
+		"missing bound":         {`{"between": [1, 0]}`, `null`},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var result bytes.Buffer
+
+			err := Apply(strings.NewReader(scenario.Rule), strings.NewReader(`{"age": 30}`), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, result.String())
+		})
+	}
+}
+
+func TestBetweenWithCoercion(t *testing.T) {
+	engine, err := NewEngine(WithCoercion(StrongNumericCoercion))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var result bytes.Buffer
+
+	err = engine.Apply(strings.NewReader(`{"between": ["5", 1, 10]}`), strings.NewReader(`{}`), &result)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.JSONEq(t, `false`, result.String())
+}
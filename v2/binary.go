@@ -0,0 +1,337 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// binaryMagic starts the binary form of rules, followed by the version of
+// the format
+var binaryMagic = []byte("JLR")
+
+const binaryVersion = 1
+
+// binaryFlags tell what the rule is like, after the version
+const (
+	// binaryComplete is set when the paths the rule reads are known, and
+	// follow the flags
+	binaryComplete = 1 << iota
+
+	// binaryIntegers is set when the rule has integers, see WithIntegers
+	binaryIntegers
+)
+
+// binaryInteger tags integers, after the kinds of values
+const binaryInteger = byte(otherKind) + 1
+
+// maxBinaryDepth bounds the nesting of the values UnmarshalBinary reads,
+// like encoding/json bounds the nesting of documents
+const maxBinaryDepth = 10000
+
+var errTruncated = errors.New("unexpected end of data")
+
+// MarshalBinary encodes the rule in a compact binary form, which
+// UnmarshalBinary reads back without parsing JSON nor looking for the
+// paths the rule reads again, to store compiled rules or ship them between
+// services. The keys of objects are sorted, so rules decoding to the same
+// values give the same bytes.
+func (r *Rule) MarshalBinary() ([]byte, error) {
+	e := binaryEncoder{buf: append([]byte(nil), binaryMagic...)}
+	e.buf = append(e.buf, binaryVersion)
+
+	var flags byte
+	if r.complete {
+		flags |= binaryComplete
+	}
+
+	if r.integers {
+		flags |= binaryIntegers
+	}
+
+	e.buf = append(e.buf, flags)
+
+	if r.complete {
+		e.uvarint(len(r.read))
+
+		for _, path := range r.read {
+			e.string(path)
+		}
+	}
+
+	if err := e.value(r.tree); err != nil {
+		return nil, fmt.Errorf("error encoding rule: %w", err)
+	}
+
+	return e.buf, nil
+}
+
+// UnmarshalBinary reads a rule encoded by MarshalBinary into r, which must
+// be a new Rule: compiled rules may be in use, and never change.
+func (r *Rule) UnmarshalBinary(data []byte) error {
+	// a rule has a tree unless it is null, which reads no paths
+	if r.tree != nil || r.complete {
+		return errors.New("error decoding rule: rule already compiled")
+	}
+
+	if !bytes.HasPrefix(data, binaryMagic) || len(data) < len(binaryMagic)+2 {
+		return errors.New("error decoding rule: not a binary rule")
+	}
+
+	if version := data[len(binaryMagic)]; version != binaryVersion {
+		return fmt.Errorf("error decoding rule: unsupported version %d", version)
+	}
+
+	d := binaryDecoder{data: data[len(binaryMagic)+1:]}
+
+	tree, paths, flags, err := d.rule()
+	if err != nil {
+		return fmt.Errorf("error decoding rule: %w", err)
+	}
+
+	r.reset(tree, paths, flags&binaryComplete != 0, flags&binaryIntegers != 0)
+
+	return nil
+}
+
+type binaryEncoder struct {
+	buf []byte
+}
+
+func (e *binaryEncoder) uvarint(n int) {
+	var buf [binary.MaxVarintLen64]byte
+
+	e.buf = append(e.buf, buf[:binary.PutUvarint(buf[:], uint64(n))]...)
+}
+
+func (e *binaryEncoder) string(s string) {
+	e.uvarint(len(s))
+	e.buf = append(e.buf, s...)
+}
+
+// value writes the kind of a value and its content: a byte for booleans,
+// the bits of numbers, the length of strings, lists and objects followed
+// by their content, the entries of objects by key
+func (e *binaryEncoder) value(v interface{}) error {
+	tagged := valueOf(v)
+
+	if i, ok := tagged.integer(); ok {
+		var buf [8]byte
+		binary.BigEndian.PutUint64(buf[:], uint64(i))
+		e.buf = append(append(e.buf, binaryInteger), buf[:]...)
+
+		return nil
+	}
+
+	e.buf = append(e.buf, byte(tagged.kind))
+
+	switch tagged.kind {
+	case boolKind:
+		e.buf = append(e.buf, byte(tagged.n))
+	case numberKind:
+		var buf [8]byte
+		binary.BigEndian.PutUint64(buf[:], math.Float64bits(tagged.n))
+		e.buf = append(e.buf, buf[:]...)
+	case stringKind:
+		e.string(tagged.s)
+	case listKind:
+		list, ok := v.([]interface{})
+		if !ok {
+			return fmt.Errorf("unsupported list %T", v)
+		}
+
+		e.uvarint(len(list))
+
+		for _, element := range list {
+			if err := e.value(element); err != nil {
+				return err
+			}
+		}
+	case objectKind:
+		object, ok := v.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("unsupported object %T", v)
+		}
+
+		keys := make([]string, 0, len(object))
+		for key := range object {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		e.uvarint(len(keys))
+
+		for _, key := range keys {
+			e.string(key)
+
+			if err := e.value(object[key]); err != nil {
+				return err
+			}
+		}
+	case otherKind:
+		return fmt.Errorf("unsupported value %T", v)
+	}
+
+	return nil
+}
+
+type binaryDecoder struct {
+	data []byte
+}
+
+// rule reads what follows the version: the flags, the paths the rule
+// reads if they are known, and the rule
+func (d *binaryDecoder) rule() (interface{}, []string, byte, error) {
+	flags, err := d.byte()
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	if flags > binaryComplete|binaryIntegers {
+		return nil, nil, 0, fmt.Errorf("unknown flags %d", flags)
+	}
+
+	var paths []string
+
+	if flags&binaryComplete != 0 {
+		n, err := d.length()
+		if err != nil {
+			return nil, nil, 0, err
+		}
+
+		paths = make([]string, n)
+		for i := range paths {
+			if paths[i], err = d.string(); err != nil {
+				return nil, nil, 0, err
+			}
+		}
+	}
+
+	tree, err := d.value(0)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	if len(d.data) > 0 {
+		return nil, nil, 0, errors.New("invalid data after rule")
+	}
+
+	return tree, paths, flags, nil
+}
+
+func (d *binaryDecoder) byte() (byte, error) {
+	if len(d.data) == 0 {
+		return 0, errTruncated
+	}
+
+	b := d.data[0]
+	d.data = d.data[1:]
+
+	return b, nil
+}
+
+// length reads the length of a string, a list or an object, which can't
+// be longer than the data left since their elements take a byte at least
+func (d *binaryDecoder) length() (int, error) {
+	n, size := binary.Uvarint(d.data)
+	if size <= 0 || n > uint64(len(d.data)-size) {
+		return 0, errTruncated
+	}
+
+	d.data = d.data[size:]
+
+	return int(n), nil
+}
+
+func (d *binaryDecoder) string() (string, error) {
+	n, err := d.length()
+	if err != nil {
+		return "", err
+	}
+
+	s := string(d.data[:n])
+	d.data = d.data[n:]
+
+	return s, nil
+}
+
+func (d *binaryDecoder) value(depth int) (interface{}, error) {
+	if depth > maxBinaryDepth {
+		return nil, fmt.Errorf("values nested more than %d times", maxBinaryDepth)
+	}
+
+	tag, err := d.byte()
+	if err != nil {
+		return nil, err
+	}
+
+	if tag == binaryInteger {
+		if len(d.data) < 8 {
+			return nil, errTruncated
+		}
+
+		i := int64(binary.BigEndian.Uint64(d.data))
+		d.data = d.data[8:]
+
+		return i, nil
+	}
+
+	switch kind(tag) {
+	case nullKind:
+		return nil, nil
+	case boolKind:
+		b, err := d.byte()
+
+		return b == 1, err
+	case numberKind:
+		if len(d.data) < 8 {
+			return nil, errTruncated
+		}
+
+		n := math.Float64frombits(binary.BigEndian.Uint64(d.data))
+		d.data = d.data[8:]
+
+		return n, nil
+	case stringKind:
+		return d.string()
+	case listKind:
+		n, err := d.length()
+		if err != nil {
+			return nil, err
+		}
+
+		list := make([]interface{}, n)
+		for i := range list {
+			if list[i], err = d.value(depth + 1); err != nil {
+				return nil, err
+			}
+		}
+
+		return list, nil
+	case objectKind:
+		n, err := d.length()
+		if err != nil {
+			return nil, err
+		}
+
+		object := make(map[string]interface{}, n)
+		for i := 0; i < n; i++ {
+			key, err := d.string()
+			if err != nil {
+				return nil, err
+			}
+
+			if object[key], err = d.value(depth + 1); err != nil {
+				return nil, err
+			}
+		}
+
+		return object, nil
+	}
+
+	return nil, fmt.Errorf("unknown kind of value %d", tag)
+}
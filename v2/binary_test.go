@@ -0,0 +1,147 @@
+package jsonlogic
+
+import (
+	"encoding"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var _ encoding.BinaryMarshaler = (*Rule)(nil)
+var _ encoding.BinaryUnmarshaler = (*Rule)(nil)
+
+func TestRuleBinary(t *testing.T) {
+	data := []byte(`{"user": {"age": 30, "name": "ana"}, "items": [{"price": 5}, {"price": 12}]}`)
+
+	scenarios := map[string]struct {
+		Rule     string
+		Expected bool
+	}{
+		"known paths": {
+			Rule:     `{"and": [{">=": [{"var": "user.age"}, 18]}, {"==": [{"var": "user.name"}, "ana"]}]}`,
+			Expected: true,
+		},
+		"unknown paths": {
+			Rule:     `{"some": [{"var": "items"}, {">": [{"var": "price"}, {"var": "limit"}]}]}`,
+			Expected: true,
+		},
+		"all kinds of values": {
+			Rule:     `{"in": [{"var": "user.name"}, [null, true, false, -1.5, 1e300, "", "ana", [], {}]]}`,
+			Expected: true,
+		},
+		"not an expression": {
+			Rule:     `"text"`,
+			Expected: true,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			compiled, err := Compile(strings.NewReader(scenario.Rule))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			encoded, err := compiled.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var decoded Rule
+			if err := decoded.UnmarshalBinary(encoded); err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, compiled.tree, decoded.tree)
+			assert.Equal(t, compiled.program, decoded.program)
+			assert.Equal(t, compiled.complete, decoded.complete)
+			assert.Equal(t, compiled.read, decoded.read)
+
+			result, err := ApplyBool(&decoded, data)
+			assert.NoError(t, err)
+			assert.Equal(t, scenario.Expected, result)
+		})
+	}
+}
+
+func TestRuleBinaryIsDeterministic(t *testing.T) {
+	encode := func(rule string) []byte {
+		compiled, err := Compile(strings.NewReader(rule))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		encoded, err := compiled.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return encoded
+	}
+
+	assert.Equal(t,
+		encode(`{"merge_objects": [{"a": 1, "b": 2, "c": 3}]}`),
+		encode(`{"merge_objects": [{"c": 3, "a": 1, "b": 2}]}`))
+}
+
+func TestRuleBinaryErrors(t *testing.T) {
+	compiled, err := Compile(strings.NewReader(`{"==": [{"var": "a"}, "text"]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encoded, err := compiled.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deep := append([]byte("JLR\x01\x00"), []byte(strings.Repeat("\x04\x01", maxBinaryDepth+2))...)
+
+	scenarios := map[string]struct {
+		Data     []byte
+		Expected string
+	}{
+		"empty":          {Data: nil, Expected: "not a binary rule"},
+		"json":           {Data: []byte(`{"==": [1, 1]}`), Expected: "not a binary rule"},
+		"version":        {Data: []byte("JLR\x09\x00\x00"), Expected: "unsupported version 9"},
+		"flags":          {Data: []byte("JLR\x01\x07\x00"), Expected: "unknown flags 7"},
+		"truncated":      {Data: encoded[:len(encoded)-2], Expected: "unexpected end of data"},
+		"trailing data":  {Data: append(append([]byte(nil), encoded...), 0), Expected: "invalid data after rule"},
+		"unknown kind":   {Data: []byte("JLR\x01\x00\x09"), Expected: "unknown kind of value 9"},
+		"too long lists": {Data: []byte("JLR\x01\x00\x04\xff\xff\xff\xff\x0f"), Expected: "unexpected end of data"},
+		"too deep":       {Data: deep, Expected: "nested more than"},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var rule Rule
+
+			err := rule.UnmarshalBinary(scenario.Data)
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), scenario.Expected)
+			}
+		})
+	}
+
+	_, err = (&Rule{tree: map[string]interface{}{"var": struct{}{}}}).MarshalBinary()
+	assert.Error(t, err)
+}
+
+func TestRuleBinaryIntoCompiledRule(t *testing.T) {
+	for _, source := range []string{`{"var": "a"}`, `null`} {
+		compiled, err := Compile(strings.NewReader(source))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		encoded, err := compiled.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = compiled.UnmarshalBinary(encoded)
+		assert.EqualError(t, err, "error decoding rule: rule already compiled")
+	}
+}
@@ -0,0 +1,158 @@
+package jsonlogic
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync/atomic"
+)
+
+// ErrBudgetExceeded is wrapped by the BudgetError returned when a rule
+// needs more steps than the budget of the engine
+var ErrBudgetExceeded = errors.New("evaluation budget exceeded")
+
+// BudgetError stops evaluations needing more steps than the budget given
+// to WithStepBudget. It wraps ErrBudgetExceeded.
+type BudgetError struct {
+	// Path locates the expression being evaluated when the budget ran out
+	// as a JSON pointer, like "/and/1/map" for a "map" being the second
+	// argument of an "and". It is empty for the expressions of named
+	// rules, which aren't in the rule applied.
+	Path   string
+	Budget int
+}
+
+func (e *BudgetError) Error() string {
+	return fmt.Sprintf("%s: more than %d steps, at %q", ErrBudgetExceeded, e.Budget, e.Path)
+}
+
+func (e *BudgetError) Unwrap() error {
+	return ErrBudgetExceeded
+}
+
+// WithStepBudget limits the steps every evaluation can take, so rules
+// over large data can't use the CPU endlessly. Evaluating an operator is
+// a step, as well as evaluating the rule of an iteration, like map or
+// filter, for an element. Evaluations needing more steps fail with a
+// BudgetError. Results reused from the cache or from the memoized
+// expressions of iterations take no step.
+func WithStepBudget(steps int) Option {
+	return func(e *Engine) error {
+		if steps <= 0 {
+			return fmt.Errorf("step budget must be positive, got %d", steps)
+		}
+
+		e.stepBudget = steps
+
+		return nil
+	}
+}
+
+// startBudget gives the evaluation of rule the step budget of the engine
+func (ev *evaluator) startBudget(rule interface{}) {
+	budget := int64(ev.engine.stepBudget)
+
+	ev.budget = &budget
+	ev.rule = rule
+	ev.expression = nil
+
+	if ev.origins == nil {
+		ev.origins = make(map[uintptr]origin)
+	}
+}
+
+// origin is an expression of which solveVars made a copy. The copy is kept
+// so its address, which keys the origin, can't be reused during the
+// evaluation.
+type origin struct {
+	copy interface{}
+	rule interface{}
+}
+
+// copied records the copy of an expression made by solveVars, so the
+// expressions of the copy can be located in the rule
+func (ev *evaluator) copied(rule, copy interface{}) {
+	ev.origins[reflect.ValueOf(copy).Pointer()] = origin{copy: copy, rule: rule}
+}
+
+// origin returns the expression of the rule an expression was copied
+// from by solveVars, or the expression itself
+func (ev *evaluator) origin(expression interface{}) interface{} {
+	for isMap(expression) {
+		o, ok := ev.origins[reflect.ValueOf(expression).Pointer()]
+		if !ok {
+			break
+		}
+
+		expression = o.rule
+	}
+
+	return expression
+}
+
+// copyOrigins returns a copy of the origins of the evaluator, for a worker
+func (ev *evaluator) copyOrigins() map[uintptr]origin {
+	if ev.origins == nil {
+		return nil
+	}
+
+	origins := make(map[uintptr]origin, len(ev.origins))
+	for key, o := range ev.origins {
+		origins[key] = o
+	}
+
+	return origins
+}
+
+// enter takes a step to evaluate expression, returning the function
+// marking the end of its evaluation
+func (ev *evaluator) enter(expression interface{}) func() {
+	parent := ev.expression
+	ev.expression = expression
+
+	ev.spend(1)
+
+	return func() {
+		ev.expression = parent
+	}
+}
+
+// spend takes steps from the budget, shared with the workers of parallel
+// iterations, and fails the evaluation when it runs out
+func (ev *evaluator) spend(steps int) {
+	if atomic.AddInt64(ev.budget, -int64(steps)) >= 0 {
+		return
+	}
+
+	path, _ := pathTo(ev.rule, ev.origin(ev.expression), "")
+
+	ev.fail(&BudgetError{Path: path, Budget: ev.engine.stepBudget})
+}
+
+// pathTo looks for an expression in a rule, returning its location as a
+// JSON pointer
+func pathTo(rule, expression interface{}, path string) (string, bool) {
+	switch rule := rule.(type) {
+	case map[string]interface{}:
+		if isMap(expression) && reflect.ValueOf(rule).Pointer() == reflect.ValueOf(expression).Pointer() {
+			for operator := range rule {
+				return path + "/" + pointerToken(operator), true
+			}
+		}
+
+		for operator, values := range rule {
+			if found, ok := pathTo(values, expression, path+"/"+pointerToken(operator)); ok {
+				return found, true
+			}
+		}
+	case []interface{}:
+		for i, value := range rule {
+			if found, ok := pathTo(value, expression, path+"/"+strconv.Itoa(i)); ok {
+				return found, true
+			}
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,112 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStepBudget(t *testing.T) {
+	scenarios := map[string]struct {
+		Rule     string
+		Expected string
+		Path     string
+	}{
+		"within the budget": {`{"and": [{"==": [{"var": "a"}, 1]}, {"var": "b"}]}`, `true`, ``},
+		"long iteration":    {`{"map": [{"var": "list"}, {"*": [{"var": ""}, 2]}]}`, ``, `/map/1/*`},
+		"iteration charged": {`{"filter": [{"merge": [{"var": "list"}, {"var": "list"}]}, true]}`, ``, `/filter`},
+		"nested":            {`{"and": [true, {"some": [{"var": "list"}, {"<": [{"var": ""}, 0]}]}]}`, ``, `/and/1/some/1/</0/var`},
+	}
+
+	data := `{"a": 1, "b": true, "list": [1, 2, 3, 4, 5, 6, 7, 8, 9, 10]}`
+
+	engine, err := NewEngine(WithStepBudget(20))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parallel, err := NewEngine(WithStepBudget(20), WithParallelism(2, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			for _, e := range []*Engine{engine, parallel} {
+				var result bytes.Buffer
+
+				err := e.Apply(strings.NewReader(scenario.Rule), strings.NewReader(data), &result)
+				if scenario.Expected != "" {
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					assert.JSONEq(t, scenario.Expected, result.String())
+
+					continue
+				}
+
+				assert.True(t, errors.Is(err, ErrBudgetExceeded))
+
+				var budgetError *BudgetError
+				if assert.True(t, errors.As(err, &budgetError)) {
+					assert.Equal(t, 20, budgetError.Budget)
+
+					if e == engine {
+						assert.Equal(t, scenario.Path, budgetError.Path)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestStepBudgetPerEvaluation(t *testing.T) {
+	engine, err := NewEngine(WithStepBudget(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rule := map[string]interface{}{"+": []interface{}{
+		map[string]interface{}{"var": "a"},
+		map[string]interface{}{"var": "b"},
+	}}
+
+	for i := 0; i < 3; i++ {
+		result, err := engine.ApplyInterface(rule, map[string]interface{}{"a": 1.0, "b": 2.0})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 3.0, result)
+	}
+}
+
+func TestStepBudgetInNamedRules(t *testing.T) {
+	engine, err := NewEngine(
+		WithStepBudget(5),
+		WithRuleRegistry(Rules{"all": map[string]interface{}{"map": []interface{}{
+			map[string]interface{}{"var": "list"},
+			map[string]interface{}{"var": ""},
+		}}}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = engine.ApplyInterface(map[string]interface{}{"rule": "all"}, map[string]interface{}{"list": []interface{}{1.0, 2.0, 3.0, 4.0}})
+
+	var budgetError *BudgetError
+	if assert.True(t, errors.As(err, &budgetError)) {
+		assert.Equal(t, "", budgetError.Path)
+	}
+}
+
+func TestInvalidStepBudget(t *testing.T) {
+	_, err := NewEngine(WithStepBudget(0))
+	assert.Error(t, err)
+}
@@ -0,0 +1,129 @@
+package jsonlogic
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mitchellh/copystructure"
+)
+
+// Cache stores the results of evaluations. Its methods match the ones of
+// common LRU implementations, like github.com/hashicorp/golang-lru, which
+// can be given as they are. It must be safe for concurrent use.
+type Cache interface {
+	Get(key interface{}) (value interface{}, ok bool)
+	Add(key, value interface{}) (evicted bool)
+}
+
+// impureOperators are the operators whose results don't only depend on
+// the rule and the data
+var impureOperators = map[string]bool{
+	"now":        true,
+	"random":     true,
+	"sample_pct": true,
+	"log":        true,
+	"rule":       true,
+}
+
+// WithCache makes the engine store in cache the results of the rules,
+// keyed by a digest of the rule and the data, and reuse them when the
+// same rule is applied to identical data. Rules using the current time,
+// random numbers, logging or named rules, engines resolving variables, and
+// failed evaluations aren't cached. Cached results skip the evaluation
+// entirely: middlewares, instrumentation and logging don't see them. A
+// cache must not be shared by engines with different options.
+func WithCache(cache Cache) Option {
+	return func(e *Engine) error {
+		if cache == nil {
+			return fmt.Errorf("cache must not be nil")
+		}
+
+		e.cache = cache
+
+		return nil
+	}
+}
+
+// cached applies a rule through the cache of the engine, in ctx
+func (e *Engine) cached(ctx context.Context, rule, data interface{}) (interface{}, error) {
+	run := func() (interface{}, error) {
+		ev := e.evaluator()
+		ev.ctx = ctx
+
+		return ev.run(rule, data)
+	}
+
+	if e.resolver != nil || !isPure(rule) {
+		return run()
+	}
+
+	key, err := cacheKey(rule, data)
+	if err != nil {
+		return run()
+	}
+
+	if result, ok := e.cache.Get(key); ok {
+		// callers own the results they get, so they are copied
+		return copystructure.Copy(result)
+	}
+
+	result, err := run()
+	if err != nil {
+		return nil, err
+	}
+
+	if stored, err := copystructure.Copy(result); err == nil {
+		e.cache.Add(key, stored)
+	}
+
+	return result, nil
+}
+
+// cacheKey returns a digest of the JSON encodings of rule and data, which
+// sort the keys of the objects
+func cacheKey(rule, data interface{}) ([sha256.Size]byte, error) {
+	var key [sha256.Size]byte
+
+	encodedRule, err := json.Marshal(rule)
+	if err != nil {
+		return key, err
+	}
+
+	encodedData, err := json.Marshal(data)
+	if err != nil {
+		return key, err
+	}
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%d:", len(encodedRule))
+	h.Write(encodedRule)
+	h.Write(encodedData)
+	copy(key[:], h.Sum(nil))
+
+	return key, nil
+}
+
+// isPure tells if the result of rule only depends on the data
+func isPure(rule interface{}) bool {
+	if isSlice(rule) {
+		for _, value := range rule.([]interface{}) {
+			if !isPure(value) {
+				return false
+			}
+		}
+	}
+
+	if !isMap(rule) {
+		return true
+	}
+
+	for operator, values := range rule.(map[string]interface{}) {
+		if impureOperators[operator] || !isPure(values) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,106 @@
+package jsonlogic
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testCache struct {
+	mu     sync.Mutex
+	values map[interface{}]interface{}
+}
+
+func (c *testCache) Get(key interface{}) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	value, ok := c.values[key]
+
+	return value, ok
+}
+
+func (c *testCache) Add(key, value interface{}) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.values[key] = value
+
+	return false
+}
+
+func TestWithCache(t *testing.T) {
+	cache := &testCache{values: make(map[interface{}]interface{})}
+	evaluations := 0
+
+	engine, err := NewEngine(
+		WithCache(cache),
+		WithMiddlewares(func(operator string, args []interface{}, next Evaluator) (interface{}, error) {
+			evaluations++
+
+			return next(operator, args)
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rule := map[string]interface{}{"filter": []interface{}{
+		map[string]interface{}{"var": "scores"},
+		map[string]interface{}{">": []interface{}{map[string]interface{}{"var": ""}, float64(5)}},
+	}}
+
+	first, err := engine.ApplyInterface(rule, map[string]interface{}{"scores": []interface{}{float64(3), float64(7)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []interface{}{float64(7)}, first)
+	assert.Len(t, cache.values, 1)
+
+	count := evaluations
+
+	// results are copied, so changing them doesn't change the cache
+	first.([]interface{})[0] = "changed"
+
+	second, err := engine.ApplyInterface(rule, map[string]interface{}{"scores": []interface{}{float64(3), float64(7)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []interface{}{float64(7)}, second)
+	assert.Equal(t, count, evaluations)
+
+	_, err = engine.ApplyInterface(rule, map[string]interface{}{"scores": []interface{}{float64(9)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, cache.values, 2)
+	assert.True(t, evaluations > count)
+}
+
+func TestWithCacheSkipsImpureRules(t *testing.T) {
+	cache := &testCache{values: make(map[interface{}]interface{})}
+
+	engine, err := NewEngine(WithCache(cache))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = engine.ApplyInterface(map[string]interface{}{"date_after": []interface{}{map[string]interface{}{"now": []interface{}{}}, "2020-01-01T00:00:00Z"}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = engine.ApplyInterface(map[string]interface{}{"log": "hello"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Empty(t, cache.values)
+
+	_, err = NewEngine(WithCache(nil))
+	assert.Error(t, err)
+}
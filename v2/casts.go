@@ -0,0 +1,99 @@
+package jsonlogic
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidCast is returned, wrapped, by engines using strict casts
+// when a value can't be converted
+var ErrInvalidCast = errors.New("invalid cast")
+
+func castToNumber(value interface{}) (interface{}, bool) {
+	switch {
+	case isNumber(value):
+		return value, true
+	case isBool(value):
+		if value.(bool) {
+			return float64(1), true
+		}
+
+		return float64(0), true
+	case isString(value):
+		n, err := strconv.ParseFloat(strings.TrimSpace(value.(string)), 64)
+		if err != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+			return nil, false
+		}
+
+		return n, true
+	}
+
+	return nil, false
+}
+
+func castToString(value interface{}) (interface{}, bool) {
+	switch {
+	case isString(value), isNumber(value):
+		return toString(value), true
+	case isBool(value):
+		return strconv.FormatBool(value.(bool)), true
+	}
+
+	return nil, false
+}
+
+func castToBool(value interface{}) (interface{}, bool) {
+	switch {
+	case isBool(value):
+		return value, true
+	case isNumber(value):
+		return toNumber(value) != 0, true
+	case isString(value):
+		b, err := strconv.ParseBool(strings.TrimSpace(value.(string)))
+		if err != nil {
+			return nil, false
+		}
+
+		return b, true
+	}
+
+	return nil, false
+}
+
+// casts maps the cast operators to their conversions. Numbers, strings and
+// booleans convert between themselves when their value allows it: "12"
+// becomes 12, 1 becomes true and "false" becomes false. Anything else,
+// including null, lists, objects or a string like "abc" converted to a
+// number, fails the conversion.
+var casts = map[string]func(interface{}) (interface{}, bool){
+	"to_number": castToNumber,
+	"to_string": castToString,
+	"to_bool":   castToBool,
+}
+
+func isCastOperator(operator string) bool {
+	_, ok := casts[operator]
+
+	return ok
+}
+
+// cast converts its argument with the conversion of the operator. Failed
+// conversions are null, unless the engine uses strict casts.
+func (ev *evaluator) cast(operator string, values, data interface{}) interface{} {
+	value := ev.argument(values, data)
+
+	result, ok := casts[operator](value)
+	if !ok {
+		err := fmt.Errorf("%w: %s of %s %v", ErrInvalidCast, operator, typeOf(value), value)
+		if ev.engine.strictCasts {
+			ev.fail(err)
+		}
+
+		ev.warn("jsonlogic cast failed", "error", err)
+	}
+
+	return result
+}
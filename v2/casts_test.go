@@ -0,0 +1,76 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCastOperators(t *testing.T) {
+	data := `{"age": "33", "flag": "false", "count": 0, "tags": ["a"]}`
+
+	scenarios := map[string]struct {
+		Rule     string
+		Expected string
+	}{
+		"number from a string":       {`{"to_number": {"var": "age"}}`, `33`},
+		"number from a padded value": {`{"to_number": " 1.5 "}`, `1.5`},
+		"number from a boolean":      {`{"to_number": true}`, `1`},
+		"number from garbage":        {`{"to_number": "abc"}`, `null`},
+		"number from infinity":       {`{"to_number": "Inf"}`, `null`},
+		"number from null":           {`{"to_number": {"var": "missing"}}`, `null`},
+		"number from a list":         {`{"to_number": {"var": "tags"}}`, `null`},
+		"string from a number":       {`{"to_string": 1.50}`, `"1.5"`},
+		"string from a boolean":      {`{"to_string": [false]}`, `"false"`},
+		"string from null":           {`{"to_string": null}`, `null`},
+		"bool from a string":         {`{"to_bool": {"var": "flag"}}`, `false`},
+		"bool from a number":         {`{"to_bool": {"var": "count"}}`, `false`},
+		"bool from garbage":          {`{"to_bool": "yes"}`, `null`},
+		"compare explicitly": {
+			`{"===": [{"to_number": {"var": "age"}}, 33]}`,
+			`true`,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var result bytes.Buffer
+
+			err := Apply(strings.NewReader(scenario.Rule), strings.NewReader(data), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, result.String())
+		})
+	}
+}
+
+func TestStrictCasts(t *testing.T) {
+	engine, err := NewEngine(WithStrictCasts())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var result bytes.Buffer
+
+	err = engine.Apply(strings.NewReader(`{"to_number": "12"}`), strings.NewReader(`{}`), &result)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.JSONEq(t, `12`, result.String())
+
+	err = engine.Apply(strings.NewReader(`{"+": [1, {"to_number": "abc"}]}`), strings.NewReader(`{}`), &result)
+	assert.True(t, errors.Is(err, ErrInvalidCast))
+
+	_, err = engine.ApplyRaw([]byte(`{"to_bool": {"var": "x"}}`), []byte(`{}`))
+	assert.True(t, errors.Is(err, ErrInvalidCast))
+
+	_, err = engine.ApplyInterface(map[string]interface{}{"to_string": []interface{}{nil}}, nil)
+	assert.True(t, errors.Is(err, ErrInvalidCast))
+}
@@ -0,0 +1,463 @@
+// Package cel translates JSON Logic rules to CEL expressions (Common
+// Expression Language), so rules written in JSON Logic can be enforced by
+// systems reading CEL, like Kubernetes admission policies or Envoy:
+//
+//	expression, err := cel.Translate(strings.NewReader(`{">=": [{"var": "age"}, 18]}`), "object")
+//	// object.age >= 18.0
+//
+// CEL is typed where JSON Logic converts values as needed, so only rules
+// whose meaning is the same in both languages are translated:
+//
+//   - numbers are doubles, as are the numbers of JSON documents in CEL
+//   - == and != compare like === and !==, without conversions
+//   - the arguments of !, and, or and the conditions of if must be
+//     booleans: CEL has no truthiness
+//   - in checks the elements of lists, or substrings when the list is a
+//     string literal
+//
+// Operators without an equivalent, like reduce or missing, make the
+// translation fail.
+package cel
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// expr is a translated expression; compound ones are wrapped in
+// parentheses when used as operands
+type expr struct {
+	code     string
+	compound bool
+}
+
+func (e expr) operand() string {
+	if e.compound {
+		return "(" + e.code + ")"
+	}
+
+	return e.code
+}
+
+type translator struct {
+	// scopes are the names of the variables holding the data, followed by
+	// the ones holding the elements of the iterations being translated
+	scopes []string
+}
+
+// Translate reads a rule and returns the equivalent CEL expression, in
+// which the data is the variable named root
+func Translate(rule io.Reader, root string) (string, error) {
+	var _rule interface{}
+
+	err := json.NewDecoder(rule).Decode(&_rule)
+	if err != nil {
+		return "", fmt.Errorf("error parsing rule: %w", err)
+	}
+
+	if !isIdentifier(root) {
+		return "", fmt.Errorf("invalid root variable %q", root)
+	}
+
+	t := &translator{scopes: []string{root}}
+
+	translated, err := t.expr(_rule, "")
+	if err != nil {
+		return "", err
+	}
+
+	return translated.code, nil
+}
+
+func (t *translator) expr(rule interface{}, path string) (expr, error) {
+	switch value := rule.(type) {
+	case nil:
+		return expr{code: "null"}, nil
+	case bool:
+		return expr{code: strconv.FormatBool(value)}, nil
+	case float64:
+		return expr{code: number(value)}, nil
+	case string:
+		return expr{code: strconv.Quote(value)}, nil
+	case []interface{}:
+		elements, err := t.list(value, path)
+		if err != nil {
+			return expr{}, err
+		}
+
+		return expr{code: "[" + strings.Join(elements, ", ") + "]"}, nil
+	case map[string]interface{}:
+		if len(value) != 1 {
+			return expr{}, fmt.Errorf("%s: rules must have a single operator, got %d", location(path), len(value))
+		}
+
+		for operator, args := range value {
+			return t.operation(operator, args, path+"/"+pointerToken(operator))
+		}
+	}
+
+	return expr{}, fmt.Errorf("%s: unsupported value %v", location(path), rule)
+}
+
+func (t *translator) list(values []interface{}, path string) ([]string, error) {
+	elements := make([]string, 0, len(values))
+	for i, value := range values {
+		element, err := t.expr(value, path+"/"+strconv.Itoa(i))
+		if err != nil {
+			return nil, err
+		}
+
+		elements = append(elements, element.code)
+	}
+
+	return elements, nil
+}
+
+// args translates the arguments of an operator, given as a list or alone
+func (t *translator) args(values interface{}, path string) ([]expr, error) {
+	list, ok := values.([]interface{})
+	if !ok {
+		arg, err := t.expr(values, path)
+		if err != nil {
+			return nil, err
+		}
+
+		return []expr{arg}, nil
+	}
+
+	args := make([]expr, 0, len(list))
+	for i, value := range list {
+		arg, err := t.expr(value, path+"/"+strconv.Itoa(i))
+		if err != nil {
+			return nil, err
+		}
+
+		args = append(args, arg)
+	}
+
+	return args, nil
+}
+
+var binaryOperators = map[string]string{
+	"==":  "==",
+	"===": "==",
+	"!=":  "!=",
+	"!==": "!=",
+	"<":   "<",
+	"<=":  "<=",
+	">":   ">",
+	">=":  ">=",
+}
+
+var chainedOperators = map[string]string{
+	"and": "&&",
+	"or":  "||",
+	"+":   "+",
+	"*":   "*",
+}
+
+func (t *translator) operation(operator string, values interface{}, path string) (expr, error) {
+	switch operator {
+	case "var":
+		return t.variable(values, path)
+	case "all", "some", "none", "filter", "map":
+		return t.iteration(operator, values, path)
+	case "in":
+		return t.in(values, path)
+	}
+
+	args, err := t.args(values, path)
+	if err != nil {
+		return expr{}, err
+	}
+
+	switch {
+	case binaryOperators[operator] != "" && len(args) == 2:
+		return binary(args[0], binaryOperators[operator], args[1]), nil
+	case (operator == "<" || operator == "<=" || operator == ">" || operator == ">=") && len(args) == 3:
+		// between
+		return binary(binary(args[0], operator, args[1]), "&&", binary(args[1], operator, args[2])), nil
+	case chainedOperators[operator] != "" && len(args) > 1:
+		return chain(args, chainedOperators[operator]), nil
+	case (operator == "and" || operator == "or" || operator == "*" || operator == "+") && len(args) == 1:
+		if operator == "+" {
+			return expr{code: "double(" + args[0].code + ")"}, nil
+		}
+
+		return args[0], nil
+	case operator == "-" && len(args) == 2:
+		return binary(args[0], "-", args[1]), nil
+	case operator == "-" && len(args) == 1:
+		return expr{code: "-" + args[0].operand()}, nil
+	case operator == "/" && len(args) == 2:
+		return binary(args[0], "/", args[1]), nil
+	case operator == "!" && len(args) == 1:
+		return expr{code: "!" + args[0].operand()}, nil
+	case operator == "!!" && len(args) == 1:
+		return args[0], nil
+	case operator == "if" || operator == "?:":
+		return conditional(args, path)
+	case operator == "cat" && len(args) > 0:
+		return cat(values, args), nil
+	case operator == "merge" && len(args) > 0:
+		return chain(args, "+"), nil
+	case (operator == "match" || operator == "regex") && len(args) == 2:
+		return expr{code: args[0].operand() + ".matches(" + args[1].code + ")"}, nil
+	}
+
+	return expr{}, fmt.Errorf("%s: operator %q with %d arguments has no CEL equivalent", location(path), operator, len(args))
+}
+
+func binary(a expr, operator string, b expr) expr {
+	return expr{code: a.operand() + " " + operator + " " + b.operand(), compound: true}
+}
+
+func chain(args []expr, operator string) expr {
+	operands := make([]string, 0, len(args))
+	for _, arg := range args {
+		operands = append(operands, arg.operand())
+	}
+
+	return expr{code: strings.Join(operands, " "+operator+" "), compound: true}
+}
+
+// conditional translates if, whose conditions and results alternate,
+// ending with the result when no condition is true
+func conditional(args []expr, path string) (expr, error) {
+	if len(args) < 3 || len(args)%2 == 0 {
+		return expr{}, fmt.Errorf("%s: if must have conditions with their results, and a last result", location(path))
+	}
+
+	result := args[len(args)-1]
+	for i := len(args) - 3; i >= 0; i -= 2 {
+		result = expr{code: args[i].operand() + " ? " + args[i+1].operand() + " : " + result.operand(), compound: true}
+	}
+
+	return result, nil
+}
+
+// cat converts the arguments that aren't string literals to strings
+func cat(values interface{}, args []expr) expr {
+	list, ok := values.([]interface{})
+	if !ok {
+		list = []interface{}{values}
+	}
+
+	operands := make([]expr, 0, len(args))
+	for i, arg := range args {
+		if _, ok := list[i].(string); !ok {
+			arg = expr{code: "string(" + arg.code + ")"}
+		}
+
+		operands = append(operands, arg)
+	}
+
+	if len(operands) == 1 {
+		return operands[0]
+	}
+
+	return chain(operands, "+")
+}
+
+func (t *translator) in(values interface{}, path string) (expr, error) {
+	args, err := t.args(values, path)
+	if err != nil {
+		return expr{}, err
+	}
+
+	if len(args) != 2 {
+		return expr{}, fmt.Errorf("%s: in must have 2 arguments, got %d", location(path), len(args))
+	}
+
+	if list, ok := values.([]interface{}); ok {
+		if _, ok := list[1].(string); ok {
+			return expr{code: args[1].code + ".contains(" + args[0].code + ")"}, nil
+		}
+	}
+
+	return binary(args[0], "in", args[1]), nil
+}
+
+// iteration translates the iterators to the macros of CEL, the elements
+// being in a variable of their own
+func (t *translator) iteration(operator string, values interface{}, path string) (expr, error) {
+	list, ok := values.([]interface{})
+	if !ok || len(list) != 2 {
+		return expr{}, fmt.Errorf("%s: %s must have a list and a rule", location(path), operator)
+	}
+
+	subject, err := t.expr(list[0], path+"/0")
+	if err != nil {
+		return expr{}, err
+	}
+
+	element := "x"
+	if depth := len(t.scopes) - 1; depth > 0 {
+		element += strconv.Itoa(depth)
+	}
+
+	t.scopes = append(t.scopes, element)
+	predicate, err := t.expr(list[1], path+"/1")
+	t.scopes = t.scopes[:len(t.scopes)-1]
+
+	if err != nil {
+		return expr{}, err
+	}
+
+	macro := map[string]string{"all": "all", "some": "exists", "none": "exists", "filter": "filter", "map": "map"}[operator]
+	code := subject.operand() + "." + macro + "(" + element + ", " + predicate.code + ")"
+
+	if operator == "none" {
+		return expr{code: "!" + code}, nil
+	}
+
+	return expr{code: code}, nil
+}
+
+// variable translates var to the selection of the fields of the data, or
+// of the element of the iteration being translated. Defaults are used
+// when the field is missing.
+func (t *translator) variable(values interface{}, path string) (expr, error) {
+	name := values
+	var fallback interface{}
+	hasFallback := false
+
+	if list, ok := values.([]interface{}); ok {
+		if len(list) == 0 {
+			return expr{code: t.scopes[len(t.scopes)-1]}, nil
+		}
+
+		name = list[0]
+		if len(list) > 1 {
+			fallback, hasFallback = list[1], true
+		}
+	}
+
+	if number, ok := name.(float64); ok {
+		name = strconv.FormatFloat(number, 'f', -1, 64)
+	}
+
+	_name, ok := name.(string)
+	if !ok {
+		return expr{}, fmt.Errorf("%s: computed paths have no CEL equivalent", location(path))
+	}
+
+	selection := t.scopes[len(t.scopes)-1]
+	checks := make([]string, 0)
+
+	for _, part := range pathParts(_name) {
+		index, err := strconv.Atoi(part)
+		if err == nil && index < 0 {
+			return expr{}, fmt.Errorf("%s: negative indexes have no CEL equivalent", location(path))
+		}
+
+		if err == nil {
+			if hasFallback {
+				return expr{}, fmt.Errorf("%s: defaults of paths with indexes have no CEL equivalent", location(path))
+			}
+
+			selection += "[" + strconv.Itoa(index) + "]"
+
+			continue
+		}
+
+		if isIdentifier(part) {
+			selection += "." + part
+		} else {
+			if hasFallback {
+				return expr{}, fmt.Errorf("%s: defaults of path %q have no CEL equivalent", location(path), _name)
+			}
+
+			selection += "[" + strconv.Quote(part) + "]"
+
+			continue
+		}
+
+		checks = append(checks, "has("+selection+")")
+	}
+
+	if !hasFallback {
+		return expr{code: selection}, nil
+	}
+
+	translated, err := t.expr(fallback, path+"/1")
+	if err != nil {
+		return expr{}, err
+	}
+
+	if len(checks) == 0 {
+		return expr{code: selection}, nil
+	}
+
+	return expr{code: strings.Join(checks, " && ") + " ? " + selection + " : " + translated.operand(), compound: true}, nil
+}
+
+// number writes a CEL double literal
+func number(value float64) string {
+	if math.IsInf(value, 0) || math.IsNaN(value) {
+		return "double(" + strconv.Quote(strconv.FormatFloat(value, 'g', -1, 64)) + ")"
+	}
+
+	code := strconv.FormatFloat(value, 'g', -1, 64)
+	if !strings.ContainsAny(code, ".e") {
+		code += ".0"
+	}
+
+	return code
+}
+
+var identifier = regexp.MustCompile(`^[_a-zA-Z][_a-zA-Z0-9]*$`)
+
+// reserved are the words CEL doesn't accept as field names
+var reserved = map[string]bool{
+	"as": true, "break": true, "const": true, "continue": true, "else": true,
+	"false": true, "for": true, "function": true, "if": true, "import": true,
+	"in": true, "let": true, "loop": true, "package": true, "namespace": true,
+	"null": true, "return": true, "true": true, "var": true, "void": true,
+	"while": true,
+}
+
+func isIdentifier(name string) bool {
+	return identifier.MatchString(name) && !reserved[name]
+}
+
+// pathParts splits a var path into the properties and indexes it goes
+// through. Paths starting with a slash are JSON pointers, the others are
+// separated by dots.
+func pathParts(path string) []string {
+	if !strings.HasPrefix(path, "/") {
+		parts := make([]string, 0)
+		for _, part := range strings.Split(path, ".") {
+			if part != "" {
+				parts = append(parts, part)
+			}
+		}
+
+		return parts
+	}
+
+	parts := strings.Split(path[1:], "/")
+	for i, part := range parts {
+		parts[i] = strings.Replace(strings.Replace(part, "~1", "/", -1), "~0", "~", -1)
+	}
+
+	return parts
+}
+
+// pointerToken escapes a JSON pointer token as defined by RFC 6901
+func pointerToken(token string) string {
+	return strings.Replace(strings.Replace(token, "~", "~0", -1), "/", "~1", -1)
+}
+
+func location(path string) string {
+	if path == "" {
+		return "/"
+	}
+
+	return path
+}
@@ -0,0 +1,156 @@
+package cel
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslate(t *testing.T) {
+	scenarios := map[string]struct {
+		rule     string
+		expected string
+	}{
+		"comparison": {
+			rule:     `{">=": [{"var": "age"}, 18]}`,
+			expected: `data.age >= 18.0`,
+		},
+		"strict equality": {
+			rule:     `{"===": [{"var": "user.name"}, "ana"]}`,
+			expected: `data.user.name == "ana"`,
+		},
+		"between": {
+			rule:     `{"<": [0, {"var": "temp"}, 100]}`,
+			expected: `(0.0 < data.temp) && (data.temp < 100.0)`,
+		},
+		"descending between": {
+			rule:     `{">=": [100, {"var": "temp"}, 0]}`,
+			expected: `(100.0 >= data.temp) && (data.temp >= 0.0)`,
+		},
+		"logic": {
+			rule:     `{"and": [{"==": [{"var": "a"}, 1]}, {"or": [{"var": "b"}, {"!": {"var": "c"}}]}]}`,
+			expected: `(data.a == 1.0) && (data.b || !data.c)`,
+		},
+		"conditions": {
+			rule:     `{"if": [{"<": [{"var": "t"}, 0]}, "ice", {"<": [{"var": "t"}, 100]}, "water", "steam"]}`,
+			expected: `(data.t < 0.0) ? "ice" : ((data.t < 100.0) ? "water" : "steam")`,
+		},
+		"arithmetic": {
+			rule:     `{"+": [{"*": [{"var": "price"}, {"var": "qty"}]}, 1.5, {"-": {"var": "discount"}}]}`,
+			expected: `(data.price * data.qty) + 1.5 + -data.discount`,
+		},
+		"in a list": {
+			rule:     `{"in": [{"var": "country"}, ["PT", "BR"]]}`,
+			expected: `data.country in ["PT", "BR"]`,
+		},
+		"in a string": {
+			rule:     `{"in": ["@", "a@b"]}`,
+			expected: `"a@b".contains("@")`,
+		},
+		"cat": {
+			rule:     `{"cat": ["hello ", {"var": "name"}, 1]}`,
+			expected: `"hello " + string(data.name) + string(1.0)`,
+		},
+		"match": {
+			rule:     `{"match": [{"var": "email"}, "@example\\.com$"]}`,
+			expected: `data.email.matches("@example\\.com$")`,
+		},
+		"regex": {
+			rule:     `{"regex": [{"var": "sku"}, "^SKU-"]}`,
+			expected: `data.sku.matches("^SKU-")`,
+		},
+		"iterations": {
+			rule:     `{"some": [{"var": "items"}, {">": [{"var": "price"}, 100]}]}`,
+			expected: `data.items.exists(x, x.price > 100.0)`,
+		},
+		"nested iterations": {
+			rule:     `{"all": [{"var": "orders"}, {"none": [{"var": "lines"}, {"==": [{"var": ""}, "refund"]}]}]}`,
+			expected: `data.orders.all(x, !x.lines.exists(x1, x1 == "refund"))`,
+		},
+		"map and filter": {
+			rule:     `{"map": [{"filter": [{"var": "l"}, {">": [{"var": ""}, 0]}]}, {"*": [{"var": ""}, 2]}]}`,
+			expected: `data.l.filter(x, x > 0.0).map(x, x * 2.0)`,
+		},
+		"default": {
+			rule:     `{"var": ["user.plan", "free"]}`,
+			expected: `has(data.user) && has(data.user.plan) ? data.user.plan : "free"`,
+		},
+		"indexes and keys": {
+			rule:     `{"var": "items.0.first-name"}`,
+			expected: `data.items[0]["first-name"]`,
+		},
+		"pointer": {
+			rule:     `{"var": "/a.b/0/c~1d"}`,
+			expected: `data["a.b"][0]["c/d"]`,
+		},
+		"whole data": {
+			rule:     `{"var": ""}`,
+			expected: `data`,
+		},
+		"literals": {
+			rule:     `[null, true, 1e21, 0.5, "a\"b"]`,
+			expected: `[null, true, 1e+21, 0.5, "a\"b"]`,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			expression, err := Translate(strings.NewReader(scenario.rule), "data")
+			assert.NoError(t, err)
+			assert.Equal(t, scenario.expected, expression)
+		})
+	}
+}
+
+func TestTranslateUnsupported(t *testing.T) {
+	scenarios := map[string]struct {
+		rule     string
+		expected string
+	}{
+		"operator": {
+			rule:     `{"and": [true, {"reduce": [[1], {"+": [{"var": "current"}, {"var": "accumulator"}]}, 0]}]}`,
+			expected: `/and/1/reduce: operator "reduce" with 3 arguments has no CEL equivalent`,
+		},
+		"modulo": {
+			rule:     `{"%": [{"var": "n"}, 2]}`,
+			expected: `/%: operator "%" with 2 arguments has no CEL equivalent`,
+		},
+		"computed path": {
+			rule:     `{"var": {"cat": ["a", "b"]}}`,
+			expected: `/var: computed paths have no CEL equivalent`,
+		},
+		"negative index": {
+			rule:     `{"var": "items.-1"}`,
+			expected: `/var: negative indexes have no CEL equivalent`,
+		},
+		"default with index": {
+			rule:     `{"var": ["items.0", 1]}`,
+			expected: `/var: defaults of paths with indexes have no CEL equivalent`,
+		},
+		"invalid if": {
+			rule:     `{"if": [true, 1]}`,
+			expected: `/if: if must have conditions with their results, and a last result`,
+		},
+		"several operators": {
+			rule:     `{"var": "a", "cat": ["b"]}`,
+			expected: `/: rules must have a single operator, got 2`,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			_, err := Translate(strings.NewReader(scenario.rule), "data")
+			assert.EqualError(t, err, scenario.expected)
+		})
+	}
+}
+
+func TestTranslateErrors(t *testing.T) {
+	_, err := Translate(strings.NewReader(`{`), "data")
+	assert.Error(t, err)
+
+	_, err = Translate(strings.NewReader(`true`), "in")
+	assert.Error(t, err)
+}
@@ -0,0 +1,619 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// kind is the type of a generated expression. Rules are compiled to typed
+// Go code, so every expression must have a single kind.
+type kind int
+
+const (
+	kindNumber kind = iota + 1
+	kindString
+	kindBool
+	kindList
+)
+
+func (k kind) String() string {
+	switch k {
+	case kindNumber:
+		return "number"
+	case kindString:
+		return "string"
+	case kindBool:
+		return "boolean"
+	case kindList:
+		return "list"
+	}
+
+	return "unknown"
+}
+
+// goType is the Go type used for the results of a kind
+func (k kind) goType() string {
+	switch k {
+	case kindNumber:
+		return "float64"
+	case kindString:
+		return "string"
+	case kindBool:
+		return "bool"
+	}
+
+	return ""
+}
+
+type expr struct {
+	code string
+	kind kind
+
+	// for lists read from the data, the kind of their elements and the
+	// conversion applied to each of them
+	elem    kind
+	convert string
+}
+
+// config describes the function to generate
+type config struct {
+	Package  string
+	Type     string
+	Function string
+	Source   string
+}
+
+type generator struct {
+	types   map[string]ast.Expr
+	root    string
+	imports map[string]bool
+}
+
+// loadTypes parses the Go files of dir, returning the type declarations
+// and the name of the package
+func loadTypes(dir string) (map[string]ast.Expr, string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, "", err
+	}
+
+	types := make(map[string]ast.Expr)
+	pkg := ""
+
+	fset := token.NewFileSet()
+	for _, name := range files {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+
+		source, err := ioutil.ReadFile(name)
+		if err != nil {
+			return nil, "", err
+		}
+
+		file, err := parser.ParseFile(fset, name, source, 0)
+		if err != nil {
+			return nil, "", err
+		}
+
+		pkg = file.Name.Name
+
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+
+			for _, spec := range gen.Specs {
+				spec := spec.(*ast.TypeSpec)
+				types[spec.Name.Name] = spec.Type
+			}
+		}
+	}
+
+	return types, pkg, nil
+}
+
+// generate returns the source of a function evaluating rule against
+// values of the type named in c
+func generate(rule interface{}, types map[string]ast.Expr, c config) ([]byte, error) {
+	if _, ok := types[c.Type]; !ok {
+		return nil, fmt.Errorf("type %s not found", c.Type)
+	}
+
+	g := &generator{types: types, root: c.Type, imports: make(map[string]bool)}
+
+	body, err := g.expr(rule)
+	if err != nil {
+		return nil, err
+	}
+
+	if body.kind == kindList {
+		return nil, fmt.Errorf("rules returning lists are not supported")
+	}
+
+	var out bytes.Buffer
+
+	fmt.Fprintf(&out, "// Code generated by jsonlogicgen from %s; DO NOT EDIT.\n\n", c.Source)
+	fmt.Fprintf(&out, "package %s\n\n", c.Package)
+
+	if len(g.imports) > 0 {
+		imports := make([]string, 0, len(g.imports))
+		for name := range g.imports {
+			imports = append(imports, strconv.Quote(name))
+		}
+
+		sort.Strings(imports)
+
+		fmt.Fprintf(&out, "import (\n%s\n)\n\n", strings.Join(imports, "\n"))
+	}
+
+	fmt.Fprintf(&out, "// %s evaluates the rule of %s against in\n", c.Function, c.Source)
+	fmt.Fprintf(&out, "func %s(in *%s) %s {\n\treturn %s\n}\n", c.Function, c.Type, body.kind.goType(), body.code)
+
+	return format.Source(out.Bytes())
+}
+
+func (g *generator) expr(rule interface{}) (expr, error) {
+	switch value := rule.(type) {
+	case float64:
+		return expr{code: "float64(" + strconv.FormatFloat(value, 'g', -1, 64) + ")", kind: kindNumber}, nil
+	case string:
+		return expr{code: strconv.Quote(value), kind: kindString}, nil
+	case bool:
+		return expr{code: strconv.FormatBool(value), kind: kindBool}, nil
+	case map[string]interface{}:
+		if len(value) != 1 {
+			return expr{}, fmt.Errorf("rules must have a single operator, got %d", len(value))
+		}
+
+		for operator, args := range value {
+			return g.operation(operator, args)
+		}
+	}
+
+	return expr{}, fmt.Errorf("unsupported value %v", rule)
+}
+
+func (g *generator) args(values interface{}) ([]expr, error) {
+	list, ok := values.([]interface{})
+	if !ok {
+		list = []interface{}{values}
+	}
+
+	args := make([]expr, 0, len(list))
+	for _, value := range list {
+		arg, err := g.expr(value)
+		if err != nil {
+			return nil, err
+		}
+
+		args = append(args, arg)
+	}
+
+	return args, nil
+}
+
+// require checks the number and the kind of the arguments of an operator.
+// A kind of 0 accepts anything but lists.
+func require(operator string, args []expr, min, max int, k kind) error {
+	if len(args) < min || (max > 0 && len(args) > max) {
+		return fmt.Errorf("wrong number of arguments for %q: %d", operator, len(args))
+	}
+
+	for _, arg := range args {
+		if (k == 0 && arg.kind == kindList) || (k != 0 && arg.kind != k) {
+			return fmt.Errorf("unsupported %s argument for %q", arg.kind, operator)
+		}
+	}
+
+	return nil
+}
+
+func join(args []expr, separator string) string {
+	codes := make([]string, 0, len(args))
+	for _, arg := range args {
+		codes = append(codes, arg.code)
+	}
+
+	return "(" + strings.Join(codes, separator) + ")"
+}
+
+// truthy returns the code testing the truthiness of e
+func truthy(e expr) string {
+	switch e.kind {
+	case kindNumber:
+		return "(" + e.code + " != 0)"
+	case kindString:
+		return "(" + e.code + ` != "")`
+	case kindList:
+		return "(len(" + e.code + ") > 0)"
+	}
+
+	return e.code
+}
+
+func (g *generator) operation(operator string, values interface{}) (expr, error) {
+	if operator == "var" {
+		return g.variable(values)
+	}
+
+	if operator == "in" {
+		return g.in(values)
+	}
+
+	args, err := g.args(values)
+	if err != nil {
+		return expr{}, err
+	}
+
+	switch operator {
+	case "==", "===", "!=", "!==":
+		if err := require(operator, args, 2, 2, 0); err != nil {
+			return expr{}, err
+		}
+
+		if args[0].kind != args[1].kind {
+			return expr{}, fmt.Errorf("comparing %s with %s is not supported", args[0].kind, args[1].kind)
+		}
+
+		return expr{code: join(args, " "+operator[:2]+" "), kind: kindBool}, nil
+	case "<", "<=", ">", ">=":
+		if err := require(operator, args, 2, 3, kindNumber); err != nil {
+			return expr{}, err
+		}
+
+		if len(args) == 3 {
+			return expr{code: fmt.Sprintf("(%s %s %s && %s %s %s)", args[0].code, operator, args[1].code, args[1].code, operator, args[2].code), kind: kindBool}, nil
+		}
+
+		return expr{code: join(args, " "+operator+" "), kind: kindBool}, nil
+	case "and", "or":
+		if err := require(operator, args, 1, 0, kindBool); err != nil {
+			return expr{}, err
+		}
+
+		separator := " && "
+		if operator == "or" {
+			separator = " || "
+		}
+
+		return expr{code: join(args, separator), kind: kindBool}, nil
+	case "!", "!!":
+		if len(args) != 1 {
+			return expr{}, fmt.Errorf("wrong number of arguments for %q: %d", operator, len(args))
+		}
+
+		if operator == "!" {
+			return expr{code: "!" + truthy(args[0]), kind: kindBool}, nil
+		}
+
+		return expr{code: truthy(args[0]), kind: kindBool}, nil
+	case "if", "?:":
+		return g.conditional(operator, args)
+	case "+", "*":
+		if err := require(operator, args, 1, 0, kindNumber); err != nil {
+			return expr{}, err
+		}
+
+		return expr{code: join(args, " "+operator+" "), kind: kindNumber}, nil
+	case "-", "/":
+		if err := require(operator, args, 1, 2, kindNumber); err != nil {
+			return expr{}, err
+		}
+
+		if len(args) == 1 && operator == "-" {
+			return expr{code: "(-" + args[0].code + ")", kind: kindNumber}, nil
+		}
+
+		return expr{code: join(args, " "+operator+" "), kind: kindNumber}, nil
+	case "%":
+		if err := require(operator, args, 2, 2, kindNumber); err != nil {
+			return expr{}, err
+		}
+
+		g.imports["math"] = true
+
+		return expr{code: fmt.Sprintf("math.Mod(%s, %s)", args[0].code, args[1].code), kind: kindNumber}, nil
+	case "min", "max":
+		if err := require(operator, args, 1, 0, kindNumber); err != nil {
+			return expr{}, err
+		}
+
+		g.imports["math"] = true
+
+		function := "math.Min"
+		if operator == "max" {
+			function = "math.Max"
+		}
+
+		code := args[0].code
+		for _, arg := range args[1:] {
+			code = fmt.Sprintf("%s(%s, %s)", function, code, arg.code)
+		}
+
+		return expr{code: code, kind: kindNumber}, nil
+	case "cat":
+		if err := require(operator, args, 1, 0, 0); err != nil {
+			return expr{}, err
+		}
+
+		g.imports["strings"] = true
+
+		parts := make([]expr, 0, len(args))
+		for _, arg := range args {
+			switch arg.kind {
+			case kindNumber:
+				g.imports["strconv"] = true
+				arg.code = fmt.Sprintf("strconv.FormatFloat(%s, 'f', -1, 64)", arg.code)
+			case kindBool:
+				return expr{}, fmt.Errorf("unsupported boolean argument for %q", operator)
+			}
+
+			parts = append(parts, arg)
+		}
+
+		return expr{code: "strings.TrimSpace" + join(parts, " + "), kind: kindString}, nil
+	}
+
+	return expr{}, fmt.Errorf("operator %q is not supported", operator)
+}
+
+func (g *generator) conditional(operator string, args []expr) (expr, error) {
+	if len(args) < 3 || len(args)%2 == 0 {
+		return expr{}, fmt.Errorf("%q needs a condition, a result and a default", operator)
+	}
+
+	result := args[1].kind
+	if result == kindList {
+		return expr{}, fmt.Errorf("unsupported list result for %q", operator)
+	}
+
+	var code strings.Builder
+
+	fmt.Fprintf(&code, "func() %s {\n", result.goType())
+	for i := 0; i < len(args)-1; i += 2 {
+		if args[i+1].kind != result {
+			return expr{}, fmt.Errorf("results of %q have different types: %s and %s", operator, result, args[i+1].kind)
+		}
+
+		fmt.Fprintf(&code, "if %s {\nreturn %s\n}\n", truthy(args[i]), args[i+1].code)
+	}
+
+	last := args[len(args)-1]
+	if last.kind != result {
+		return expr{}, fmt.Errorf("results of %q have different types: %s and %s", operator, result, last.kind)
+	}
+
+	fmt.Fprintf(&code, "return %s\n}()", last.code)
+
+	return expr{code: code.String(), kind: result}, nil
+}
+
+func (g *generator) in(values interface{}) (expr, error) {
+	list, ok := values.([]interface{})
+	if !ok || len(list) != 2 {
+		return expr{}, fmt.Errorf("%q needs a value and a list or a string", "in")
+	}
+
+	needle, err := g.expr(list[0])
+	if err != nil {
+		return expr{}, err
+	}
+
+	if needle.kind == kindList {
+		return expr{}, fmt.Errorf("unsupported list argument for %q", "in")
+	}
+
+	if literals, ok := list[1].([]interface{}); ok {
+		if len(literals) == 0 {
+			return expr{code: "false", kind: kindBool}, nil
+		}
+
+		tests := make([]expr, 0, len(literals))
+		for _, literal := range literals {
+			element, err := g.expr(literal)
+			if err != nil {
+				return expr{}, err
+			}
+
+			if element.kind != needle.kind {
+				return expr{}, fmt.Errorf("looking for a %s among %s values is not supported", needle.kind, element.kind)
+			}
+
+			tests = append(tests, expr{code: needle.code + " == " + element.code})
+		}
+
+		return expr{code: join(tests, " || "), kind: kindBool}, nil
+	}
+
+	haystack, err := g.expr(list[1])
+	if err != nil {
+		return expr{}, err
+	}
+
+	switch {
+	case haystack.kind == kindString && needle.kind == kindString:
+		g.imports["strings"] = true
+
+		return expr{code: fmt.Sprintf("strings.Contains(%s, %s)", haystack.code, needle.code), kind: kindBool}, nil
+	case haystack.kind == kindList && haystack.elem == needle.kind:
+		code := fmt.Sprintf("func() bool {\nfor _, v := range %s {\nif %s == %s {\nreturn true\n}\n}\nreturn false\n}()", haystack.code, convert("v", haystack.convert), needle.code)
+
+		return expr{code: code, kind: kindBool}, nil
+	}
+
+	return expr{}, fmt.Errorf("looking for a %s in a %s is not supported", needle.kind, haystack.kind)
+}
+
+func convert(code, conversion string) string {
+	if conversion == "" {
+		return code
+	}
+
+	return conversion + "(" + code + ")"
+}
+
+// variable compiles a var to the access of a field of in, found through
+// the names used by encoding/json
+func (g *generator) variable(values interface{}) (expr, error) {
+	path, ok := values.(string)
+	if list, isList := values.([]interface{}); isList && len(list) == 1 {
+		path, ok = list[0].(string)
+	}
+
+	if !ok || path == "" {
+		return expr{}, fmt.Errorf("unsupported var %v: only paths to fields are supported", values)
+	}
+
+	code := "in"
+	current := ast.Expr(&ast.Ident{Name: g.root})
+
+	for _, name := range strings.Split(path, ".") {
+		fields, err := g.structFields(current)
+		if err != nil {
+			return expr{}, fmt.Errorf("var %q: %w", path, err)
+		}
+
+		field, ok := fields[name]
+		if !ok {
+			return expr{}, fmt.Errorf("var %q: no field for %q", path, name)
+		}
+
+		code += "." + field.name
+		current = field.typ
+	}
+
+	k, conversion, elem, elemConversion, err := g.classify(current)
+	if err != nil {
+		return expr{}, fmt.Errorf("var %q: %w", path, err)
+	}
+
+	return expr{code: convert(code, conversion), kind: k, elem: elem, convert: elemConversion}, nil
+}
+
+type field struct {
+	name string
+	typ  ast.Expr
+}
+
+// structFields returns the fields of a struct by their JSON name
+func (g *generator) structFields(typ ast.Expr) (map[string]field, error) {
+	for {
+		ident, ok := typ.(*ast.Ident)
+		if !ok {
+			break
+		}
+
+		resolved, ok := g.types[ident.Name]
+		if !ok {
+			return nil, fmt.Errorf("%s is not a struct", ident.Name)
+		}
+
+		typ = resolved
+	}
+
+	structType, ok := typ.(*ast.StructType)
+	if !ok {
+		return nil, fmt.Errorf("only structs have fields")
+	}
+
+	fields := make(map[string]field)
+	for _, f := range structType.Fields.List {
+		jsonName := ""
+		if f.Tag != nil {
+			tag, err := strconv.Unquote(f.Tag.Value)
+			if err != nil {
+				return nil, err
+			}
+
+			jsonName = strings.Split(reflect.StructTag(tag).Get("json"), ",")[0]
+		}
+
+		if jsonName == "-" {
+			continue
+		}
+
+		for _, name := range f.Names {
+			if !name.IsExported() {
+				continue
+			}
+
+			key := jsonName
+			if key == "" {
+				key = name.Name
+			}
+
+			fields[key] = field{name: name.Name, typ: f.Type}
+		}
+	}
+
+	return fields, nil
+}
+
+var numberTypes = map[string]bool{
+	"int": true, "int8": true, "int16": true, "int32": true, "int64": true,
+	"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true,
+	"float32": true, "float64": true,
+}
+
+// classify returns the kind of the values of a Go type, and the conversion
+// needed to get them as the Go type of the kind
+func (g *generator) classify(typ ast.Expr) (kind, string, kind, string, error) {
+	if array, ok := typ.(*ast.ArrayType); ok {
+		elem, conversion, _, _, err := g.classify(array.Elt)
+		if err != nil {
+			return 0, "", 0, "", err
+		}
+
+		if elem == kindList {
+			return 0, "", 0, "", fmt.Errorf("nested lists are not supported")
+		}
+
+		return kindList, "", elem, conversion, nil
+	}
+
+	ident, ok := typ.(*ast.Ident)
+	if !ok {
+		return 0, "", 0, "", fmt.Errorf("unsupported field type")
+	}
+
+	var k kind
+
+	switch {
+	case numberTypes[ident.Name]:
+		k = kindNumber
+	case ident.Name == "string":
+		k = kindString
+	case ident.Name == "bool":
+		k = kindBool
+	default:
+		resolved, ok := g.types[ident.Name]
+		if !ok {
+			return 0, "", 0, "", fmt.Errorf("unsupported field type %s", ident.Name)
+		}
+
+		k, _, elem, conversion, err := g.classify(resolved)
+		if err != nil || k == kindList {
+			return k, "", elem, conversion, err
+		}
+
+		return k, k.goType(), 0, "", nil
+	}
+
+	if ident.Name == k.goType() {
+		return k, "", 0, "", nil
+	}
+
+	return k, k.goType(), 0, "", nil
+}
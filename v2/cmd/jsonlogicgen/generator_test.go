@@ -0,0 +1,103 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateMatchesGoldenFiles(t *testing.T) {
+	dir := filepath.Join("internal", "example")
+
+	types, pkg, err := loadTypes(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := map[string]string{
+		"adult.json":    "IsAdult",
+		"discount.json": "Discount",
+		"greeting.json": "Greeting",
+		"eligible.json": "IsEligible",
+	}
+
+	for file, function := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", file), func(t *testing.T) {
+			source, err := ioutil.ReadFile(filepath.Join(dir, file))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var rule interface{}
+			if err := json.Unmarshal(source, &rule); err != nil {
+				t.Fatal(err)
+			}
+
+			code, err := generate(rule, types, config{Package: pkg, Type: "Person", Function: function, Source: file})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			golden, err := ioutil.ReadFile(filepath.Join(dir, file[:len(file)-len(".json")]+"_gen.go"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, string(golden), string(code), "run go generate ./... to update the golden files")
+		})
+	}
+}
+
+func TestGenerateRejectsUntypedRules(t *testing.T) {
+	types, _, err := loadTypes(filepath.Join("internal", "example"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := map[string]struct {
+		Rule     string
+		Expected string
+	}{
+		"unknown field": {
+			Rule:     `{"var": "email"}`,
+			Expected: `var "email": no field for "email"`,
+		},
+		"ignored field": {
+			Rule:     `{"var": "Secret"}`,
+			Expected: `var "Secret": no field for "Secret"`,
+		},
+		"property of a string": {
+			Rule:     `{"var": "name.first"}`,
+			Expected: `var "name.first": string is not a struct`,
+		},
+		"comparison of different types": {
+			Rule:     `{"==": [{"var": "age"}, "18"]}`,
+			Expected: `comparing number with string is not supported`,
+		},
+		"results of different types": {
+			Rule:     `{"if": [{"var": "member"}, "yes", 0]}`,
+			Expected: `results of "if" have different types: string and number`,
+		},
+		"unsupported operator": {
+			Rule:     `{"map": [{"var": "tags"}, "tag"]}`,
+			Expected: `operator "map" is not supported`,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var rule interface{}
+			if err := json.Unmarshal([]byte(scenario.Rule), &rule); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := generate(rule, types, config{Package: "example", Type: "Person", Function: "Rule", Source: "rule.json"})
+
+			assert.EqualError(t, err, scenario.Expected)
+		})
+	}
+}
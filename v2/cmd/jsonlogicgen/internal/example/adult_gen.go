@@ -0,0 +1,8 @@
+// Code generated by jsonlogicgen from adult.json; DO NOT EDIT.
+
+package example
+
+// IsAdult evaluates the rule of adult.json against in
+func IsAdult(in *Person) bool {
+	return (float64(in.Age) >= float64(18))
+}
@@ -0,0 +1,15 @@
+// Code generated by jsonlogicgen from eligible.json; DO NOT EDIT.
+
+package example
+
+// IsEligible evaluates the rule of eligible.json against in
+func IsEligible(in *Person) bool {
+	return ((float64(18) <= float64(in.Age) && float64(in.Age) <= float64(65)) && (in.Address.Country == "PT" || in.Address.Country == "BR" || in.Address.Country == "FR") && !func() bool {
+		for _, v := range in.Tags {
+			if v == "banned" {
+				return true
+			}
+		}
+		return false
+	}() && (string(in.Status) != "inactive") && (in.Member || (in.Address.Zip != "")))
+}
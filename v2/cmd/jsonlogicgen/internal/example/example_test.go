@@ -0,0 +1,64 @@
+package example
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"testing"
+
+	"github.com/bewica/jsonlogic/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+var people = map[string]Person{
+	"member with a high score": {Name: "Ana", Age: 34, Score: 95, Member: true, Status: "active", Tags: []string{"vip"}, Address: Address{Country: "PT", Zip: "1000"}},
+	"member":                   {Name: "Bruno", Age: 70, Score: 40, Member: true, Status: "active", Tags: []string{}, Address: Address{Country: "BR"}},
+	"minor":                    {Name: "Carla", Age: 12, Score: 99, Status: "active", Tags: []string{}, Address: Address{Country: "FR", Zip: "75001"}},
+	"banned":                   {Name: "Diego", Age: 40, Status: "active", Tags: []string{"new", "banned"}, Address: Address{Country: "PT", Zip: "4000"}},
+	"inactive":                 {Name: "Eva", Age: 18, Member: true, Status: "inactive", Tags: []string{}, Address: Address{Country: "FR"}},
+	"abroad":                   {Name: " Fred", Age: 30, Status: "active", Tags: []string{}, Address: Address{Country: "US", Zip: "10001"}},
+	"without zip":              {Name: "Gil", Age: 65, Status: "active", Tags: []string{}, Address: Address{Country: "BR"}},
+}
+
+// interpret applies a rule file to the JSON form of a person
+func interpret(t *testing.T, file string, person Person) interface{} {
+	source, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var rule interface{}
+	if err := json.Unmarshal(source, &rule); err != nil {
+		t.Fatal(err)
+	}
+
+	encoded, err := json.Marshal(person)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var data interface{}
+	if err := json.Unmarshal(encoded, &data); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := jsonlogic.ApplyInterface(rule, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return result
+}
+
+func TestGeneratedCodeMatchesInterpreter(t *testing.T) {
+	for name, person := range people {
+		person := person
+
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			assert.Equal(t, interpret(t, "adult.json", person), IsAdult(&person))
+			assert.Equal(t, interpret(t, "discount.json", person), Discount(&person))
+			assert.Equal(t, interpret(t, "greeting.json", person), Greeting(&person))
+			assert.Equal(t, interpret(t, "eligible.json", person), IsEligible(&person))
+		})
+	}
+}
@@ -0,0 +1,26 @@
+// Package example holds rules compiled by jsonlogicgen, whose generated
+// code is checked against the interpreter
+package example
+
+//go:generate go run github.com/bewica/jsonlogic/v2/cmd/jsonlogicgen -rule adult.json -type Person -func IsAdult -o adult_gen.go
+//go:generate go run github.com/bewica/jsonlogic/v2/cmd/jsonlogicgen -rule discount.json -type Person -func Discount -o discount_gen.go
+//go:generate go run github.com/bewica/jsonlogic/v2/cmd/jsonlogicgen -rule greeting.json -type Person -func Greeting -o greeting_gen.go
+//go:generate go run github.com/bewica/jsonlogic/v2/cmd/jsonlogicgen -rule eligible.json -type Person -func IsEligible -o eligible_gen.go
+
+type Status string
+
+type Address struct {
+	Country string `json:"country"`
+	Zip     string `json:"zip"`
+}
+
+type Person struct {
+	Name    string   `json:"name"`
+	Age     int      `json:"age"`
+	Score   float64  `json:"score"`
+	Member  bool     `json:"member"`
+	Status  Status   `json:"status"`
+	Tags    []string `json:"tags"`
+	Address Address  `json:"address"`
+	Secret  string   `json:"-"`
+}
@@ -0,0 +1,75 @@
+// Command jsonlogicgen compiles a JSON Logic rule to a Go function
+// evaluating it against a struct, for hot paths where interpreting the
+// rule is too slow:
+//
+//	jsonlogicgen -rule adult.json -type Person -func IsAdult -o adult_gen.go
+//
+// The struct is looked up in the Go files of the current directory, and
+// var paths are resolved through the names its fields have in JSON. The
+// generated code is typed: rules comparing or combining values of
+// different types, and operators without a typed equivalent, are
+// rejected at generation time.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	rule := flag.String("rule", "", "file with the rule to compile")
+	typ := flag.String("type", "", "name of the struct the rule is applied to")
+	function := flag.String("func", "", "name of the function to generate")
+	dir := flag.String("dir", ".", "directory of the package declaring the struct")
+	output := flag.String("o", "", "file to write, instead of the standard output")
+	flag.Parse()
+
+	if *rule == "" || *typ == "" || *function == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(*rule, *typ, *function, *dir, *output); err != nil {
+		fmt.Fprintln(os.Stderr, "jsonlogicgen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(ruleFile, typ, function, dir, output string) error {
+	source, err := ioutil.ReadFile(ruleFile)
+	if err != nil {
+		return err
+	}
+
+	var rule interface{}
+	if err := json.Unmarshal(source, &rule); err != nil {
+		return fmt.Errorf("error parsing rule: %w", err)
+	}
+
+	types, pkg, err := loadTypes(dir)
+	if err != nil {
+		return err
+	}
+
+	code, err := generate(rule, types, config{
+		Package:  pkg,
+		Type:     typ,
+		Function: function,
+		Source:   filepath.Base(ruleFile),
+	})
+	if err != nil {
+		return err
+	}
+
+	if output == "" {
+		_, err = os.Stdout.Write(code)
+
+		return err
+	}
+
+	return ioutil.WriteFile(output, code, 0644)
+}
@@ -0,0 +1,27 @@
+// Loads jsonlogic.wasm, built from this directory, and returns the
+// functions it exposes, throwing the errors instead of returning them.
+// wasm_exec.js, from the Go distribution used for the build, must be
+// loaded first.
+//
+//   const jsonlogic = await loadJSONLogic("jsonlogic.wasm");
+//   jsonlogic.apply({"var": "a"}, {a: 1}); // 1
+async function loadJSONLogic(url) {
+  const go = new Go();
+  const { instance } = await WebAssembly.instantiateStreaming(fetch(url), go.importObject);
+  go.run(instance);
+
+  return {
+    apply(rule, data) {
+      const { result, error } = globalThis.jsonlogic.apply(rule, data);
+      if (error !== undefined) {
+        throw new Error(error);
+      }
+
+      return result;
+    },
+
+    isValid(rule) {
+      return globalThis.jsonlogic.isValid(rule);
+    },
+  };
+}
@@ -0,0 +1,82 @@
+//go:build js && wasm
+// +build js,wasm
+
+// Command jsonlogicwasm exposes the evaluation to JavaScript, so rules can
+// be previewed in a browser with the same engine running on the servers.
+// Build it with:
+//
+//	GOOS=js GOARCH=wasm go build -o jsonlogic.wasm ./cmd/jsonlogicwasm
+//
+// and load it with the wasm_exec.js file of the Go distribution, or with
+// jsonlogic.js which wraps both. Once running, it defines a global
+// jsonlogic object:
+//
+//	jsonlogic.apply(rule, data)  // {result: ...} or {error: "..."}
+//	jsonlogic.isValid(rule)      // true or false
+//
+// Rules and data are JavaScript values or JSON strings.
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"syscall/js"
+
+	"github.com/bewica/jsonlogic/v2"
+)
+
+func main() {
+	register(js.Global())
+
+	// the functions must stay around for JavaScript to call them
+	select {}
+}
+
+func register(global js.Value) {
+	global.Set("jsonlogic", map[string]interface{}{
+		"apply":   js.FuncOf(apply),
+		"isValid": js.FuncOf(isValid),
+	})
+}
+
+// apply evaluates args[0] against args[1], returning {result} or {error}
+func apply(this js.Value, args []js.Value) interface{} {
+	if len(args) == 0 {
+		return failure("a rule is required")
+	}
+
+	data := "{}"
+	if len(args) > 1 && args[1].Type() != js.TypeUndefined && args[1].Type() != js.TypeNull {
+		data = toJSON(args[1])
+	}
+
+	result, err := jsonlogic.ApplyRaw(json.RawMessage(toJSON(args[0])), json.RawMessage(data))
+	if err != nil {
+		return failure(err.Error())
+	}
+
+	return map[string]interface{}{
+		"result": js.Global().Get("JSON").Call("parse", string(result)),
+	}
+}
+
+func isValid(this js.Value, args []js.Value) interface{} {
+	if len(args) == 0 {
+		return false
+	}
+
+	return jsonlogic.IsValid(strings.NewReader(toJSON(args[0])))
+}
+
+// toJSON returns the JSON of a value, which strings already are
+func toJSON(value js.Value) string {
+	if value.Type() == js.TypeString {
+		return value.String()
+	}
+
+	return js.Global().Get("JSON").Call("stringify", value).String()
+}
+
+func failure(message string) interface{} {
+	return map[string]interface{}{"error": message}
+}
@@ -0,0 +1,67 @@
+//go:build js && wasm
+// +build js,wasm
+
+package main
+
+import (
+	"fmt"
+	"syscall/js"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApply(t *testing.T) {
+	register(js.Global())
+	object := js.Global().Get("Object")
+
+	scenarios := map[string]struct {
+		rule     interface{}
+		data     interface{}
+		expected string
+		error    bool
+	}{
+		"json strings": {
+			rule:     `{"var": "a"}`,
+			data:     `{"a": 1}`,
+			expected: `1`,
+		},
+		"javascript values": {
+			rule:     map[string]interface{}{"cat": []interface{}{"hello ", map[string]interface{}{"var": "name"}}},
+			data:     map[string]interface{}{"name": "Ana"},
+			expected: `"hello Ana"`,
+		},
+		"no data": {
+			rule:     `{"missing": ["a"]}`,
+			data:     js.Undefined(),
+			expected: `["a"]`,
+		},
+		"invalid rule": {
+			rule:  `{"var": `,
+			data:  `{}`,
+			error: true,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			result := js.Global().Get("jsonlogic").Call("apply", scenario.rule, scenario.data)
+
+			if scenario.error {
+				assert.Equal(t, js.TypeString, result.Get("error").Type())
+
+				return
+			}
+
+			assert.True(t, object.Call("hasOwn", result, "result").Bool())
+			assert.JSONEq(t, scenario.expected, js.Global().Get("JSON").Call("stringify", result.Get("result")).String())
+		})
+	}
+}
+
+func TestIsValid(t *testing.T) {
+	register(js.Global())
+
+	assert.True(t, js.Global().Get("jsonlogic").Call("isValid", `{"var": "a"}`).Bool())
+	assert.False(t, js.Global().Get("jsonlogic").Call("isValid", map[string]interface{}{"nope": 1}).Bool())
+}
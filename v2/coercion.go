@@ -0,0 +1,181 @@
+package jsonlogic
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrTypeMismatch is returned, wrapped, by engines using StrictCoercion
+// when an operator gets values of types it doesn't combine
+var ErrTypeMismatch = errors.New("type mismatch")
+
+// CoercionProfile is the way comparisons and arithmetic convert the values
+// they get
+type CoercionProfile int
+
+const (
+	// SpecCoercion converts values like the JavaScript implementation of
+	// JSON Logic: "1" == 1, "10" < "9" and "2" * 3 is 6. It is the default.
+	SpecCoercion CoercionProfile = iota
+
+	// StrictCoercion doesn't convert values: comparing values of different
+	// types, ordering values other than numbers and strings, or computing
+	// with values other than numbers fails with ErrTypeMismatch. Null only
+	// equals null.
+	StrictCoercion
+
+	// StrongNumericCoercion never converts between strings and numbers in
+	// comparisons: "1" == 1 is false, and so are "1" < 2 and "1" >= 0.
+	// Everything else follows SpecCoercion.
+	StrongNumericCoercion
+
+	// CompatJS reproduces the answers of json-logic-js, quirks included,
+	// for deployments evaluating the same rules in JavaScript and Go: "0" ==
+	// false, [] is falsy but {} and "0" are truthy, and and or return one
+	// of their values, + reads numbers with parseFloat ("3abc" + 1 is 4),
+	// cat writes numbers and arrays like JavaScript, in finds elements by
+	// strict equality, and so on. The iterators use the same truthiness,
+	// map keeps every result and reduce doesn't convert its accumulator.
+	// See the readme for the remaining divergences.
+	CompatJS
+)
+
+var coercionProfiles = []string{"spec", "strict", "strong-numeric", "compat-js"}
+
+// String returns the name of the profile: "spec", "strict",
+// "strong-numeric" or "compat-js"
+func (p CoercionProfile) String() string {
+	if p < SpecCoercion || p > CompatJS {
+		return fmt.Sprintf("CoercionProfile(%d)", int(p))
+	}
+
+	return coercionProfiles[p]
+}
+
+// ParseCoercionProfile returns the profile with the given name, to choose
+// it from configuration files
+func ParseCoercionProfile(name string) (CoercionProfile, error) {
+	for i, profile := range coercionProfiles {
+		if profile == name {
+			return CoercionProfile(i), nil
+		}
+	}
+
+	return SpecCoercion, fmt.Errorf("unknown coercion profile %q", name)
+}
+
+// WithCoercion chooses how the comparison operators (==, !=, <, <=, > and
+// >=) and the arithmetic operators convert the values they get. === and
+// !== never convert values. CompatJS changes the logical operators, cat
+// and in too.
+func WithCoercion(profile CoercionProfile) Option {
+	return func(e *Engine) error {
+		if profile < SpecCoercion || profile > CompatJS {
+			return fmt.Errorf("unknown coercion profile %d", profile)
+		}
+
+		e.coercion = profile
+
+		return nil
+	}
+}
+
+var coercedComparisons = map[string]bool{
+	"==": true,
+	"!=": true,
+	"<":  true,
+	"<=": true,
+	">":  true,
+	">=": true,
+}
+
+// coerce evaluates the operators whose result depends on the coercion
+// profile of the engine, telling if it did. Arithmetic is only checked,
+// and left to the operators.
+func (ev *evaluator) coerce(operator string, values interface{}) (interface{}, bool) {
+	if ev.engine.coercion == CompatJS {
+		return ev.compatJS(operator, values)
+	}
+
+	if arithmeticOperators[operator] {
+		if ev.engine.coercion == StrictCoercion {
+			for _, value := range toSlice(values) {
+				if !isNumber(value) {
+					ev.fail(fmt.Errorf("%w: %s of %s %v", ErrTypeMismatch, operator, typeOf(value), value))
+				}
+			}
+		}
+
+		return nil, false
+	}
+
+	parsed, ok := values.([]interface{})
+	if !coercedComparisons[operator] || !ok {
+		return nil, false
+	}
+
+	switch {
+	case len(parsed) == 2:
+		return ev.compareCoerced(operator, parsed[0], parsed[1]), true
+	case len(parsed) == 3 && operator != "==" && operator != "!=":
+		return ev.chained(operator, parsed), true
+	}
+
+	return nil, false
+}
+
+func (ev *evaluator) compareCoerced(operator string, a, b interface{}) bool {
+	switch operator {
+	case "==":
+		return ev.equalsCoerced(a, b)
+	case "!=":
+		return !ev.equalsCoerced(a, b)
+	}
+
+	if ev.engine.coercion == StrictCoercion && !(isNumber(a) && isNumber(b)) && !(isString(a) && isString(b)) {
+		ev.fail(fmt.Errorf("%w: %s of %s %v and %s %v", ErrTypeMismatch, operator, typeOf(a), a, typeOf(b), b))
+	}
+
+	if mixesStringsAndNumbers(a, b) {
+		return false
+	}
+
+	switch operator {
+	case "<":
+		return ev.less(a, b)
+	case ">":
+		return ev.less(b, a)
+	case "<=":
+		return ev.less(a, b) || ev.equals(a, b)
+	}
+
+	return ev.less(b, a) || ev.equals(a, b)
+}
+
+func (ev *evaluator) equalsCoerced(a, b interface{}) bool {
+	if ev.engine.coercion == StrictCoercion {
+		if a == nil || b == nil {
+			return a == b
+		}
+
+		if typeOf(a) != typeOf(b) {
+			ev.fail(fmt.Errorf("%w: comparing %s %v with %s %v", ErrTypeMismatch, typeOf(a), a, typeOf(b), b))
+		}
+
+		if isString(a) || isNumber(a) {
+			return ev.equals(a, b)
+		}
+
+		return deepEquals(a, b)
+	}
+
+	if mixesStringsAndNumbers(a, b) {
+		return false
+	}
+
+	return ev.equals(a, b)
+}
+
+func mixesStringsAndNumbers(a, b interface{}) bool {
+	return (isString(a) && isNumber(b)) || (isNumber(a) && isString(b))
+}
@@ -0,0 +1,104 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoercionProfiles(t *testing.T) {
+	scenarios := map[string]struct {
+		Profile  CoercionProfile
+		Rule     string
+		Expected string
+	}{
+		"spec equality":                  {SpecCoercion, `{"==": ["1", 1]}`, `true`},
+		"spec ordering":                  {SpecCoercion, `{"<": ["1", 2]}`, `true`},
+		"spec arithmetic":                {SpecCoercion, `{"*": ["2", 3]}`, `6`},
+		"strict equality":                {StrictCoercion, `{"==": [1, 1]}`, `true`},
+		"strict inequality":              {StrictCoercion, `{"!=": ["a", "b"]}`, `true`},
+		"strict null":                    {StrictCoercion, `{"==": [{"var": "missing"}, null]}`, `true`},
+		"strict null with a value":       {StrictCoercion, `{"!=": [{"var": "missing"}, 0]}`, `true`},
+		"strict lists":                   {StrictCoercion, `{"==": [{"merge": [1, 2]}, {"merge": [1, 2]}]}`, `true`},
+		"strict ordering":                {StrictCoercion, `{"<=": ["a", "b"]}`, `true`},
+		"strict between":                 {StrictCoercion, `{"<": [1, 2, 3]}`, `true`},
+		"strict arithmetic":              {StrictCoercion, `{"+": [1, 2]}`, `3`},
+		"strong-numeric equality":        {StrongNumericCoercion, `{"==": ["1", 1]}`, `false`},
+		"strong-numeric inequality":      {StrongNumericCoercion, `{"!=": [1, "1"]}`, `true`},
+		"strong-numeric ordering":        {StrongNumericCoercion, `{">=": ["1", 0]}`, `false`},
+		"strong-numeric between":         {StrongNumericCoercion, `{"<=": [1, "2", 3]}`, `false`},
+		"strong-numeric same types":      {StrongNumericCoercion, `{"<": [9, 10]}`, `true`},
+		"strong-numeric booleans":        {StrongNumericCoercion, `{"==": [true, 1]}`, `true`},
+		"strong-numeric arithmetic":      {StrongNumericCoercion, `{"*": ["2", 3]}`, `6`},
+		"strong-numeric strict equality": {StrongNumericCoercion, `{"===": [1, 1]}`, `true`},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			engine, err := NewEngine(WithCoercion(scenario.Profile))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var result bytes.Buffer
+
+			err = engine.Apply(strings.NewReader(scenario.Rule), strings.NewReader(`{}`), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, result.String())
+		})
+	}
+}
+
+func TestStrictCoercionMismatches(t *testing.T) {
+	engine, err := NewEngine(WithCoercion(StrictCoercion))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := map[string]struct {
+		Rule     string
+		Expected string
+	}{
+		"equality":   {`{"==": ["1", 1]}`, `type mismatch: comparing string 1 with number 1`},
+		"ordering":   {`{"<": [true, 2]}`, `type mismatch: < of boolean true and number 2`},
+		"null":       {`{">": [{"var": "missing"}, 0]}`, `type mismatch: > of null <nil> and number 0`},
+		"between":    {`{"<": [1, "2", 3]}`, `type mismatch: < of number 1 and string 2`},
+		"arithmetic": {`{"*": ["2", 3]}`, `type mismatch: * of string 2`},
+		"unary":      {`{"-": "2"}`, `type mismatch: - of string 2`},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var result bytes.Buffer
+
+			err := engine.Apply(strings.NewReader(scenario.Rule), strings.NewReader(`{}`), &result)
+			assert.True(t, errors.Is(err, ErrTypeMismatch))
+			assert.Contains(t, err.Error(), scenario.Expected)
+		})
+	}
+}
+
+func TestParseCoercionProfile(t *testing.T) {
+	for _, profile := range []CoercionProfile{SpecCoercion, StrictCoercion, StrongNumericCoercion, CompatJS} {
+		parsed, err := ParseCoercionProfile(profile.String())
+		assert.NoError(t, err)
+		assert.Equal(t, profile, parsed)
+	}
+
+	_, err := ParseCoercionProfile("loose")
+	assert.Error(t, err)
+
+	assert.Equal(t, "CoercionProfile(7)", CoercionProfile(7).String())
+}
+
+func TestWithCoercion(t *testing.T) {
+	_, err := NewEngine(WithCoercion(CoercionProfile(4)))
+	assert.Error(t, err)
+}
@@ -0,0 +1,149 @@
+package jsonlogic
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+// collation compares strings following the conventions of a language.
+// Collators can't be used concurrently, so they are pooled.
+type collation struct {
+	tag language.Tag
+
+	// ordering and insensitive tell which of <, >, sort and of == and !=
+	// use the collation
+	ordering    bool
+	insensitive bool
+
+	collators    sync.Pool
+	insensitives sync.Pool
+}
+
+func newCollation() *collation {
+	c := &collation{tag: language.Und}
+	c.collators.New = func() interface{} {
+		return collate.New(c.tag)
+	}
+	c.insensitives.New = func() interface{} {
+		return collate.New(c.tag, collate.IgnoreCase, collate.IgnoreDiacritics, collate.IgnoreWidth)
+	}
+
+	return c
+}
+
+// WithCollation makes <, <=, >, >=, between and sort order strings
+// following the conventions of a language, given as a BCP 47 tag like
+// "fr" or "sv-SE", instead of comparing their bytes: "é" then comes
+// between "e" and "f", and "Z" after "a".
+func WithCollation(locale string) Option {
+	return func(e *Engine) error {
+		tag, err := language.Parse(locale)
+		if err != nil {
+			return fmt.Errorf("invalid collation locale %q: %w", locale, err)
+		}
+
+		if e.collation == nil {
+			e.collation = newCollation()
+		}
+
+		e.collation.tag = tag
+		e.collation.ordering = true
+
+		return nil
+	}
+}
+
+// WithInsensitiveEquality makes == and != ignore the case, diacritics
+// and width of strings: "Élan" == "elan". It follows the language given
+// to WithCollation, if any. === and !== still compare strings exactly.
+func WithInsensitiveEquality() Option {
+	return func(e *Engine) error {
+		if e.collation == nil {
+			e.collation = newCollation()
+		}
+
+		e.collation.insensitive = true
+
+		return nil
+	}
+}
+
+func (c *collation) compare(a, b string) int {
+	collator := c.collators.Get().(*collate.Collator)
+	defer c.collators.Put(collator)
+
+	return collator.CompareString(a, b)
+}
+
+func (c *collation) equal(a, b string) bool {
+	collator := c.insensitives.Get().(*collate.Collator)
+	defer c.insensitives.Put(collator)
+
+	return collator.CompareString(a, b) == 0
+}
+
+// less is less, comparing strings with the collation of the engine and
+// numbers within its epsilon
+func (ev *evaluator) less(a, b interface{}) bool {
+	if ev.approximate(a, b) {
+		return ev.nearlyLess(toNumber(a), toNumber(b))
+	}
+
+	if c := ev.engine.collation; c != nil && c.ordering && !(isNumber(a) && isNumber(b)) {
+		return c.compare(toString(a), toString(b)) < 0
+	}
+
+	return less(a, b)
+}
+
+// equals is equals, comparing strings with the collation of the engine
+// and numbers within its epsilon
+func (ev *evaluator) equals(a, b interface{}) bool {
+	if ev.approximate(a, b) {
+		return ev.nearlyEquals(toNumber(a), toNumber(b))
+	}
+
+	if c := ev.engine.collation; c != nil && c.insensitive && isString(a) && isString(b) {
+		return c.equal(a.(string), b.(string))
+	}
+
+	return equals(a, b)
+}
+
+// sortValues is sortValues, ordering strings with the collation of the
+// engine
+func (ev *evaluator) sortValues(values interface{}) interface{} {
+	list, args := ev.listArgs("sort", values)
+
+	c := ev.engine.collation
+	if c == nil || !c.ordering {
+		return sortValues(list, args)
+	}
+
+	descending := len(args) > 0 && args[0] == "desc"
+
+	sorted := make([]interface{}, len(list))
+	copy(sorted, list)
+
+	order := func(a, b interface{}) int {
+		if isString(a) && isString(b) {
+			return c.compare(a.(string), b.(string))
+		}
+
+		return compare(a, b)
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if descending {
+			return order(sorted[j], sorted[i]) < 0
+		}
+
+		return order(sorted[i], sorted[j]) < 0
+	})
+
+	return sorted
+}
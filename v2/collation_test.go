@@ -0,0 +1,61 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollation(t *testing.T) {
+	scenarios := map[string]struct {
+		Options  []Option
+		Rule     string
+		Expected string
+	}{
+		"bytes by default":          {nil, `{"<": ["é", "f"]}`, `false`},
+		"accents":                   {[]Option{WithCollation("fr")}, `{"<": ["é", "f"]}`, `true`},
+		"case":                      {[]Option{WithCollation("en")}, `{">": ["Z", "a"]}`, `true`},
+		"between":                   {[]Option{WithCollation("en")}, `{"<=": ["a", "Élan", "f"]}`, `true`},
+		"language rules":            {[]Option{WithCollation("sv")}, `{"<": ["ö", "z"]}`, `false`},
+		"numbers":                   {[]Option{WithCollation("en")}, `{"<": [9, 10]}`, `true`},
+		"sort":                      {[]Option{WithCollation("de")}, `{"sort": [{"merge": ["zebra", "Äpfel", "apfel", "Bär"]}]}`, `["apfel", "Äpfel", "Bär", "zebra"]`},
+		"sort descending":           {[]Option{WithCollation("de")}, `{"sort": [{"merge": ["b", "Ä", "c"]}, "desc"]}`, `["c", "b", "Ä"]`},
+		"sort by bytes":             {nil, `{"sort": [{"merge": ["b", "Ä", "a"]}]}`, `["a", "b", "Ä"]`},
+		"equality stays exact":      {[]Option{WithCollation("fr")}, `{"==": ["élan", "Elan"]}`, `false`},
+		"insensitive equality":      {[]Option{WithInsensitiveEquality()}, `{"==": ["Élan", "elan"]}`, `true`},
+		"insensitive inequality":    {[]Option{WithInsensitiveEquality()}, `{"!=": ["Élan", "elan"]}`, `false`},
+		"insensitive width":         {[]Option{WithInsensitiveEquality()}, `{"==": ["ＡＢＣ", "abc"]}`, `true`},
+		"different letters":         {[]Option{WithInsensitiveEquality()}, `{"==": ["élan", "elan!"]}`, `false`},
+		"strict equality stays":     {[]Option{WithInsensitiveEquality()}, `{"===": ["Élan", "elan"]}`, `false`},
+		"with a coercion profile":   {[]Option{WithInsensitiveEquality(), WithCoercion(StrictCoercion)}, `{"==": ["Élan", "elan"]}`, `true`},
+		"ordering with equality":    {[]Option{WithCollation("en"), WithInsensitiveEquality()}, `{">=": ["Élan", "elan"]}`, `true`},
+		"options in any order":      {[]Option{WithInsensitiveEquality(), WithCollation("en")}, `{"<": ["é", "f"]}`, `true`},
+		"numbers are never strings": {[]Option{WithInsensitiveEquality()}, `{"==": ["1", 1]}`, `true`},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			engine, err := NewEngine(scenario.Options...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var result bytes.Buffer
+
+			err = engine.Apply(strings.NewReader(scenario.Rule), strings.NewReader(`{}`), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, result.String())
+		})
+	}
+}
+
+func TestWithCollation(t *testing.T) {
+	_, err := NewEngine(WithCollation("not a locale!"))
+	assert.Error(t, err)
+}
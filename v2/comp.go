@@ -1,22 +1,39 @@
 package jsonlogic
 
 import (
+	"math"
 	"reflect"
+	"strings"
 )
 
 func less(a, b interface{}) bool {
-	if isNumber(a) && isNumber(b) {
-		return toNumber(b) > toNumber(a)
+	va, vb := valueOf(a), valueOf(b)
+
+	if va.kind == numberKind && vb.kind == numberKind {
+		if c, ok := compareExactly(va, vb); ok {
+			return c < 0
+		}
+
+		return vb.n > va.n
 	}
 
-	return toString(b) > toString(a)
+	return vb.text() > va.text()
 }
 
-func hardEquals(a, b interface{}) bool {
-	ra := reflect.ValueOf(a).Kind()
-	rb := reflect.ValueOf(b).Kind()
+// compared warns when a comparison gets a number and a value which doesn't
+// read as one, like "abc", which are then compared as text
+func (ev *evaluator) compared(operator string, a, b interface{}) {
+	if ev.engine.logger == nil || isNumber(a) == isNumber(b) {
+		return
+	}
 
-	if ra != rb {
+	if !isNumeric(a) || !isNumeric(b) {
+		ev.warn("jsonlogic implicit coercion", "operator", operator, "values", []interface{}{a, b})
+	}
+}
+
+func hardEquals(a, b interface{}) bool {
+	if valueOf(a).kind != valueOf(b).kind {
 		return false
 	}
 
@@ -24,11 +41,126 @@ func hardEquals(a, b interface{}) bool {
 }
 
 func equals(a, b interface{}) bool {
-	if isNumber(a) && isNumber(b) {
-		return toNumber(a) == toNumber(b)
+	va, vb := valueOf(a), valueOf(b)
+
+	if va.kind == numberKind && vb.kind == numberKind {
+		if c, ok := compareExactly(va, vb); ok {
+			return c == 0
+		}
+
+		return va.n == vb.n
 	}
-	if isBool(a) && isNumber(b) {
-		return isTrue(a) == isTrue(b)
+	if va.kind == boolKind && vb.kind == numberKind {
+		return va.truthy() == vb.truthy()
 	}
-	return toString(a) == toString(b)
+	return va.text() == vb.text()
+}
+
+// compareExactly orders two numbers when one of them is an integer, which
+// floats can't hold exactly: integers are compared with integers and with
+// the exact value of floats. It returns false for the other numbers, and
+// for NaN, which compare as floats.
+func compareExactly(a, b value) (int, bool) {
+	i, aInteger := a.integer()
+	j, bInteger := b.integer()
+
+	switch {
+	case aInteger && bInteger:
+		return compareIntegers(i, j), true
+	case aInteger && !math.IsNaN(b.n):
+		return compareIntegerWithFloat(i, b.n), true
+	case bInteger && !math.IsNaN(a.n):
+		return -compareIntegerWithFloat(j, a.n), true
+	}
+
+	return 0, false
+}
+
+func compareIntegers(i, j int64) int {
+	switch {
+	case i < j:
+		return -1
+	case i > j:
+		return 1
+	}
+
+	return 0
+}
+
+// compareIntegerWithFloat orders an integer and a float which isn't NaN
+func compareIntegerWithFloat(i int64, f float64) int {
+	// floats out of the range of integers, 2^63 being a float
+	switch {
+	case f >= math.MaxInt64:
+		return -1
+	case f < math.MinInt64:
+		return 1
+	}
+
+	whole := math.Trunc(f)
+	if c := compareIntegers(i, int64(whole)); c != 0 {
+		return c
+	}
+
+	switch {
+	case f > whole:
+		return -1
+	case f < whole:
+		return 1
+	}
+
+	return 0
+}
+
+// deepEquals compares values without coercing types, looking into lists
+// and objects
+func deepEquals(a, b interface{}) bool {
+	return reflect.DeepEqual(a, b)
+}
+
+// typeRank orders values of different types: null, booleans, numbers,
+// strings, lists and objects
+func typeRank(value interface{}) int {
+	return valueOf(value).rank()
+}
+
+// compare defines a total order over values: values of different types
+// are ordered by typeRank, booleans, numbers and strings by their values
+// and lists element by element. Objects are all considered equal.
+func compare(a, b interface{}) int {
+	va, vb := valueOf(a), valueOf(b)
+
+	ra, rb := va.rank(), vb.rank()
+	if ra != rb {
+		return ra - rb
+	}
+
+	switch va.kind {
+	case boolKind, numberKind:
+		if c, ok := compareExactly(va, vb); ok {
+			return c
+		}
+
+		switch {
+		case va.n < vb.n:
+			return -1
+		case va.n > vb.n:
+			return 1
+		}
+
+		return 0
+	case stringKind:
+		return strings.Compare(va.s, vb.s)
+	case listKind:
+		_a, _b := a.([]interface{}), b.([]interface{})
+		for i := 0; i < len(_a) && i < len(_b); i++ {
+			if c := compare(_a[i], _b[i]); c != 0 {
+				return c
+			}
+		}
+
+		return len(_a) - len(_b)
+	}
+
+	return 0
 }
@@ -0,0 +1,517 @@
+package jsonlogic
+
+import (
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// compatJSOperators are the operators engines using CompatJS evaluate like
+// json-logic-js, the others behaving the same in both implementations
+var compatJSOperators = map[string]bool{
+	"==":  true,
+	"!=":  true,
+	"===": true,
+	"!==": true,
+	"<":   true,
+	"<=":  true,
+	">":   true,
+	">=":  true,
+	"!":   true,
+	"!!":  true,
+	"and": true,
+	"or":  true,
+	"if":  true,
+	"?:":  true,
+	"+":   true,
+	"-":   true,
+	"*":   true,
+	"/":   true,
+	"%":   true,
+	"min": true,
+	"max": true,
+	"cat": true,
+	"in":  true,
+}
+
+// undefined stands for the arguments json-logic-js operators are not
+// given, which JavaScript doesn't convert like null
+type undefined struct{}
+
+// compatJS evaluates the operators whose result differs in json-logic-js,
+// telling if it did
+func (ev *evaluator) compatJS(operator string, values interface{}) (interface{}, bool) {
+	if !compatJSOperators[operator] {
+		return nil, false
+	}
+
+	args := toSlice(values)
+	arg := func(i int) interface{} {
+		if i < len(args) {
+			return args[i]
+		}
+
+		return undefined{}
+	}
+
+	switch operator {
+	case "==":
+		return jsLooseEquals(arg(0), arg(1)), true
+	case "!=":
+		return !jsLooseEquals(arg(0), arg(1)), true
+	case "===":
+		return jsStrictEquals(arg(0), arg(1)), true
+	case "!==":
+		return !jsStrictEquals(arg(0), arg(1)), true
+	case "<":
+		if len(args) > 2 {
+			return jsLess(arg(0), arg(1)) && jsLess(arg(1), arg(2)), true
+		}
+
+		return jsLess(arg(0), arg(1)), true
+	case "<=":
+		if len(args) > 2 {
+			return jsLessOrEqual(arg(0), arg(1)) && jsLessOrEqual(arg(1), arg(2)), true
+		}
+
+		return jsLessOrEqual(arg(0), arg(1)), true
+	case ">":
+		return jsLess(arg(1), arg(0)), true
+	case ">=":
+		return jsLessOrEqual(arg(1), arg(0)), true
+	case "!":
+		return !jsTruthy(arg(0)), true
+	case "!!":
+		return jsTruthy(arg(0)), true
+	case "and":
+		for _, value := range args {
+			if !jsTruthy(value) {
+				return value, true
+			}
+		}
+
+		return defined(arg(len(args) - 1)), true
+	case "or":
+		for _, value := range args {
+			if jsTruthy(value) {
+				return value, true
+			}
+		}
+
+		return defined(arg(len(args) - 1)), true
+	case "if", "?:":
+		return ev.compatConditional(args), true
+	case "+":
+		result := float64(0)
+		for _, value := range args {
+			result += jsParseFloat(value)
+		}
+
+		return result, true
+	case "*":
+		if len(args) == 0 {
+			return nil, true
+		}
+
+		// like reduce without an initial value, a single value is returned
+		// as it is
+		var result interface{} = args[0]
+		for _, value := range args[1:] {
+			result = jsParseFloat(result) * jsParseFloat(value)
+		}
+
+		return result, true
+	case "-":
+		if _, ok := arg(1).(undefined); ok {
+			return -jsToNumber(arg(0)), true
+		}
+
+		return jsToNumber(arg(0)) - jsToNumber(arg(1)), true
+	case "/", "%":
+		dividend, divisor := jsToNumber(arg(0)), jsToNumber(arg(1))
+		if divisor == 0 && !ev.divideByZero(operator, dividend) {
+			return nil, true
+		}
+
+		if operator == "%" {
+			return math.Mod(dividend, divisor), true
+		}
+
+		return dividend / divisor, true
+	case "min", "max":
+		return jsExtremum(operator, args), true
+	case "cat":
+		var builder strings.Builder
+		for _, value := range args {
+			builder.WriteString(jsJoinString(value))
+		}
+
+		return builder.String(), true
+	}
+
+	return jsIn(arg(0), arg(1)), true
+}
+
+// compatConditional picks the value of the first truthy condition of an if,
+// or its last value when their number is odd
+func (ev *evaluator) compatConditional(args []interface{}) interface{} {
+	i := 0
+	for ; i < len(args)-1; i += 2 {
+		if jsTruthy(args[i]) {
+			ev.chose(strconv.Itoa(i + 1))
+
+			return args[i+1]
+		}
+	}
+
+	if len(args) == i+1 {
+		ev.chose(strconv.Itoa(i))
+
+		return args[i]
+	}
+
+	return nil
+}
+
+// truthy tells if a value is truthy for the coercion profile of the engine
+func (ev *evaluator) truthy(value interface{}) bool {
+	if source, ok := value.(DataSource); ok {
+		// like lists, without reading them
+		return source.Len() > 0
+	}
+
+	if ev.engine.coercion == CompatJS {
+		return jsTruthy(value)
+	}
+
+	return isTrue(value)
+}
+
+// defined returns null for undefined values, which can't be results
+func defined(value interface{}) interface{} {
+	if _, ok := value.(undefined); ok {
+		return nil
+	}
+
+	return value
+}
+
+// jsTruthy is the truthiness of json-logic-js: that of JavaScript, except
+// for empty arrays which are falsy
+func jsTruthy(value interface{}) bool {
+	switch value := value.(type) {
+	case bool:
+		return value
+	case float64:
+		return value != 0 && !math.IsNaN(value)
+	case string:
+		return value != ""
+	case []interface{}:
+		return len(value) > 0
+	case map[string]interface{}:
+		return true
+	}
+
+	return false
+}
+
+// jsNullish tells if a value is null or undefined
+func jsNullish(value interface{}) bool {
+	_, ok := value.(undefined)
+
+	return value == nil || ok
+}
+
+// jsLooseEquals is the == of JavaScript
+func jsLooseEquals(a, b interface{}) bool {
+	if jsNullish(a) || jsNullish(b) {
+		return jsNullish(a) && jsNullish(b)
+	}
+
+	if isObject(a) && isObject(b) {
+		return sameObject(a, b)
+	}
+
+	if isObject(a) {
+		return jsLooseEquals(jsToString(a), b)
+	}
+
+	if isObject(b) {
+		return jsLooseEquals(a, jsToString(b))
+	}
+
+	if isString(a) && isString(b) {
+		return a.(string) == b.(string)
+	}
+
+	return jsToNumber(a) == jsToNumber(b)
+}
+
+// jsStrictEquals is the === of JavaScript
+func jsStrictEquals(a, b interface{}) bool {
+	if isObject(a) || isObject(b) {
+		return isObject(a) && isObject(b) && sameObject(a, b)
+	}
+
+	if reflect.TypeOf(a) != reflect.TypeOf(b) {
+		return false
+	}
+
+	return a == b
+}
+
+// jsLess is the < of JavaScript, comparing strings as strings and
+// anything else as numbers
+func jsLess(a, b interface{}) bool {
+	a, b = jsPrimitive(a), jsPrimitive(b)
+
+	if isString(a) && isString(b) {
+		return a.(string) < b.(string)
+	}
+
+	return jsToNumber(a) < jsToNumber(b)
+}
+
+// jsLessOrEqual is the <= of JavaScript, false when a value isn't a number
+func jsLessOrEqual(a, b interface{}) bool {
+	a, b = jsPrimitive(a), jsPrimitive(b)
+
+	if isString(a) && isString(b) {
+		return a.(string) <= b.(string)
+	}
+
+	return jsToNumber(a) <= jsToNumber(b)
+}
+
+// jsIn is the in of json-logic-js: a substring of a string, or an element
+// of an array by strict equality
+func jsIn(a, b interface{}) bool {
+	switch b := b.(type) {
+	case string:
+		return b != "" && strings.Contains(b, jsToString(a))
+	case []interface{}:
+		for _, element := range b {
+			if jsStrictEquals(element, a) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// jsExtremum is Math.min or Math.max: Infinity or -Infinity without
+// values, and NaN if one of them isn't a number
+func jsExtremum(operator string, args []interface{}) float64 {
+	result := math.Inf(1)
+	if operator == "max" {
+		result = math.Inf(-1)
+	}
+
+	for _, value := range args {
+		n := jsToNumber(value)
+
+		switch {
+		case math.IsNaN(n):
+			return n
+		case operator == "min" && n < result, operator == "max" && n > result:
+			result = n
+		}
+	}
+
+	return result
+}
+
+func isObject(value interface{}) bool {
+	return isSlice(value) || isMap(value)
+}
+
+// sameObject tells if two arrays or objects are the same, not only equal
+func sameObject(a, b interface{}) bool {
+	if isSlice(a) && isSlice(b) {
+		x, y := a.([]interface{}), b.([]interface{})
+
+		// empty arrays can't be told apart, and are never the same
+		return len(x) > 0 && len(x) == len(y) && &x[0] == &y[0]
+	}
+
+	if isMap(a) && isMap(b) {
+		return reflect.ValueOf(a).Pointer() == reflect.ValueOf(b).Pointer()
+	}
+
+	return false
+}
+
+// jsPrimitive converts arrays and objects to strings, as JavaScript does
+// before comparing them
+func jsPrimitive(value interface{}) interface{} {
+	if isObject(value) {
+		return jsToString(value)
+	}
+
+	return value
+}
+
+// jsToNumber is the Number function of JavaScript
+func jsToNumber(value interface{}) float64 {
+	switch value := value.(type) {
+	case nil:
+		return 0
+	case bool:
+		if value {
+			return 1
+		}
+
+		return 0
+	case float64:
+		return value
+	case string:
+		return jsStringToNumber(value)
+	case []interface{}:
+		return jsStringToNumber(jsToString(value))
+	}
+
+	return math.NaN()
+}
+
+var (
+	jsDecimal = regexp.MustCompile(`^[+-]?(\d+\.?\d*|\.\d+)([eE][+-]?\d+)?$`)
+	jsFloat   = regexp.MustCompile(`^[+-]?(Infinity|(\d+\.?\d*|\.\d+)([eE][+-]?\d+)?)`)
+)
+
+func jsStringToNumber(s string) float64 {
+	s = strings.TrimSpace(s)
+
+	switch s {
+	case "":
+		return 0
+	case "Infinity", "+Infinity":
+		return math.Inf(1)
+	case "-Infinity":
+		return math.Inf(-1)
+	}
+
+	if len(s) > 2 && s[0] == '0' {
+		base := map[byte]int{'x': 16, 'X': 16, 'o': 8, 'O': 8, 'b': 2, 'B': 2}[s[1]]
+		if base != 0 {
+			n, err := strconv.ParseUint(s[2:], base, 64)
+			if err != nil {
+				return math.NaN()
+			}
+
+			return float64(n)
+		}
+	}
+
+	if !jsDecimal.MatchString(s) {
+		return math.NaN()
+	}
+
+	// out of range values are infinite, like in JavaScript
+	n, _ := strconv.ParseFloat(s, 64)
+
+	return n
+}
+
+// jsParseFloat is the parseFloat function of JavaScript, reading the
+// number a string starts with
+func jsParseFloat(value interface{}) float64 {
+	prefix := jsFloat.FindString(strings.TrimSpace(jsToString(value)))
+
+	switch strings.TrimLeft(prefix, "+-") {
+	case "":
+		return math.NaN()
+	case "Infinity":
+		if prefix[0] == '-' {
+			return math.Inf(-1)
+		}
+
+		return math.Inf(1)
+	}
+
+	n, _ := strconv.ParseFloat(prefix, 64)
+
+	return n
+}
+
+// jsToString is the String function of JavaScript
+func jsToString(value interface{}) string {
+	switch value := value.(type) {
+	case undefined:
+		return "undefined"
+	case nil:
+		return "null"
+	case bool:
+		return strconv.FormatBool(value)
+	case float64:
+		return jsNumberString(value)
+	case string:
+		return value
+	case []interface{}:
+		elements := make([]string, len(value))
+		for i, element := range value {
+			elements[i] = jsJoinString(element)
+		}
+
+		return strings.Join(elements, ",")
+	}
+
+	return "[object Object]"
+}
+
+// jsJoinString converts a value to a string like Array.prototype.join,
+// null and undefined being empty
+func jsJoinString(value interface{}) string {
+	if jsNullish(value) {
+		return ""
+	}
+
+	return jsToString(value)
+}
+
+// jsNumberString formats a number like JavaScript: without exponent
+// unless it is at least 1e21 or less than 1e-6
+func jsNumberString(f float64) string {
+	switch {
+	case math.IsNaN(f):
+		return "NaN"
+	case math.IsInf(f, 1):
+		return "Infinity"
+	case math.IsInf(f, -1):
+		return "-Infinity"
+	case f == 0:
+		return "0"
+	case f < 0:
+		return "-" + jsNumberString(-f)
+	}
+
+	mantissa := strconv.FormatFloat(f, 'e', -1, 64)
+	i := strings.IndexByte(mantissa, 'e')
+	exponent, _ := strconv.Atoi(mantissa[i+1:])
+	digits := strings.Replace(mantissa[:i], ".", "", 1)
+
+	// the decimal point is after the first n digits
+	n, k := exponent+1, len(digits)
+
+	switch {
+	case k <= n && n <= 21:
+		return digits + strings.Repeat("0", n-k)
+	case 0 < n && n <= 21:
+		return digits[:n] + "." + digits[n:]
+	case -6 < n && n <= 0:
+		return "0." + strings.Repeat("0", -n) + digits
+	}
+
+	s := digits[:1]
+	if k > 1 {
+		s += "." + digits[1:]
+	}
+
+	if exponent > 0 {
+		return s + "e+" + strconv.Itoa(exponent)
+	}
+
+	return s + "e" + strconv.Itoa(exponent)
+}
@@ -0,0 +1,97 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompatJS(t *testing.T) {
+	engine, err := NewEngine(WithCoercion(CompatJS))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := `{"list": [1, 2, 3], "zero": "0"}`
+
+	scenarios := map[string]struct {
+		Rule     string
+		Expected string
+	}{
+		"string equals false":       {`{"==": ["0", false]}`, `true`},
+		"null equals only null":     {`{"==": [null, false]}`, `false`},
+		"missing equals null":       {`{"==": [{"var": "missing"}, null]}`, `true`},
+		"array equals its string":   {`{"==": [[1, 2], "1,2"]}`, `true`},
+		"array equals its number":   {`{"==": [1, [1]]}`, `true`},
+		"arrays are not equal":      {`{"==": [[1], [1]]}`, `false`},
+		"same array":                {`{"==": [{"var": "list"}, {"var": "list"}]}`, `true`},
+		"strict equality":           {`{"===": [1, "1"]}`, `false`},
+		"empty array is falsy":      {`{"!!": [[]]}`, `false`},
+		"zero string is truthy":     {`{"!!": [{"var": "zero"}]}`, `true`},
+		"object is truthy":          {`{"!": [{}]}`, `false`},
+		"and returns a falsy value": {`{"and": [1, "", 2]}`, `""`},
+		"and returns the last":      {`{"and": [1, 2]}`, `2`},
+		"or returns the last":       {`{"or": [0, ""]}`, `""`},
+		"if with an empty array":    {`{"if": [[], "yes", "no"]}`, `"no"`},
+		"ternary with a string":     {`{"?:": ["a", "yes", "no"]}`, `"yes"`},
+		"plus parses floats":        {`{"+": ["3abc", 1]}`, `4`},
+		"plus without values":       {`{"+": []}`, `0`},
+		"times a single value":      {`{"*": ["2"]}`, `"2"`},
+		"minus converts strings":    {`{"-": ["5", "2"]}`, `3`},
+		"minus of a boolean":        {`{"-": [true]}`, `-1`},
+		"strings are ordered":       {`{"<": ["10", "9"]}`, `true`},
+		"between":                   {`{"<": [1, "2", 3]}`, `true`},
+		"null is zero":              {`{"<": [null, 1]}`, `true`},
+		"not a number":              {`{">=": ["a", 1]}`, `false`},
+		"max without values":        {`{"max": []}`, `null`},
+		"max converts strings":      {`{"max": [1, "3"]}`, `3`},
+		"cat of large numbers":      {`{"cat": [1e21, null, [1, 2]]}`, `"1e+211,2"`},
+		"cat of small numbers":      {`{"cat": [0.0000001, 0.000001]}`, `"1e-70.000001"`},
+		"cat of booleans":           {`{"cat": [1.5, true]}`, `"1.5true"`},
+		"in by strict equality":     {`{"in": [1, ["1", 2]]}`, `false`},
+		"in converts to string":     {`{"in": [1, "a1"]}`, `true`},
+		"division by zero":          {`{"/": [1, 0]}`, `null`},
+		"filter":                    {`{"filter": [{"var": "list"}, {"%": [{"var": ""}, 2]}]}`, `[1, 3]`},
+		"map keeps every result":    {`{"map": [{"var": "list"}, {"==": [{"var": ""}, 1]}]}`, `[true, false, false]`},
+		"reduce keeps strings":      {`{"reduce": [["a", "b"], {"cat": [{"var": "accumulator"}, {"var": "current"}]}, ""]}`, `"ab"`},
+		"reduce from null":          {`{"reduce": [[], {"var": "current"}]}`, `null`},
+		"some":                      {`{"some": [[[], [1]], {"var": ""}]}`, `true`},
+		"all":                       {`{"all": [[[], [1]], {"var": ""}]}`, `false`},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var result bytes.Buffer
+
+			err := engine.Apply(strings.NewReader(scenario.Rule), strings.NewReader(data), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, result.String())
+		})
+	}
+}
+
+func TestJSNumberString(t *testing.T) {
+	scenarios := map[string]struct {
+		Number   float64
+		Expected string
+	}{
+		"integer":  {42, "42"},
+		"fraction": {-1.25, "-1.25"},
+		"large":    {1e20, "100000000000000000000"},
+		"exponent": {1.5e21, "1.5e+21"},
+		"small":    {1.5e-7, "1.5e-7"},
+		"decimal":  {0.000123, "0.000123"},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			assert.Equal(t, scenario.Expected, jsNumberString(scenario.Number))
+		})
+	}
+}
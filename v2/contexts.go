@@ -0,0 +1,90 @@
+package jsonlogic
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// ApplyContexts applies a rule to named documents. See Engine.ApplyContexts.
+func ApplyContexts(rule io.Reader, contexts map[string]io.Reader, result io.Writer) error {
+	return defaultEngine.ApplyContexts(rule, contexts, result)
+}
+
+// ApplyContexts is like Apply, with the data made of several named
+// documents, like an event and a static configuration, which the rule
+// reads under their names: {"var": "event.amount"} or
+// {"var": "config.threshold"}. Names can't be empty or contain dots, and
+// nil readers are null documents.
+func (e *Engine) ApplyContexts(rule io.Reader, contexts map[string]io.Reader, result io.Writer) error {
+	if rule == nil {
+		return fmt.Errorf("error Apply-ing nil rule")
+	}
+
+	order := e.newKeyOrder()
+
+	var _rule interface{}
+
+	err := e.readJSON(order, rule, &_rule)
+	if err != nil {
+		return fmt.Errorf("error parsing rule: %w", err)
+	}
+
+	_data, err := e.decodeContexts(contexts, order)
+	if err != nil {
+		return err
+	}
+
+	a := e.newArena()
+	defer a.release()
+
+	output, err := e.evaluateIn(context.Background(), order, a, _rule, _data)
+	if err != nil {
+		return err
+	}
+
+	output, err = e.prepare(order, output)
+	if err != nil {
+		return err
+	}
+
+	return e.write(result, output)
+}
+
+// decodeContexts reads named documents into an object holding them under
+// their names
+func (e *Engine) decodeContexts(contexts map[string]io.Reader, order keyOrder) (map[string]interface{}, error) {
+	names := make([]string, 0, len(contexts))
+	for name := range contexts {
+		if name == "" || strings.Contains(name, ".") {
+			return nil, fmt.Errorf("invalid context name %q", name)
+		}
+
+		names = append(names, name)
+	}
+
+	// errors are reported for the first name in order
+	sort.Strings(names)
+
+	data := make(map[string]interface{}, len(contexts))
+	for _, name := range names {
+		if contexts[name] == nil {
+			data[name] = nil
+
+			continue
+		}
+
+		var value interface{}
+
+		err := e.readJSON(order, contexts[name], &value)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing context %q: %w", name, err)
+		}
+
+		data[name] = value
+	}
+
+	return data, nil
+}
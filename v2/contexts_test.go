@@ -0,0 +1,65 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyContexts(t *testing.T) {
+	contexts := func() map[string]io.Reader {
+		return map[string]io.Reader{
+			"event":  strings.NewReader(`{"amount": 1500, "user": {"age": 17}}`),
+			"config": strings.NewReader(`{"threshold": 1000, "adult": 18}`),
+			"empty":  nil,
+		}
+	}
+
+	scenarios := map[string]struct {
+		Rule     string
+		Expected string
+	}{
+		"both documents": {`{">": [{"var": "event.amount"}, {"var": "config.threshold"}]}`, `true`},
+		"nested paths":   {`{"<": [{"var": "event.user.age"}, {"var": "config.adult"}]}`, `true`},
+		"whole document": {`{"var": "config"}`, `{"threshold": 1000, "adult": 18}`},
+		"null document":  {`{"var": ["empty", "none"]}`, `"none"`},
+		"missing":        {`{"missing": ["event.amount", "config.limit"]}`, `["config.limit"]`},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var result bytes.Buffer
+
+			err := ApplyContexts(strings.NewReader(scenario.Rule), contexts(), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, result.String())
+		})
+	}
+}
+
+func TestApplyContextsErrors(t *testing.T) {
+	scenarios := map[string]struct {
+		Contexts map[string]io.Reader
+		Error    string
+	}{
+		"empty name":    {map[string]io.Reader{"": strings.NewReader(`{}`)}, `invalid context name ""`},
+		"name with dot": {map[string]io.Reader{"a.b": strings.NewReader(`{}`)}, `invalid context name "a.b"`},
+		"invalid JSON":  {map[string]io.Reader{"a": strings.NewReader(`{`), "b": strings.NewReader(`[`)}, `error parsing context "a": unexpected EOF`},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var result bytes.Buffer
+
+			err := ApplyContexts(strings.NewReader(`{"var": "a"}`), scenario.Contexts, &result)
+			assert.EqualError(t, err, scenario.Error)
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package jsonlogic
+
+import (
+	"fmt"
+	"io"
+	"math"
+)
+
+// DefaultSizeHint is the length EstimateCost assumes for the lists of the
+// data it has no hint for
+const DefaultSizeHint = 100
+
+// SizeHints are the expected lengths of the lists of the data, or numbers
+// of entries of its objects, by the path var reads them with, as written
+// in the rule: {"items": 50, ".tags": 5}
+type SizeHints map[string]int
+
+// Cost is an estimate of the work needed to evaluate a rule
+type Cost struct {
+	// Nodes counts the operators and the values of the rule
+	Nodes int `json:"nodes"`
+
+	// LoopDepth is how deep iterations are nested: 0 without any, 2 for a
+	// map within the predicate of a filter
+	LoopDepth int `json:"loop_depth"`
+
+	// Operations is the number of operators evaluated in the worst case,
+	// iterations going through every element of their lists and
+	// conditions through every branch
+	Operations int64 `json:"operations"`
+}
+
+// EstimateCost reads a rule and estimates its cost without evaluating it,
+// so rules too expensive to be evaluated can be rejected when they are
+// submitted. Iterations multiply the operations of their predicate by the
+// length of their list: the length of literal lists, the hint given for
+// the path of var, or DefaultSizeHint. Lists made by operators, like
+// merge or filter, are as long as the lists they are made from can be.
+// Operations saturate at math.MaxInt64.
+func EstimateCost(rule io.Reader, hints SizeHints) (Cost, error) {
+	var tree interface{}
+
+	err := readJSON(rule, &tree)
+	if err != nil {
+		return Cost{}, fmt.Errorf("error parsing rule: %w", err)
+	}
+
+	e := &estimator{hints: hints}
+	e.cost.Operations, _ = e.estimate(tree, 0)
+
+	return e.cost, nil
+}
+
+type estimator struct {
+	hints SizeHints
+	cost  Cost
+}
+
+// estimate returns the operations needed to evaluate an expression at a
+// given depth of iterations, and the length of the list it gives, if any
+func (e *estimator) estimate(rule interface{}, depth int) (int64, int64) {
+	if isSlice(rule) {
+		var operations int64
+		for _, value := range rule.([]interface{}) {
+			o, _ := e.estimate(value, depth)
+			operations = saturatingAdd(operations, o)
+		}
+
+		return operations, int64(len(rule.([]interface{})))
+	}
+
+	e.cost.Nodes++
+
+	if !isMap(rule) {
+		return 0, 0
+	}
+
+	for operator, values := range rule.(map[string]interface{}) {
+		if operator == "var" {
+			path, _ := varArgs(values)
+			if size, ok := e.hints[path]; ok {
+				return 1, int64(size)
+			}
+
+			return 1, DefaultSizeHint
+		}
+
+		if operator == "let" {
+			values = letOperands(values)
+		}
+
+		if operator == "def" {
+			values = defOperands(values)
+		}
+
+		args := toSlice(values)
+		if !isSlice(values) {
+			args = []interface{}{values}
+		}
+
+		if iteratorOperators[operator] && len(args) >= 2 {
+			return e.iteration(operator, args, depth)
+		}
+
+		operations := int64(1)
+		sizes := make([]int64, len(args))
+
+		for i, arg := range args {
+			var o int64
+			o, sizes[i] = e.estimate(arg, depth)
+			operations = saturatingAdd(operations, o)
+		}
+
+		return operations, listSize(operator, sizes)
+	}
+
+	return 0, 0
+}
+
+// iteration estimates an iterator operator, whose predicate is evaluated
+// for every element of its list
+func (e *estimator) iteration(operator string, args []interface{}, depth int) (int64, int64) {
+	if depth+1 > e.cost.LoopDepth {
+		e.cost.LoopDepth = depth + 1
+	}
+
+	operations, size := e.estimate(args[0], depth)
+	predicate, _ := e.estimate(args[1], depth+1)
+
+	operations = saturatingAdd(saturatingAdd(1, operations), saturatingMultiply(size, predicate))
+
+	for _, arg := range args[2:] {
+		o, _ := e.estimate(arg, depth)
+		operations = saturatingAdd(operations, o)
+	}
+
+	switch operator {
+	case "map", "filter", "map_obj", "filter_obj", "groupby":
+		return operations, size
+	}
+
+	return operations, 0
+}
+
+// listSize returns the longest list an operator can give from lists of
+// the given lengths
+func listSize(operator string, sizes []int64) int64 {
+	var size int64
+
+	switch operator {
+	case "merge":
+		for _, s := range sizes {
+			// values that aren't lists are merged as one element
+			if s == 0 {
+				s = 1
+			}
+
+			size = saturatingAdd(size, s)
+		}
+	case "product":
+		size = 1
+		for _, s := range sizes {
+			size = saturatingMultiply(size, s)
+		}
+	default:
+		for _, s := range sizes {
+			if s > size {
+				size = s
+			}
+		}
+	}
+
+	return size
+}
+
+func saturatingAdd(a, b int64) int64 {
+	if a > math.MaxInt64-b {
+		return math.MaxInt64
+	}
+
+	return a + b
+}
+
+func saturatingMultiply(a, b int64) int64 {
+	if a != 0 && b > math.MaxInt64/a {
+		return math.MaxInt64
+	}
+
+	return a * b
+}
@@ -0,0 +1,49 @@
+package jsonlogic
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateCost(t *testing.T) {
+	scenarios := map[string]struct {
+		Rule     string
+		Hints    SizeHints
+		Expected Cost
+	}{
+		"literal":            {`true`, nil, Cost{Nodes: 1}},
+		"comparison":         {`{"==": [{"var": "a"}, 1]}`, nil, Cost{Nodes: 3, Operations: 2}},
+		"every branch":       {`{"if": [{"var": "a"}, {"+": [1, 2]}, {"var": "b"}]}`, nil, Cost{Nodes: 6, Operations: 4}},
+		"default size":       {`{"map": [{"var": "items"}, {"*": [{"var": ""}, 2]}]}`, nil, Cost{Nodes: 5, LoopDepth: 1, Operations: 202}},
+		"hinted size":        {`{"map": [{"var": "items"}, {"*": [{"var": ""}, 2]}]}`, SizeHints{"items": 10}, Cost{Nodes: 5, LoopDepth: 1, Operations: 22}},
+		"literal list":       {`{"some": [[1, 2, 3], {"==": [{"var": ""}, 2]}]}`, nil, Cost{Nodes: 7, LoopDepth: 1, Operations: 7}},
+		"nested iterations":  {`{"filter": [{"var": "orders"}, {"some": [{"var": ".items"}, {">": [{"var": ".price"}, 10]}]}]}`, SizeHints{"orders": 20, ".items": 5}, Cost{Nodes: 7, LoopDepth: 2, Operations: 242}},
+		"over a filter":      {`{"map": [{"filter": [{"var": "a"}, true]}, {"var": ""}]}`, SizeHints{"a": 10}, Cost{Nodes: 5, LoopDepth: 1, Operations: 13}},
+		"over a merge":       {`{"all": [{"merge": [{"var": "a"}, {"var": "b"}, 1]}, {"var": ""}]}`, SizeHints{"a": 10, "b": 5}, Cost{Nodes: 6, LoopDepth: 1, Operations: 20}},
+		"over a product":     {`{"map": [{"product": [{"var": "a"}, {"var": "b"}]}, {"var": ""}]}`, SizeHints{"a": 10, "b": 5}, Cost{Nodes: 5, LoopDepth: 1, Operations: 54}},
+		"reduce":             {`{"reduce": [{"var": "a"}, {"+": [{"var": "current"}, {"var": "accumulator"}]}, 0]}`, SizeHints{"a": 4}, Cost{Nodes: 6, LoopDepth: 1, Operations: 14}},
+		"sequential loops":   {`{"and": [{"all": [{"var": "a"}, true]}, {"none": [{"var": "b"}, false]}]}`, SizeHints{"a": 1, "b": 1}, Cost{Nodes: 7, LoopDepth: 1, Operations: 5}},
+		"saturated":          {`{"map": [{"product": [{"var": "a"}, {"var": "a"}, {"var": "a"}]}, {"var": ""}]}`, SizeHints{"a": math.MaxInt32}, Cost{Nodes: 6, LoopDepth: 1, Operations: math.MaxInt64}},
+		"iterator shorthand": {`{"map": {"var": "a"}}`, nil, Cost{Nodes: 2, Operations: 2}},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			cost, err := EstimateCost(strings.NewReader(scenario.Rule), scenario.Hints)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, scenario.Expected, cost)
+		})
+	}
+}
+
+func TestEstimateCostOfInvalidRule(t *testing.T) {
+	_, err := EstimateCost(strings.NewReader(`{"map": [`), nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,253 @@
+package jsonlogic
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+)
+
+// CoverageEntry tells how often an expression or a branch of a rule was
+// exercised
+type CoverageEntry struct {
+	// Path locates the expression or the branch in the rule as a JSON
+	// pointer, like "/if/1" for the result of the first condition of an if
+	Path string `json:"path"`
+
+	// Operator is the operator of the expression, empty for branches
+	Operator string `json:"operator,omitempty"`
+
+	// Hits counts the evaluations of the expression, or the times the
+	// branch was chosen
+	Hits int `json:"hits"`
+
+	// Truthy and Falsy count the results of the expression that were truthy
+	// and falsy: a condition which is never one or the other doesn't
+	// depend on the data it is given
+	Truthy int `json:"truthy"`
+	Falsy  int `json:"falsy"`
+}
+
+// Coverage records which expressions of a rule, and which branches of its
+// if, ?: and switch, are exercised by the data it is applied to with
+// ApplyWithCoverage, to find the parts of long-lived rules that are never
+// used. It is safe for concurrent use.
+type Coverage struct {
+	rule *Rule
+
+	// entries are in the order of the rule, indexed by the address of
+	// their expression, or by the address of the expression choosing them
+	// and their path from it
+	entries     []CoverageEntry
+	expressions map[uintptr]int
+	branches    map[branchKey]int
+
+	mu sync.Mutex
+}
+
+type branchKey struct {
+	expression uintptr
+	path       string
+}
+
+// NewCoverage returns an empty coverage of a compiled rule
+func NewCoverage(rule *Rule) *Coverage {
+	c := &Coverage{
+		rule:        rule,
+		expressions: make(map[uintptr]int),
+		branches:    make(map[branchKey]int),
+	}
+
+	c.index(rule.tree, "")
+
+	return c
+}
+
+func (c *Coverage) index(rule interface{}, path string) {
+	switch rule := rule.(type) {
+	case []interface{}:
+		for i, value := range rule {
+			c.index(value, path+"/"+strconv.Itoa(i))
+		}
+	case map[string]interface{}:
+		address := reflect.ValueOf(rule).Pointer()
+
+		for operator, values := range rule {
+			_path := path + "/" + pointerToken(operator)
+
+			c.expressions[address] = len(c.entries)
+			c.entries = append(c.entries, CoverageEntry{Path: _path, Operator: operator})
+
+			for _, branch := range branches(operator, values) {
+				c.branches[branchKey{address, branch}] = len(c.entries)
+				c.entries = append(c.entries, CoverageEntry{Path: _path + "/" + branch})
+			}
+
+			c.index(values, _path)
+		}
+	}
+}
+
+// branches returns the paths of the branches of an expression from it
+func branches(operator string, values interface{}) []string {
+	parsed, ok := values.([]interface{})
+	if !ok {
+		return nil
+	}
+
+	var paths []string
+
+	switch operator {
+	case "if":
+		for i := 1; i < len(parsed); i += 2 {
+			paths = append(paths, strconv.Itoa(i))
+		}
+
+		if len(parsed) > 1 && len(parsed)%2 == 1 {
+			paths = append(paths, strconv.Itoa(len(parsed)-1))
+		}
+	case "?:":
+		if len(parsed) == 3 {
+			paths = append(paths, "1", "2")
+		}
+	case "switch":
+		if len(parsed) < 2 {
+			return nil
+		}
+
+		cases, _ := parsed[1].([]interface{})
+		for i := range cases {
+			paths = append(paths, fmt.Sprintf("1/%d/1", i))
+		}
+
+		if len(parsed) == 3 {
+			paths = append(paths, "2")
+		}
+	}
+
+	return paths
+}
+
+// Report returns the entries of the coverage, in the order of the rule
+func (c *Coverage) Report() []CoverageEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	report := make([]CoverageEntry, len(c.entries))
+	copy(report, c.entries)
+
+	return report
+}
+
+// Uncovered returns the entries of the coverage which were never
+// exercised, in the order of the rule
+func (c *Coverage) Uncovered() []CoverageEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	uncovered := make([]CoverageEntry, 0)
+	for _, entry := range c.entries {
+		if entry.Hits == 0 {
+			uncovered = append(uncovered, entry)
+		}
+	}
+
+	return uncovered
+}
+
+// add adds the counts of an evaluation to the coverage
+func (c *Coverage) add(counts []CoverageEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, count := range counts {
+		c.entries[i].Hits += count.Hits
+		c.entries[i].Truthy += count.Truthy
+		c.entries[i].Falsy += count.Falsy
+	}
+}
+
+// coverageRun holds the counts of a single evaluation, added to the
+// coverage when it ends
+type coverageRun struct {
+	coverage *Coverage
+	counts   []CoverageEntry
+}
+
+// ApplyWithCoverage applies a compiled rule to JSON data like ApplyBool,
+// recording the expressions and branches it exercises in coverage. See
+// Engine.ApplyWithCoverage.
+func ApplyWithCoverage(coverage *Coverage, data []byte) (interface{}, error) {
+	return defaultEngine.ApplyWithCoverage(coverage, data)
+}
+
+// ApplyWithCoverage applies the compiled rule of coverage to JSON data,
+// recording the expressions and branches it exercises, and returns its
+// result. Applying it to a corpus of samples tells the parts of the rule
+// they never use. Like traced evaluations, these evaluations don't use the
+// cache, the memoization and the parallelism of the engine, and the
+// expressions of named rules aren't recorded. The expressions and
+// branches exercised by evaluations that fail are recorded all the same.
+func (e *Engine) ApplyWithCoverage(coverage *Coverage, data []byte) (interface{}, error) {
+	if err := e.checkRule(coverage.rule); err != nil {
+		return nil, err
+	}
+
+	_data, err := e.decodeData(coverage.rule, data)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing data %w", err)
+	}
+
+	ev := e.evaluator()
+	ev.coverage = &coverageRun{
+		coverage: coverage,
+		counts:   make([]CoverageEntry, len(coverage.entries)),
+	}
+	ev.origins = make(map[uintptr]origin)
+
+	result, err := ev.run(coverage.rule.tree, _data)
+
+	coverage.add(ev.coverage.counts)
+
+	return result, err
+}
+
+// cover evaluates an expression, counting its evaluation and whether its
+// result is truthy
+func (ev *evaluator) cover(rules, data interface{}) interface{} {
+	parent := ev.expression
+	ev.expression = rules
+
+	result := ev.applyRule(rules, data)
+
+	ev.expression = parent
+
+	i, ok := ev.coverage.coverage.expressions[reflect.ValueOf(ev.origin(rules)).Pointer()]
+	if !ok {
+		return result
+	}
+
+	count := &ev.coverage.counts[i]
+	count.Hits++
+
+	if isTrue(result) {
+		count.Truthy++
+	} else {
+		count.Falsy++
+	}
+
+	return result
+}
+
+// chose counts the choice of a branch of the expression being evaluated,
+// given by its path from the expression
+func (ev *evaluator) chose(branch string) {
+	if ev.coverage == nil || !isMap(ev.expression) {
+		return
+	}
+
+	key := branchKey{reflect.ValueOf(ev.origin(ev.expression)).Pointer(), branch}
+	if i, ok := ev.coverage.coverage.branches[key]; ok {
+		ev.coverage.counts[i].Hits++
+	}
+}
@@ -0,0 +1,69 @@
+package jsonlogic
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoverage(t *testing.T) {
+	rule, err := Compile(strings.NewReader(`{"if": [
+		{"<": [{"var": "age"}, 18]}, "minor",
+		{">=": [{"var": "age"}, 120]}, "unlikely",
+		{"cat": ["adult ", {"var": "country"}]}
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	coverage := NewCoverage(rule)
+
+	for _, sample := range []string{`{"age": 12}`, `{"age": 40, "country": "PT"}`, `{"age": 60, "country": "BR"}`} {
+		if _, err := ApplyWithCoverage(coverage, []byte(sample)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	assert.Equal(t, []CoverageEntry{
+		{Path: "/if", Operator: "if", Hits: 3, Truthy: 3},
+		{Path: "/if/1", Hits: 1},
+		{Path: "/if/3"},
+		{Path: "/if/4", Hits: 2},
+		{Path: "/if/0/<", Operator: "<", Hits: 3, Truthy: 1, Falsy: 2},
+		{Path: "/if/0/</0/var", Operator: "var", Hits: 3, Truthy: 3},
+		{Path: "/if/2/>=", Operator: ">=", Hits: 3, Falsy: 3},
+		{Path: "/if/2/>=/0/var", Operator: "var", Hits: 3, Truthy: 3},
+		{Path: "/if/4/cat", Operator: "cat", Hits: 3, Truthy: 3},
+		{Path: "/if/4/cat/1/var", Operator: "var", Hits: 3, Truthy: 2, Falsy: 1},
+	}, coverage.Report())
+
+	assert.Equal(t, []CoverageEntry{{Path: "/if/3"}}, coverage.Uncovered())
+}
+
+func TestCoverageOfIterationsAndSwitches(t *testing.T) {
+	rule, err := Compile(strings.NewReader(`{"switch": [{"var": "kind"}, [
+		["list", {"some": [{"var": "items"}, {">": [{"var": ".price"}, 100]}]}],
+		["single", {"?:": [{"var": "paid"}, "done", "pending"]}]
+	], "unknown"]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	engine, err := NewEngine(WithParallelism(2, 1), WithCache(&testCache{values: make(map[interface{}]interface{})}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	coverage := NewCoverage(rule)
+
+	for _, sample := range []string{`{"kind": "list", "items": [{"price": 10}, {"price": 200}]}`, `{"kind": "list", "items": []}`, `{"kind": "single", "paid": true}`} {
+		if _, err := engine.ApplyWithCoverage(coverage, []byte(sample)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	assert.Equal(t, []CoverageEntry{{Path: "/switch/2"}, {Path: "/switch/1/1/1/?:/2"}}, coverage.Uncovered())
+
+	assert.Contains(t, coverage.Report(), CoverageEntry{Path: "/switch/1/0/1/some/1/>", Operator: ">", Hits: 2, Truthy: 1, Falsy: 1})
+}
@@ -0,0 +1,81 @@
+package jsonlogic
+
+import (
+	"fmt"
+)
+
+// DataSource is a list read element by element, for lists too large to be
+// decoded up front, like the rows of a database cursor or the records of a
+// memory-mapped file. DataSources can be anywhere in the data given to
+// ApplyInterface, in the fields of structs or the values of a VarResolver.
+//
+// filter, map, reduce, all, some, none and groupby read their elements one
+// at a time, in order, without keeping the ones they don't return, and
+// all, some and none stop reading at the first element deciding their
+// result. var reads single elements by their index: "rows.3.amount". Other
+// operators, and results, get the elements read into a list.
+//
+// Elements of DataSources are never read by parallel workers, so Get isn't
+// called concurrently by an evaluation. Engines with a Cache key
+// evaluations by the JSON encoding of the data, so DataSources given to
+// them must encode as their content, by implementing json.Marshaler.
+type DataSource interface {
+	// Len returns the number of elements
+	Len() int
+
+	// Get returns the element at index i, from 0 to Len() - 1, as decoded
+	// JSON or as a Go value, like the data of ApplyInterface. Errors fail
+	// the evaluation.
+	Get(i int) (interface{}, error)
+}
+
+// listSource is a decoded list read as a DataSource
+type listSource []interface{}
+
+func (l listSource) Len() int {
+	return len(l)
+}
+
+func (l listSource) Get(i int) (interface{}, error) {
+	return l[i], nil
+}
+
+// source returns the list an iteration goes through as a DataSource
+func source(list interface{}) DataSource {
+	if source, ok := list.(DataSource); ok {
+		return source
+	}
+
+	return listSource(list.([]interface{}))
+}
+
+// element reads an element of a DataSource, failing the evaluation when it
+// can't be read
+func element(source DataSource, i int) interface{} {
+	if list, ok := source.(listSource); ok {
+		return list[i]
+	}
+
+	value, err := source.Get(i)
+	if err != nil {
+		panic(evaluationError{err: fmt.Errorf("error reading element %d of data source: %w", i, err)})
+	}
+
+	return fromGoData(value)
+}
+
+// materialize reads the elements of a DataSource into a list, leaving
+// other values as they are
+func materialize(value interface{}) interface{} {
+	source, ok := value.(DataSource)
+	if !ok {
+		return value
+	}
+
+	list := make([]interface{}, source.Len())
+	for i := range list {
+		list[i] = element(source, i)
+	}
+
+	return list
+}
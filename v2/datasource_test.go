@@ -0,0 +1,175 @@
+package jsonlogic
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testSource generates rows without storing them, counting how many it
+// reads
+type testSource struct {
+	length int
+	reads  int
+	err    error
+}
+
+func (s *testSource) Len() int {
+	return s.length
+}
+
+func (s *testSource) Get(i int) (interface{}, error) {
+	s.reads++
+
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return testRow{ID: i, Amount: float64(i * 10)}, nil
+}
+
+type testRow struct {
+	ID     int     `json:"id"`
+	Amount float64 `json:"amount"`
+}
+
+func TestDataSource(t *testing.T) {
+	scenarios := map[string]struct {
+		Rule     string
+		Expected interface{}
+		Reads    int
+	}{
+		"filter": {
+			Rule:     `{"filter": [{"var": "rows"}, {">=": [{"var": ".amount"}, 30]}]}`,
+			Expected: []interface{}{testRow{ID: 3, Amount: 30}, testRow{ID: 4, Amount: 40}},
+			Reads:    5,
+		},
+		"map": {
+			Rule:     `{"map": [{"var": "rows"}, {"var": ".id"}]}`,
+			Expected: []interface{}{0.0, 1.0, 2.0, 3.0, 4.0},
+			Reads:    5,
+		},
+		"reduce": {
+			Rule:     `{"reduce": [{"var": "rows"}, {"+": [{"var": "accumulator"}, {"var": "current.amount"}]}, 0]}`,
+			Expected: 100.0,
+			Reads:    5,
+		},
+		"some stops at the first match": {
+			Rule:     `{"some": [{"var": "rows"}, {"==": [{"var": ".id"}, 1]}]}`,
+			Expected: true,
+			Reads:    2,
+		},
+		"all stops at the first failure": {
+			Rule:     `{"all": [{"var": "rows"}, {"<": [{"var": ".id"}, 0]}]}`,
+			Expected: false,
+			Reads:    1,
+		},
+		"none": {
+			Rule:     `{"none": [{"var": "rows"}, {">": [{"var": ".id"}, 10]}]}`,
+			Expected: true,
+			Reads:    5,
+		},
+		"groupby": {
+			Rule: `{"groupby": [{"var": "rows"}, {"if": [{">": [{"var": ".id"}, 2]}, "high", "low"]}]}`,
+			Expected: map[string]interface{}{
+				"low":  []interface{}{testRow{ID: 0}, testRow{ID: 1, Amount: 10}, testRow{ID: 2, Amount: 20}},
+				"high": []interface{}{testRow{ID: 3, Amount: 30}, testRow{ID: 4, Amount: 40}},
+			},
+			Reads: 5,
+		},
+		"positions": {
+			Rule:     `{"filter": [{"var": "rows"}, {"<": [{"var": "$index"}, 1]}]}`,
+			Expected: []interface{}{testRow{ID: 0}},
+			Reads:    5,
+		},
+		"index": {
+			Rule:     `{"var": "rows.3.amount"}`,
+			Expected: 30.0,
+			Reads:    1,
+		},
+		"negative index": {
+			Rule:     `{"var": "rows.-1.id"}`,
+			Expected: 4.0,
+			Reads:    1,
+		},
+		"missing index": {
+			Rule:     `{"var": ["rows.5.id", "none"]}`,
+			Expected: "none",
+			Reads:    0,
+		},
+		"other operators": {
+			Rule:     `{"count": {"var": "rows"}}`,
+			Expected: 5.0,
+			Reads:    5,
+		},
+		"truthiness": {
+			Rule:     `{"if": [{"var": "rows"}, "rows", "none"]}`,
+			Expected: "rows",
+			Reads:    5,
+		},
+		"results": {
+			Rule:     `{"var": "rows"}`,
+			Expected: []interface{}{testRow{ID: 0}, testRow{ID: 1, Amount: 10}, testRow{ID: 2, Amount: 20}, testRow{ID: 3, Amount: 30}, testRow{ID: 4, Amount: 40}},
+			Reads:    5,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			rows := &testSource{length: 5}
+
+			var rule interface{}
+			if err := readJSON(strings.NewReader(scenario.Rule), &rule); err != nil {
+				t.Fatal(err)
+			}
+
+			result, err := ApplyInterface(rule, map[string]interface{}{"rows": rows})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, scenario.Expected, result)
+			assert.Equal(t, scenario.Reads, rows.reads)
+		})
+	}
+}
+
+func TestDataSourceErrors(t *testing.T) {
+	failure := errors.New("cursor closed")
+
+	rule := map[string]interface{}{"some": []interface{}{
+		map[string]interface{}{"var": "rows"},
+		map[string]interface{}{"var": ".id"},
+	}}
+
+	_, err := ApplyInterface(rule, map[string]interface{}{"rows": &testSource{length: 3, err: failure}})
+	assert.True(t, errors.Is(err, failure))
+}
+
+func TestDataSourceWithParallelism(t *testing.T) {
+	engine, err := NewEngine(WithParallelism(4, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rows := &testSource{length: 100}
+
+	rule := map[string]interface{}{"map": []interface{}{
+		map[string]interface{}{"var": "rows"},
+		map[string]interface{}{"var": ".amount"},
+	}}
+
+	result, err := engine.ApplyInterface(rule, struct {
+		Rows DataSource `json:"rows"`
+	}{Rows: rows})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, result, 100)
+	assert.Equal(t, 990.0, result.([]interface{})[99])
+	assert.Equal(t, 100, rows.reads)
+}
@@ -0,0 +1,447 @@
+package jsonlogic
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+// dateLayouts are the formats accepted for dates given as strings. Dates
+// without an offset are in the timezone of the engine.
+var dateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+// dayFirstLayouts and monthFirstLayouts are the formats accepted in
+// addition to dateLayouts by engines with a date locale
+var (
+	dayFirstLayouts   = []string{"02/01/2006 15:04:05", "02/01/2006 15:04", "02/01/2006"}
+	monthFirstLayouts = []string{"01/02/2006 15:04:05", "01/02/2006 15:04", "01/02/2006"}
+)
+
+// dateSettings are the timezone and the conventions of a locale the date
+// operators follow
+type dateSettings struct {
+	location  *time.Location
+	layouts   []string
+	weekStart time.Weekday
+}
+
+// defaultDates are the settings of engines without timezone and locale:
+// UTC, and weeks starting on Monday as in ISO-8601
+var defaultDates = dateSettings{location: time.UTC, layouts: dateLayouts, weekStart: time.Monday}
+
+// WithTimezone makes the date operators read the dates written without an
+// offset, like "2024-03-01" or "2024-03-01T09:00:00", in a timezone of the
+// IANA database, like "Europe/Paris", and find the start of days, weeks,
+// months and years there. Calendar units, days and larger, are added to
+// dates in that timezone too, across changes of daylight saving time.
+// Dates are still written in UTC.
+func WithTimezone(name string) Option {
+	return func(e *Engine) error {
+		location, err := time.LoadLocation(name)
+		if err != nil {
+			return fmt.Errorf("unknown timezone %q: %w", name, err)
+		}
+
+		e.dates.location = location
+
+		return nil
+	}
+}
+
+// sundayRegions and saturdayRegions are the regions whose weeks start on
+// Sunday or Saturday, from the week data of the Unicode CLDR
+var (
+	sundayRegions = "AG AS BD BR BS BT BW BZ CA CN CO DM DO ET GT GU HK HN ID IL IN JM JP KE KH KR LA MH MM MO MT MX MZ NI NP PA PE PH PK PR PT PY SA SG SV TH TT TW UM US VE VI WS YE ZA ZW"
+
+	saturdayRegions = "AE AF BH DJ DZ EG IQ IR JO KW LY OM QA SD SY"
+
+	// monthFirstRegions write the month before the day
+	monthFirstRegions = "US PH FM MH PW"
+)
+
+// WithDateLocale makes the date operators follow the conventions of a
+// locale, given as a BCP 47 tag like "en-US" or "fr": dates written with
+// slashes are read day first, "15/06/2024", or month first in regions like
+// the United States, "06/15/2024", with an optional time, and weeks start
+// on the day they start in the region of the locale, Monday by default.
+func WithDateLocale(locale string) Option {
+	return func(e *Engine) error {
+		tag, err := language.Parse(locale)
+		if err != nil {
+			return fmt.Errorf("unknown locale %q: %w", locale, err)
+		}
+
+		region, _ := tag.Region()
+		code := region.String()
+
+		layouts := dayFirstLayouts
+		if strings.Contains(monthFirstRegions, code) {
+			layouts = monthFirstLayouts
+		}
+
+		e.dates.layouts = append(append([]string(nil), dateLayouts...), layouts...)
+
+		e.dates.weekStart = time.Monday
+		switch {
+		case strings.Contains(sundayRegions, code):
+			e.dates.weekStart = time.Sunday
+		case strings.Contains(saturdayRegions, code):
+			e.dates.weekStart = time.Saturday
+		}
+
+		return nil
+	}
+}
+
+// toTime reads a date given either as a string in one of the layouts of
+// the settings or as a number of seconds since the Unix epoch
+func (d *dateSettings) toTime(value interface{}) (time.Time, bool) {
+	if isNumber(value) {
+		sec, frac := math.Modf(toNumber(value))
+
+		return time.Unix(int64(sec), int64(frac*1e9)).UTC(), true
+	}
+
+	if !isString(value) {
+		return time.Time{}, false
+	}
+
+	for _, layout := range d.layouts {
+		t, err := time.ParseInLocation(layout, value.(string), d.location)
+		if err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+func fromTime(t time.Time) interface{} {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+func (d *dateSettings) toTimes(values interface{}, count int) ([]time.Time, bool) {
+	if !isSlice(values) {
+		return nil, false
+	}
+
+	parsed := values.([]interface{})
+	if len(parsed) != count {
+		return nil, false
+	}
+
+	times := make([]time.Time, count)
+	for i, value := range parsed {
+		t, ok := d.toTime(value)
+		if !ok {
+			return nil, false
+		}
+
+		times[i] = t
+	}
+
+	return times, true
+}
+
+// now returns the time of the evaluation, read from the clock of the
+// engine the first time, so that all the now of a rule are the same
+func (ev *evaluator) now() interface{} {
+	return fromTime(ev.time())
+}
+
+func (ev *evaluator) time() time.Time {
+	if ev.started.IsZero() {
+		ev.started = ev.engine.clock()
+	}
+
+	return ev.started
+}
+
+func (d *dateSettings) dateBefore(values interface{}) interface{} {
+	times, ok := d.toTimes(values, 2)
+
+	return ok && times[0].Before(times[1])
+}
+
+func (d *dateSettings) dateAfter(values interface{}) interface{} {
+	times, ok := d.toTimes(values, 2)
+
+	return ok && times[0].After(times[1])
+}
+
+// dateBetween checks if a date is within an inclusive interval:
+// {"date_between": [date, start, end]}
+func (d *dateSettings) dateBetween(values interface{}) interface{} {
+	times, ok := d.toTimes(values, 3)
+
+	return ok && !times[0].Before(times[1]) && !times[0].After(times[2])
+}
+
+// isoDuration matches ISO-8601 durations like P1Y2M3DT4H5M6S or P2W,
+// optionally negated with a leading minus sign
+var isoDuration = regexp.MustCompile(`^(-)?P(?:(\d+)Y)?(?:(\d+)M)?(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+
+// units are the time units accepted by date_add and date_diff, along with
+// months and years, which follow the calendar
+var units = map[string]time.Duration{
+	"second":  time.Second,
+	"minute":  time.Minute,
+	"hour":    time.Hour,
+	"day":     24 * time.Hour,
+	"week":    7 * 24 * time.Hour,
+	"seconds": time.Second,
+	"minutes": time.Minute,
+	"hours":   time.Hour,
+	"days":    24 * time.Hour,
+	"weeks":   7 * 24 * time.Hour,
+}
+
+// addMonths adds months to t on the calendar, keeping the time of day and
+// the day of the month, or the last day of the target month when it is
+// shorter: a month after January 31st is February 28th or 29th
+func addMonths(t time.Time, months int) time.Time {
+	year, month, day := t.Date()
+	hour, min, sec := t.Clock()
+
+	last := time.Date(year, month+time.Month(months)+1, 0, 0, 0, 0, 0, t.Location()).Day()
+	if day > last {
+		day = last
+	}
+
+	return time.Date(year, month+time.Month(months), day, hour, min, sec, t.Nanosecond(), t.Location())
+}
+
+// addISODuration adds an ISO-8601 duration to t. Years, months, weeks and
+// days follow the calendar, so P1M added to January 31st is the last day of
+// February.
+func addISODuration(t time.Time, duration string) (time.Time, bool) {
+	parts := isoDuration.FindStringSubmatch(duration)
+	if parts == nil || strings.HasSuffix(duration, "P") || strings.HasSuffix(duration, "T") {
+		return time.Time{}, false
+	}
+
+	sign := 1
+	if parts[1] == "-" {
+		sign = -1
+	}
+
+	component := func(i int) int {
+		n, _ := strconv.Atoi(parts[i])
+
+		return sign * n
+	}
+
+	seconds, _ := strconv.ParseFloat(parts[8], 64)
+
+	t = addMonths(t, 12*component(2)+component(3))
+	t = t.AddDate(0, 0, 7*component(4)+component(5))
+	t = t.Add(time.Duration(component(6)) * time.Hour)
+	t = t.Add(time.Duration(component(7)) * time.Minute)
+	t = t.Add(time.Duration(float64(sign) * seconds * float64(time.Second)))
+
+	return t, true
+}
+
+// dateAdd shifts a date either by an ISO-8601 duration or by an amount of
+// units: {"date_add": [date, "P30D"]} or {"date_add": [date, 30, "days"]}.
+// It returns null when the arguments are invalid.
+func (d *dateSettings) dateAdd(values interface{}) interface{} {
+	if !isSlice(values) {
+		return nil
+	}
+
+	parsed := values.([]interface{})
+	if len(parsed) < 2 {
+		return nil
+	}
+
+	t, ok := d.toTime(parsed[0])
+	if !ok {
+		return nil
+	}
+
+	// calendar units are added in the timezone of the settings
+	t = t.In(d.location)
+
+	if len(parsed) == 2 && isString(parsed[1]) {
+		t, ok = addISODuration(t, parsed[1].(string))
+		if !ok {
+			return nil
+		}
+
+		return fromTime(t)
+	}
+
+	if len(parsed) != 3 || !isNumber(parsed[1]) || !isString(parsed[2]) {
+		return nil
+	}
+
+	amount := toNumber(parsed[1])
+
+	switch parsed[2].(string) {
+	case "month", "months":
+		return fromTime(addMonths(t, int(amount)))
+	case "year", "years":
+		return fromTime(addMonths(t, 12*int(amount)))
+	}
+
+	// whole days and weeks follow the calendar, like years and months, so
+	// they keep the time of day across changes of daylight saving time
+	if amount == math.Trunc(amount) {
+		switch parsed[2].(string) {
+		case "day", "days":
+			return fromTime(t.AddDate(0, 0, int(amount)))
+		case "week", "weeks":
+			return fromTime(t.AddDate(0, 0, 7*int(amount)))
+		}
+	}
+
+	unit, ok := units[parsed[2].(string)]
+	if !ok {
+		return nil
+	}
+
+	return fromTime(t.Add(time.Duration(amount * float64(unit))))
+}
+
+// dateDiff returns how many units (seconds by default) separate two dates,
+// negative when the first one is the earliest: {"date_diff": [a, b, "days"]}.
+// Months and years are the whole ones completed on the calendar, like
+// date_add adds them: from January 15th to March 14th is 1 month.
+func (d *dateSettings) dateDiff(values interface{}) interface{} {
+	if !isSlice(values) {
+		return nil
+	}
+
+	parsed := values.([]interface{})
+
+	name := "seconds"
+	if len(parsed) == 3 {
+		if !isString(parsed[2]) {
+			return nil
+		}
+
+		name = parsed[2].(string)
+		parsed = parsed[:2]
+	}
+
+	times, ok := d.toTimes(parsed, 2)
+	if !ok {
+		return nil
+	}
+
+	switch name {
+	case "month", "months":
+		return float64(monthsBetween(times[0].In(d.location), times[1].In(d.location)))
+	case "year", "years":
+		return float64(monthsBetween(times[0].In(d.location), times[1].In(d.location)) / 12)
+	}
+
+	unit, ok := units[name]
+	if !ok {
+		return nil
+	}
+
+	return float64(times[0].Sub(times[1])) / float64(unit)
+}
+
+// monthsBetween returns how many whole months separate b from a, negative
+// when a is the earliest: the most months added to b, the way date_add adds
+// them, which don't go past a
+func monthsBetween(a, b time.Time) int {
+	months := (a.Year()-b.Year())*12 + int(a.Month()) - int(b.Month())
+
+	for months > 0 && addMonths(b, months).After(a) {
+		months--
+	}
+
+	for months < 0 && addMonths(b, months).Before(a) {
+		months++
+	}
+
+	return months
+}
+
+// startOf returns the start of the day, week, month or year of t in the
+// timezone of the settings
+func (d *dateSettings) startOf(t time.Time, unit string) (time.Time, bool) {
+	t = t.In(d.location)
+	year, month, day := t.Date()
+
+	switch unit {
+	case "day":
+		return time.Date(year, month, day, 0, 0, 0, 0, d.location), true
+	case "week":
+		offset := (int(t.Weekday()) - int(d.weekStart) + 7) % 7
+
+		return time.Date(year, month, day-offset, 0, 0, 0, 0, d.location), true
+	case "month":
+		return time.Date(year, month, 1, 0, 0, 0, 0, d.location), true
+	case "year":
+		return time.Date(year, time.January, 1, 0, 0, 0, 0, d.location), true
+	}
+
+	return time.Time{}, false
+}
+
+// dateStartOf returns the start of the day, week, month or year of a date:
+// {"date_start_of": [date, "week"]}. It returns null when the arguments are
+// invalid.
+func (d *dateSettings) dateStartOf(values interface{}) interface{} {
+	if !isSlice(values) {
+		return nil
+	}
+
+	parsed := values.([]interface{})
+	if len(parsed) != 2 || !isString(parsed[1]) {
+		return nil
+	}
+
+	t, ok := d.toTime(parsed[0])
+	if !ok {
+		return nil
+	}
+
+	start, ok := d.startOf(t, parsed[1].(string))
+	if !ok {
+		return nil
+	}
+
+	return fromTime(start)
+}
+
+// dateSame checks if two dates are in the same day, week, month or year:
+// {"date_same": [date, {"now": []}, "day"]} is true for dates of today
+func (d *dateSettings) dateSame(values interface{}) interface{} {
+	if !isSlice(values) {
+		return false
+	}
+
+	parsed := values.([]interface{})
+	if len(parsed) != 3 || !isString(parsed[2]) {
+		return false
+	}
+
+	times, ok := d.toTimes(parsed[:2], 2)
+	if !ok {
+		return false
+	}
+
+	a, ok := d.startOf(times[0], parsed[2].(string))
+	if !ok {
+		return false
+	}
+
+	b, _ := d.startOf(times[1], parsed[2].(string))
+
+	return a.Equal(b)
+}
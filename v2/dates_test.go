@@ -0,0 +1,371 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func fixedClock(value string) func() time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		panic(err)
+	}
+
+	return func() time.Time {
+		return t
+	}
+}
+
+func TestDateOperators(t *testing.T) {
+	engine, err := NewEngine(WithClock(fixedClock("2020-06-15T12:00:00Z")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := map[string]struct {
+		Rule     string
+		Data     string
+		Expected string
+	}{
+		"now uses the engine clock": {
+			Rule:     `{"now": []}`,
+			Data:     `{}`,
+			Expected: `"2020-06-15T12:00:00Z"`,
+		},
+		"date before": {
+			Rule:     `{"date_before": [{"var": "signup"}, {"now": []}]}`,
+			Data:     `{"signup": "2020-01-01T00:00:00Z"}`,
+			Expected: `true`,
+		},
+		"date before compares instants, not strings": {
+			Rule:     `{"date_before": ["2020-06-15T13:00:00+02:00", "2020-06-15T12:00:00Z"]}`,
+			Data:     `{}`,
+			Expected: `true`,
+		},
+		"date after with epoch seconds": {
+			Rule:     `{"date_after": [{"var": "expires"}, {"now": []}]}`,
+			Data:     `{"expires": 1592222400.5}`,
+			Expected: `true`,
+		},
+		"date between is inclusive": {
+			Rule:     `{"date_between": [{"now": []}, "2020-06-01", "2020-06-15T12:00:00Z"]}`,
+			Data:     `{}`,
+			Expected: `true`,
+		},
+		"date outside of interval": {
+			Rule:     `{"date_between": ["2020-07-01", "2020-06-01", "2020-06-30"]}`,
+			Data:     `{}`,
+			Expected: `false`,
+		},
+		"date add with an ISO-8601 duration": {
+			Rule:     `{"date_add": [{"now": []}, "P1M2DT3H"]}`,
+			Data:     `{}`,
+			Expected: `"2020-07-17T15:00:00Z"`,
+		},
+		"date add with a negative ISO-8601 duration": {
+			Rule:     `{"date_add": ["2020-03-01T00:00:00Z", "-P1D"]}`,
+			Data:     `{}`,
+			Expected: `"2020-02-29T00:00:00Z"`,
+		},
+		"date add with units": {
+			Rule:     `{"date_add": ["2020-01-31T00:00:00Z", 36, "hours"]}`,
+			Data:     `{}`,
+			Expected: `"2020-02-01T12:00:00Z"`,
+		},
+		"date add with calendar units": {
+			Rule:     `{"date_add": ["2020-01-15T00:00:00Z", 1, "years"]}`,
+			Data:     `{}`,
+			Expected: `"2021-01-15T00:00:00Z"`,
+		},
+		"date add of a month at the end of January": {
+			Rule:     `{"date_add": ["2021-01-31T10:00:00Z", 1, "months"]}`,
+			Data:     `{}`,
+			Expected: `"2021-02-28T10:00:00Z"`,
+		},
+		"date add of a month at the end of January of a leap year": {
+			Rule:     `{"date_add": ["2020-01-31T00:00:00Z", "P1M"]}`,
+			Data:     `{}`,
+			Expected: `"2020-02-29T00:00:00Z"`,
+		},
+		"date add of a negative month at the end of March": {
+			Rule:     `{"date_add": ["2021-03-31T00:00:00Z", "-P1M"]}`,
+			Data:     `{}`,
+			Expected: `"2021-02-28T00:00:00Z"`,
+		},
+		"date add of a year to a leap day": {
+			Rule:     `{"date_add": ["2020-02-29T00:00:00Z", 1, "years"]}`,
+			Data:     `{}`,
+			Expected: `"2021-02-28T00:00:00Z"`,
+		},
+		"date add of months then days": {
+			Rule:     `{"date_add": ["2021-01-31T00:00:00Z", "P1M1D"]}`,
+			Data:     `{}`,
+			Expected: `"2021-03-01T00:00:00Z"`,
+		},
+		"date add with an invalid duration": {
+			Rule:     `{"date_add": ["2020-01-15T00:00:00Z", "P"]}`,
+			Data:     `{}`,
+			Expected: `null`,
+		},
+		"created more than 30 days ago": {
+			Rule:     `{">": [{"date_diff": [{"now": []}, {"var": "created"}, "days"]}, 30]}`,
+			Data:     `{"created": "2020-05-01T12:00:00Z"}`,
+			Expected: `true`,
+		},
+		"date diff defaults to seconds": {
+			Rule:     `{"date_diff": ["2020-01-01T00:01:00Z", "2020-01-01T00:00:00Z"]}`,
+			Data:     `{}`,
+			Expected: `60`,
+		},
+		"date diff in months": {
+			Rule:     `{"date_diff": ["2020-03-15", "2020-01-15", "months"]}`,
+			Data:     `{}`,
+			Expected: `2`,
+		},
+		"date diff in months counts whole months": {
+			Rule:     `{"date_diff": ["2020-03-14", "2020-01-15", "month"]}`,
+			Data:     `{}`,
+			Expected: `1`,
+		},
+		"date diff in months under a month": {
+			Rule:     `{"date_diff": ["2020-02-16", "2020-02-01", "month"]}`,
+			Data:     `{}`,
+			Expected: `0`,
+		},
+		"date diff in months backwards under a month": {
+			Rule:     `{"date_diff": ["2020-02-01", "2020-02-16", "month"]}`,
+			Data:     `{}`,
+			Expected: `0`,
+		},
+		"date diff in months to the end of a shorter month": {
+			Rule:     `{"date_diff": ["2021-02-28", "2021-01-31", "months"]}`,
+			Data:     `{}`,
+			Expected: `1`,
+		},
+		"date diff in months from the end of a shorter month": {
+			Rule:     `{"date_diff": ["2021-03-30", "2021-02-28", "months"]}`,
+			Data:     `{}`,
+			Expected: `1`,
+		},
+		"date diff in months backwards to the end of a shorter month": {
+			Rule:     `{"date_diff": ["2020-02-29", "2020-03-31", "months"]}`,
+			Data:     `{}`,
+			Expected: `-1`,
+		},
+		"date diff in months backwards": {
+			Rule:     `{"date_diff": ["2020-01-15", "2020-04-15", "months"]}`,
+			Data:     `{}`,
+			Expected: `-3`,
+		},
+		"date diff in months across the end of a month": {
+			Rule:     `{"date_diff": [{"date_add": ["2020-01-31", 1, "months"]}, "2020-01-31", "months"]}`,
+			Data:     `{}`,
+			Expected: `1`,
+		},
+		"date diff in years": {
+			Rule:     `{"date_diff": ["2024-02-29", "2020-02-29", "years"]}`,
+			Data:     `{}`,
+			Expected: `4`,
+		},
+		"date diff in years counts whole years": {
+			Rule:     `{"date_diff": ["2021-07-01", "2020-01-01", "year"]}`,
+			Data:     `{}`,
+			Expected: `1`,
+		},
+		"date diff in years from a leap day": {
+			Rule:     `{"date_diff": ["2021-02-28", "2020-02-29", "years"]}`,
+			Data:     `{}`,
+			Expected: `1`,
+		},
+		"date diff in years backwards": {
+			Rule:     `{"date_diff": ["2019-01-02", "2021-01-01", "years"]}`,
+			Data:     `{}`,
+			Expected: `-1`,
+		},
+		"date diff with an unknown unit": {
+			Rule:     `{"date_diff": ["2020-01-02", "2020-01-01", "fortnights"]}`,
+			Data:     `{}`,
+			Expected: `null`,
+		},
+		"invalid dates never compare": {
+			Rule:     `{"date_before": ["yesterday", "2020-06-30"]}`,
+			Data:     `{}`,
+			Expected: `false`,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var result bytes.Buffer
+
+			err := engine.Apply(strings.NewReader(scenario.Rule), strings.NewReader(scenario.Data), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, result.String())
+		})
+	}
+}
+
+func TestEngineRejectsNilClock(t *testing.T) {
+	_, err := NewEngine(WithClock(nil))
+	assert.Error(t, err)
+}
+
+func TestNowIsPinnedPerEvaluation(t *testing.T) {
+	var mu sync.Mutex
+	ticks := 0
+
+	// every reading of the clock is a second later
+	clock := func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+
+		ticks++
+
+		return time.Date(2020, 6, 15, 12, 0, ticks, 0, time.UTC)
+	}
+
+	engine, err := NewEngine(WithClock(clock), WithParallelism(4, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rule := `{"merge": [
+		{"now": []},
+		{"date_add": [{"now": []}, 0, "seconds"]},
+		{"map": [[1, 2, 3, 4, 5, 6, 7, 8], {"now": []}]}
+	]}`
+
+	for _, expected := range []string{"2020-06-15T12:00:01Z", "2020-06-15T12:00:02Z"} {
+		var result bytes.Buffer
+
+		err := engine.Apply(strings.NewReader(rule), strings.NewReader(`{}`), &result)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var times []string
+		if err := json.Unmarshal(result.Bytes(), &times); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, times, 10)
+		for _, value := range times {
+			assert.Equal(t, expected, value)
+		}
+	}
+}
+
+func TestDateSettings(t *testing.T) {
+	clock := fixedClock("2024-03-30T23:30:00Z")
+
+	scenarios := map[string]struct {
+		Options  []Option
+		Rule     string
+		Expected string
+	}{
+		"dates without offset are UTC by default": {
+			Rule:     `{"date_add": ["2024-03-01", 0, "days"]}`,
+			Expected: `"2024-03-01T00:00:00Z"`,
+		},
+		"dates without offset are in the timezone": {
+			Options:  []Option{WithTimezone("Europe/Paris")},
+			Rule:     `{"date_add": ["2024-03-01", 0, "days"]}`,
+			Expected: `"2024-02-29T23:00:00Z"`,
+		},
+		"dates with offset ignore the timezone": {
+			Options:  []Option{WithTimezone("Europe/Paris")},
+			Rule:     `{"date_add": ["2024-03-01T00:00:00Z", 0, "days"]}`,
+			Expected: `"2024-03-01T00:00:00Z"`,
+		},
+		"days keep the time across daylight saving time": {
+			Options:  []Option{WithTimezone("Europe/Paris")},
+			Rule:     `{"date_add": ["2024-03-30T12:00:00", 1, "day"]}`,
+			Expected: `"2024-03-31T10:00:00Z"`,
+		},
+		"is today in UTC": {
+			Rule:     `{"date_same": ["2024-03-30T08:00:00Z", {"now": []}, "day"]}`,
+			Expected: `true`,
+		},
+		"is not today in the timezone": {
+			Options:  []Option{WithTimezone("Europe/Paris")},
+			Rule:     `{"date_same": ["2024-03-30T08:00:00Z", {"now": []}, "day"]}`,
+			Expected: `false`,
+		},
+		"same month": {
+			Rule:     `{"date_same": ["2024-03-01", "2024-03-31", "month"]}`,
+			Expected: `true`,
+		},
+		"unknown unit": {
+			Rule:     `{"date_same": ["2024-03-01", "2024-03-01", "hour"]}`,
+			Expected: `false`,
+		},
+		"start of day in the timezone": {
+			Options:  []Option{WithTimezone("Europe/Paris")},
+			Rule:     `{"date_start_of": [{"now": []}, "day"]}`,
+			Expected: `"2024-03-30T23:00:00Z"`,
+		},
+		"weeks start on Monday by default": {
+			Rule:     `{"date_start_of": ["2024-03-30", "week"]}`,
+			Expected: `"2024-03-25T00:00:00Z"`,
+		},
+		"weeks start on Sunday in the United States": {
+			Options:  []Option{WithDateLocale("en-US")},
+			Rule:     `{"date_start_of": ["2024-03-30", "week"]}`,
+			Expected: `"2024-03-24T00:00:00Z"`,
+		},
+		"start of year": {
+			Rule:     `{"date_start_of": ["2024-03-30T10:00:00Z", "year"]}`,
+			Expected: `"2024-01-01T00:00:00Z"`,
+		},
+		"slash dates need a locale": {
+			Rule:     `{"date_add": ["03/04/2024", 0, "days"]}`,
+			Expected: `null`,
+		},
+		"slash dates are day first in France": {
+			Options:  []Option{WithDateLocale("fr-FR")},
+			Rule:     `{"date_add": ["03/04/2024", 0, "days"]}`,
+			Expected: `"2024-04-03T00:00:00Z"`,
+		},
+		"slash dates are month first in the United States": {
+			Options:  []Option{WithDateLocale("en-US")},
+			Rule:     `{"date_add": ["03/04/2024 10:30", 0, "days"]}`,
+			Expected: `"2024-03-04T10:30:00Z"`,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			engine, err := NewEngine(append(scenario.Options, WithClock(clock))...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var result bytes.Buffer
+
+			err = engine.Apply(strings.NewReader(scenario.Rule), strings.NewReader(`{}`), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, result.String())
+		})
+	}
+}
+
+func TestDateSettingsRejectUnknownNames(t *testing.T) {
+	_, err := NewEngine(WithTimezone("Mars/Olympus"))
+	assert.Error(t, err)
+
+	_, err = NewEngine(WithDateLocale("not a locale"))
+	assert.Error(t, err)
+}
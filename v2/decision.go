@@ -0,0 +1,119 @@
+package jsonlogic
+
+import (
+	"fmt"
+)
+
+// HitPolicy tells which rows of a DecisionTable make its outcome
+type HitPolicy string
+
+const (
+	// FirstMatch makes the outcome of the first matching row the outcome
+	// of the table
+	FirstMatch HitPolicy = "first"
+	// AllMatches makes the list of the outcomes of all the matching rows,
+	// in order, the outcome of the table
+	AllMatches HitPolicy = "all"
+)
+
+// DecisionTable is an ordered list of conditions with their outcomes,
+// what usually ends up written as a long if chain. It can be read from
+// JSON:
+//
+//	{
+//	  "policy": "first",
+//	  "rows": [
+//	    {"when": {">=": [{"var": "total"}, 1000]}, "then": "gold"},
+//	    {"when": {">=": [{"var": "total"}, 100]}, "then": "silver"}
+//	  ],
+//	  "default": "bronze"
+//	}
+type DecisionTable struct {
+	// Policy is FirstMatch when empty
+	Policy HitPolicy     `json:"policy,omitempty"`
+	Rows   []DecisionRow `json:"rows"`
+	// Default is the outcome when no row matches, with FirstMatch
+	Default interface{} `json:"default,omitempty"`
+}
+
+// DecisionRow is a row of a DecisionTable: when its condition is truthy,
+// Then is its outcome. Both are rules applied to the data.
+type DecisionRow struct {
+	When interface{} `json:"when"`
+	Then interface{} `json:"then"`
+}
+
+// Decision is the result of a DecisionTable
+type Decision struct {
+	// Outcome is the outcome of the matching row with FirstMatch, and the
+	// list of the outcomes of the matching rows with AllMatches
+	Outcome interface{}
+	// Matched are the indexes of the matching rows
+	Matched []int
+}
+
+// Decide applies a decision table to decoded data. See Engine.Decide.
+func Decide(table *DecisionTable, data interface{}) (*Decision, error) {
+	return defaultEngine.Decide(table, data)
+}
+
+// Decide applies a decision table to decoded data, checking the conditions
+// of its rows in order. With FirstMatch, it stops at the first matching
+// row, and the outcome of the table is the default when there is none.
+// With AllMatches, every row is checked.
+func (e *Engine) Decide(table *DecisionTable, data interface{}) (*Decision, error) {
+	policy := table.Policy
+	if policy == "" {
+		policy = FirstMatch
+	}
+
+	if policy != FirstMatch && policy != AllMatches {
+		return nil, fmt.Errorf("unknown hit policy %q", policy)
+	}
+
+	data = fromGoData(data)
+
+	decision := &Decision{Matched: make([]int, 0)}
+	outcomes := make([]interface{}, 0)
+
+	for i, row := range table.Rows {
+		matched, err := e.evaluate(row.When, data)
+		if err != nil {
+			return nil, fmt.Errorf("error applying the condition of row %d: %w", i, err)
+		}
+
+		if !isTrue(matched) {
+			continue
+		}
+
+		outcome, err := e.evaluate(row.Then, data)
+		if err != nil {
+			return nil, fmt.Errorf("error applying the outcome of row %d: %w", i, err)
+		}
+
+		decision.Matched = append(decision.Matched, i)
+
+		if policy == FirstMatch {
+			decision.Outcome = outcome
+
+			return decision, nil
+		}
+
+		outcomes = append(outcomes, outcome)
+	}
+
+	if policy == AllMatches {
+		decision.Outcome = outcomes
+
+		return decision, nil
+	}
+
+	outcome, err := e.evaluate(table.Default, data)
+	if err != nil {
+		return nil, fmt.Errorf("error applying the default outcome: %w", err)
+	}
+
+	decision.Outcome = outcome
+
+	return decision, nil
+}
@@ -0,0 +1,101 @@
+package jsonlogic
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecide(t *testing.T) {
+	tiers := `[
+		{"when": {">=": [{"var": "total"}, 1000]}, "then": "gold"},
+		{"when": {">=": [{"var": "total"}, 100]}, "then": "silver"},
+		{"when": {"==": [{"var": "country"}, "PT"]}, "then": {"cat": ["local-", {"var": "country"}]}}
+	]`
+
+	scenarios := map[string]struct {
+		table    string
+		data     string
+		expected *Decision
+	}{
+		"first match": {
+			table:    `{"rows": ` + tiers + `}`,
+			data:     `{"total": 500, "country": "PT"}`,
+			expected: &Decision{Outcome: "silver", Matched: []int{1}},
+		},
+		"explicit first match": {
+			table:    `{"policy": "first", "rows": ` + tiers + `}`,
+			data:     `{"total": 5000}`,
+			expected: &Decision{Outcome: "gold", Matched: []int{0}},
+		},
+		"computed outcome": {
+			table:    `{"rows": ` + tiers + `}`,
+			data:     `{"total": 10, "country": "PT"}`,
+			expected: &Decision{Outcome: "local-PT", Matched: []int{2}},
+		},
+		"default": {
+			table:    `{"rows": ` + tiers + `, "default": "bronze"}`,
+			data:     `{"total": 10}`,
+			expected: &Decision{Outcome: "bronze", Matched: []int{}},
+		},
+		"no match without default": {
+			table:    `{"rows": ` + tiers + `}`,
+			data:     `{"total": 10}`,
+			expected: &Decision{Outcome: nil, Matched: []int{}},
+		},
+		"all matches": {
+			table:    `{"policy": "all", "rows": ` + tiers + `, "default": "ignored"}`,
+			data:     `{"total": 5000, "country": "PT"}`,
+			expected: &Decision{Outcome: []interface{}{"gold", "silver", "local-PT"}, Matched: []int{0, 1, 2}},
+		},
+		"all matches without match": {
+			table:    `{"policy": "all", "rows": ` + tiers + `}`,
+			data:     `{"total": 10}`,
+			expected: &Decision{Outcome: []interface{}{}, Matched: []int{}},
+		},
+		"catch all row": {
+			table:    `{"rows": [{"when": {"var": "vip"}, "then": 1}, {"when": true, "then": 2}]}`,
+			data:     `{}`,
+			expected: &Decision{Outcome: 2.0, Matched: []int{1}},
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var table DecisionTable
+			assert.NoError(t, json.Unmarshal([]byte(scenario.table), &table))
+
+			var data interface{}
+			assert.NoError(t, json.Unmarshal([]byte(scenario.data), &data))
+
+			decision, err := Decide(&table, data)
+			assert.NoError(t, err)
+			assert.Equal(t, scenario.expected, decision)
+		})
+	}
+}
+
+func TestDecideErrors(t *testing.T) {
+	engine, err := NewEngine(WithStrictCasts())
+	assert.NoError(t, err)
+
+	scenarios := map[string]*DecisionTable{
+		"unknown policy": {Policy: "some"},
+		"condition": {Rows: []DecisionRow{
+			{When: map[string]interface{}{"to_number": "abc"}, Then: 1.0},
+		}},
+		"outcome": {Rows: []DecisionRow{
+			{When: true, Then: map[string]interface{}{"to_number": "abc"}},
+		}},
+		"default": {Default: map[string]interface{}{"to_number": "abc"}},
+	}
+
+	for name, table := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			_, err := engine.Decide(table, nil)
+			assert.Error(t, err)
+		})
+	}
+}
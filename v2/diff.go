@@ -0,0 +1,198 @@
+package jsonlogic
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+)
+
+// ChangeType tells how an expression changed between two rules
+type ChangeType string
+
+const (
+	// Added expressions only exist in the second rule
+	Added ChangeType = "added"
+	// Removed expressions only exist in the first rule
+	Removed ChangeType = "removed"
+	// Modified expressions were replaced by another one
+	Modified ChangeType = "modified"
+)
+
+// Change is an expression that differs between two rules
+type Change struct {
+	// Path locates the expression as a JSON pointer in the second rule, or
+	// in the first one when it was removed. Paths refer to the full form of
+	// the shorthands: {"var": "a"} is read as {"var": ["a"]}.
+	Path string
+	Type ChangeType
+	// Before is the expression in the first rule, nil when it was added
+	Before interface{}
+	// After is the expression in the second rule, nil when it was removed
+	After interface{}
+}
+
+func (c Change) String() string {
+	switch c.Type {
+	case Added:
+		return fmt.Sprintf("%s: added %s", c.Path, describe(c.After))
+	case Removed:
+		return fmt.Sprintf("%s: removed %s", c.Path, describe(c.Before))
+	}
+
+	return fmt.Sprintf("%s: %s became %s", c.Path, describe(c.Before), describe(c.After))
+}
+
+func describe(expression interface{}) string {
+	encoded, err := encodeCanonical(expression, "")
+	if err != nil {
+		return fmt.Sprint(expression)
+	}
+
+	return string(encoded)
+}
+
+// Equal reads two rules and tells if they are the same once in canonical
+// form, see Hash
+func Equal(a, b io.Reader) (bool, error) {
+	_a, _b, err := decodePair(a, b)
+	if err != nil {
+		return false, err
+	}
+
+	return reflect.DeepEqual(_a, _b), nil
+}
+
+// Diff reads two rules and lists the expressions that changed from the
+// first to the second, once in canonical form. Changes are reported at
+// the deepest expression possible: a different argument of an operator
+// is reported alone, while a different operator replaces the whole
+// expression. Elements of lists are matched, so inserting a condition
+// into an "and" is reported as such.
+func Diff(a, b io.Reader) ([]Change, error) {
+	_a, _b, err := decodePair(a, b)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]Change, 0)
+	diff(_a, _b, "", &changes)
+
+	return changes, nil
+}
+
+func decodePair(a, b io.Reader) (interface{}, interface{}, error) {
+	var _a, _b interface{}
+
+	err := readJSON(a, &_a)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error parsing first rule: %w", err)
+	}
+
+	err = readJSON(b, &_b)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error parsing second rule: %w", err)
+	}
+
+	return canonical(expand(_a)), canonical(expand(_b)), nil
+}
+
+func diff(a, b interface{}, path string, changes *[]Change) {
+	if reflect.DeepEqual(a, b) {
+		return
+	}
+
+	if isSlice(a) && isSlice(b) {
+		diffLists(a.([]interface{}), b.([]interface{}), path, changes)
+
+		return
+	}
+
+	if isMap(a) && isMap(b) && sameKeys(a.(map[string]interface{}), b.(map[string]interface{})) {
+		_a, _b := a.(map[string]interface{}), b.(map[string]interface{})
+		for _, key := range sortedKeys(_a) {
+			diff(_a[key], _b[key], path+"/"+pointerToken(key), changes)
+		}
+
+		return
+	}
+
+	*changes = append(*changes, Change{Path: path, Type: Modified, Before: a, After: b})
+}
+
+func sameKeys(a, b map[string]interface{}) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for key := range a {
+		if _, ok := b[key]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// diffLists matches the elements common to both lists, in order, and
+// compares the ones in between
+func diffLists(a, b []interface{}, path string, changes *[]Change) {
+	// common[i][j] is the length of the longest common subsequence of
+	// a[i:] and b[j:]
+	common := make([][]int, len(a)+1)
+	for i := range common {
+		common[i] = make([]int, len(b)+1)
+	}
+
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			switch {
+			case reflect.DeepEqual(a[i], b[j]):
+				common[i][j] = common[i+1][j+1] + 1
+			case common[i+1][j] >= common[i][j+1]:
+				common[i][j] = common[i+1][j]
+			default:
+				common[i][j] = common[i][j+1]
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		if i < len(a) && j < len(b) && reflect.DeepEqual(a[i], b[j]) {
+			i++
+			j++
+
+			continue
+		}
+
+		// the elements up to the next common one differ
+		nextA, nextB := i, j
+		for nextA < len(a) && nextB < len(b) && !reflect.DeepEqual(a[nextA], b[nextB]) {
+			if common[nextA+1][nextB] >= common[nextA][nextB+1] {
+				nextA++
+			} else {
+				nextB++
+			}
+		}
+
+		if nextA == len(a) || nextB == len(b) {
+			// there is no common element left
+			nextA, nextB = len(a), len(b)
+		}
+
+		for i < nextA && j < nextB {
+			diff(a[i], b[j], path+"/"+strconv.Itoa(j), changes)
+			i++
+			j++
+		}
+
+		for ; j < nextB; j++ {
+			*changes = append(*changes, Change{Path: path + "/" + strconv.Itoa(j), Type: Added, After: b[j]})
+		}
+
+		for ; i < nextA; i++ {
+			*changes = append(*changes, Change{Path: path + "/" + strconv.Itoa(i), Type: Removed, Before: a[i]})
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package jsonlogic
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEqual(t *testing.T) {
+	scenarios := map[string]struct {
+		a, b     string
+		expected bool
+	}{
+		"same rule": {
+			a:        `{"==": [{"var": "a"}, 1]}`,
+			b:        `{"==": [{"var": "a"}, 1]}`,
+			expected: true,
+		},
+		"layout": {
+			a:        `{"==": [{"var": "a"}, 1.0]}`,
+			b:        "{\"==\":[{\"var\":[\"a\"]},\n1]}",
+			expected: true,
+		},
+		"different rule": {
+			a: `{"==": [{"var": "a"}, 1]}`,
+			b: `{"==": [{"var": "b"}, 1]}`,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			equal, err := Equal(strings.NewReader(scenario.a), strings.NewReader(scenario.b))
+			assert.NoError(t, err)
+			assert.Equal(t, scenario.expected, equal)
+		})
+	}
+}
+
+func TestDiff(t *testing.T) {
+	scenarios := map[string]struct {
+		a, b     string
+		expected []string
+	}{
+		"no change": {
+			a:        `{"and": [{"var": "a"}, true]}`,
+			b:        `{"and": [{"var": ["a"]}, true]}`,
+			expected: []string{},
+		},
+		"argument": {
+			a:        `{">=": [{"var": "age"}, 18]}`,
+			b:        `{">=": [{"var": "age"}, 21]}`,
+			expected: []string{`/>=/1: 18 became 21`},
+		},
+		"nested var": {
+			a:        `{"if": [{"==": [{"var": "country"}, "PT"]}, "yes", "no"]}`,
+			b:        `{"if": [{"==": [{"var": "region"}, "PT"]}, "yes", "no"]}`,
+			expected: []string{`/if/0/==/0/var/0: "country" became "region"`},
+		},
+		"operator": {
+			a:        `{"and": [{"<": [{"var": "a"}, 1]}]}`,
+			b:        `{"and": [{"<=": [{"var": "a"}, 1]}]}`,
+			expected: []string{`/and/0: {"<":[{"var":["a"]},1]} became {"<=":[{"var":["a"]},1]}`},
+		},
+		"inserted condition": {
+			a:        `{"and": [{"var": "a"}, {"var": "b"}]}`,
+			b:        `{"and": [{"var": "z"}, {"var": "a"}, {"var": "b"}]}`,
+			expected: []string{`/and/0: added {"var":["z"]}`},
+		},
+		"removed condition": {
+			a:        `{"or": [{"var": "a"}, {"var": "b"}, {"var": "c"}]}`,
+			b:        `{"or": [{"var": "a"}, {"var": "c"}]}`,
+			expected: []string{`/or/1: removed {"var":["b"]}`},
+		},
+		"replaced and appended": {
+			a:        `{"in": [{"var": "c"}, ["PT", "ES"]]}`,
+			b:        `{"in": [{"var": "c"}, ["PT", "FR", "IT"]]}`,
+			expected: []string{`/in/1/1: "ES" became "FR"`, `/in/1/2: added "IT"`},
+		},
+		"truncated": {
+			a:        `{"cat": ["a", "b", "c"]}`,
+			b:        `{"cat": ["a"]}`,
+			expected: []string{`/cat/1: removed "b"`, `/cat/2: removed "c"`},
+		},
+		"literal objects": {
+			a:        `{"map": [{"var": "l"}, {"a": 1, "b": 2}]}`,
+			b:        `{"map": [{"var": "l"}, {"a": 1, "b": 3}]}`,
+			expected: []string{`/map/1/b: 2 became 3`},
+		},
+		"whole rule": {
+			a:        `true`,
+			b:        `{"var": "a"}`,
+			expected: []string{`: true became {"var":["a"]}`},
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			changes, err := Diff(strings.NewReader(scenario.a), strings.NewReader(scenario.b))
+			assert.NoError(t, err)
+
+			described := make([]string, 0, len(changes))
+			for _, change := range changes {
+				described = append(described, change.String())
+			}
+
+			assert.Equal(t, scenario.expected, described)
+		})
+	}
+}
+
+func TestDiffChange(t *testing.T) {
+	changes, err := Diff(strings.NewReader(`{"or": [1, 2]}`), strings.NewReader(`{"or": [1]}`))
+	assert.NoError(t, err)
+	assert.Equal(t, []Change{{Path: "/or/1", Type: Removed, Before: 2.0}}, changes)
+}
+
+func TestDiffErrors(t *testing.T) {
+	_, err := Diff(strings.NewReader(`{`), strings.NewReader(`{}`))
+	assert.Error(t, err)
+
+	_, err = Equal(strings.NewReader(`{}`), strings.NewReader(`[`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,87 @@
+package jsonlogic
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrDivisionByZero is returned, wrapped, by engines using DivideToError
+// when / or % divide by zero
+var ErrDivisionByZero = errors.New("division by zero")
+
+// DivisionPolicy is what / and % do when dividing by zero
+type DivisionPolicy int
+
+const (
+	// DivideToNull makes divisions by zero null. It is the default.
+	DivideToNull DivisionPolicy = iota
+
+	// DivideToError fails the evaluation with ErrDivisionByZero
+	DivideToError
+
+	// DivideToInfinity follows JavaScript: dividing by zero gives Infinity
+	// or -Infinity, and 0 / 0 or a remainder by zero give NaN. What becomes
+	// of these values is then up to the NonFinitePolicy of the engine.
+	DivideToInfinity
+)
+
+// WithDivisionPolicy chooses what / and % do when dividing by zero
+func WithDivisionPolicy(policy DivisionPolicy) Option {
+	return func(e *Engine) error {
+		if policy < DivideToNull || policy > DivideToInfinity {
+			return fmt.Errorf("unknown division policy %d", policy)
+		}
+
+		e.divisionPolicy = policy
+
+		return nil
+	}
+}
+
+// divideByZero applies the division policy of the engine, telling if the
+// division must go on
+func (ev *evaluator) divideByZero(operator string, dividend float64) bool {
+	switch ev.engine.divisionPolicy {
+	case DivideToInfinity:
+		return true
+	case DivideToError:
+		ev.fail(fmt.Errorf("%w: %v %s 0", ErrDivisionByZero, dividend, operator))
+	}
+
+	ev.warn("jsonlogic division by zero", "operator", operator, "dividend", dividend)
+
+	return false
+}
+
+// div divides the first value by the next ones: {"/": [12, 2, 3]} is 2
+func (ev *evaluator) div(values interface{}) interface{} {
+	parsed := toSlice(values)
+	if len(parsed) == 0 {
+		return float64(0)
+	}
+
+	result := toNumber(parsed[0])
+	for _, value := range parsed[1:] {
+		divisor := toNumber(value)
+		if divisor == 0 && !ev.divideByZero("/", result) {
+			return nil
+		}
+
+		result /= divisor
+	}
+
+	return result
+}
+
+// mod returns the remainder of the division of a by b
+func (ev *evaluator) mod(a, b interface{}) interface{} {
+	dividend := toNumber(a)
+	divisor := toNumber(b)
+
+	if divisor == 0 && !ev.divideByZero("%", dividend) {
+		return nil
+	}
+
+	return math.Mod(dividend, divisor)
+}
@@ -0,0 +1,104 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDivision(t *testing.T) {
+	scenarios := map[string]struct {
+		Rule     string
+		Expected string
+	}{
+		"division":             {`{"/": [12, 2, 3]}`, `2`},
+		"zero dividend":        {`{"/": [0, 5]}`, `0`},
+		"division by zero":     {`{"/": [1, 0]}`, `null`},
+		"zero divided by zero": {`{"/": [0, 0]}`, `null`},
+		"chained zero":         {`{"/": [12, 2, {"var": "zero"}]}`, `null`},
+		"division by a string": {`{"/": [1, "0"]}`, `null`},
+		"remainder":            {`{"%": [7, 4]}`, `3`},
+		"remainder by zero":    {`{"%": [7, 0]}`, `null`},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var result bytes.Buffer
+
+			err := Apply(strings.NewReader(scenario.Rule), strings.NewReader(`{"zero": 0}`), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, result.String())
+		})
+	}
+}
+
+func TestDivisionPolicies(t *testing.T) {
+	scenarios := map[string]struct {
+		Policy   DivisionPolicy
+		Rule     string
+		Expected interface{}
+		Err      error
+	}{
+		"null":                   {DivideToNull, `{"/": [1, 0]}`, nil, nil},
+		"error":                  {DivideToError, `{"/": [1, 0]}`, nil, ErrDivisionByZero},
+		"error on remainder":     {DivideToError, `{"%": [1, 0]}`, nil, ErrDivisionByZero},
+		"error without zero":     {DivideToError, `{"/": [1, 4]}`, 0.25, nil},
+		"infinity":               {DivideToInfinity, `{"/": [1, 0]}`, math.Inf(1), nil},
+		"negative infinity":      {DivideToInfinity, `{"/": [-1, 0]}`, math.Inf(-1), nil},
+		"infinity then division": {DivideToInfinity, `{"/": [1, 0, 2]}`, math.Inf(1), nil},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			engine, err := NewEngine(WithDivisionPolicy(scenario.Policy), WithNonFinitePolicy(KeepNonFinite))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var rule interface{}
+			if err := json.Unmarshal([]byte(scenario.Rule), &rule); err != nil {
+				t.Fatal(err)
+			}
+
+			result, err := engine.ApplyInterface(rule, nil)
+			if scenario.Err != nil {
+				assert.True(t, errors.Is(err, scenario.Err))
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, scenario.Expected, result)
+		})
+	}
+}
+
+func TestDivisionToNaN(t *testing.T) {
+	engine, err := NewEngine(WithDivisionPolicy(DivideToInfinity), WithNonFinitePolicy(KeepNonFinite))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, rule := range []interface{}{
+		map[string]interface{}{"/": []interface{}{0.0, 0.0}},
+		map[string]interface{}{"%": []interface{}{7.0, 0.0}},
+	} {
+		result, err := engine.ApplyInterface(rule, nil)
+		assert.NoError(t, err)
+		assert.True(t, math.IsNaN(result.(float64)))
+	}
+}
+
+func TestWithDivisionPolicy(t *testing.T) {
+	_, err := NewEngine(WithDivisionPolicy(DivisionPolicy(-1)))
+	assert.Error(t, err)
+}
@@ -0,0 +1,127 @@
+package jsonlogic
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+)
+
+// DryRunReport sums up the evaluations of a rule over sample data
+type DryRunReport struct {
+	Samples int `json:"samples"`
+
+	// Results counts the distinct results of the evaluations which
+	// succeeded, the most frequent first
+	Results []ResultCount `json:"results"`
+
+	// Failed counts the evaluations which failed, including samples that
+	// can't be read and evaluations that panicked, and Errors their
+	// distinct errors, the most frequent first
+	Failed int          `json:"failed"`
+	Errors []ErrorCount `json:"errors"`
+
+	// Uncovered are the expressions of the rule no sample evaluated, and
+	// the branches of its if, ?: and switch no sample chose, those having
+	// no Operator. See Coverage.
+	Uncovered []CoverageEntry `json:"uncovered"`
+}
+
+// ResultCount is a result of the evaluations of a DryRun, in canonical
+// form, with how many samples gave it
+type ResultCount struct {
+	Result json.RawMessage `json:"result"`
+	Count  int             `json:"count"`
+}
+
+// ErrorCount is an error of the evaluations of a DryRun, with how many
+// samples failed with it
+type ErrorCount struct {
+	Message string `json:"message"`
+	Count   int    `json:"count"`
+}
+
+// DryRun evaluates a rule over samples of data. See Engine.DryRun.
+func DryRun(rule io.Reader, samples []io.Reader) (*DryRunReport, error) {
+	return defaultEngine.DryRun(rule, samples)
+}
+
+// DryRun evaluates a rule over samples of JSON data and reports the
+// distribution of its results, its errors and the parts of the rule no
+// sample used, to check a new rule against a corpus of real documents
+// before deploying it. The error is returned only when the rule can't be
+// read.
+func (e *Engine) DryRun(rule io.Reader, samples []io.Reader) (*DryRunReport, error) {
+	compiled, err := e.Compile(rule)
+	if err != nil {
+		return nil, err
+	}
+
+	coverage := NewCoverage(compiled)
+	results := make(map[string]int)
+	failures := make(map[string]int)
+
+	report := &DryRunReport{Samples: len(samples)}
+
+	for _, sample := range samples {
+		result, err := e.dryRun(coverage, sample)
+		if err != nil {
+			report.Failed++
+			failures[err.Error()]++
+
+			continue
+		}
+
+		results[string(result)]++
+	}
+
+	report.Results = make([]ResultCount, 0, len(results))
+	for result, count := range results {
+		report.Results = append(report.Results, ResultCount{Result: json.RawMessage(result), Count: count})
+	}
+
+	sort.Slice(report.Results, func(i, j int) bool {
+		a, b := report.Results[i], report.Results[j]
+
+		return a.Count > b.Count || a.Count == b.Count && string(a.Result) < string(b.Result)
+	})
+
+	report.Errors = make([]ErrorCount, 0, len(failures))
+	for message, count := range failures {
+		report.Errors = append(report.Errors, ErrorCount{Message: message, Count: count})
+	}
+
+	sort.Slice(report.Errors, func(i, j int) bool {
+		a, b := report.Errors[i], report.Errors[j]
+
+		return a.Count > b.Count || a.Count == b.Count && a.Message < b.Message
+	})
+
+	report.Uncovered = coverage.Uncovered()
+
+	return report, nil
+}
+
+// dryRun evaluates a sample, returning the canonical form of the result
+func (e *Engine) dryRun(coverage *Coverage, sample io.Reader) (result []byte, err error) {
+	// operators may panic on data they don't expect, which must not stop
+	// the evaluation of the other samples
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("evaluation panicked: %v", r)
+		}
+	}()
+
+	data, err := ioutil.ReadAll(sample)
+	if err != nil {
+		return nil, fmt.Errorf("error reading data: %w", err)
+	}
+
+	output, err := e.ApplyWithCoverage(coverage, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodeCanonical(output, "")
+}
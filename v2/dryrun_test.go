@@ -0,0 +1,55 @@
+package jsonlogic
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDryRun(t *testing.T) {
+	rule := `{"if": [
+		{">=": [{"var": "total"}, 1000]}, "gold",
+		{">=": [{"var": "total"}, 100]}, "silver",
+		{"/": [{"var": "total"}, {"var": "divisor"}]}
+	]}`
+
+	engine, err := NewEngine(WithDivisionPolicy(DivideToError))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	samples := []io.Reader{
+		strings.NewReader(`{"total": 150, "divisor": 1}`),
+		strings.NewReader(`{"total": 200, "divisor": 1}`),
+		strings.NewReader(`{"total": 10, "divisor": 2}`),
+		strings.NewReader(`{"total": 10, "divisor": 0}`),
+		strings.NewReader(`{"total": 10}`),
+		strings.NewReader(`{"total": `),
+	}
+
+	report, err := engine.DryRun(strings.NewReader(rule), samples)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 6, report.Samples)
+	assert.Equal(t, []ResultCount{
+		{Result: []byte(`"silver"`), Count: 2},
+		{Result: []byte(`5`), Count: 1},
+	}, report.Results)
+
+	// the missing divisor is null, which is 0
+	assert.Equal(t, 3, report.Failed)
+	assert.Len(t, report.Errors, 2)
+	assert.Equal(t, ErrorCount{Message: "division by zero: 10 / 0", Count: 2}, report.Errors[0])
+	assert.Contains(t, report.Errors[1].Message, "error parsing data")
+
+	assert.Equal(t, []CoverageEntry{{Path: "/if/1"}}, report.Uncovered)
+}
+
+func TestDryRunInvalidRule(t *testing.T) {
+	_, err := DryRun(strings.NewReader(`{"==": [`), nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,386 @@
+package jsonlogic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// Engine evaluates JSON Logic rules using a given configuration.
+// Engines are created with NewEngine and are safe for concurrent use.
+type Engine struct {
+	clock       func() time.Time
+	random      func() float64
+	strictCasts bool
+	pathSyntax  PathSyntax
+	coercion    CoercionProfile
+	collation   *collation
+	dates       dateSettings
+	middlewares []Middleware
+	extensions  map[string]OperatorInfo
+	operatorSet string
+	allowed     map[string]bool
+
+	varConvention   VarConvention
+	divisionPolicy  DivisionPolicy
+	nonFinitePolicy NonFinitePolicy
+	epsilon         float64
+	integers        bool
+	output          outputFormat
+	transformers    []OutputTransformer
+	keyOrder        bool
+	arena           bool
+	maxListLength   int
+	maxResultSize   int
+	stepBudget      int
+
+	instrumentation Instrumentation
+	logger          Logger
+	audit           *auditLog
+	resolver        VarResolver
+	env             interface{}
+	cache           Cache
+	registry        RuleRegistry
+	patterns        *patternCache
+
+	// workers holds a token for every goroutine evaluating iterations
+	workers           chan struct{}
+	minParallelLength int
+}
+
+// Option configures an Engine created by NewEngine
+type Option func(*Engine) error
+
+var defaultEngine = newEngine()
+
+func newEngine() *Engine {
+	return &Engine{
+		clock:    time.Now,
+		random:   rand.Float64,
+		dates:    defaultDates,
+		patterns: newPatternCache(maxCachedPatterns),
+	}
+}
+
+// NewEngine returns an Engine configured with the given options.
+// The package level functions behave like an Engine without options.
+func NewEngine(options ...Option) (*Engine, error) {
+	e := newEngine()
+
+	for _, option := range options {
+		if err := option(e); err != nil {
+			return nil, err
+		}
+	}
+
+	return e, nil
+}
+
+// WithClock replaces the source of the current time used by the date
+// operators, which is mostly useful to write deterministic tests.
+func WithClock(clock func() time.Time) Option {
+	return func(e *Engine) error {
+		if clock == nil {
+			return fmt.Errorf("clock must not be nil")
+		}
+
+		e.clock = clock
+
+		return nil
+	}
+}
+
+// WithStrictCasts makes the cast operators (to_number, to_string and
+// to_bool) fail the evaluation with ErrInvalidCast when a value can't be
+// converted, instead of returning null.
+func WithStrictCasts() Option {
+	return func(e *Engine) error {
+		e.strictCasts = true
+
+		return nil
+	}
+}
+
+// evaluator holds the state of a single evaluation
+type evaluator struct {
+	engine *Engine
+
+	// ctx is the context of the evaluation, given to the instrumentation
+	ctx context.Context
+
+	// tracing enables recording the evaluation of each expression in a
+	// tree starting at root; current is the expression being evaluated
+	tracing bool
+	root    *Trace
+	current *Trace
+
+	// prefetched holds the values of the paths resolved before the
+	// evaluation by a BatchVarResolver, nil for the missing ones
+	prefetched map[string]interface{}
+
+	// iterating counts the iterations being evaluated, whose closed
+	// expressions are memoized in memo, keyed by their address, and
+	// parents holds the data they are evaluated against, the innermost
+	// last; index is the position of the element the innermost one is
+	// evaluating, and key its name for iterations over objects
+	iterating int
+	memo      map[uintptr]memoEntry
+	parents   []interface{}
+	index     int
+	key       interface{}
+
+	// worker is set for the evaluators of the chunks of a parallel
+	// iteration
+	worker bool
+
+	// references are the names of the named rules being evaluated
+	references []string
+
+	// keys identify the expressions of the rule being evaluated whose
+	// results are shared, in shared, with the other rules of ApplyAll
+	keys   map[uintptr]expressionKey
+	shared map[expressionKey]interface{}
+
+	// budget holds the steps left to the evaluation of rule, shared with
+	// the workers of parallel iterations; expression is the expression of
+	// rule being evaluated, or a copy of it whose origin is in origins
+	budget     *int64
+	rule       interface{}
+	expression interface{}
+	origins    map[uintptr]origin
+
+	// coverage counts the expressions and branches evaluated, see
+	// ApplyWithCoverage
+	coverage *coverageRun
+
+	// started is the time of the evaluation, which now gives
+	started time.Time
+
+	// scopes are the values bound by the lets and the functions being
+	// evaluated, the innermost last, functions the functions defined by
+	// the defs around the expression being evaluated, and calling the
+	// functions being called
+	scopes    []map[string]interface{}
+	functions []map[string]*function
+	calling   []*function
+
+	// arena allocates the temporary values of the evaluation, see
+	// WithArena
+	arena *arena
+}
+
+func (e *Engine) evaluator() *evaluator {
+	return &evaluator{engine: e}
+}
+
+// evaluationError carries the errors raised while evaluating a rule up to
+// evaluate, telling them apart from any other panic
+type evaluationError struct {
+	err error
+}
+
+// fail aborts the evaluation with err
+func (ev *evaluator) fail(err error) {
+	panic(evaluationError{err: err})
+}
+
+// evaluate applies a decoded rule to decoded data
+func (e *Engine) evaluate(rule, data interface{}) (interface{}, error) {
+	return e.evaluateWith(context.Background(), nil, rule, data)
+}
+
+// evaluateWith is like evaluate, in ctx, allocating the temporary values
+// of the evaluation from a when it isn't nil. The result may share them,
+// so it must be encoded before a is released.
+func (e *Engine) evaluateWith(ctx context.Context, a *arena, rule, data interface{}) (interface{}, error) {
+	if e.cache != nil {
+		return e.cached(ctx, rule, data)
+	}
+
+	ev := e.acquire()
+	ev.ctx = ctx
+	ev.arena = a
+	defer release(ev)
+
+	return ev.run(rule, data)
+}
+
+// run applies a decoded rule to decoded data, returning the errors raised
+// by the operators. Anything but an object is returned as is.
+func (ev *evaluator) run(rule, data interface{}) (interface{}, error) {
+	result := rule
+
+	err := ev.execute(rule, data, func() {
+		result = materialize(ev.apply(rule, data))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// execute calls evaluate to evaluate a decoded rule against decoded data,
+// once the evaluation is instrumented, budgeted and its vars prefetched,
+// returning the errors raised by the operators. Anything but an object
+// isn't evaluated.
+func (ev *evaluator) execute(rule, data interface{}, evaluate func()) (err error) {
+	if ev.engine.instrumentation != nil {
+		done := ev.instrument()
+		defer func() {
+			done(err)
+		}()
+	}
+
+	if !isMap(rule) {
+		return nil
+	}
+
+	defer recoverFailure(&err)
+
+	if ev.engine.stepBudget > 0 {
+		ev.startBudget(rule)
+	}
+
+	if resolver, ok := ev.engine.resolver.(BatchVarResolver); ok {
+		ev.prefetch(resolver, rule, data)
+	}
+
+	evaluate()
+
+	return nil
+}
+
+// recoverFailure stops the panics raised by fail, storing their error in err
+func recoverFailure(err *error) {
+	if r := recover(); r != nil {
+		failure, ok := r.(evaluationError)
+		if !ok {
+			panic(r)
+		}
+
+		*err = failure.err
+	}
+}
+
+// decode reads the rule and the data given to Apply, recording the order
+// of the keys of their objects in order unless it is nil
+func (e *Engine) decode(rule, data io.Reader, order keyOrder) (interface{}, interface{}, error) {
+	if rule == nil {
+		return nil, nil, fmt.Errorf("error Apply-ing nil rule")
+	}
+	if data == nil {
+		// best effort, nil data is likely no-data needed
+		data = strings.NewReader("{}")
+	}
+	var _rule interface{}
+	var _data interface{}
+
+	err := e.readJSON(order, rule, &_rule)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error parsing rule: %w", err)
+	}
+
+	err = e.readJSON(order, data, &_data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error parsing data %w", err)
+	}
+
+	return _rule, _data, nil
+}
+
+// Apply read the rule and it's data from io.Reader, executes it
+// and write back a JSON into an io.Writer result
+func (e *Engine) Apply(rule, data io.Reader, result io.Writer) error {
+	return e.ApplyWithContext(context.Background(), rule, data, result)
+}
+
+// ApplyWithContext is like Apply, giving ctx to the instrumentation of the
+// engine, see WithInstrumentation
+func (e *Engine) ApplyWithContext(ctx context.Context, rule, data io.Reader, result io.Writer) error {
+	order := e.newKeyOrder()
+
+	_rule, _data, err := e.decode(rule, data, order)
+	if err != nil {
+		return err
+	}
+
+	a := e.newArena()
+	defer a.release()
+
+	output, err := e.evaluateIn(ctx, order, a, _rule, _data)
+	if err != nil {
+		return err
+	}
+
+	output, err = e.prepare(order, output)
+	if err != nil {
+		return err
+	}
+
+	return e.write(result, output)
+}
+
+// ApplyRaw is like Apply, but works with raw JSON messages
+func (e *Engine) ApplyRaw(rule, data json.RawMessage) (json.RawMessage, error) {
+	return e.ApplyRawWithContext(context.Background(), rule, data)
+}
+
+// ApplyRawWithContext is like ApplyRaw, giving ctx to the instrumentation
+// of the engine, see WithInstrumentation
+func (e *Engine) ApplyRawWithContext(ctx context.Context, rule, data json.RawMessage) (json.RawMessage, error) {
+	var _rule interface{}
+	var _data interface{}
+
+	order := e.newKeyOrder()
+
+	err := e.unmarshal(order, rule, &_rule)
+	if err != nil {
+		return nil, err
+	}
+
+	err = e.unmarshal(order, data, &_data)
+	if err != nil {
+		return nil, err
+	}
+
+	a := e.newArena()
+	defer a.release()
+
+	result, err := e.evaluateIn(ctx, order, a, _rule, _data)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err = e.prepare(order, result)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.marshal(result, false)
+}
+
+// ApplyInterface is like Apply, but works with already decoded values.
+// The data may hold any Go values, like typed slices and maps, numbers of
+// any type and structs, which are read like their JSON encoding. The rule
+// and the data are never modified, even by operators like set which return
+// modified copies. Results may share lists and objects with the data, so
+// modifying them changes the data too.
+func (e *Engine) ApplyInterface(rule, data interface{}) (interface{}, error) {
+	return e.ApplyInterfaceWithContext(context.Background(), rule, data)
+}
+
+// ApplyInterfaceWithContext is like ApplyInterface, giving ctx to the
+// instrumentation of the engine, see WithInstrumentation
+func (e *Engine) ApplyInterfaceWithContext(ctx context.Context, rule, data interface{}) (interface{}, error) {
+	result, err := e.evaluateWith(ctx, nil, rule, fromGoData(data))
+	if err != nil {
+		return nil, err
+	}
+
+	return e.transform(result)
+}
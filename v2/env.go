@@ -0,0 +1,57 @@
+package jsonlogic
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// envPrefix is the prefix of the var paths reading the environment of the
+// engine
+const envPrefix = "$env"
+
+// WithEnvironment gives an engine a metadata object, like the tenant, the
+// deployment environment or the region it serves, which rules read under
+// the $env prefix of var: {"var": "$env.tenant"}, or {"var": "$env"} for
+// the whole object, without it being copied into every data. Paths after
+// the prefix are dot paths. The object is copied as JSON, so changing it
+// afterwards doesn't change the engine, and rules can't modify it.
+func WithEnvironment(env map[string]interface{}) Option {
+	return func(e *Engine) error {
+		encoded, err := json.Marshal(env)
+		if err != nil {
+			return fmt.Errorf("invalid environment: %w", err)
+		}
+
+		var decoded interface{}
+		if err := json.Unmarshal(encoded, &decoded); err != nil {
+			return fmt.Errorf("invalid environment: %w", err)
+		}
+
+		if decoded == nil {
+			decoded = map[string]interface{}{}
+		}
+
+		e.env = decoded
+
+		return nil
+	}
+}
+
+// environment reads the var paths starting with the $env prefix from the
+// environment of the engine, telling if values is such a path
+func (ev *evaluator) environment(values interface{}) (interface{}, bool) {
+	path, fallback := varArgs(values)
+	if path != envPrefix && !strings.HasPrefix(path, envPrefix+".") {
+		return nil, false
+	}
+
+	path = strings.TrimPrefix(strings.TrimPrefix(path, envPrefix), ".")
+
+	value := getVar(path, ev.engine.env, DotPaths)
+	if value == nil {
+		return fallback, true
+	}
+
+	return value, true
+}
@@ -0,0 +1,67 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnvironment(t *testing.T) {
+	env := map[string]interface{}{
+		"tenant": "acme",
+		"limits": map[string]interface{}{"order": 1000},
+	}
+
+	engine, err := NewEngine(WithEnvironment(env))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// changing the environment afterwards doesn't change the engine
+	env["tenant"] = "other"
+
+	scenarios := map[string]struct {
+		Rule     string
+		Data     string
+		Expected string
+	}{
+		"value":             {`{"var": "$env.tenant"}`, `{}`, `"acme"`},
+		"nested value":      {`{">": [{"var": "amount"}, {"var": "$env.limits.order"}]}`, `{"amount": 1500}`, `true`},
+		"whole environment": {`{"var": "$env"}`, `{}`, `{"tenant": "acme", "limits": {"order": 1000}}`},
+		"default":           {`{"var": ["$env.region", "eu"]}`, `{}`, `"eu"`},
+		"not from the data": {`{"var": "$env.tenant"}`, `{"$env": {"tenant": "evil"}}`, `"acme"`},
+		"other prefixes":    {`{"var": "$environment"}`, `{"$environment": 1}`, `1`},
+		"in an iteration":   {`{"map": [{"var": "items"}, {"cat": [{"var": "$env.tenant"}, "-", {"var": ""}]}]}`, `{"items": [1, 2]}`, `["acme-1", "acme-2"]`},
+		"can't be modified": {`{"set": [{"var": "$env"}, "tenant", "x"]}`, `{}`, `{"tenant": "x", "limits": {"order": 1000}}`},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var result bytes.Buffer
+
+			err := engine.Apply(strings.NewReader(scenario.Rule), strings.NewReader(scenario.Data), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, result.String())
+		})
+	}
+}
+
+func TestEnvironmentIsOptIn(t *testing.T) {
+	var result bytes.Buffer
+
+	err := Apply(strings.NewReader(`{"var": "$env.tenant"}`), strings.NewReader(`{"$env": {"tenant": "data"}}`), &result)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.JSONEq(t, `"data"`, result.String())
+
+	_, err = NewEngine(WithEnvironment(map[string]interface{}{"f": func() {}}))
+	assert.Error(t, err)
+}
@@ -0,0 +1,42 @@
+package jsonlogic
+
+import (
+	"fmt"
+	"math"
+)
+
+// WithEpsilon makes the comparison operators (==, !=, <, <=, > and >=),
+// and between and the range operators built on them, consider numbers
+// equal when they differ by epsilon at most, so that sums of prices like
+// 0.1 + 0.2 equal 0.3 despite the rounding of floating point numbers.
+// Numbers are then only smaller than others by more than epsilon. ===,
+// !== and CompatJS, which compare like JavaScript, are exact.
+func WithEpsilon(epsilon float64) Option {
+	return func(e *Engine) error {
+		if !(epsilon >= 0) || math.IsInf(epsilon, 0) {
+			return fmt.Errorf("epsilon must be a finite positive number, got %v", epsilon)
+		}
+
+		e.epsilon = epsilon
+
+		return nil
+	}
+}
+
+// approximate tells if two values are numbers compared within the epsilon
+// of the engine
+func (ev *evaluator) approximate(a, b interface{}) bool {
+	return ev.engine.epsilon > 0 && isNumber(a) && isNumber(b)
+}
+
+// nearlyEquals tells if numbers differ by the epsilon of the engine at most
+func (ev *evaluator) nearlyEquals(a, b float64) bool {
+	// infinities are only equal to themselves
+	return a == b || math.Abs(a-b) <= ev.engine.epsilon
+}
+
+// nearlyLess tells if a number is smaller than another by more than the
+// epsilon of the engine
+func (ev *evaluator) nearlyLess(a, b float64) bool {
+	return b-a > ev.engine.epsilon
+}
@@ -0,0 +1,82 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEpsilon(t *testing.T) {
+	data := `{"prices": [0.1, 0.2], "total": 0.3}`
+	sum := `{"+": [{"var": "prices.0"}, {"var": "prices.1"}]}`
+
+	scenarios := map[string]struct {
+		Options  []Option
+		Rule     string
+		Expected string
+	}{
+		"exact by default":    {nil, `{"==": [` + sum + `, {"var": "total"}]}`, `false`},
+		"equal":               {nil, `{"==": [` + sum + `, {"var": "total"}]}`, `true`},
+		"not equal":           {nil, `{"!=": [` + sum + `, {"var": "total"}]}`, `false`},
+		"at most":             {nil, `{"<=": [` + sum + `, {"var": "total"}]}`, `true`},
+		"at least":            {nil, `{">=": [{"var": "total"}, ` + sum + `]}`, `true`},
+		"not smaller":         {nil, `{"<": [{"var": "total"}, ` + sum + `]}`, `false`},
+		"not greater":         {nil, `{">": [` + sum + `, {"var": "total"}]}`, `false`},
+		"chained":             {nil, `{"<=": [0.3, ` + sum + `, 0.3]}`, `true`},
+		"between":             {nil, `{"between": [` + sum + `, 0, 0.3, "[]"]}`, `true`},
+		"further than":        {nil, `{"==": [0.3, 0.31]}`, `false`},
+		"smaller by more":     {nil, `{"<": [0.3, 0.31]}`, `true`},
+		"strict equality":     {nil, `{"===": [` + sum + `, {"var": "total"}]}`, `false`},
+		"strings":             {nil, `{"==": ["0.30000000000000004", 0.3]}`, `false`},
+		"strict coercion":     {[]Option{WithCoercion(StrictCoercion)}, `{"==": [` + sum + `, {"var": "total"}]}`, `true`},
+		"javascript coercion": {[]Option{WithCoercion(CompatJS)}, `{"==": [` + sum + `, {"var": "total"}]}`, `false`},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			options := scenario.Options
+			if name != "exact by default" {
+				options = append(options, WithEpsilon(1e-9))
+			}
+
+			engine, err := NewEngine(options...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var result bytes.Buffer
+
+			err = engine.Apply(strings.NewReader(scenario.Rule), strings.NewReader(data), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, result.String())
+		})
+	}
+}
+
+func TestEpsilonWithInfinities(t *testing.T) {
+	engine, err := NewEngine(WithEpsilon(0.01))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ev := engine.evaluator()
+
+	assert.True(t, ev.equals(math.Inf(1), math.Inf(1)))
+	assert.False(t, ev.equals(math.Inf(1), math.Inf(-1)))
+	assert.False(t, ev.less(math.Inf(1), math.Inf(1)))
+	assert.True(t, ev.less(1.0, math.Inf(1)))
+}
+
+func TestWithEpsilon(t *testing.T) {
+	for _, epsilon := range []float64{-1, math.NaN(), math.Inf(1)} {
+		_, err := NewEngine(WithEpsilon(epsilon))
+		assert.Error(t, err)
+	}
+}
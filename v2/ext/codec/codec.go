@@ -0,0 +1,133 @@
+// Package codec adds operators encoding and decoding strings to JSON
+// Logic, to read the encoded fields of payloads like webhooks:
+//
+//   - base64_encode and base64_decode, using the standard alphabet with
+//     padding, or the URL alphabet when given "url":
+//     {"base64_decode": [{"var": "payload.data"}, "url"]}. Decoding accepts
+//     values with or without padding.
+//   - url_encode and url_decode, escaping strings to be put in queries, or
+//     in paths when given "path": {"url_encode": [{"var": "name"}, "path"]}
+//
+// They are evaluated by a middleware given to the engine:
+//
+//	engine, err := jsonlogic.NewEngine(jsonlogic.WithMiddlewares(codec.Operators))
+//
+// Info describes them for jsonlogic.WithOperatorInfo.
+//
+// Values that aren't strings, that can't be decoded, or that don't decode
+// to UTF-8 text give null.
+//
+// jsonlogic.IsValid and jsonlogic.Lint don't know these operators.
+package codec
+
+import (
+	"encoding/base64"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/bewica/jsonlogic/v2"
+)
+
+// Operators is a jsonlogic.Middleware evaluating base64_encode,
+// base64_decode, url_encode and url_decode, and passing the other
+// operators to the next evaluator
+func Operators(operator string, args []interface{}, next jsonlogic.Evaluator) (interface{}, error) {
+	switch operator {
+	case "base64_encode", "base64_decode", "url_encode", "url_decode":
+	default:
+		return next(operator, args)
+	}
+
+	if len(args) == 0 || len(args) > 2 {
+		return nil, nil
+	}
+
+	s, ok := args[0].(string)
+	if !ok {
+		return nil, nil
+	}
+
+	variant := ""
+	if len(args) == 2 {
+		variant, ok = args[1].(string)
+		if !ok {
+			return nil, nil
+		}
+	}
+
+	switch operator {
+	case "base64_encode":
+		encoding, ok := base64Encodings[variant]
+		if !ok {
+			return nil, nil
+		}
+
+		return encoding.EncodeToString([]byte(s)), nil
+	case "base64_decode":
+		return base64Decode(s, variant), nil
+	case "url_encode":
+		return urlEncode(s, variant), nil
+	}
+
+	return urlDecode(s, variant), nil
+}
+
+// Info describes the operators of Operators, for jsonlogic.WithOperatorInfo
+var Info = []jsonlogic.OperatorInfo{
+	{Name: "base64_encode", MinArgs: 1, MaxArgs: 2, Categories: []string{"string", "encoding"}},
+	{Name: "base64_decode", MinArgs: 1, MaxArgs: 2, Categories: []string{"string", "encoding"}},
+	{Name: "url_encode", MinArgs: 1, MaxArgs: 2, Categories: []string{"string", "encoding"}},
+	{Name: "url_decode", MinArgs: 1, MaxArgs: 2, Categories: []string{"string", "encoding"}},
+}
+
+// base64Encodings are the encodings of the variants of base64
+var base64Encodings = map[string]*base64.Encoding{
+	"":    base64.StdEncoding,
+	"url": base64.URLEncoding,
+}
+
+func base64Decode(s, variant string) interface{} {
+	encoding, ok := base64Encodings[variant]
+	if !ok {
+		return nil
+	}
+
+	decoded, err := encoding.WithPadding(base64.NoPadding).DecodeString(strings.TrimRight(s, "="))
+	if err != nil || !utf8.Valid(decoded) {
+		return nil
+	}
+
+	return string(decoded)
+}
+
+func urlEncode(s, variant string) interface{} {
+	switch variant {
+	case "":
+		return url.QueryEscape(s)
+	case "path":
+		return url.PathEscape(s)
+	}
+
+	return nil
+}
+
+func urlDecode(s, variant string) interface{} {
+	var decoded string
+	var err error
+
+	switch variant {
+	case "":
+		decoded, err = url.QueryUnescape(s)
+	case "path":
+		decoded, err = url.PathUnescape(s)
+	default:
+		return nil
+	}
+
+	if err != nil || !utf8.ValidString(decoded) {
+		return nil
+	}
+
+	return decoded
+}
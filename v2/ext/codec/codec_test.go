@@ -0,0 +1,58 @@
+package codec
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/bewica/jsonlogic/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOperators(t *testing.T) {
+	scenarios := map[string]struct {
+		Rule     string
+		Expected string
+	}{
+		"base64 encode":              {`{"base64_encode": "hello?"}`, `"aGVsbG8/"`},
+		"base64 encode for urls":     {`{"base64_encode": ["hello?", "url"]}`, `"aGVsbG8_"`},
+		"base64 encode with padding": {`{"base64_encode": "hi"}`, `"aGk="`},
+		"base64 decode":              {`{"base64_decode": {"var": "payload.data"}}`, `"{\"id\": 42}"`},
+		"base64 decode for urls":     {`{"base64_decode": ["aGVsbG8_", "url"]}`, `"hello?"`},
+		"base64 decode unpadded":     {`{"base64_decode": "aGk"}`, `"hi"`},
+		"base64 decode invalid":      {`{"base64_decode": "a*b"}`, `null`},
+		"base64 decode binary":       {`{"base64_decode": "//79"}`, `null`},
+		"base64 unknown variant":     {`{"base64_encode": ["hi", "hex"]}`, `null`},
+		"base64 of a number":         {`{"base64_encode": 42}`, `null`},
+		"url encode":                 {`{"url_encode": "a b&c=d/é"}`, `"a+b%26c%3Dd%2F%C3%A9"`},
+		"url encode a path":          {`{"url_encode": ["a b/c", "path"]}`, `"a%20b%2Fc"`},
+		"url decode":                 {`{"url_decode": {"var": "payload.query"}}`, `"a b&c"`},
+		"url decode a path":          {`{"url_decode": ["a+b%20c", "path"]}`, `"a+b c"`},
+		"url decode invalid":         {`{"url_decode": "%zz"}`, `null`},
+		"url unknown variant":        {`{"url_decode": ["a", "form"]}`, `null`},
+		"nested":                     {`{"==": [{"base64_decode": {"base64_encode": "round trip"}}, "round trip"]}`, `true`},
+		"other operators":            {`{"cat": ["a", "b"]}`, `"ab"`},
+		"missing argument":           {`{"base64_encode": []}`, `null`},
+	}
+
+	engine, err := jsonlogic.NewEngine(jsonlogic.WithMiddlewares(Operators))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := `{"payload": {"data": "eyJpZCI6IDQyfQ==", "query": "a+b%26c"}}`
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var result bytes.Buffer
+
+			err := engine.Apply(strings.NewReader(scenario.Rule), strings.NewReader(data), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, result.String())
+		})
+	}
+}
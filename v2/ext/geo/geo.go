@@ -0,0 +1,255 @@
+// Package geo adds geospatial operators to JSON Logic:
+//
+//   - geo_within tells if a point is inside a GeoJSON Polygon or
+//     MultiPolygon, or a Feature holding one, and outside of its holes:
+//     {"geo_within": [{"var": "address.location"}, {"var": "area"}]}
+//   - geo_distance_lt tells if the great-circle distance between two points
+//     is less than some meters:
+//     {"geo_distance_lt": [{"var": "location"}, {"var": "store"}, 5000]}
+//
+// They are evaluated by a middleware given to the engine:
+//
+//	engine, err := jsonlogic.NewEngine(jsonlogic.WithMiddlewares(geo.Operators))
+//
+// Info describes them for jsonlogic.WithOperatorInfo.
+//
+// Points are GeoJSON Points, or [longitude, latitude] lists as in GeoJSON.
+// Objects in rules are operators, so polygons usually come from the data.
+// Points and geometries that can't be read give null.
+//
+// jsonlogic.IsValid and jsonlogic.Lint don't know these operators.
+package geo
+
+import (
+	"math"
+
+	"github.com/bewica/jsonlogic/v2"
+)
+
+// earthRadius is the mean radius of the Earth in meters
+const earthRadius = 6371008.8
+
+// Operators is a jsonlogic.Middleware evaluating geo_within and
+// geo_distance_lt, and passing the other operators to the next evaluator
+func Operators(operator string, args []interface{}, next jsonlogic.Evaluator) (interface{}, error) {
+	switch operator {
+	case "geo_within":
+		return within(args), nil
+	case "geo_distance_lt":
+		return distanceLess(args), nil
+	}
+
+	return next(operator, args)
+}
+
+// Info describes the operators of Operators, for jsonlogic.WithOperatorInfo
+var Info = []jsonlogic.OperatorInfo{
+	{Name: "geo_within", MinArgs: 2, MaxArgs: 2, Categories: []string{"geo"}},
+	{Name: "geo_distance_lt", MinArgs: 3, MaxArgs: 3, Categories: []string{"geo", "comparison"}},
+}
+
+func within(args []interface{}) interface{} {
+	if len(args) != 2 {
+		return nil
+	}
+
+	p, ok := point(args[0])
+	if !ok {
+		return nil
+	}
+
+	polygons, ok := geometry(args[1])
+	if !ok {
+		return nil
+	}
+
+	for _, polygon := range polygons {
+		if contains(polygon, p) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func distanceLess(args []interface{}) interface{} {
+	if len(args) != 3 {
+		return nil
+	}
+
+	a, ok := point(args[0])
+	if !ok {
+		return nil
+	}
+
+	b, ok := point(args[1])
+	if !ok {
+		return nil
+	}
+
+	meters, ok := args[2].(float64)
+	if !ok {
+		return nil
+	}
+
+	return Distance(a, b) < meters
+}
+
+// Point is a position given by its longitude and latitude in degrees
+type Point struct {
+	Longitude float64
+	Latitude  float64
+}
+
+// Distance returns the great-circle distance between two points in
+// meters, using the haversine formula on a spherical Earth
+func Distance(a, b Point) float64 {
+	latA, latB := radians(a.Latitude), radians(b.Latitude)
+	dLat := latB - latA
+	dLon := radians(b.Longitude - a.Longitude)
+
+	h := math.Pow(math.Sin(dLat/2), 2) + math.Cos(latA)*math.Cos(latB)*math.Pow(math.Sin(dLon/2), 2)
+
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
+
+// polygon is a list of linear rings, the first one being the exterior and
+// the others holes
+type polygon [][]Point
+
+// contains tells if a point is inside the exterior ring of a polygon and
+// outside its holes
+func contains(p polygon, point Point) bool {
+	if len(p) == 0 || !inRing(p[0], point) {
+		return false
+	}
+
+	for _, hole := range p[1:] {
+		if inRing(hole, point) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// inRing casts a ray from a point and counts the edges of a ring it
+// crosses, an odd count meaning the point is inside
+func inRing(ring []Point, point Point) bool {
+	inside := false
+
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		a, b := ring[i], ring[j]
+		if (a.Latitude > point.Latitude) == (b.Latitude > point.Latitude) {
+			continue
+		}
+
+		longitude := a.Longitude + (point.Latitude-a.Latitude)*(b.Longitude-a.Longitude)/(b.Latitude-a.Latitude)
+		if point.Longitude < longitude {
+			inside = !inside
+		}
+	}
+
+	return inside
+}
+
+// point reads a GeoJSON Point or a [longitude, latitude] list
+func point(value interface{}) (Point, bool) {
+	if object, ok := value.(map[string]interface{}); ok {
+		if object["type"] != "Point" {
+			return Point{}, false
+		}
+
+		value = object["coordinates"]
+	}
+
+	position, ok := value.([]interface{})
+	if !ok || len(position) < 2 {
+		return Point{}, false
+	}
+
+	longitude, ok := position[0].(float64)
+	if !ok {
+		return Point{}, false
+	}
+
+	latitude, ok := position[1].(float64)
+	if !ok {
+		return Point{}, false
+	}
+
+	return Point{Longitude: longitude, Latitude: latitude}, true
+}
+
+// geometry reads the polygons of a GeoJSON Polygon, MultiPolygon or
+// Feature holding one of them
+func geometry(value interface{}) ([]polygon, bool) {
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+
+	switch object["type"] {
+	case "Feature":
+		return geometry(object["geometry"])
+	case "Polygon":
+		p, ok := readPolygon(object["coordinates"])
+		if !ok {
+			return nil, false
+		}
+
+		return []polygon{p}, true
+	case "MultiPolygon":
+		list, ok := object["coordinates"].([]interface{})
+		if !ok {
+			return nil, false
+		}
+
+		polygons := make([]polygon, 0, len(list))
+		for _, coordinates := range list {
+			p, ok := readPolygon(coordinates)
+			if !ok {
+				return nil, false
+			}
+
+			polygons = append(polygons, p)
+		}
+
+		return polygons, true
+	}
+
+	return nil, false
+}
+
+func readPolygon(value interface{}) (polygon, bool) {
+	rings, ok := value.([]interface{})
+	if !ok {
+		return nil, false
+	}
+
+	p := make(polygon, 0, len(rings))
+	for _, r := range rings {
+		positions, ok := r.([]interface{})
+		if !ok {
+			return nil, false
+		}
+
+		ring := make([]Point, 0, len(positions))
+		for _, position := range positions {
+			vertex, ok := point(position)
+			if !ok {
+				return nil, false
+			}
+
+			ring = append(ring, vertex)
+		}
+
+		p = append(p, ring)
+	}
+
+	return p, true
+}
@@ -0,0 +1,90 @@
+package geo
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/bewica/jsonlogic/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+const data = `{
+	"inside": [2.35, 48.86],
+	"hole": [2.345, 48.855],
+	"outside": [2.5, 48.9],
+	"point": {"type": "Point", "coordinates": [2.35, 48.86]},
+	"area": {
+		"type": "Polygon",
+		"coordinates": [
+			[[2.3, 48.8], [2.4, 48.8], [2.4, 48.9], [2.3, 48.9], [2.3, 48.8]],
+			[[2.34, 48.85], [2.35, 48.85], [2.35, 48.86], [2.34, 48.86], [2.34, 48.85]]
+		]
+	},
+	"feature": {
+		"type": "Feature",
+		"properties": {},
+		"geometry": {
+			"type": "MultiPolygon",
+			"coordinates": [
+				[[[0, 0], [1, 0], [1, 1], [0, 1], [0, 0]]],
+				[[[2.3, 48.8], [2.4, 48.8], [2.4, 48.9], [2.3, 48.8]]]
+			]
+		}
+	},
+	"paris": [2.3522, 48.8566],
+	"london": [-0.1276, 51.5072],
+	"line": {"type": "LineString", "coordinates": [[0, 0], [1, 1]]}
+}`
+
+func TestOperators(t *testing.T) {
+	scenarios := map[string]struct {
+		Rule     string
+		Expected string
+	}{
+		"within":                  {`{"geo_within": [{"var": "inside"}, {"var": "area"}]}`, `true`},
+		"outside":                 {`{"geo_within": [{"var": "outside"}, {"var": "area"}]}`, `false`},
+		"in a hole":               {`{"geo_within": [{"var": "hole"}, {"var": "area"}]}`, `false`},
+		"point":                   {`{"geo_within": [{"var": "point"}, {"var": "area"}]}`, `true`},
+		"literal point":           {`{"geo_within": [[2.39, 48.81], {"var": "area"}]}`, `true`},
+		"feature":                 {`{"geo_within": [[0.5, 0.5], {"var": "feature"}]}`, `true`},
+		"outside a feature":       {`{"geo_within": [[1.5, 0.5], {"var": "feature"}]}`, `false`},
+		"unsupported geometry":    {`{"geo_within": [[0.5, 0.5], {"var": "line"}]}`, `null`},
+		"not a point":             {`{"geo_within": ["Paris", {"var": "area"}]}`, `null`},
+		"distance":                {`{"geo_distance_lt": [{"var": "paris"}, {"var": "london"}, 350000]}`, `true`},
+		"too far":                 {`{"geo_distance_lt": [{"var": "paris"}, {"var": "london"}, 300000]}`, `false`},
+		"missing distance":        {`{"geo_distance_lt": [{"var": "paris"}, {"var": "london"}]}`, `null`},
+		"other operators":         {`{"+": [1, 2]}`, `3`},
+		"nested in other":         {`{"if": [{"geo_within": [{"var": "inside"}, {"var": "area"}]}, "deliver", "no"]}`, `"deliver"`},
+		"distance to a geo point": {`{"geo_distance_lt": [{"var": "point"}, {"var": "paris"}, 1000]}`, `true`},
+	}
+
+	engine, err := jsonlogic.NewEngine(jsonlogic.WithMiddlewares(Operators))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var result bytes.Buffer
+
+			err := engine.Apply(strings.NewReader(scenario.Rule), strings.NewReader(data), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, result.String())
+		})
+	}
+}
+
+func TestDistance(t *testing.T) {
+	paris := Point{Longitude: 2.3522, Latitude: 48.8566}
+	london := Point{Longitude: -0.1276, Latitude: 51.5072}
+
+	assert.InDelta(t, 343500, Distance(paris, london), 1000)
+	assert.Equal(t, 0.0, Distance(paris, paris))
+	assert.InDelta(t, math.Pi*earthRadius, Distance(Point{0, 0}, Point{180, 0}), 1)
+}
@@ -0,0 +1,106 @@
+// Package flags evaluates feature flags written as JSON Logic rules,
+// built from the usual templates:
+//
+//	flag := logic.Or(
+//		flags.Allowlist("user.id", "alice", "bob"),
+//		logic.And(flags.Attributes(map[string]interface{}{"user.country": "FR"}), flags.Rollout("new-checkout", "user.id", 25)),
+//	)
+//
+//	on, err := flags.Evaluate(flag, map[string]interface{}{"user": user})
+//
+// A flag is on when its rule evaluates to a truthy value. The templates
+// are plain rules, which can be marshaled to JSON to be stored by a flag
+// backend and evaluated from there.
+package flags
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/bewica/jsonlogic/v2"
+	"github.com/bewica/jsonlogic/v2/logic"
+)
+
+// Rollout returns a rule turning a flag on for a percentage of the users,
+// identified by an attribute. Users are bucketed by a hash of the flag
+// name and of their identifier, so a user keeps the same answer from one
+// evaluation to the other, gets it independently from the other flags,
+// and stays in when the percentage grows. Users without the attribute are
+// left out. The percentage has a precision of 0.01.
+func Rollout(flag, attribute string, percent float64) logic.Rule {
+	bucket := logic.Op("hash_sha256", logic.Cat(flag, ":", logic.Var(attribute)), 10000)
+
+	// missing gives a list, which ! must get within a list
+	present := logic.Op("!", logic.Missing(attribute))
+
+	return logic.And(present, bucket.Lt(percent*100))
+}
+
+// Allowlist returns a rule turning a flag on for the users whose
+// attribute is one of the given values
+func Allowlist(attribute string, values ...interface{}) logic.Rule {
+	if values == nil {
+		values = []interface{}{}
+	}
+
+	return logic.Var(attribute).In(values)
+}
+
+// Attributes returns a rule turning a flag on for the users whose
+// attributes, given by their var path, all have the given values:
+// {"user.country": "FR", "user.plan": "pro"}. Values are compared without
+// conversions, as with ===. An empty map matches every user.
+func Attributes(attributes map[string]interface{}) logic.Rule {
+	paths := make([]string, 0, len(attributes))
+	for path := range attributes {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+
+	conditions := make([]interface{}, 0, len(paths))
+	for _, path := range paths {
+		conditions = append(conditions, logic.Var(path).StrictEq(attributes[path]))
+	}
+
+	return logic.And(conditions...)
+}
+
+// Evaluate tells if a flag is on for a user given their attributes, with
+// the default engine. See EvaluateWith.
+func Evaluate(flag interface{}, attributes map[string]interface{}) (bool, error) {
+	return EvaluateWith(defaultEngine, flag, attributes)
+}
+
+var defaultEngine, _ = jsonlogic.NewEngine()
+
+// EvaluateWith tells if a flag is on for a user given their attributes,
+// which may hold any Go value that can be marshaled to JSON. The flag is a
+// logic.Rule, a rule in JSON as json.RawMessage or []byte, or a decoded
+// one.
+func EvaluateWith(engine *jsonlogic.Engine, flag interface{}, attributes map[string]interface{}) (bool, error) {
+	var rule interface{}
+
+	switch f := flag.(type) {
+	case logic.Rule:
+		rule = f.Interface()
+	case json.RawMessage:
+		if err := json.Unmarshal(f, &rule); err != nil {
+			return false, fmt.Errorf("error parsing flag: %w", err)
+		}
+	case []byte:
+		if err := json.Unmarshal(f, &rule); err != nil {
+			return false, fmt.Errorf("error parsing flag: %w", err)
+		}
+	default:
+		rule = f
+	}
+
+	on, err := engine.ApplyInterface(logic.Truthy(logic.Value(rule)).Interface(), logic.Value(attributes).Interface())
+	if err != nil {
+		return false, err
+	}
+
+	return on == true, nil
+}
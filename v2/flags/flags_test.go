@@ -0,0 +1,182 @@
+package flags
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/bewica/jsonlogic/v2"
+	"github.com/bewica/jsonlogic/v2/logic"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluate(t *testing.T) {
+	scenarios := map[string]struct {
+		Flag       interface{}
+		Attributes map[string]interface{}
+		Expected   bool
+	}{
+		"allowed": {
+			Flag:       Allowlist("user.id", "alice", "bob"),
+			Attributes: map[string]interface{}{"user": map[string]interface{}{"id": "bob"}},
+			Expected:   true,
+		},
+		"not allowed": {
+			Flag:       Allowlist("user.id", "alice", "bob"),
+			Attributes: map[string]interface{}{"user": map[string]interface{}{"id": "carol"}},
+			Expected:   false,
+		},
+		"empty allowlist": {
+			Flag:       Allowlist("user.id"),
+			Attributes: map[string]interface{}{"user": map[string]interface{}{"id": "bob"}},
+			Expected:   false,
+		},
+		"allowed numbers": {
+			Flag:       Allowlist("id", 1, 2, 3),
+			Attributes: map[string]interface{}{"id": 2},
+			Expected:   true,
+		},
+		"targeted": {
+			Flag:       Attributes(map[string]interface{}{"country": "FR", "plan": "pro"}),
+			Attributes: map[string]interface{}{"country": "FR", "plan": "pro", "age": 30},
+			Expected:   true,
+		},
+		"not targeted": {
+			Flag:       Attributes(map[string]interface{}{"country": "FR", "plan": "pro"}),
+			Attributes: map[string]interface{}{"country": "FR", "plan": "free"},
+			Expected:   false,
+		},
+		"targeted by any value": {
+			Flag:       Attributes(map[string]interface{}{}),
+			Attributes: map[string]interface{}{},
+			Expected:   true,
+		},
+		"targeted with conversions": {
+			Flag:       Attributes(map[string]interface{}{"age": 30}),
+			Attributes: map[string]interface{}{"age": "30"},
+			Expected:   false,
+		},
+		"rollout to everybody": {
+			Flag:       Rollout("checkout", "id", 100),
+			Attributes: map[string]interface{}{"id": "alice"},
+			Expected:   true,
+		},
+		"rollout to nobody": {
+			Flag:       Rollout("checkout", "id", 0),
+			Attributes: map[string]interface{}{"id": "alice"},
+			Expected:   false,
+		},
+		"rollout without identifier": {
+			Flag:       Rollout("checkout", "id", 100),
+			Attributes: map[string]interface{}{},
+			Expected:   false,
+		},
+		"combined": {
+			Flag:       logic.Or(Allowlist("id", "alice"), logic.And(Attributes(map[string]interface{}{"country": "FR"}), Rollout("checkout", "id", 100))),
+			Attributes: map[string]interface{}{"id": "bob", "country": "FR"},
+			Expected:   true,
+		},
+		"json": {
+			Flag:       json.RawMessage(`{"in": [{"var": "id"}, ["alice"]]}`),
+			Attributes: map[string]interface{}{"id": "alice"},
+			Expected:   true,
+		},
+		"bytes": {
+			Flag:       []byte(`{"==": [{"var": "id"}, "alice"]}`),
+			Attributes: map[string]interface{}{"id": "bob"},
+			Expected:   false,
+		},
+		"decoded": {
+			Flag:       map[string]interface{}{"var": "beta"},
+			Attributes: map[string]interface{}{"beta": true},
+			Expected:   true,
+		},
+		"truthy": {
+			Flag:       map[string]interface{}{"var": "plan"},
+			Attributes: map[string]interface{}{"plan": "pro"},
+			Expected:   true,
+		},
+		"constant": {
+			Flag:       true,
+			Attributes: nil,
+			Expected:   true,
+		},
+		"structs": {
+			Flag: Attributes(map[string]interface{}{"user.plan": "pro"}),
+			Attributes: map[string]interface{}{"user": struct {
+				Plan string `json:"plan"`
+			}{Plan: "pro"}},
+			Expected: true,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			on, err := Evaluate(scenario.Flag, scenario.Attributes)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, scenario.Expected, on)
+		})
+	}
+}
+
+func TestEvaluateInvalidJSON(t *testing.T) {
+	_, err := Evaluate(json.RawMessage(`{"var": `), nil)
+	assert.Error(t, err)
+}
+
+func TestEvaluateWith(t *testing.T) {
+	engine, err := jsonlogic.NewEngine(jsonlogic.WithRandom(func() float64 { return 0 }))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	on, err := EvaluateWith(engine, logic.Op("sample_pct", 1), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, on)
+}
+
+func TestRollout(t *testing.T) {
+	users := 10000
+
+	in := func(flag string, percent float64) map[string]bool {
+		selected := make(map[string]bool)
+		for i := 0; i < users; i++ {
+			id := fmt.Sprintf("user-%d", i)
+
+			on, err := Evaluate(Rollout(flag, "id", percent), map[string]interface{}{"id": id})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if on {
+				selected[id] = true
+			}
+		}
+
+		return selected
+	}
+
+	quarter := in("checkout", 25)
+	assert.InDelta(t, users/4, len(quarter), float64(users)/50)
+
+	half := in("checkout", 50)
+	for id := range quarter {
+		assert.True(t, half[id], "%s left the rollout when it grew", id)
+	}
+
+	other := in("search", 25)
+	shared := 0
+	for id := range quarter {
+		if other[id] {
+			shared++
+		}
+	}
+
+	assert.InDelta(t, users/16, shared, float64(users)/50)
+}
@@ -0,0 +1,76 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+)
+
+// Format reads a rule and writes it back in canonical form, indented with
+// indent: object keys are sorted and numbers written the same way
+// whatever their original notation (1.0, 1e0 and 1 are all 1). Rules with
+// the same meaning and a different layout are formatted alike, so their
+// diffs show only what changed.
+func Format(rule io.Reader, indent string) ([]byte, error) {
+	var tree interface{}
+
+	err := readJSON(rule, &tree)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing rule: %w", err)
+	}
+
+	return encodeCanonical(tree, indent)
+}
+
+// Minify is like Format, without any whitespace
+func Minify(rule io.Reader) ([]byte, error) {
+	return Format(rule, "")
+}
+
+// encodeCanonical writes a decoded rule as JSON. Numbers are written from
+// their float64 value, which is what the evaluation sees, and characters
+// HTML gives a meaning to are left alone since rules are full of them.
+func encodeCanonical(tree interface{}, indent string) ([]byte, error) {
+	var buffer bytes.Buffer
+
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	if indent != "" {
+		encoder.SetIndent("", indent)
+	}
+
+	if err := encoder.Encode(canonical(tree)); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSuffix(buffer.Bytes(), []byte("\n")), nil
+}
+
+// canonical returns a copy of value without the values having more than
+// one encoding: -0 is 0
+func canonical(value interface{}) interface{} {
+	switch v := value.(type) {
+	case float64:
+		if v == 0 && math.Signbit(v) {
+			return float64(0)
+		}
+	case []interface{}:
+		list := make([]interface{}, 0, len(v))
+		for _, element := range v {
+			list = append(list, canonical(element))
+		}
+
+		return list
+	case map[string]interface{}:
+		object := make(map[string]interface{}, len(v))
+		for key, element := range v {
+			object[key] = canonical(element)
+		}
+
+		return object
+	}
+
+	return value
+}
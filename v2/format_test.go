@@ -0,0 +1,82 @@
+package jsonlogic
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	scenarios := map[string]struct {
+		rule     string
+		expected string
+	}{
+		"sorted keys": {
+			rule:     `{"if": [{"var": "a"}, {"z": 1, "a": 2}, null]}`,
+			expected: "{\n  \"if\": [\n    {\n      \"var\": \"a\"\n    },\n    {\n      \"a\": 2,\n      \"z\": 1\n    },\n    null\n  ]\n}",
+		},
+		"operators are not escaped": {
+			rule:     `{"<": [1, 2]}`,
+			expected: "{\n  \"<\": [\n    1,\n    2\n  ]\n}",
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			formatted, err := Format(strings.NewReader(scenario.rule), "  ")
+			assert.NoError(t, err)
+			assert.Equal(t, scenario.expected, string(formatted))
+		})
+	}
+}
+
+func TestMinify(t *testing.T) {
+	scenarios := map[string]struct {
+		rule     string
+		expected string
+	}{
+		"whitespace": {
+			rule:     "{\n  \"and\": [ true,\n false ]\n}",
+			expected: `{"and":[true,false]}`,
+		},
+		"numbers": {
+			rule:     `[1.0, 1e0, 1.50, -0, 0.000001, 1e21, 12345678901234567890]`,
+			expected: `[1,1,1.5,0,0.000001,1e+21,12345678901234567000]`,
+		},
+		"strings": {
+			rule:     `{"cat": ["a", "<&>", "é"]}`,
+			expected: `{"cat":["a","<&>","é"]}`,
+		},
+		"key order": {
+			rule:     `{"map": [{"var": "l"}, {"b": 1, "a": {"d": 1, "c": 2}}]}`,
+			expected: `{"map":[{"var":"l"},{"a":{"c":2,"d":1},"b":1}]}`,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			minified, err := Minify(strings.NewReader(scenario.rule))
+			assert.NoError(t, err)
+			assert.Equal(t, scenario.expected, string(minified))
+		})
+	}
+}
+
+func TestFormatIsStable(t *testing.T) {
+	first, err := Format(strings.NewReader(`{"or": [{"==": [{"var": "a"}, 1.0]}, {"in": ["x", ["x", "y"]]}]}`), "\t")
+	assert.NoError(t, err)
+
+	second, err := Format(strings.NewReader(string(first)), "\t")
+	assert.NoError(t, err)
+	assert.Equal(t, string(first), string(second))
+}
+
+func TestFormatErrors(t *testing.T) {
+	_, err := Format(strings.NewReader(`{"and": [}`), "  ")
+	assert.Error(t, err)
+
+	_, err = Minify(strings.NewReader(``))
+	assert.Error(t, err)
+}
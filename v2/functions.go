@@ -0,0 +1,231 @@
+package jsonlogic
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrUnknownFunction is returned when call names a function no
+	// enclosing def defines
+	ErrUnknownFunction = errors.New("unknown function")
+	// ErrRecursiveFunction is returned when a function calls itself,
+	// directly or through other functions
+	ErrRecursiveFunction = errors.New("recursive function")
+)
+
+// definition is a function of a def: the names of its parameters and the
+// expression of its result
+type definition struct {
+	params []string
+	body   interface{}
+}
+
+// function is a function defined by a def being evaluated, with what its
+// body can read: the data, the data and the position of the iterations,
+// the values of the lets and the functions around the def, its own
+// included
+type function struct {
+	definition
+	name      string
+	data      interface{}
+	parents   []interface{}
+	index     int
+	key       interface{}
+	scopes    []map[string]interface{}
+	functions []map[string]*function
+}
+
+// defArgs reads the arguments of def: an object of functions, written as
+// lists of the names of their parameters and of their body, and the
+// expression calling them under the "in" key. Names can't be empty, and
+// names of parameters can't contain dots.
+func defArgs(values interface{}) (map[string]definition, interface{}, bool) {
+	args, ok := values.(map[string]interface{})
+	if !ok {
+		// a list around the object, like the arguments of other operators
+		parsed := toSlice(values)
+		if len(parsed) != 1 || !isMap(parsed[0]) {
+			return nil, nil, false
+		}
+
+		args = parsed[0].(map[string]interface{})
+	}
+
+	body, ok := args[letBody]
+	if !ok {
+		return nil, nil, false
+	}
+
+	definitions := make(map[string]definition, len(args)-1)
+	for name, value := range args {
+		if name == letBody {
+			continue
+		}
+
+		parts := toSlice(value)
+		if name == "" || len(parts) != 2 || !isSlice(parts[0]) {
+			return nil, nil, false
+		}
+
+		var params []string
+		for _, param := range parts[0].([]interface{}) {
+			if !isString(param) || param == "" || strings.Contains(param.(string), ".") {
+				return nil, nil, false
+			}
+
+			params = append(params, param.(string))
+		}
+
+		definitions[name] = definition{params: params, body: parts[1]}
+	}
+
+	return definitions, body, true
+}
+
+// paramNames returns the parameters of a function as the bindings of a let
+func (d definition) paramNames() map[string]interface{} {
+	names := make(map[string]interface{}, len(d.params))
+	for _, param := range d.params {
+		names[param] = nil
+	}
+
+	return names
+}
+
+// defOperands returns the bodies of the functions of a def and its body as
+// a list of expressions, for the functions going through the expressions
+// of rules
+func defOperands(values interface{}) interface{} {
+	definitions, body, ok := defArgs(values)
+	if !ok {
+		return values
+	}
+
+	operands := make([]interface{}, 0, len(definitions)+1)
+	for _, definition := range definitions {
+		operands = append(operands, definition.body)
+	}
+
+	return append(operands, body)
+}
+
+// def defines functions for an expression, which calls them with call:
+// {"def": {"isAdult": [["person"], {">=": [{"var": "person.age"}, 18]}],
+// "in": {"filter": [{"var": "users"}, {"call": ["isAdult", {"var": ""}]}]}}}.
+// The bodies of the functions read their parameters with var, before the
+// values of the lets around the def and the data it is evaluated against,
+// and call the other functions of the def and those around it. It returns
+// null when the arguments are invalid.
+func (ev *evaluator) def(values, data interface{}) interface{} {
+	definitions, body, ok := defArgs(values)
+	if !ok {
+		return nil
+	}
+
+	functions := make(map[string]*function, len(definitions))
+
+	// the slice is shared with the workers of parallel iterations, so it
+	// is copied instead of appended to in place
+	previous := ev.functions
+	ev.functions = append(ev.functions[:len(ev.functions):len(ev.functions)], functions)
+	defer func() {
+		ev.functions = previous
+	}()
+
+	for name, definition := range definitions {
+		functions[name] = &function{
+			definition: definition,
+			name:       name,
+			data:       data,
+			parents:    ev.parents,
+			index:      ev.index,
+			key:        ev.key,
+			scopes:     ev.scopes,
+			functions:  ev.functions,
+		}
+	}
+
+	return ev.parseValues(body, data)
+}
+
+// callFunction calls a function defined by a def around it, with the
+// values of its parameters: {"call": ["isAdult", {"var": "user"}]}.
+// Missing arguments are null, and extra ones are left out.
+func (ev *evaluator) callFunction(values interface{}) interface{} {
+	parsed := toSlice(values)
+	if len(parsed) == 0 || !isString(parsed[0]) {
+		ev.fail(fmt.Errorf("%w: the name must be a string", ErrUnknownFunction))
+	}
+
+	name := parsed[0].(string)
+
+	var fn *function
+	for i := len(ev.functions) - 1; i >= 0 && fn == nil; i-- {
+		fn = ev.functions[i][name]
+	}
+
+	if fn == nil {
+		ev.fail(fmt.Errorf("%w %q", ErrUnknownFunction, name))
+	}
+
+	for _, calling := range ev.calling {
+		if calling == fn {
+			ev.fail(fmt.Errorf("%w %q", ErrRecursiveFunction, name))
+		}
+	}
+
+	params := make(map[string]interface{}, len(fn.params))
+	for i, param := range fn.params {
+		var value interface{}
+		if i+1 < len(parsed) {
+			value = parsed[i+1]
+		}
+
+		params[param] = value
+	}
+
+	parents, index, key := ev.parents, ev.index, ev.key
+	scopes, functions, calling := ev.scopes, ev.functions, ev.calling
+	defer func() {
+		ev.parents, ev.index, ev.key = parents, index, key
+		ev.scopes, ev.functions, ev.calling = scopes, functions, calling
+	}()
+
+	ev.parents, ev.index, ev.key = fn.parents, fn.index, fn.key
+	ev.scopes = append(fn.scopes[:len(fn.scopes):len(fn.scopes)], params)
+	ev.functions = fn.functions
+	ev.calling = append(ev.calling[:len(ev.calling):len(ev.calling)], fn)
+
+	return ev.parseValues(fn.body, fn.data)
+}
+
+// solveDef replaces in a def the vars of iteration predicates reading the
+// data around the iteration, as solveVars does, except those reading the
+// parameters of its functions
+func (ev *evaluator) solveDef(values, data interface{}) interface{} {
+	definitions, body, ok := defArgs(values)
+	if !ok {
+		return ev.solveVars(values, data)
+	}
+
+	solved := make(map[string]interface{}, len(definitions)+1)
+
+	for name, definition := range definitions {
+		scope := make(map[string]interface{}, len(definition.params))
+		params := make([]interface{}, len(definition.params))
+		for i, param := range definition.params {
+			scope[param] = unsolved{}
+			params[i] = param
+		}
+
+		ev.scopes = append(ev.scopes[:len(ev.scopes):len(ev.scopes)], scope)
+		solved[name] = []interface{}{params, ev.solveVars(definition.body, data)}
+		ev.scopes = ev.scopes[:len(ev.scopes)-1]
+	}
+
+	solved[letBody] = ev.solveVars(body, data)
+
+	return solved
+}
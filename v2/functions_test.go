@@ -0,0 +1,129 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFunctions(t *testing.T) {
+	data := `{"limit": 18, "users": [{"name": "Ana", "age": 34}, {"name": "Bo", "age": 12}], "age": 99}`
+
+	scenarios := map[string]struct {
+		Rule     string
+		Expected string
+	}{
+		"across iterations": {
+			Rule: `{"def": {"isAdult": [["person"], {">=": [{"var": "person.age"}, {"var": "limit"}]}], "in": [
+				{"filter": [{"var": "users"}, {"call": ["isAdult", {"var": ""}]}]},
+				{"some": [{"var": "users"}, {"!": {"call": ["isAdult", {"var": ""}]}}]}
+			]}}`,
+			Expected: `[[{"name": "Ana", "age": 34}], true]`,
+		},
+		"several parameters": {
+			Rule:     `{"def": {"clamp": [["x", "low", "high"], {"max": [{"var": "low"}, {"min": [{"var": "x"}, {"var": "high"}]}]}], "in": {"call": ["clamp", 42, 0, 10]}}}`,
+			Expected: `10`,
+		},
+		"missing arguments": {
+			Rule:     `{"def": {"f": [["a", "b"], [{"var": "a"}, {"var": "b"}]], "in": {"call": ["f", 1]}}}`,
+			Expected: `[1, null]`,
+		},
+		"parameters shadow the data": {
+			Rule:     `{"def": {"f": [["age"], {"var": "age"}], "in": {"call": ["f", 1]}}}`,
+			Expected: `1`,
+		},
+		"the data of the def": {
+			Rule:     `{"map": [{"var": "users"}, {"def": {"label": [["prefix"], {"cat": [{"var": "prefix"}, {"var": ".name"}]}], "in": {"call": ["label", "user "]}}}]}`,
+			Expected: `["user Ana", "user Bo"]`,
+		},
+		"other functions": {
+			Rule:     `{"def": {"double": [["x"], {"*": [{"var": "x"}, 2]}], "quadruple": [["x"], {"call": ["double", {"call": ["double", {"var": "x"}]}]}], "in": {"call": ["quadruple", 3]}}}`,
+			Expected: `12`,
+		},
+		"nested defs": {
+			Rule:     `{"def": {"f": [[], 1], "in": {"def": {"g": [[], {"+": [{"call": ["f"]}, 1]}], "in": {"call": ["g"]}}}}}`,
+			Expected: `2`,
+		},
+		"inner defs shadow outer ones": {
+			Rule:     `{"def": {"f": [[], 1], "in": {"def": {"f": [[], 2], "in": {"call": ["f"]}}}}}`,
+			Expected: `2`,
+		},
+		"lets around the def": {
+			Rule:     `{"let": {"n": 5, "in": {"def": {"addN": [["x"], {"+": [{"var": "x"}, {"var": "n"}]}], "in": {"call": ["addN", 1]}}}}}`,
+			Expected: `6`,
+		},
+		"parameters don't leak": {
+			Rule:     `{"def": {"f": [["limit"], {"var": "limit"}], "in": [{"call": ["f", 1]}, {"var": "limit"}]}}`,
+			Expected: `[1, 18]`,
+		},
+		"invalid definition": {
+			Rule:     `{"def": {"f": [1, 2], "in": 1}}`,
+			Expected: `null`,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var result bytes.Buffer
+
+			err := Apply(strings.NewReader(scenario.Rule), strings.NewReader(data), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, result.String())
+		})
+	}
+}
+
+func TestFunctionErrors(t *testing.T) {
+	scenarios := map[string]struct {
+		Rule  string
+		Error error
+	}{
+		"unknown function":   {`{"call": ["f", 1]}`, ErrUnknownFunction},
+		"out of the def":     {`{"+": [{"def": {"f": [[], 1], "in": 1}}, {"call": ["f"]}]}`, ErrUnknownFunction},
+		"not a name":         {`{"call": [1]}`, ErrUnknownFunction},
+		"recursion":          {`{"def": {"f": [["n"], {"call": ["f", {"var": "n"}]}], "in": {"call": ["f", 1]}}}`, ErrRecursiveFunction},
+		"indirect recursion": {`{"def": {"f": [[], {"call": ["g"]}], "g": [[], {"call": ["f"]}], "in": {"call": ["f"]}}}`, ErrRecursiveFunction},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var result bytes.Buffer
+
+			err := Apply(strings.NewReader(scenario.Rule), strings.NewReader(`{}`), &result)
+			assert.True(t, errors.Is(err, scenario.Error), "got %v", err)
+		})
+	}
+}
+
+func TestFunctionAnalysis(t *testing.T) {
+	rule := `{"def": {"isAdult": [["p"], {">=": [{"var": "p.age"}, {"var": "limit"}]}], "in": {"some": [{"var": "users"}, {"call": ["isAdult", {"var": ""}]}]}}}`
+
+	assert.True(t, IsValid(strings.NewReader(rule)))
+	assert.False(t, IsValid(strings.NewReader(`{"def": {"f": [["a.b"], 1], "in": 1}}`)))
+
+	warnings, err := Lint(strings.NewReader(rule))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Empty(t, warnings)
+
+	compiled, err := Compile(strings.NewReader(rule))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := ApplyBool(compiled, []byte(`{"limit": 18, "users": [{"age": 20}], "p": {"age": 1}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, result)
+}
@@ -5,4 +5,6 @@ go 1.13
 require (
 	github.com/mitchellh/copystructure v1.0.0
 	github.com/stretchr/testify v1.4.0
+	golang.org/x/text v0.13.0
+	gopkg.in/yaml.v2 v2.2.2
 )
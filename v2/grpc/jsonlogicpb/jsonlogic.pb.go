@@ -0,0 +1,504 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: jsonlogic.proto
+
+package jsonlogicpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type EvaluateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Rule:
+	//
+	//	*EvaluateRequest_RuleJson
+	//	*EvaluateRequest_RuleId
+	Rule isEvaluateRequest_Rule `protobuf_oneof:"rule"`
+	// data_json is the data the rule is applied to, {} when empty
+	DataJson      string `protobuf:"bytes,3,opt,name=data_json,json=dataJson,proto3" json:"data_json,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EvaluateRequest) Reset() {
+	*x = EvaluateRequest{}
+	mi := &file_jsonlogic_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvaluateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvaluateRequest) ProtoMessage() {}
+
+func (x *EvaluateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jsonlogic_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvaluateRequest.ProtoReflect.Descriptor instead.
+func (*EvaluateRequest) Descriptor() ([]byte, []int) {
+	return file_jsonlogic_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *EvaluateRequest) GetRule() isEvaluateRequest_Rule {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+func (x *EvaluateRequest) GetRuleJson() string {
+	if x != nil {
+		if x, ok := x.Rule.(*EvaluateRequest_RuleJson); ok {
+			return x.RuleJson
+		}
+	}
+	return ""
+}
+
+func (x *EvaluateRequest) GetRuleId() string {
+	if x != nil {
+		if x, ok := x.Rule.(*EvaluateRequest_RuleId); ok {
+			return x.RuleId
+		}
+	}
+	return ""
+}
+
+func (x *EvaluateRequest) GetDataJson() string {
+	if x != nil {
+		return x.DataJson
+	}
+	return ""
+}
+
+type isEvaluateRequest_Rule interface {
+	isEvaluateRequest_Rule()
+}
+
+type EvaluateRequest_RuleJson struct {
+	// rule_json is the rule itself
+	RuleJson string `protobuf:"bytes,1,opt,name=rule_json,json=ruleJson,proto3,oneof"`
+}
+
+type EvaluateRequest_RuleId struct {
+	// rule_id is the id of a rule stored by Compile
+	RuleId string `protobuf:"bytes,2,opt,name=rule_id,json=ruleId,proto3,oneof"`
+}
+
+func (*EvaluateRequest_RuleJson) isEvaluateRequest_Rule() {}
+
+func (*EvaluateRequest_RuleId) isEvaluateRequest_Rule() {}
+
+type EvaluateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ResultJson    string                 `protobuf:"bytes,1,opt,name=result_json,json=resultJson,proto3" json:"result_json,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EvaluateResponse) Reset() {
+	*x = EvaluateResponse{}
+	mi := &file_jsonlogic_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvaluateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvaluateResponse) ProtoMessage() {}
+
+func (x *EvaluateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_jsonlogic_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvaluateResponse.ProtoReflect.Descriptor instead.
+func (*EvaluateResponse) Descriptor() ([]byte, []int) {
+	return file_jsonlogic_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *EvaluateResponse) GetResultJson() string {
+	if x != nil {
+		return x.ResultJson
+	}
+	return ""
+}
+
+type ValidateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RuleJson      string                 `protobuf:"bytes,1,opt,name=rule_json,json=ruleJson,proto3" json:"rule_json,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateRequest) Reset() {
+	*x = ValidateRequest{}
+	mi := &file_jsonlogic_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateRequest) ProtoMessage() {}
+
+func (x *ValidateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jsonlogic_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateRequest.ProtoReflect.Descriptor instead.
+func (*ValidateRequest) Descriptor() ([]byte, []int) {
+	return file_jsonlogic_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ValidateRequest) GetRuleJson() string {
+	if x != nil {
+		return x.RuleJson
+	}
+	return ""
+}
+
+type ValidateResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// valid tells if the rule only uses supported operators
+	Valid bool `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	// warnings are the constructs that are valid but likely mistakes
+	Warnings      []*Warning `protobuf:"bytes,2,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateResponse) Reset() {
+	*x = ValidateResponse{}
+	mi := &file_jsonlogic_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateResponse) ProtoMessage() {}
+
+func (x *ValidateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_jsonlogic_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateResponse.ProtoReflect.Descriptor instead.
+func (*ValidateResponse) Descriptor() ([]byte, []int) {
+	return file_jsonlogic_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ValidateResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *ValidateResponse) GetWarnings() []*Warning {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+type Warning struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// path locates the expression in the rule as a JSON pointer
+	Path          string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Check         string `protobuf:"bytes,2,opt,name=check,proto3" json:"check,omitempty"`
+	Message       string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Warning) Reset() {
+	*x = Warning{}
+	mi := &file_jsonlogic_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Warning) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Warning) ProtoMessage() {}
+
+func (x *Warning) ProtoReflect() protoreflect.Message {
+	mi := &file_jsonlogic_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Warning.ProtoReflect.Descriptor instead.
+func (*Warning) Descriptor() ([]byte, []int) {
+	return file_jsonlogic_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Warning) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *Warning) GetCheck() string {
+	if x != nil {
+		return x.Check
+	}
+	return ""
+}
+
+func (x *Warning) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type CompileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RuleJson      string                 `protobuf:"bytes,1,opt,name=rule_json,json=ruleJson,proto3" json:"rule_json,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompileRequest) Reset() {
+	*x = CompileRequest{}
+	mi := &file_jsonlogic_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompileRequest) ProtoMessage() {}
+
+func (x *CompileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jsonlogic_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompileRequest.ProtoReflect.Descriptor instead.
+func (*CompileRequest) Descriptor() ([]byte, []int) {
+	return file_jsonlogic_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CompileRequest) GetRuleJson() string {
+	if x != nil {
+		return x.RuleJson
+	}
+	return ""
+}
+
+type CompileResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// rule_id identifies the rule, the same for rules differing only by
+	// their layout
+	RuleId        string `protobuf:"bytes,1,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompileResponse) Reset() {
+	*x = CompileResponse{}
+	mi := &file_jsonlogic_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompileResponse) ProtoMessage() {}
+
+func (x *CompileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_jsonlogic_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompileResponse.ProtoReflect.Descriptor instead.
+func (*CompileResponse) Descriptor() ([]byte, []int) {
+	return file_jsonlogic_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CompileResponse) GetRuleId() string {
+	if x != nil {
+		return x.RuleId
+	}
+	return ""
+}
+
+var File_jsonlogic_proto protoreflect.FileDescriptor
+
+const file_jsonlogic_proto_rawDesc = "" +
+	"\n" +
+	"\x0fjsonlogic.proto\x12\fjsonlogic.v1\"p\n" +
+	"\x0fEvaluateRequest\x12\x1d\n" +
+	"\trule_json\x18\x01 \x01(\tH\x00R\bruleJson\x12\x19\n" +
+	"\arule_id\x18\x02 \x01(\tH\x00R\x06ruleId\x12\x1b\n" +
+	"\tdata_json\x18\x03 \x01(\tR\bdataJsonB\x06\n" +
+	"\x04rule\"3\n" +
+	"\x10EvaluateResponse\x12\x1f\n" +
+	"\vresult_json\x18\x01 \x01(\tR\n" +
+	"resultJson\".\n" +
+	"\x0fValidateRequest\x12\x1b\n" +
+	"\trule_json\x18\x01 \x01(\tR\bruleJson\"[\n" +
+	"\x10ValidateResponse\x12\x14\n" +
+	"\x05valid\x18\x01 \x01(\bR\x05valid\x121\n" +
+	"\bwarnings\x18\x02 \x03(\v2\x15.jsonlogic.v1.WarningR\bwarnings\"M\n" +
+	"\aWarning\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x14\n" +
+	"\x05check\x18\x02 \x01(\tR\x05check\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"-\n" +
+	"\x0eCompileRequest\x12\x1b\n" +
+	"\trule_json\x18\x01 \x01(\tR\bruleJson\"*\n" +
+	"\x0fCompileResponse\x12\x17\n" +
+	"\arule_id\x18\x01 \x01(\tR\x06ruleId2\xe9\x01\n" +
+	"\tJSONLogic\x12I\n" +
+	"\bEvaluate\x12\x1d.jsonlogic.v1.EvaluateRequest\x1a\x1e.jsonlogic.v1.EvaluateResponse\x12I\n" +
+	"\bValidate\x12\x1d.jsonlogic.v1.ValidateRequest\x1a\x1e.jsonlogic.v1.ValidateResponse\x12F\n" +
+	"\aCompile\x12\x1c.jsonlogic.v1.CompileRequest\x1a\x1d.jsonlogic.v1.CompileResponseB1Z/github.com/bewica/jsonlogic/v2/grpc/jsonlogicpbb\x06proto3"
+
+var (
+	file_jsonlogic_proto_rawDescOnce sync.Once
+	file_jsonlogic_proto_rawDescData []byte
+)
+
+func file_jsonlogic_proto_rawDescGZIP() []byte {
+	file_jsonlogic_proto_rawDescOnce.Do(func() {
+		file_jsonlogic_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_jsonlogic_proto_rawDesc), len(file_jsonlogic_proto_rawDesc)))
+	})
+	return file_jsonlogic_proto_rawDescData
+}
+
+var file_jsonlogic_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_jsonlogic_proto_goTypes = []any{
+	(*EvaluateRequest)(nil),  // 0: jsonlogic.v1.EvaluateRequest
+	(*EvaluateResponse)(nil), // 1: jsonlogic.v1.EvaluateResponse
+	(*ValidateRequest)(nil),  // 2: jsonlogic.v1.ValidateRequest
+	(*ValidateResponse)(nil), // 3: jsonlogic.v1.ValidateResponse
+	(*Warning)(nil),          // 4: jsonlogic.v1.Warning
+	(*CompileRequest)(nil),   // 5: jsonlogic.v1.CompileRequest
+	(*CompileResponse)(nil),  // 6: jsonlogic.v1.CompileResponse
+}
+var file_jsonlogic_proto_depIdxs = []int32{
+	4, // 0: jsonlogic.v1.ValidateResponse.warnings:type_name -> jsonlogic.v1.Warning
+	0, // 1: jsonlogic.v1.JSONLogic.Evaluate:input_type -> jsonlogic.v1.EvaluateRequest
+	2, // 2: jsonlogic.v1.JSONLogic.Validate:input_type -> jsonlogic.v1.ValidateRequest
+	5, // 3: jsonlogic.v1.JSONLogic.Compile:input_type -> jsonlogic.v1.CompileRequest
+	1, // 4: jsonlogic.v1.JSONLogic.Evaluate:output_type -> jsonlogic.v1.EvaluateResponse
+	3, // 5: jsonlogic.v1.JSONLogic.Validate:output_type -> jsonlogic.v1.ValidateResponse
+	6, // 6: jsonlogic.v1.JSONLogic.Compile:output_type -> jsonlogic.v1.CompileResponse
+	4, // [4:7] is the sub-list for method output_type
+	1, // [1:4] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_jsonlogic_proto_init() }
+func file_jsonlogic_proto_init() {
+	if File_jsonlogic_proto != nil {
+		return
+	}
+	file_jsonlogic_proto_msgTypes[0].OneofWrappers = []any{
+		(*EvaluateRequest_RuleJson)(nil),
+		(*EvaluateRequest_RuleId)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_jsonlogic_proto_rawDesc), len(file_jsonlogic_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_jsonlogic_proto_goTypes,
+		DependencyIndexes: file_jsonlogic_proto_depIdxs,
+		MessageInfos:      file_jsonlogic_proto_msgTypes,
+	}.Build()
+	File_jsonlogic_proto = out.File
+	file_jsonlogic_proto_goTypes = nil
+	file_jsonlogic_proto_depIdxs = nil
+}
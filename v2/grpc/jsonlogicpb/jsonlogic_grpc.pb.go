@@ -0,0 +1,209 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: jsonlogic.proto
+
+package jsonlogicpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	JSONLogic_Evaluate_FullMethodName = "/jsonlogic.v1.JSONLogic/Evaluate"
+	JSONLogic_Validate_FullMethodName = "/jsonlogic.v1.JSONLogic/Validate"
+	JSONLogic_Compile_FullMethodName  = "/jsonlogic.v1.JSONLogic/Compile"
+)
+
+// JSONLogicClient is the client API for JSONLogic service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// JSONLogic evaluates JSON Logic rules. Rules, data and results are JSON
+// documents, passed as strings.
+type JSONLogicClient interface {
+	// Evaluate applies a rule to data
+	Evaluate(ctx context.Context, in *EvaluateRequest, opts ...grpc.CallOption) (*EvaluateResponse, error)
+	// Validate checks a rule, and lists its suspicious constructs
+	Validate(ctx context.Context, in *ValidateRequest, opts ...grpc.CallOption) (*ValidateResponse, error)
+	// Compile stores a rule on the server, to be evaluated by its id
+	Compile(ctx context.Context, in *CompileRequest, opts ...grpc.CallOption) (*CompileResponse, error)
+}
+
+type jSONLogicClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewJSONLogicClient(cc grpc.ClientConnInterface) JSONLogicClient {
+	return &jSONLogicClient{cc}
+}
+
+func (c *jSONLogicClient) Evaluate(ctx context.Context, in *EvaluateRequest, opts ...grpc.CallOption) (*EvaluateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EvaluateResponse)
+	err := c.cc.Invoke(ctx, JSONLogic_Evaluate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jSONLogicClient) Validate(ctx context.Context, in *ValidateRequest, opts ...grpc.CallOption) (*ValidateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ValidateResponse)
+	err := c.cc.Invoke(ctx, JSONLogic_Validate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jSONLogicClient) Compile(ctx context.Context, in *CompileRequest, opts ...grpc.CallOption) (*CompileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CompileResponse)
+	err := c.cc.Invoke(ctx, JSONLogic_Compile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// JSONLogicServer is the server API for JSONLogic service.
+// All implementations must embed UnimplementedJSONLogicServer
+// for forward compatibility.
+//
+// JSONLogic evaluates JSON Logic rules. Rules, data and results are JSON
+// documents, passed as strings.
+type JSONLogicServer interface {
+	// Evaluate applies a rule to data
+	Evaluate(context.Context, *EvaluateRequest) (*EvaluateResponse, error)
+	// Validate checks a rule, and lists its suspicious constructs
+	Validate(context.Context, *ValidateRequest) (*ValidateResponse, error)
+	// Compile stores a rule on the server, to be evaluated by its id
+	Compile(context.Context, *CompileRequest) (*CompileResponse, error)
+	mustEmbedUnimplementedJSONLogicServer()
+}
+
+// UnimplementedJSONLogicServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedJSONLogicServer struct{}
+
+func (UnimplementedJSONLogicServer) Evaluate(context.Context, *EvaluateRequest) (*EvaluateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Evaluate not implemented")
+}
+func (UnimplementedJSONLogicServer) Validate(context.Context, *ValidateRequest) (*ValidateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Validate not implemented")
+}
+func (UnimplementedJSONLogicServer) Compile(context.Context, *CompileRequest) (*CompileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Compile not implemented")
+}
+func (UnimplementedJSONLogicServer) mustEmbedUnimplementedJSONLogicServer() {}
+func (UnimplementedJSONLogicServer) testEmbeddedByValue()                   {}
+
+// UnsafeJSONLogicServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to JSONLogicServer will
+// result in compilation errors.
+type UnsafeJSONLogicServer interface {
+	mustEmbedUnimplementedJSONLogicServer()
+}
+
+func RegisterJSONLogicServer(s grpc.ServiceRegistrar, srv JSONLogicServer) {
+	// If the following call pancis, it indicates UnimplementedJSONLogicServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&JSONLogic_ServiceDesc, srv)
+}
+
+func _JSONLogic_Evaluate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EvaluateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JSONLogicServer).Evaluate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JSONLogic_Evaluate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JSONLogicServer).Evaluate(ctx, req.(*EvaluateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JSONLogic_Validate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JSONLogicServer).Validate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JSONLogic_Validate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JSONLogicServer).Validate(ctx, req.(*ValidateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JSONLogic_Compile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JSONLogicServer).Compile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JSONLogic_Compile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JSONLogicServer).Compile(ctx, req.(*CompileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// JSONLogic_ServiceDesc is the grpc.ServiceDesc for JSONLogic service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var JSONLogic_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "jsonlogic.v1.JSONLogic",
+	HandlerType: (*JSONLogicServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Evaluate",
+			Handler:    _JSONLogic_Evaluate_Handler,
+		},
+		{
+			MethodName: "Validate",
+			Handler:    _JSONLogic_Validate_Handler,
+		},
+		{
+			MethodName: "Compile",
+			Handler:    _JSONLogic_Compile_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "jsonlogic.proto",
+}
@@ -0,0 +1,137 @@
+// Package grpc serves the evaluation of JSON Logic rules over gRPC, so
+// services written in other languages share the semantics of this
+// implementation. The service is defined in jsonlogicpb/jsonlogic.proto:
+//
+//	server := grpc.NewServer()
+//	jsonlogicpb.RegisterJSONLogicServer(server, jsonlogicgrpc.NewServer(engine))
+//
+// where jsonlogicgrpc is this package, imported along google.golang.org/grpc.
+// It is a module of its own, so users of the evaluation alone don't depend
+// on gRPC.
+package grpc
+
+//go:generate protoc -I jsonlogicpb --go_out=jsonlogicpb --go_opt=paths=source_relative --go-grpc_out=jsonlogicpb --go-grpc_opt=paths=source_relative jsonlogic.proto
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+
+	"github.com/bewica/jsonlogic/v2"
+	"github.com/bewica/jsonlogic/v2/grpc/jsonlogicpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxCompiledRules bounds how many rules Compile keeps around
+const maxCompiledRules = 4096
+
+// Server implements the JSONLogic service with an Engine
+type Server struct {
+	jsonlogicpb.UnimplementedJSONLogicServer
+
+	engine *jsonlogic.Engine
+
+	mu    sync.RWMutex
+	rules map[string]*jsonlogic.Rule
+}
+
+// NewServer returns a Server evaluating rules with engine. Rules stored by
+// Compile are kept in memory, up to a few thousands: past that, they are
+// all dropped, and evaluating them by id fails with NotFound until they
+// are compiled again.
+func NewServer(engine *jsonlogic.Engine) *Server {
+	return &Server{
+		engine: engine,
+		rules:  make(map[string]*jsonlogic.Rule),
+	}
+}
+
+// Evaluate applies a rule, given as JSON or by the id Compile returned, to
+// data. Rules or data that can't be read, and evaluations failing, are
+// reported as InvalidArgument.
+func (s *Server) Evaluate(ctx context.Context, request *jsonlogicpb.EvaluateRequest) (*jsonlogicpb.EvaluateResponse, error) {
+	data := request.GetDataJson()
+	if data == "" {
+		data = "{}"
+	}
+
+	var output json.RawMessage
+	var err error
+
+	// both kinds of rules give the same bytes: their results go through
+	// the output transformers, keep the order of keys and are bounded the
+	// same way
+	switch rule := request.GetRule().(type) {
+	case *jsonlogicpb.EvaluateRequest_RuleJson:
+		output, err = s.engine.ApplyRawWithContext(ctx, json.RawMessage(rule.RuleJson), json.RawMessage(data))
+	case *jsonlogicpb.EvaluateRequest_RuleId:
+		s.mu.RLock()
+		compiled, ok := s.rules[rule.RuleId]
+		s.mu.RUnlock()
+
+		if !ok {
+			return nil, status.Errorf(codes.NotFound, "unknown rule id %q", rule.RuleId)
+		}
+
+		output, err = s.engine.ApplyCompiledWithContext(ctx, compiled, json.RawMessage(data))
+	default:
+		return nil, status.Error(codes.InvalidArgument, "a rule or a rule id is required")
+	}
+
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return &jsonlogicpb.EvaluateResponse{ResultJson: string(output)}, nil
+}
+
+// Validate checks that a rule only uses supported operators, and lists
+// the warnings of jsonlogic.Lint
+func (s *Server) Validate(ctx context.Context, request *jsonlogicpb.ValidateRequest) (*jsonlogicpb.ValidateResponse, error) {
+	warnings, err := jsonlogic.Lint(strings.NewReader(request.GetRuleJson()))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	response := &jsonlogicpb.ValidateResponse{
+		Valid:    jsonlogic.IsValid(strings.NewReader(request.GetRuleJson())),
+		Warnings: make([]*jsonlogicpb.Warning, 0, len(warnings)),
+	}
+
+	for _, warning := range warnings {
+		response.Warnings = append(response.Warnings, &jsonlogicpb.Warning{
+			Path:    warning.Path,
+			Check:   warning.Check,
+			Message: warning.Message,
+		})
+	}
+
+	return response, nil
+}
+
+// Compile stores a rule, returning the id to evaluate it with: its
+// jsonlogic.Hash, so compiling the same rule again returns the same id
+func (s *Server) Compile(ctx context.Context, request *jsonlogicpb.CompileRequest) (*jsonlogicpb.CompileResponse, error) {
+	id, err := jsonlogic.Hash(strings.NewReader(request.GetRuleJson()))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	compiled, err := s.engine.Compile(strings.NewReader(request.GetRuleJson()))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	s.mu.Lock()
+	if _, ok := s.rules[id]; !ok && len(s.rules) >= maxCompiledRules {
+		s.rules = make(map[string]*jsonlogic.Rule)
+	}
+	s.rules[id] = compiled
+	s.mu.Unlock()
+
+	return &jsonlogicpb.CompileResponse{RuleId: id}, nil
+}
+
+var _ jsonlogicpb.JSONLogicServer = (*Server)(nil)
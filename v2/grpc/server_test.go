@@ -0,0 +1,192 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/bewica/jsonlogic/v2"
+	"github.com/bewica/jsonlogic/v2/grpc/jsonlogicpb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func client(t *testing.T, options ...jsonlogic.Option) jsonlogicpb.JSONLogicClient {
+	engine, err := jsonlogic.NewEngine(append([]jsonlogic.Option{jsonlogic.WithStrictCasts()}, options...)...)
+	assert.NoError(t, err)
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	jsonlogicpb.RegisterJSONLogicServer(server, NewServer(engine))
+
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return listener.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return jsonlogicpb.NewJSONLogicClient(conn)
+}
+
+func TestEvaluate(t *testing.T) {
+	c := client(t)
+	ctx := context.Background()
+
+	compiled, err := c.Compile(ctx, &jsonlogicpb.CompileRequest{RuleJson: `{"cat": ["hello ", {"var": "name"}]}`})
+	assert.NoError(t, err)
+
+	scenarios := map[string]struct {
+		request  *jsonlogicpb.EvaluateRequest
+		expected string
+		code     codes.Code
+	}{
+		"rule": {
+			request: &jsonlogicpb.EvaluateRequest{
+				Rule:     &jsonlogicpb.EvaluateRequest_RuleJson{RuleJson: `{">=": [{"var": "age"}, 18]}`},
+				DataJson: `{"age": 20}`,
+			},
+			expected: `true`,
+		},
+		"no data": {
+			request: &jsonlogicpb.EvaluateRequest{
+				Rule: &jsonlogicpb.EvaluateRequest_RuleJson{RuleJson: `{"missing": ["a"]}`},
+			},
+			expected: `["a"]`,
+		},
+		"compiled rule": {
+			request: &jsonlogicpb.EvaluateRequest{
+				Rule:     &jsonlogicpb.EvaluateRequest_RuleId{RuleId: compiled.GetRuleId()},
+				DataJson: `{"name": "Ana"}`,
+			},
+			expected: `"hello Ana"`,
+		},
+		"unknown rule id": {
+			request: &jsonlogicpb.EvaluateRequest{
+				Rule: &jsonlogicpb.EvaluateRequest_RuleId{RuleId: "nope"},
+			},
+			code: codes.NotFound,
+		},
+		"invalid rule": {
+			request: &jsonlogicpb.EvaluateRequest{
+				Rule: &jsonlogicpb.EvaluateRequest_RuleJson{RuleJson: `{"var": `},
+			},
+			code: codes.InvalidArgument,
+		},
+		"failed evaluation": {
+			request: &jsonlogicpb.EvaluateRequest{
+				Rule: &jsonlogicpb.EvaluateRequest_RuleJson{RuleJson: `{"to_number": "abc"}`},
+			},
+			code: codes.InvalidArgument,
+		},
+		"no rule": {
+			request: &jsonlogicpb.EvaluateRequest{DataJson: `{}`},
+			code:    codes.InvalidArgument,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			response, err := c.Evaluate(ctx, scenario.request)
+			if scenario.code != codes.OK {
+				assert.Equal(t, scenario.code, status.Code(err))
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.JSONEq(t, scenario.expected, response.GetResultJson())
+		})
+	}
+}
+
+func TestEvaluateByIdMatchesEvaluateByJSON(t *testing.T) {
+	scenarios := map[string]struct {
+		options []jsonlogic.Option
+		rule    string
+		data    string
+		code    codes.Code
+	}{
+		"key order": {
+			options: []jsonlogic.Option{jsonlogic.WithKeyOrder()},
+			rule:    `{"merge": [[{"z": 1, "a": 2}], {"var": "user"}]}`,
+			data:    `{"user": {"name": "Ana", "age": 34}}`,
+		},
+		"output transformers": {
+			options: []jsonlogic.Option{jsonlogic.WithOutputTransformers(jsonlogic.RoundFloats(1), jsonlogic.StripNulls())},
+			rule:    `{"var": ""}`,
+			data:    `{"price": 1.25, "note": null}`,
+		},
+		"result size": {
+			options: []jsonlogic.Option{jsonlogic.WithMaxResultSize(8)},
+			rule:    `{"var": "name"}`,
+			data:    `{"name": "Ana Maria"}`,
+			code:    codes.InvalidArgument,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			c := client(t, scenario.options...)
+			ctx := context.Background()
+
+			compiled, err := c.Compile(ctx, &jsonlogicpb.CompileRequest{RuleJson: scenario.rule})
+			assert.NoError(t, err)
+
+			byJSON, err := c.Evaluate(ctx, &jsonlogicpb.EvaluateRequest{
+				Rule:     &jsonlogicpb.EvaluateRequest_RuleJson{RuleJson: scenario.rule},
+				DataJson: scenario.data,
+			})
+			assert.Equal(t, scenario.code, status.Code(err))
+
+			byID, err := c.Evaluate(ctx, &jsonlogicpb.EvaluateRequest{
+				Rule:     &jsonlogicpb.EvaluateRequest_RuleId{RuleId: compiled.GetRuleId()},
+				DataJson: scenario.data,
+			})
+			assert.Equal(t, scenario.code, status.Code(err))
+
+			assert.Equal(t, byJSON.GetResultJson(), byID.GetResultJson())
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	c := client(t)
+
+	response, err := c.Validate(context.Background(), &jsonlogicpb.ValidateRequest{RuleJson: `{"if": [true, 1, 2]}`})
+	assert.NoError(t, err)
+	assert.True(t, response.GetValid())
+	assert.Len(t, response.GetWarnings(), 1)
+	assert.Equal(t, "constant-condition", response.GetWarnings()[0].GetCheck())
+
+	response, err = c.Validate(context.Background(), &jsonlogicpb.ValidateRequest{RuleJson: `{"nope": [1]}`})
+	assert.NoError(t, err)
+	assert.False(t, response.GetValid())
+
+	_, err = c.Validate(context.Background(), &jsonlogicpb.ValidateRequest{RuleJson: `{`})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestCompile(t *testing.T) {
+	c := client(t)
+
+	first, err := c.Compile(context.Background(), &jsonlogicpb.CompileRequest{RuleJson: `{"var": "a"}`})
+	assert.NoError(t, err)
+
+	second, err := c.Compile(context.Background(), &jsonlogicpb.CompileRequest{RuleJson: `{"var": ["a"]}`})
+	assert.NoError(t, err)
+	assert.Equal(t, first.GetRuleId(), second.GetRuleId())
+
+	_, err = c.Compile(context.Background(), &jsonlogicpb.CompileRequest{RuleJson: `[`})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
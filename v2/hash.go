@@ -0,0 +1,61 @@
+package jsonlogic
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+)
+
+// Hash reads a rule and returns a digest of its canonical form, the same
+// for rules differing only by their layout: whitespace, key order, the
+// notation of numbers and the shorthand {"var": "a"} for {"var": ["a"]}.
+// It identifies a version of a rule, for cache keys or audit trails.
+func Hash(rule io.Reader) (string, error) {
+	var tree interface{}
+
+	err := readJSON(rule, &tree)
+	if err != nil {
+		return "", fmt.Errorf("error parsing rule: %w", err)
+	}
+
+	return digest(expand(tree))
+}
+
+// digest returns the SHA-256 digest of the canonical encoding of a value
+func digest(value interface{}) (string, error) {
+	encoded, err := encodeCanonical(value, "")
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(encoded)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// expand replaces the shorthands of a rule by their full form
+func expand(rule interface{}) interface{} {
+	if isSlice(rule) {
+		for i, value := range rule.([]interface{}) {
+			rule.([]interface{})[i] = expand(value)
+		}
+
+		return rule
+	}
+
+	if !isMap(rule) {
+		return rule
+	}
+
+	object := rule.(map[string]interface{})
+	for operator, values := range object {
+		if operator == "var" && !isSlice(values) {
+			values = []interface{}{values}
+		}
+
+		object[operator] = expand(values)
+	}
+
+	return rule
+}
@@ -0,0 +1,74 @@
+package jsonlogic
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHash(t *testing.T) {
+	scenarios := map[string]struct {
+		a, b  string
+		equal bool
+	}{
+		"whitespace": {
+			a:     `{"==": [{"var": "a"}, 1]}`,
+			b:     "{\"==\":[\n\t{\"var\":\"a\"},1\n]}",
+			equal: true,
+		},
+		"key order": {
+			a:     `{"map": [{"var": "l"}, {"a": 1, "b": 2}]}`,
+			b:     `{"map": [{"var": "l"}, {"b": 2, "a": 1}]}`,
+			equal: true,
+		},
+		"numbers": {
+			a:     `{"+": [1, 2.50, -0]}`,
+			b:     `{"+": [1.0, 2.5e0, 0]}`,
+			equal: true,
+		},
+		"var shorthand": {
+			a:     `{"var": "user.name"}`,
+			b:     `{"var": ["user.name"]}`,
+			equal: true,
+		},
+		"different values": {
+			a: `{"==": [{"var": "a"}, 1]}`,
+			b: `{"==": [{"var": "a"}, "1"]}`,
+		},
+		"different argument order": {
+			a: `{"<": [{"var": "a"}, 1]}`,
+			b: `{"<": [1, {"var": "a"}]}`,
+		},
+		"different operators": {
+			a: `{"==": [1, 1]}`,
+			b: `{"===": [1, 1]}`,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			a, err := Hash(strings.NewReader(scenario.a))
+			assert.NoError(t, err)
+
+			b, err := Hash(strings.NewReader(scenario.b))
+			assert.NoError(t, err)
+
+			assert.Equal(t, scenario.equal, a == b)
+		})
+	}
+}
+
+func TestHashIsStable(t *testing.T) {
+	// digests are stored by users, so they must not change between releases:
+	// this is the sha256 of {"and":[{"var":["a"]},true]}
+	hash, err := Hash(strings.NewReader(`{"and": [{"var": "a"}, true]}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "34f813112e4b2b975cf8ffe9504c5d8c4504074e91b223ca25eed4b2f4529cf5", hash)
+}
+
+func TestHashErrors(t *testing.T) {
+	_, err := Hash(strings.NewReader(`{"and": `))
+	assert.Error(t, err)
+}
@@ -0,0 +1,72 @@
+package jsonlogic
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"hash"
+	"hash/fnv"
+	"math"
+)
+
+// hashOperators map the hashing operators to their hash function
+var hashOperators = map[string]func() hash.Hash{
+	"hash_sha256": sha256.New,
+	"hash_md5":    md5.New,
+	"hash_fnv": func() hash.Hash {
+		return fnv.New32a()
+	},
+}
+
+// hashValue evaluates the hashing operators, which digest strings,
+// numbers, in their shortest decimal form, and booleans. hash_sha256 and
+// hash_md5 return the digest as hex by default, hash_fnv as the integer of
+// FNV-1a on 32 bits. Given "hex" or "int", they return the digest in that
+// form, the integers of hash_sha256 and hash_md5 being their first 32
+// bits. Given a number of buckets, they return the integer modulo that
+// number, to bucket values consistently:
+// {"<": [{"hash_fnv": [{"var": "user_id"}, 100]}, 25]}. Values that can't
+// be hashed and invalid forms give null.
+func hashValue(operator string, values interface{}) interface{} {
+	parsed := toSlice(values)
+	if len(parsed) == 0 || len(parsed) > 2 {
+		return nil
+	}
+
+	text, ok := castToString(parsed[0])
+	if !ok {
+		return nil
+	}
+
+	h := hashOperators[operator]()
+	h.Write([]byte(text.(string)))
+	digest := h.Sum(nil)
+
+	output := interface{}("hex")
+	if operator == "hash_fnv" {
+		output = "int"
+	}
+
+	if len(parsed) == 2 {
+		output = parsed[1]
+	}
+
+	integer := float64(binary.BigEndian.Uint32(digest))
+
+	switch {
+	case output == "hex":
+		return hex.EncodeToString(digest)
+	case output == "int":
+		return integer
+	case isNumber(output):
+		buckets := toNumber(output)
+		if buckets < 1 || buckets != math.Trunc(buckets) {
+			return nil
+		}
+
+		return math.Mod(integer, buckets)
+	}
+
+	return nil
+}
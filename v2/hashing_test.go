@@ -0,0 +1,49 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHashOperators(t *testing.T) {
+	scenarios := map[string]struct {
+		Rule     string
+		Expected string
+	}{
+		"sha256":                    {`{"hash_sha256": {"var": "user_id"}}`, `"6d894aa3ee802549d7f340e7c1cf0d1c1cb14cd84f768d92ffaa6785337c4997"`},
+		"md5":                       {`{"hash_md5": {"var": "user_id"}}`, `"7631bc07a1cc8fcd56e70fc6b2fb4a43"`},
+		"fnv":                       {`{"hash_fnv": {"var": "user_id"}}`, `39875499`},
+		"fnv as hex":                {`{"hash_fnv": [{"var": "user_id"}, "hex"]}`, `"026073ab"`},
+		"sha256 as an integer":      {`{"hash_sha256": [{"var": "user_id"}, "int"]}`, `1837714083`},
+		"buckets":                   {`{"hash_fnv": [{"var": "user_id"}, 100]}`, `99`},
+		"buckets of sha256":         {`{"hash_sha256": [42, 10]}`, `8`},
+		"number as text":            {`{"==": [{"hash_md5": 42}, {"hash_md5": "42"}]}`, `true`},
+		"boolean":                   {`{"hash_sha256": true}`, `"b5bea41b6c623f7c09f1bf24dcae58ebab3c0cdd90ad966bc43a45b44867e12b"`},
+		"rollout":                   {`{"<": [{"%": [{"hash_fnv": {"var": "user_id"}}, 100]}, 25]}`, `false`},
+		"null":                      {`{"hash_fnv": {"var": "nothing"}}`, `null`},
+		"list":                      {`{"hash_fnv": [{"merge": [1]}]}`, `null`},
+		"no buckets":                {`{"hash_fnv": ["a", 0]}`, `null`},
+		"fractional buckets":        {`{"hash_fnv": ["a", 2.5]}`, `null`},
+		"unknown form":              {`{"hash_md5": ["a", "base64"]}`, `null`},
+		"too many arguments":        {`{"hash_md5": ["a", "hex", 1]}`, `null`},
+		"deterministic buckets":     {`{"==": [{"hash_sha256": ["a", 7]}, {"hash_sha256": ["a", 7]}]}`, `true`},
+		"salted with an experiment": {`{"hash_fnv": [{"cat": ["experiment:", {"var": "user_id"}]}, 2]}`, `0`},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var result bytes.Buffer
+
+			err := Apply(strings.NewReader(scenario.Rule), strings.NewReader(`{"user_id": "user-42"}`), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, result.String())
+		})
+	}
+}
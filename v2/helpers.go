@@ -1,74 +1,74 @@
 package jsonlogic
 
-import (
-	"reflect"
-	"strconv"
-)
-
-func is(obj interface{}, kind reflect.Kind) bool {
-	return obj != nil && reflect.TypeOf(obj).Kind() == kind
-}
-
 func isBool(obj interface{}) bool {
-	return is(obj, reflect.Bool)
+	return valueOf(obj).kind == boolKind
 }
 
 func isString(obj interface{}) bool {
-	return is(obj, reflect.String)
+	return valueOf(obj).kind == stringKind
 }
 
 func isNumber(obj interface{}) bool {
-	return is(obj, reflect.Float64)
+	return valueOf(obj).kind == numberKind
 }
 
 func isPrimitive(obj interface{}) bool {
-	return isBool(obj) || isString(obj) || isNumber(obj)
+	return valueOf(obj).isPrimitive()
 }
 
 func isMap(obj interface{}) bool {
-	return is(obj, reflect.Map)
+	return valueOf(obj).kind == objectKind
 }
 
 func isSlice(obj interface{}) bool {
-	return is(obj, reflect.Slice)
+	return valueOf(obj).kind == listKind
 }
 
-func isTrue(obj interface{}) bool {
-	if isBool(obj) {
-		return obj.(bool)
+// toSlice returns values as a list, wrapping single values
+func toSlice(values interface{}) []interface{} {
+	if isSlice(values) {
+		return values.([]interface{})
 	}
 
-	if isNumber(obj) {
-		n := toNumber(obj)
-		return n != 0
-	}
-
-	if isString(obj) || isSlice(obj) || isMap(obj) {
-		length := reflect.ValueOf(obj).Len()
-		return length > 0
-	}
+	return []interface{}{values}
+}
 
-	return false
+func isTrue(obj interface{}) bool {
+	return valueOf(obj).truthy()
 }
 
 func toNumber(value interface{}) float64 {
-	if isString(value) {
-		w, _ := strconv.ParseFloat(value.(string), 64)
+	n, _ := valueOf(value).number()
+
+	return n
+}
 
-		return w
+// isNumeric tells if a value reads as a number: numbers, strings holding
+// one, and booleans and null, which are 1 and 0. toNumber takes the other
+// values, like "abc" or lists, for 0.
+func isNumeric(value interface{}) bool {
+	v := valueOf(value)
+	if _, ok := v.number(); ok {
+		return true
 	}
 
-	return value.(float64)
+	return v.kind == boolKind || v.kind == nullKind
 }
 
-func toString(value interface{}) string {
-	if isNumber(value) {
-		return strconv.FormatFloat(value.(float64), 'f', -1, 64)
+// coerced warns about the values computed with by an operator which don't
+// read as numbers, and which arithmetic takes for 0 all the same
+func (ev *evaluator) coerced(operator string, values interface{}) {
+	if ev.engine.logger == nil {
+		return
 	}
 
-	if value == nil {
-		return ""
+	for _, value := range toSlice(values) {
+		if !isNumeric(value) {
+			ev.warn("jsonlogic implicit coercion", "operator", operator, "value", value)
+		}
 	}
+}
 
-	return value.(string)
+func toString(value interface{}) string {
+	return valueOf(value).text()
 }
@@ -0,0 +1,157 @@
+package jsonlogic
+
+import (
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+// identifierOperators are the operators validating and comparing UUIDs
+// and ULIDs
+var identifierOperators = map[string]bool{
+	"is_uuid":      true,
+	"uuid_equals":  true,
+	"is_ulid":      true,
+	"ulid_time":    true,
+	"ulid_compare": true,
+}
+
+// parseUUID reads a UUID in its canonical form,
+// "f81d4fae-7dec-11d0-a765-00a0c91e6bf6", in any case, optionally within
+// braces or prefixed by "urn:uuid:", and returns its bytes
+func parseUUID(value interface{}) ([]byte, bool) {
+	s, ok := value.(string)
+	if !ok {
+		return nil, false
+	}
+
+	if len(s) > 9 && strings.EqualFold(s[:9], "urn:uuid:") {
+		s = s[9:]
+	} else if strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}") {
+		s = s[1 : len(s)-1]
+	}
+
+	if len(s) != 36 || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return nil, false
+	}
+
+	b, err := hex.DecodeString(s[:8] + s[9:13] + s[14:18] + s[19:23] + s[24:])
+	if err != nil {
+		return nil, false
+	}
+
+	return b, true
+}
+
+// isUUID tells if a value is a UUID, of a given version if any:
+// {"is_uuid": [{"var": "id"}, 4]}
+func isUUID(values []interface{}) interface{} {
+	uuid, ok := parseUUID(values[0])
+	if !ok {
+		return false
+	}
+
+	if len(values) < 2 {
+		return true
+	}
+
+	if !isNumber(values[1]) {
+		return nil
+	}
+
+	return float64(uuid[6]>>4) == toNumber(values[1])
+}
+
+// crockford is the alphabet of ULIDs, Crockford's Base32
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// parseULID reads a ULID, "01ARZ3NDEKTSV4RRFFQ69G5FAV", returning it in
+// upper case. The first character is at most 7, as larger ones overflow
+// 128 bits.
+func parseULID(value interface{}) (string, bool) {
+	s, ok := value.(string)
+	if !ok || len(s) != 26 {
+		return "", false
+	}
+
+	s = strings.ToUpper(s)
+	if s[0] > '7' {
+		return "", false
+	}
+
+	for i := 0; i < len(s); i++ {
+		if strings.IndexByte(crockford, s[i]) < 0 {
+			return "", false
+		}
+	}
+
+	return s, true
+}
+
+// ulidTime returns the time a ULID was generated, held in milliseconds by
+// its first 10 characters
+func ulidTime(ulid string) time.Time {
+	var milliseconds int64
+	for i := 0; i < 10; i++ {
+		milliseconds = milliseconds<<5 | int64(strings.IndexByte(crockford, ulid[i]))
+	}
+
+	return time.Unix(milliseconds/1000, milliseconds%1000*int64(time.Millisecond))
+}
+
+// identifier evaluates the operators on UUIDs and ULIDs:
+//
+//   - is_uuid and is_ulid tell if a value is a UUID, of a given version for
+//     is_uuid, or a ULID
+//   - uuid_equals compares UUIDs regardless of their case and notation
+//   - ulid_time returns the time a ULID was generated, as a RFC3339 string
+//   - ulid_compare orders two ULIDs, returning -1, 0 or 1, which is the
+//     order of the times they were generated when they differ
+//
+// Values that aren't UUIDs or ULIDs give null, except for is_uuid and
+// is_ulid.
+func identifier(operator string, values interface{}) interface{} {
+	parsed := toSlice(values)
+	if len(parsed) == 0 {
+		return nil
+	}
+
+	switch operator {
+	case "is_uuid":
+		return isUUID(parsed)
+	case "is_ulid":
+		_, ok := parseULID(parsed[0])
+
+		return ok
+	case "ulid_time":
+		ulid, ok := parseULID(parsed[0])
+		if !ok {
+			return nil
+		}
+
+		return fromTime(ulidTime(ulid))
+	}
+
+	if len(parsed) != 2 {
+		return nil
+	}
+
+	if operator == "uuid_equals" {
+		a, okA := parseUUID(parsed[0])
+		b, okB := parseUUID(parsed[1])
+		if !okA || !okB {
+			return nil
+		}
+
+		return string(a) == string(b)
+	}
+
+	a, okA := parseULID(parsed[0])
+	b, okB := parseULID(parsed[1])
+	if !okA || !okB {
+		return nil
+	}
+
+	// the characters of the alphabet are in the order of their values
+	return float64(strings.Compare(a, b))
+}
@@ -0,0 +1,63 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdentifiers(t *testing.T) {
+	scenarios := map[string]struct {
+		Rule     string
+		Expected string
+	}{
+		"uuid":                   {`{"is_uuid": {"var": "uuid"}}`, `true`},
+		"uuid in upper case":     {`{"is_uuid": "F81D4FAE-7DEC-11D0-A765-00A0C91E6BF6"}`, `true`},
+		"uuid within braces":     {`{"is_uuid": "{f81d4fae-7dec-11d0-a765-00a0c91e6bf6}"}`, `true`},
+		"uuid urn":               {`{"is_uuid": "urn:uuid:f81d4fae-7dec-11d0-a765-00a0c91e6bf6"}`, `true`},
+		"uuid version":           {`{"is_uuid": [{"var": "uuid"}, 4]}`, `true`},
+		"other uuid version":     {`{"is_uuid": ["f81d4fae-7dec-11d0-a765-00a0c91e6bf6", 4]}`, `false`},
+		"invalid uuid version":   {`{"is_uuid": [{"var": "uuid"}, "4"]}`, `null`},
+		"uuid without hyphens":   {`{"is_uuid": "f81d4fae7dec11d0a76500a0c91e6bf6"}`, `false`},
+		"uuid with other digits": {`{"is_uuid": "g81d4fae-7dec-11d0-a765-00a0c91e6bf6"}`, `false`},
+		"not a uuid":             {`{"is_uuid": 42}`, `false`},
+		"equal uuids":            {`{"uuid_equals": [{"var": "uuid"}, "{3F2504E0-4F89-41D3-9A0C-0305E82C3301}"]}`, `true`},
+		"different uuids":        {`{"uuid_equals": [{"var": "uuid"}, "3f2504e0-4f89-41d3-9a0c-0305e82c3302"]}`, `false`},
+		"comparing invalid uuid": {`{"uuid_equals": [{"var": "uuid"}, "3f2504e0"]}`, `null`},
+		"ulid":                   {`{"is_ulid": {"var": "ulid"}}`, `true`},
+		"ulid in lower case":     {`{"is_ulid": "01arz3ndektsv4rrffq69g5fav"}`, `true`},
+		"ulid overflowing":       {`{"is_ulid": "81ARZ3NDEKTSV4RRFFQ69G5FAV"}`, `false`},
+		"ulid with other digits": {`{"is_ulid": "01ARZ3NDEKTSV4RRFFQ69G5FAU"}`, `false`},
+		"ulid too short":         {`{"is_ulid": "01ARZ3NDEKTSV4RRFFQ69G5FA"}`, `false`},
+		"ulid time":              {`{"ulid_time": {"var": "ulid"}}`, `"2016-07-30T23:54:10.259Z"`},
+		"ulid time of invalid":   {`{"ulid_time": "nope"}`, `null`},
+		"ulid time as a date":    {`{"date_before": [{"ulid_time": {"var": "ulid"}}, "2020-01-01T00:00:00Z"]}`, `true`},
+		"older ulid":             {`{"ulid_compare": [{"var": "ulid"}, "01BX5ZZKBKACTAV9WEVGEMMVRZ"]}`, `-1`},
+		"newer ulid":             {`{"ulid_compare": ["01BX5ZZKBKACTAV9WEVGEMMVRZ", {"var": "ulid"}]}`, `1`},
+		"same ulid":              {`{"ulid_compare": [{"var": "ulid"}, "01arz3ndektsv4rrffq69g5fav"]}`, `0`},
+		"comparing invalid ulid": {`{"ulid_compare": [{"var": "ulid"}, "nope"]}`, `null`},
+		"filtering by ulid time": {`{"filter": [{"var": "ids"}, {"date_after": [{"ulid_time": {"var": ""}}, "2017-01-01T00:00:00Z"]}]}`, `["01BX5ZZKBKACTAV9WEVGEMMVRZ"]`},
+	}
+
+	data := `{
+		"uuid": "3f2504e0-4f89-41d3-9a0c-0305e82c3301",
+		"ulid": "01ARZ3NDEKTSV4RRFFQ69G5FAV",
+		"ids": ["01ARZ3NDEKTSV4RRFFQ69G5FAV", "01BX5ZZKBKACTAV9WEVGEMMVRZ"]
+	}`
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var result bytes.Buffer
+
+			err := Apply(strings.NewReader(scenario.Rule), strings.NewReader(data), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, result.String())
+		})
+	}
+}
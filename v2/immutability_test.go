@@ -0,0 +1,86 @@
+package jsonlogic
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluationDoesntChangeTheData(t *testing.T) {
+	rules := []string{
+		`{"map": [{"var": "users"}, {"set": [{"var": ""}, "age", {"+": [{"var": ".age"}, 1]}]}]}`,
+		`{"map": [{"var": "users"}, {"set": [{"var": ""}, "address.city", "Paris", "tags.0", "z"]}]}`,
+		`{"map": [{"var": "users"}, {"unset": [{"var": ""}, "tags.0", "address.city"]}]}`,
+		`{"map": [{"var": "users"}, {"rename": [{"var": ""}, "name", "profile.name"]}]}`,
+		`{"merge_objects": [{"var": "users.0"}, {"var": "users.1"}]}`,
+		`{"set": [{"var": ""}, "users.0.name", "Grace"]}`,
+		`{"sort": [{"var": "numbers"}]}`,
+		`{"sort": [{"var": "numbers"}, "desc"]}`,
+		`{"reverse": {"var": "numbers"}}`,
+		`{"unique": {"var": "numbers"}}`,
+		`{"slice": [{"var": "numbers"}, 1]}`,
+		`{"flatten": {"var": "nested"}}`,
+		`{"merge": [{"var": "numbers"}, {"var": "numbers"}]}`,
+		`{"median": {"var": "numbers"}}`,
+		`{"filter": [{"var": "users"}, {">": [{"var": ".age"}, 30]}]}`,
+		`{"reduce": [{"var": "numbers"}, {"+": [{"var": "current"}, {"var": "accumulator"}]}, 0]}`,
+		`{"groupby": [{"var": "users"}, {"var": ".address.city"}]}`,
+		`{"map_obj": [{"var": "users.0.address"}, {"cat": [{"var": ".value"}, "!"]}]}`,
+		`{"filter_obj": [{"var": "users.0"}, {"!=": [{"var": ".key"}, "tags"]}]}`,
+		`{"pick": [{"var": "users.0"}, "name"]}`,
+		`{"omit": [{"var": "users.0"}, "name"]}`,
+		`{"zip": [{"var": "numbers"}, {"var": "numbers"}]}`,
+		`{"product": [{"var": "numbers"}, {"var": "users.0.tags"}]}`,
+		`{"union": [{"var": "numbers"}, {"var": "numbers"}]}`,
+		`{"in_sorted": [3, {"var": "numbers"}, "sort"]}`,
+		`{"some": [{"var": "users.0.address"}, {"==": [{"var": ".value"}, "London"]}]}`,
+	}
+
+	engines := map[string][]Option{
+		"default":  nil,
+		"cached":   {WithCache(&testCache{values: make(map[interface{}]interface{})})},
+		"parallel": {WithParallelism(4, 1)},
+	}
+
+	const document = `{
+		"users": [
+			{"name": "Ada", "age": 36, "address": {"city": "London"}, "tags": ["a", "b"]},
+			{"name": "Grace", "age": 29, "address": {"city": "New York"}, "tags": ["c"]}
+		],
+		"numbers": [3, 1, 2, 3],
+		"nested": [[1, [2]], [3]]
+	}`
+
+	decode := func(document string) interface{} {
+		var decoded interface{}
+		if err := json.Unmarshal([]byte(document), &decoded); err != nil {
+			t.Fatal(err)
+		}
+
+		return decoded
+	}
+
+	for name, options := range engines {
+		engine, err := NewEngine(options...)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, rule := range rules {
+			t.Run(fmt.Sprintf("SCENARIO:%s %s", name, rule), func(t *testing.T) {
+				data := decode(document)
+
+				// evaluated twice, for the cache to return its result
+				for i := 0; i < 2; i++ {
+					if _, err := engine.ApplyInterface(decode(rule), data); err != nil {
+						t.Fatal(err)
+					}
+				}
+
+				assert.Equal(t, decode(document), data)
+			})
+		}
+	}
+}
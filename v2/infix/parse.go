@@ -0,0 +1,569 @@
+// Package infix reads and writes rules in an infix syntax, easier to read
+// than nested JSON:
+//
+//	rule, err := infix.Parse(`age >= 18 and country in ["GB", "IE"]`)
+//	// {"and": [{">=": [{"var": "age"}, 18]}, {"in": [{"var": "country"}, ["GB", "IE"]]}]}
+//
+// Names like user.name or items.0 read the data with var. Literals are
+// written as in JSON. From the lowest precedence to the highest, the
+// operators are:
+//
+//   - or
+//   - and
+//   - not
+//   - ==, !=, ===, !==, <, <=, >, >= and in; a < b < c and a <= b <= c
+//     test if b is between a and c
+//   - + and -
+//   - *, / and %
+//   - unary -
+//
+// Any other operator is called like a function, its name being quoted
+// when it isn't an identifier: if(score > 10, "high", "low"),
+// some(items, price > 100), var("first-name", "none"), "!!"(tags).
+package infix
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// SyntaxError describes why an expression can't be parsed
+type SyntaxError struct {
+	// Offset locates the problem, in bytes from the start of the expression
+	Offset  int
+	Message string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("offset %d: %s", e.Offset, e.Message)
+}
+
+// Parse reads an expression and returns the equivalent rule, in the form
+// of decoded JSON expected by jsonlogic.ApplyInterface. It fails with a
+// *SyntaxError when the expression is invalid.
+func Parse(expression string) (interface{}, error) {
+	tokens, err := tokenize(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+
+	rule, err := p.expression(precedenceOr)
+	if err != nil {
+		return nil, err
+	}
+
+	if next := p.peek(); next.kind != tokenEnd {
+		return nil, p.unexpected(next)
+	}
+
+	return rule, nil
+}
+
+type tokenKind int
+
+const (
+	tokenEnd tokenKind = iota
+	tokenNumber
+	tokenString
+	tokenName
+	tokenSymbol
+)
+
+type token struct {
+	kind   tokenKind
+	text   string
+	offset int
+}
+
+func (t token) String() string {
+	if t.kind == tokenEnd {
+		return "end of expression"
+	}
+
+	return strconv.Quote(t.text)
+}
+
+// symbols are the punctuation and operators, longest first so they are
+// matched greedily
+var symbols = []string{
+	"===", "!==",
+	"==", "!=", "<=", ">=",
+	"<", ">", "+", "-", "*", "/", "%", "(", ")", "[", "]", ",", ".",
+}
+
+func tokenize(expression string) ([]token, error) {
+	tokens := make([]token, 0)
+
+	for offset := 0; offset < len(expression); {
+		c := expression[offset]
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			offset++
+		case c == '"':
+			length, err := stringLength(expression[offset:])
+			if err != nil {
+				return nil, &SyntaxError{Offset: offset, Message: err.Error()}
+			}
+
+			tokens = append(tokens, token{kind: tokenString, text: expression[offset : offset+length], offset: offset})
+			offset += length
+		case c >= '0' && c <= '9':
+			length := numberLength(expression[offset:])
+			tokens = append(tokens, token{kind: tokenNumber, text: expression[offset : offset+length], offset: offset})
+			offset += length
+		case isNameStart(c):
+			length := 1
+			for offset+length < len(expression) && isNamePart(expression[offset+length]) {
+				length++
+			}
+
+			tokens = append(tokens, token{kind: tokenName, text: expression[offset : offset+length], offset: offset})
+			offset += length
+		default:
+			symbol := ""
+			for _, candidate := range symbols {
+				if strings.HasPrefix(expression[offset:], candidate) {
+					symbol = candidate
+
+					break
+				}
+			}
+
+			if symbol == "" {
+				r, _ := utf8.DecodeRuneInString(expression[offset:])
+
+				return nil, &SyntaxError{Offset: offset, Message: fmt.Sprintf("unexpected character %q", r)}
+			}
+
+			tokens = append(tokens, token{kind: tokenSymbol, text: symbol, offset: offset})
+			offset += len(symbol)
+		}
+	}
+
+	return append(tokens, token{kind: tokenEnd, offset: len(expression)}), nil
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isNamePart(c byte) bool {
+	return isNameStart(c) || (c >= '0' && c <= '9')
+}
+
+// stringLength returns the length of the JSON string starting s
+func stringLength(s string) (int, error) {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unterminated string")
+}
+
+// numberLength returns the length of the JSON number starting s
+func numberLength(s string) int {
+	i := digits(s, 0)
+
+	if i+1 < len(s) && s[i] == '.' && s[i+1] >= '0' && s[i+1] <= '9' {
+		i = digits(s, i+1)
+	}
+
+	if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		j := i + 1
+		if j < len(s) && (s[j] == '+' || s[j] == '-') {
+			j++
+		}
+
+		if j < len(s) && s[j] >= '0' && s[j] <= '9' {
+			i = digits(s, j)
+		}
+	}
+
+	return i
+}
+
+func digits(s string, i int) int {
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+
+	return i
+}
+
+const (
+	precedenceOr = iota + 1
+	precedenceAnd
+	precedenceNot
+	precedenceComparison
+	precedenceAdditive
+	precedenceMultiplicative
+	precedenceUnary
+	precedencePrimary
+)
+
+// binaryOperators are the infix operators with their precedence
+// ordering are the comparisons which can be chained: a < b < c
+var ordering = map[string]bool{"<": true, "<=": true, ">": true, ">=": true}
+
+var binaryOperators = map[string]int{
+	"or":  precedenceOr,
+	"and": precedenceAnd,
+	"==":  precedenceComparison,
+	"!=":  precedenceComparison,
+	"===": precedenceComparison,
+	"!==": precedenceComparison,
+	"<":   precedenceComparison,
+	"<=":  precedenceComparison,
+	">":   precedenceComparison,
+	">=":  precedenceComparison,
+	"in":  precedenceComparison,
+	"+":   precedenceAdditive,
+	"-":   precedenceAdditive,
+	"*":   precedenceMultiplicative,
+	"/":   precedenceMultiplicative,
+	"%":   precedenceMultiplicative,
+}
+
+// variadicOperators take any number of arguments, so chains of them are
+// written as a single operation
+var variadicOperators = map[string]bool{
+	"or":  true,
+	"and": true,
+	"+":   true,
+	"*":   true,
+}
+
+// keywords are the names which aren't read as paths
+var keywords = map[string]bool{
+	"and":   true,
+	"or":    true,
+	"not":   true,
+	"in":    true,
+	"true":  true,
+	"false": true,
+	"null":  true,
+}
+
+type parser struct {
+	tokens   []token
+	position int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.position]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.position]
+	if t.kind != tokenEnd {
+		p.position++
+	}
+
+	return t
+}
+
+func (p *parser) unexpected(t token) error {
+	return &SyntaxError{Offset: t.offset, Message: "unexpected " + t.String()}
+}
+
+func (p *parser) expect(symbol string) error {
+	if t := p.next(); t.kind != tokenSymbol || t.text != symbol {
+		return &SyntaxError{Offset: t.offset, Message: fmt.Sprintf("expected %q, got %s", symbol, t)}
+	}
+
+	return nil
+}
+
+// operator returns the binary operator of the next token, if any
+func (p *parser) operator() (string, int) {
+	t := p.peek()
+	if t.kind != tokenSymbol && (t.kind != tokenName || !keywords[t.text]) {
+		return "", 0
+	}
+
+	precedence, ok := binaryOperators[t.text]
+	if !ok {
+		return "", 0
+	}
+
+	return t.text, precedence
+}
+
+// expression reads operations whose operators have at least the given
+// precedence
+func (p *parser) expression(minimum int) (interface{}, error) {
+	left, err := p.unary(minimum)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		operator, precedence := p.operator()
+		if operator == "" || precedence < minimum {
+			return left, nil
+		}
+
+		start := p.next()
+
+		if precedence == precedenceComparison {
+			left, err = p.comparison(operator, left, start)
+		} else {
+			left, err = p.chain(operator, precedence, left)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// chain reads the right operands of a left associative operator, merging
+// the ones taking any number of arguments in a single operation
+func (p *parser) chain(operator string, precedence int, left interface{}) (interface{}, error) {
+	args := []interface{}{left}
+
+	for {
+		right, err := p.expression(precedence + 1)
+		if err != nil {
+			return nil, err
+		}
+
+		args = append(args, right)
+
+		if next, _ := p.operator(); next != operator || !variadicOperators[operator] {
+			return map[string]interface{}{operator: args}, nil
+		}
+
+		p.next()
+	}
+}
+
+// comparison reads the right operand of a comparison, which can be
+// followed by another one for between
+func (p *parser) comparison(operator string, left interface{}, start token) (interface{}, error) {
+	right, err := p.expression(precedenceComparison + 1)
+	if err != nil {
+		return nil, err
+	}
+
+	args := []interface{}{left, right}
+
+	if next, _ := p.operator(); next != "" && binaryOperators[next] == precedenceComparison {
+		t := p.next()
+		if next != operator || !ordering[operator] {
+			return nil, &SyntaxError{Offset: t.offset, Message: fmt.Sprintf("%q can't follow %q: only <, <=, > and >= can be chained, with themselves", next, operator)}
+		}
+
+		last, err := p.expression(precedenceComparison + 1)
+		if err != nil {
+			return nil, err
+		}
+
+		args = append(args, last)
+
+		if next, _ := p.operator(); next != "" && binaryOperators[next] == precedenceComparison {
+			return nil, &SyntaxError{Offset: p.peek().offset, Message: "only 3 operands can be compared together"}
+		}
+	}
+
+	return map[string]interface{}{operator: args}, nil
+}
+
+// unary reads not and unary minus, when allowed by the precedence, and
+// the operands with a higher precedence
+func (p *parser) unary(minimum int) (interface{}, error) {
+	t := p.peek()
+
+	if t.kind == tokenName && t.text == "not" {
+		if minimum > precedenceNot {
+			return nil, &SyntaxError{Offset: t.offset, Message: "not must be in parentheses here"}
+		}
+
+		p.next()
+
+		operand, err := p.expression(precedenceNot)
+		if err != nil {
+			return nil, err
+		}
+
+		return map[string]interface{}{"!": []interface{}{operand}}, nil
+	}
+
+	if t.kind == tokenSymbol && t.text == "-" {
+		p.next()
+
+		if number := p.peek(); number.kind == tokenNumber {
+			p.next()
+
+			value, err := strconv.ParseFloat(number.text, 64)
+			if err != nil {
+				return nil, &SyntaxError{Offset: number.offset, Message: err.Error()}
+			}
+
+			return -value, nil
+		}
+
+		operand, err := p.unary(precedenceUnary)
+		if err != nil {
+			return nil, err
+		}
+
+		return map[string]interface{}{"-": []interface{}{operand}}, nil
+	}
+
+	return p.primary()
+}
+
+func (p *parser) primary() (interface{}, error) {
+	t := p.next()
+
+	switch t.kind {
+	case tokenNumber:
+		value, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, &SyntaxError{Offset: t.offset, Message: err.Error()}
+		}
+
+		return value, nil
+	case tokenString:
+		var value string
+		if err := json.Unmarshal([]byte(t.text), &value); err != nil {
+			return nil, &SyntaxError{Offset: t.offset, Message: "invalid string " + t.text}
+		}
+
+		if next := p.peek(); next.kind == tokenSymbol && next.text == "(" {
+			return p.call(value)
+		}
+
+		return value, nil
+	case tokenName:
+		switch t.text {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		}
+
+		if keywords[t.text] {
+			return nil, p.unexpected(t)
+		}
+
+		if next := p.peek(); next.kind == tokenSymbol && next.text == "(" {
+			return p.call(t.text)
+		}
+
+		return p.path(t.text)
+	case tokenSymbol:
+		switch t.text {
+		case "(":
+			rule, err := p.expression(precedenceOr)
+			if err != nil {
+				return nil, err
+			}
+
+			return rule, p.expect(")")
+		case "[":
+			elements, err := p.list("]")
+			if err != nil {
+				return nil, err
+			}
+
+			return elements, nil
+		}
+	}
+
+	return nil, p.unexpected(t)
+}
+
+// path reads the segments following the first name of a path, which are
+// names or indexes
+func (p *parser) path(first string) (interface{}, error) {
+	segments := []string{first}
+
+	for {
+		if t := p.peek(); t.kind != tokenSymbol || t.text != "." {
+			return map[string]interface{}{"var": strings.Join(segments, ".")}, nil
+		}
+
+		p.next()
+
+		t := p.next()
+
+		switch {
+		case t.kind == tokenName:
+			segments = append(segments, t.text)
+		case t.kind == tokenNumber && isIndexes(t.text):
+			// items.0.1 is read as items, then the number 0.1
+			segments = append(segments, t.text)
+		default:
+			return nil, &SyntaxError{Offset: t.offset, Message: fmt.Sprintf("expected a name or an index, got %s", t)}
+		}
+	}
+}
+
+// isIndexes tells if a number is made of indexes separated by a dot
+func isIndexes(number string) bool {
+	for _, index := range strings.Split(number, ".") {
+		if index == "" || digits(index, 0) != len(index) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// call reads the arguments of an operator called like a function
+func (p *parser) call(operator string) (interface{}, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+
+	args, err := p.list(")")
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{operator: args}, nil
+}
+
+// list reads expressions separated by commas, up to the closing symbol
+func (p *parser) list(closing string) ([]interface{}, error) {
+	elements := make([]interface{}, 0)
+
+	if t := p.peek(); t.kind == tokenSymbol && t.text == closing {
+		p.next()
+
+		return elements, nil
+	}
+
+	for {
+		element, err := p.expression(precedenceOr)
+		if err != nil {
+			return nil, err
+		}
+
+		elements = append(elements, element)
+
+		t := p.next()
+		if t.kind == tokenSymbol && t.text == closing {
+			return elements, nil
+		}
+
+		if t.kind != tokenSymbol || t.text != "," {
+			return nil, &SyntaxError{Offset: t.offset, Message: fmt.Sprintf("expected \",\" or %q, got %s", closing, t)}
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package infix
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	scenarios := map[string]struct {
+		expression string
+		expected   string
+	}{
+		"comparison and membership": {
+			expression: `age >= 18 and country in ["GB", "IE"]`,
+			expected:   `{"and": [{">=": [{"var": "age"}, 18]}, {"in": [{"var": "country"}, ["GB", "IE"]]}]}`,
+		},
+		"precedence": {
+			expression: `a or b and not c == 1`,
+			expected:   `{"or": [{"var": "a"}, {"and": [{"var": "b"}, {"!": [{"==": [{"var": "c"}, 1]}]}]}]}`,
+		},
+		"parentheses": {
+			expression: `(a or b) and c`,
+			expected:   `{"and": [{"or": [{"var": "a"}, {"var": "b"}]}, {"var": "c"}]}`,
+		},
+		"chains": {
+			expression: `a + b + c * d * 2`,
+			expected:   `{"+": [{"var": "a"}, {"var": "b"}, {"*": [{"var": "c"}, {"var": "d"}, 2]}]}`,
+		},
+		"left associativity": {
+			expression: `a - b - c / 2 / 3`,
+			expected:   `{"-": [{"-": [{"var": "a"}, {"var": "b"}]}, {"/": [{"/": [{"var": "c"}, 2]}, 3]}]}`,
+		},
+		"mixed additions": {
+			expression: `a + b - c + d`,
+			expected:   `{"+": [{"-": [{"+": [{"var": "a"}, {"var": "b"}]}, {"var": "c"}]}, {"var": "d"}]}`,
+		},
+		"unary minus": {
+			expression: `-price * 2 - -1.5e2 % -(3)`,
+			expected:   `{"-": [{"*": [{"-": [{"var": "price"}]}, 2]}, {"%": [-150, {"-": [3]}]}]}`,
+		},
+		"between": {
+			expression: `0 <= temp <= 100`,
+			expected:   `{"<=": [0, {"var": "temp"}, 100]}`,
+		},
+		"descending between": {
+			expression: `100 > temp > 0`,
+			expected:   `{">": [100, {"var": "temp"}, 0]}`,
+		},
+		"paths": {
+			expression: `user.name == items.0.tags.1.2`,
+			expected:   `{"==": [{"var": "user.name"}, {"var": "items.0.tags.1.2"}]}`,
+		},
+		"calls": {
+			expression: `if(some(items, price > 100), cat("big ", var("first-name", "")), "!!"(tags), now())`,
+			expected:   `{"if": [{"some": [{"var": "items"}, {">": [{"var": "price"}, 100]}]}, {"cat": ["big ", {"var": ["first-name", ""]}]}, {"!!": [{"var": "tags"}]}, {"now": []}]}`,
+		},
+		"literals": {
+			expression: `[null, true, false, "a\"bé", 0.5, [], [1, [2]]]`,
+			expected:   `[null, true, false, "a\"bé", 0.5, [], [1, [2]]]`,
+		},
+		"strict comparisons": {
+			expression: `a === 1 or a !== "1" and a != b`,
+			expected:   `{"or": [{"===": [{"var": "a"}, 1]}, {"and": [{"!==": [{"var": "a"}, "1"]}, {"!=": [{"var": "a"}, {"var": "b"}]}]}]}`,
+		},
+		"not in operands": {
+			expression: `a and not b or not (c < d)`,
+			expected:   `{"or": [{"and": [{"var": "a"}, {"!": [{"var": "b"}]}]}, {"!": [{"<": [{"var": "c"}, {"var": "d"}]}]}]}`,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			rule, err := Parse(scenario.expression)
+			assert.NoError(t, err)
+
+			var expected interface{}
+			assert.NoError(t, json.Unmarshal([]byte(scenario.expected), &expected))
+			assert.Equal(t, expected, rule)
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	scenarios := map[string]struct {
+		expression string
+		expected   string
+	}{
+		"empty": {
+			expression: ``,
+			expected:   `offset 0: unexpected end of expression`,
+		},
+		"unknown character": {
+			expression: `a & b`,
+			expected:   `offset 2: unexpected character '&'`,
+		},
+		"unterminated string": {
+			expression: `name == "ana`,
+			expected:   `offset 8: unterminated string`,
+		},
+		"trailing tokens": {
+			expression: `a b`,
+			expected:   `offset 2: unexpected "b"`,
+		},
+		"missing parenthesis": {
+			expression: `(a or b`,
+			expected:   `offset 7: expected ")", got end of expression`,
+		},
+		"missing comma": {
+			expression: `[1 2]`,
+			expected:   `offset 3: expected "," or "]", got "2"`,
+		},
+		"chained equality": {
+			expression: `a == b == c`,
+			expected:   `offset 7: "==" can't follow "==": only <, <=, > and >= can be chained, with themselves`,
+		},
+		"mixed chain": {
+			expression: `a < b <= c`,
+			expected:   `offset 6: "<=" can't follow "<": only <, <=, > and >= can be chained, with themselves`,
+		},
+		"long chain": {
+			expression: `a < b < c < d`,
+			expected:   `offset 10: only 3 operands can be compared together`,
+		},
+		"not operand": {
+			expression: `a == not b`,
+			expected:   `offset 5: not must be in parentheses here`,
+		},
+		"keyword": {
+			expression: `and`,
+			expected:   `offset 0: unexpected "and"`,
+		},
+		"path": {
+			expression: `user."name"`,
+			expected:   `offset 5: expected a name or an index, got "\"name\""`,
+		},
+		"invalid escape": {
+			expression: `"\x"`,
+			expected:   `offset 0: invalid string "\x"`,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			_, err := Parse(scenario.expression)
+			assert.EqualError(t, err, scenario.expected)
+
+			_, ok := err.(*SyntaxError)
+			assert.True(t, ok)
+		})
+	}
+}
@@ -0,0 +1,232 @@
+package infix
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Print reads a rule and writes it in the infix syntax read by Parse
+func Print(rule io.Reader) (string, error) {
+	var _rule interface{}
+
+	err := json.NewDecoder(rule).Decode(&_rule)
+	if err != nil {
+		return "", fmt.Errorf("error parsing rule: %w", err)
+	}
+
+	return PrintInterface(_rule)
+}
+
+// PrintInterface writes a rule given in the form of decoded JSON in the
+// infix syntax read by Parse. Parsing the result gives back an equivalent
+// rule, in which the arguments of the operators are always in a list, but
+// for the paths written as names. Objects which aren't operations can't be
+// printed.
+func PrintInterface(rule interface{}) (string, error) {
+	printed, _, err := printRule(rule)
+
+	return printed, err
+}
+
+// printRule writes a rule, returning the precedence of its outermost operator
+func printRule(rule interface{}) (string, int, error) {
+	switch value := rule.(type) {
+	case []interface{}:
+		elements, err := printRules(value)
+		if err != nil {
+			return "", 0, err
+		}
+
+		return "[" + strings.Join(elements, ", ") + "]", precedencePrimary, nil
+	case map[string]interface{}:
+		if len(value) != 1 {
+			return "", 0, fmt.Errorf("objects with %d properties can't be printed: %s", len(value), strings.Join(sortedKeys(value), ", "))
+		}
+
+		for operator, args := range value {
+			return printOperation(operator, args)
+		}
+	}
+
+	literal, err := encode(rule)
+	if err != nil {
+		return "", 0, err
+	}
+
+	return literal, precedencePrimary, nil
+}
+
+func printRules(rules []interface{}) ([]string, error) {
+	printed := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		element, _, err := printRule(rule)
+		if err != nil {
+			return nil, err
+		}
+
+		printed = append(printed, element)
+	}
+
+	return printed, nil
+}
+
+// operand prints a rule, in parentheses when its precedence is lower than
+// the given one
+func operand(rule interface{}, minimum int) (string, error) {
+	printed, precedence, err := printRule(rule)
+	if err != nil {
+		return "", err
+	}
+
+	if precedence < minimum {
+		return "(" + printed + ")", nil
+	}
+
+	return printed, nil
+}
+
+func printOperation(operator string, values interface{}) (string, int, error) {
+	if operator == "var" {
+		if path, ok := printablePath(values); ok {
+			return path, precedencePrimary, nil
+		}
+	}
+
+	args, ok := values.([]interface{})
+	if !ok {
+		args = []interface{}{values}
+	}
+
+	precedence, infix := binaryOperators[operator]
+
+	switch {
+	case operator == "!" && len(args) == 1:
+		printed, err := operand(args[0], precedenceNot)
+
+		return "not " + printed, precedenceNot, err
+	case operator == "-" && len(args) == 1:
+		if _, ok := args[0].(float64); ok {
+			// -5 would be read as a number
+			printed, _, err := printRule(args[0])
+
+			return "-(" + printed + ")", precedenceUnary, err
+		}
+
+		printed, err := operand(args[0], precedenceUnary)
+
+		return "-" + printed, precedenceUnary, err
+	case infix && precedence == precedenceComparison && (len(args) == 2 || (len(args) == 3 && ordering[operator])):
+		return printInfix(operator, args, precedence, precedence+1)
+	case infix && variadicOperators[operator] && len(args) > 1:
+		return printInfix(operator, args, precedence, precedence+1)
+	case infix && len(args) == 2:
+		// left associative: a - b - c is (a - b) - c
+		left, err := operand(args[0], precedence)
+		if err != nil {
+			return "", 0, err
+		}
+
+		right, err := operand(args[1], precedence+1)
+		if err != nil {
+			return "", 0, err
+		}
+
+		return left + " " + operator + " " + right, precedence, nil
+	}
+
+	printed, err := printRules(args)
+	if err != nil {
+		return "", 0, err
+	}
+
+	name := operator
+	if !isName(operator) {
+		name, err = encode(operator)
+		if err != nil {
+			return "", 0, err
+		}
+	}
+
+	return name + "(" + strings.Join(printed, ", ") + ")", precedencePrimary, nil
+}
+
+// printInfix writes the operands separated by the operator
+func printInfix(operator string, args []interface{}, precedence, minimum int) (string, int, error) {
+	operands := make([]string, 0, len(args))
+	for _, arg := range args {
+		printed, err := operand(arg, minimum)
+		if err != nil {
+			return "", 0, err
+		}
+
+		operands = append(operands, printed)
+	}
+
+	return strings.Join(operands, " "+operator+" "), precedence, nil
+}
+
+// printablePath returns the path read by var when it can be written as a
+// name followed by names or indexes
+func printablePath(values interface{}) (string, bool) {
+	path, ok := values.(string)
+	if list, isList := values.([]interface{}); isList && len(list) == 1 {
+		path, ok = list[0].(string)
+	}
+
+	if !ok || path == "" {
+		return "", false
+	}
+
+	for i, segment := range strings.Split(path, ".") {
+		isIndex := segment != "" && digits(segment, 0) == len(segment)
+		if !(isName(segment) || (i > 0 && isIndex)) {
+			return "", false
+		}
+	}
+
+	return path, true
+}
+
+// isName tells if a string can be written as a name, which keywords can't
+func isName(s string) bool {
+	if s == "" || !isNameStart(s[0]) || keywords[s] {
+		return false
+	}
+
+	for i := 1; i < len(s); i++ {
+		if !isNamePart(s[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// encode writes a literal as JSON
+func encode(value interface{}) (string, error) {
+	var buffer bytes.Buffer
+
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+
+	if err := encoder.Encode(value); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(buffer.String(), "\n"), nil
+}
+
+func sortedKeys(object map[string]interface{}) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,105 @@
+package infix
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrint(t *testing.T) {
+	scenarios := map[string]struct {
+		rule     string
+		expected string
+	}{
+		"comparison and membership": {
+			rule:     `{"and": [{">=": [{"var": "age"}, 18]}, {"in": [{"var": "country"}, ["GB", "IE"]]}]}`,
+			expected: `age >= 18 and country in ["GB", "IE"]`,
+		},
+		"parentheses": {
+			rule:     `{"and": [{"or": [{"var": "a"}, {"var": "b"}]}, {"!": {"and": [{"var": "c"}, {"var": "d"}]}}]}`,
+			expected: `(a or b) and not (c and d)`,
+		},
+		"nested chains": {
+			rule:     `{"and": [{"and": [{"var": "a"}, {"var": "b"}]}, {"var": "c"}]}`,
+			expected: `(a and b) and c`,
+		},
+		"associativity": {
+			rule:     `{"-": [{"-": [{"var": "a"}, {"var": "b"}]}, {"-": [{"var": "c"}, 1]}]}`,
+			expected: `a - b - (c - 1)`,
+		},
+		"arithmetic": {
+			rule:     `{"*": [{"+": [{"var": "a"}, 1]}, {"-": {"var": "b"}}, {"-": [2]}, -3]}`,
+			expected: `(a + 1) * -b * -(2) * -3`,
+		},
+		"negation": {
+			rule:     `{"==": [{"!": [{"var": "a"}]}, {"!": {"==": [{"var": "b"}, 1]}}]}`,
+			expected: `(not a) == (not b == 1)`,
+		},
+		"between": {
+			rule:     `{"<": [0, {"var": "temp"}, 100]}`,
+			expected: `0 < temp < 100`,
+		},
+		"descending between": {
+			rule:     `{">=": [100, {"var": "temp"}, 0]}`,
+			expected: `100 >= temp >= 0`,
+		},
+		"calls": {
+			rule:     `{"if": [{"some": [{"var": "items"}, {">": [{"var": "price"}, 100]}]}, {"cat": ["big ", {"var": ["first-name", ""]}]}, {"!!": [{"var": "tags"}]}]}`,
+			expected: `if(some(items, price > 100), cat("big ", var("first-name", "")), "!!"(tags))`,
+		},
+		"paths": {
+			rule:     `[{"var": "items.0.name"}, {"var": ["a"]}, {"var": ""}, {"var": "in"}, {"var": "0"}, {"var": 1}, {"var": "a..b"}]`,
+			expected: `[items.0.name, a, var(""), var("in"), var("0"), var(1), var("a..b")]`,
+		},
+		"operators without infix form": {
+			rule:     `{"in": [1, 2, 3]}`,
+			expected: `"in"(1, 2, 3)`,
+		},
+		"literals": {
+			rule:     `[null, true, "a<b", 1e21, 0.5]`,
+			expected: `[null, true, "a<b", 1e+21, 0.5]`,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			expression, err := Print(strings.NewReader(scenario.rule))
+			assert.NoError(t, err)
+			assert.Equal(t, scenario.expected, expression)
+		})
+	}
+}
+
+func TestPrintRoundTrip(t *testing.T) {
+	rules := []string{
+		`{"and": [{"and": [{"var": "a"}, {"var": "b"}]}, {"or": [{"var": "c"}, {"!": [{"var": "d"}]}]}]}`,
+		`{"-": [{"-": [{"var": "a"}, {"+": [{"var": "b"}, 1]}]}, {"-": [{"var": "c"}, -1]}]}`,
+		`{"/": [{"*": [{"var": "a"}, {"%": [{"var": "b"}, 2]}]}, {"-": [{"-": [{"var": "c"}]}]}]}`,
+		`{"<=": [{"<": [{"var": "a"}, 1]}, {"var": "b"}, {"in": [{"var": "c"}, [1, {"var": "d"}]]}]}`,
+		`{"!": [{"!": [{"==": [{"!": [{"var": "a"}]}, false]}]}]}`,
+		`{"map": [{"var": "items"}, {"?:": [{"var": [""]}, {"-": [5]}, {"var": ["x.y", null]}]}]}`,
+	}
+
+	for _, rule := range rules {
+		var expected interface{}
+		assert.NoError(t, json.Unmarshal([]byte(rule), &expected))
+
+		expression, err := PrintInterface(expected)
+		assert.NoError(t, err)
+
+		parsed, err := Parse(expression)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, parsed, expression)
+	}
+}
+
+func TestPrintErrors(t *testing.T) {
+	_, err := Print(strings.NewReader(`{`))
+	assert.Error(t, err)
+
+	_, err = Print(strings.NewReader(`{"==": [{"a": 1, "b": 2}, 1]}`))
+	assert.EqualError(t, err, "objects with 2 properties can't be printed: a, b")
+}
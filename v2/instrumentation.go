@@ -0,0 +1,58 @@
+package jsonlogic
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Instrumentation receives events about the evaluations made by an Engine,
+// to forward them to tracing and metrics systems such as OpenTelemetry.
+// Its methods are called concurrently when the Engine is.
+type Instrumentation interface {
+	// ApplyStarted is called when the evaluation of a rule starts, with
+	// the context it is made in: the one given to the methods taking a
+	// context, like ApplyWithContext, and context.Background() for the
+	// others. It returns the context of the evaluation, like the context
+	// of a span started as a child of the one in ctx, and the function
+	// called once the evaluation ends, with how long it took and the error
+	// it failed with, if any.
+	ApplyStarted(ctx context.Context) (context.Context, func(elapsed time.Duration, err error))
+
+	// OperatorEvaluated is called for every operator evaluated, with the
+	// context ApplyStarted returned for the evaluation
+	OperatorEvaluated(ctx context.Context, operator string)
+}
+
+// WithInstrumentation reports the evaluations of the Engine to instrumentation
+func WithInstrumentation(instrumentation Instrumentation) Option {
+	return func(e *Engine) error {
+		if instrumentation == nil {
+			return fmt.Errorf("instrumentation must not be nil")
+		}
+
+		e.instrumentation = instrumentation
+
+		return nil
+	}
+}
+
+// instrument reports the start of an evaluation, returning the function
+// reporting its end. The evaluation is made in the context ApplyStarted
+// returns, and in the one it was given again once it ends.
+func (ev *evaluator) instrument() func(err error) {
+	parent := ev.ctx
+	if parent == nil {
+		parent = context.Background()
+	}
+
+	start := time.Now()
+
+	ctx, done := ev.engine.instrumentation.ApplyStarted(parent)
+	ev.ctx = ctx
+
+	return func(err error) {
+		ev.ctx = parent
+		done(time.Since(start), err)
+	}
+}
@@ -0,0 +1,172 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingInstrumentation struct {
+	mu        sync.Mutex
+	applies   int
+	errors    []error
+	durations []time.Duration
+	operators map[string]int
+}
+
+func (r *recordingInstrumentation) ApplyStarted(ctx context.Context) (context.Context, func(time.Duration, error)) {
+	r.mu.Lock()
+	r.applies++
+	r.mu.Unlock()
+
+	return ctx, func(elapsed time.Duration, err error) {
+		r.mu.Lock()
+		r.durations = append(r.durations, elapsed)
+		r.errors = append(r.errors, err)
+		r.mu.Unlock()
+	}
+}
+
+func (r *recordingInstrumentation) OperatorEvaluated(_ context.Context, operator string) {
+	r.mu.Lock()
+	r.operators[operator]++
+	r.mu.Unlock()
+}
+
+func TestInstrumentation(t *testing.T) {
+	instrumentation := &recordingInstrumentation{operators: map[string]int{}}
+
+	engine, err := NewEngine(WithInstrumentation(instrumentation), WithStrictCasts())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = engine.ApplyRaw([]byte(`{"and": [{"==": [{"var": "a"}, 1]}, {"==": [{"var": "b"}, 2]}]}`), []byte(`{"a": 1, "b": 2}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = engine.ApplyRaw([]byte(`{"to_number": "abc"}`), []byte(`{}`))
+	assert.Error(t, err)
+
+	assert.Equal(t, 2, instrumentation.applies)
+	assert.Len(t, instrumentation.durations, 2)
+	assert.Nil(t, instrumentation.errors[0])
+	assert.True(t, errors.Is(instrumentation.errors[1], ErrInvalidCast))
+	assert.Equal(t, map[string]int{"and": 1, "==": 2, "var": 2, "to_number": 1}, instrumentation.operators)
+}
+
+// spanKey holds the name of the span of a context, for spanInstrumentation
+type spanKey struct{}
+
+// spanInstrumentation starts a span per evaluation, recording the span
+// each one is a child of, and the span operators are evaluated in
+type spanInstrumentation struct {
+	mu        sync.Mutex
+	parents   []interface{}
+	operators []interface{}
+}
+
+func (s *spanInstrumentation) ApplyStarted(ctx context.Context) (context.Context, func(time.Duration, error)) {
+	s.mu.Lock()
+	s.parents = append(s.parents, ctx.Value(spanKey{}))
+	s.mu.Unlock()
+
+	return context.WithValue(ctx, spanKey{}, "apply"), func(time.Duration, error) {}
+}
+
+func (s *spanInstrumentation) OperatorEvaluated(ctx context.Context, operator string) {
+	s.mu.Lock()
+	s.operators = append(s.operators, ctx.Value(spanKey{}))
+	s.mu.Unlock()
+}
+
+func TestInstrumentationContext(t *testing.T) {
+	rule := `{"==": [{"var": "a"}, 1]}`
+	data := `{"a": 1}`
+
+	request := context.WithValue(context.Background(), spanKey{}, "request")
+
+	scenarios := map[string]struct {
+		Apply  func(engine *Engine) error
+		Parent interface{}
+	}{
+		"ApplyWithContext": {
+			Apply: func(engine *Engine) error {
+				var result bytes.Buffer
+
+				return engine.ApplyWithContext(request, strings.NewReader(rule), strings.NewReader(data), &result)
+			},
+			Parent: "request",
+		},
+		"ApplyRawWithContext": {
+			Apply: func(engine *Engine) error {
+				_, err := engine.ApplyRawWithContext(request, json.RawMessage(rule), json.RawMessage(data))
+
+				return err
+			},
+			Parent: "request",
+		},
+		"ApplyInterfaceWithContext": {
+			Apply: func(engine *Engine) error {
+				_, err := engine.ApplyInterfaceWithContext(request, map[string]interface{}{
+					"==": []interface{}{map[string]interface{}{"var": "a"}, 1.0},
+				}, map[string]interface{}{"a": 1})
+
+				return err
+			},
+			Parent: "request",
+		},
+		"ApplyCompiledWithContext": {
+			Apply: func(engine *Engine) error {
+				compiled, err := engine.Compile(strings.NewReader(rule))
+				if err != nil {
+					return err
+				}
+
+				_, err = engine.ApplyCompiledWithContext(request, compiled, json.RawMessage(data))
+
+				return err
+			},
+			Parent: "request",
+		},
+		"without a context": {
+			Apply: func(engine *Engine) error {
+				_, err := engine.ApplyRaw(json.RawMessage(rule), json.RawMessage(data))
+
+				return err
+			},
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			instrumentation := &spanInstrumentation{}
+
+			engine, err := NewEngine(WithInstrumentation(instrumentation))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := scenario.Apply(engine); err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, []interface{}{scenario.Parent}, instrumentation.parents)
+			assert.Equal(t, []interface{}{"apply", "apply"}, instrumentation.operators)
+		})
+	}
+}
+
+func TestInstrumentationMustNotBeNil(t *testing.T) {
+	_, err := NewEngine(WithInstrumentation(nil))
+	assert.Error(t, err)
+}
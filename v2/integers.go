@@ -0,0 +1,270 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+)
+
+// WithIntegers makes the engine read the whole numbers of JSON rules and
+// data as int64 integers, which +, -, *, %, min, max, abs and sum keep as
+// integers, so that IDs and counters larger than 2^53 survive
+// evaluations: {"+": [9007199254740993, 1]} is 9007199254740994. Results
+// are floats as soon as an argument isn't a whole number, for / and the
+// other math operators, like avg, and when an integer would
+// overflow. Integers are compared exactly, with integers and with floats. Engines using CompatJS, whose numbers are those
+// of JavaScript, read all numbers as floats.
+//
+// Whole numbers are read as integers from the JSON rules and data of all
+// the methods of the engine, and from the rules it compiles: such engines
+// only apply the rules compiled by engines keeping integers, and the
+// other engines only the rules compiled by engines which don't, so that
+// ApplyBool and Apply agree. ApplyInterface takes int64 values as
+// integers. CompileYAML compiles rules for the engines which don't keep
+// integers.
+func WithIntegers() Option {
+	return func(e *Engine) error {
+		e.integers = true
+
+		return nil
+	}
+}
+
+// keepsIntegers tells if the engine reads whole numbers as integers
+func (e *Engine) keepsIntegers() bool {
+	return e.integers && e.coercion != CompatJS
+}
+
+// checkRule fails for the rules compiled by engines reading numbers
+// differently, whose literals the engine would compare and compute with
+// differently than those of the rules it reads
+func (e *Engine) checkRule(rule *Rule) error {
+	switch {
+	case rule.integers && !e.keepsIntegers():
+		return errors.New("rule compiled by an engine keeping integers, see WithIntegers")
+	case !rule.integers && e.keepsIntegers():
+		return errors.New("rule not compiled by an engine keeping integers, see WithIntegers")
+	}
+
+	return nil
+}
+
+// maxExactFloat is 2^53, up to which floats hold all the whole numbers
+// exactly
+const maxExactFloat = 1 << 53
+
+// readJSON is the readJSON of order, reading whole numbers as integers
+// when the engine keeps them
+func (e *Engine) readJSON(order keyOrder, r io.Reader, v *interface{}) error {
+	if !e.keepsIntegers() {
+		return order.readJSON(r, v)
+	}
+
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+
+	value, err := order.read(decoder)
+	if err != nil {
+		return err
+	}
+
+	*v = value
+
+	return nil
+}
+
+// unmarshal is the unmarshal of order, reading whole numbers as integers
+// when the engine keeps them
+func (e *Engine) unmarshal(order keyOrder, raw []byte, v *interface{}) error {
+	if !e.keepsIntegers() {
+		return order.unmarshal(raw, v)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	value, err := order.read(decoder)
+	if err != nil {
+		return err
+	}
+
+	if _, err := decoder.Token(); err != io.EOF {
+		if err == nil {
+			err = errors.New("invalid data after top-level value")
+		}
+
+		return err
+	}
+
+	*v = value
+
+	return nil
+}
+
+// readNumber reads a number as an int64 when it is written as a whole
+// number which fits, and as a float64 otherwise
+func readNumber(n json.Number) (interface{}, error) {
+	if i, err := n.Int64(); err == nil {
+		return i, nil
+	}
+
+	return n.Float64()
+}
+
+// integer reads a value as an integer: int64 values, and the floats which
+// are whole numbers small enough to be exact, like the results of count
+func integer(value interface{}) (int64, bool) {
+	switch n := value.(type) {
+	case int64:
+		return n, true
+	case float64:
+		if n == math.Trunc(n) && math.Abs(n) <= maxExactFloat {
+			return int64(n), true
+		}
+	}
+
+	return 0, false
+}
+
+// number is toNumber, keeping the integers of engines keeping them
+func (ev *evaluator) number(value interface{}) interface{} {
+	if _, ok := value.(int64); ok && ev.engine.integers {
+		return value
+	}
+
+	return toNumber(value)
+}
+
+// integers reads the arguments of an arithmetic operator as integers,
+// false when one of them isn't one
+func integers(values interface{}) ([]int64, bool) {
+	parsed := toSlice(values)
+
+	numbers := make([]int64, len(parsed))
+	for i, value := range parsed {
+		n, ok := integer(value)
+		if !ok {
+			return nil, false
+		}
+
+		numbers[i] = n
+	}
+
+	return numbers, true
+}
+
+// integerOperators are the operators keeping integers
+var integerOperators = map[string]bool{
+	"sum": true,
+	"+":   true,
+	"-":   true,
+	"*":   true,
+	"%":   true,
+	"min": true,
+	"max": true,
+	"abs": true,
+}
+
+// integerArithmetic evaluates an operator of integerOperators on integers,
+// telling if it did: the arguments which aren't integers, the results
+// which overflow and the remainders of divisions by zero are left to the
+// operators working on floats
+func integerArithmetic(operator string, values interface{}) (interface{}, bool) {
+	if operator == "sum" {
+		values = aggregated(values)
+		operator = "+"
+	}
+
+	args, ok := integers(values)
+	if !ok || len(args) == 0 {
+		return nil, false
+	}
+
+	switch operator {
+	case "+":
+		result := int64(0)
+		for _, n := range args {
+			if result, ok = addIntegers(result, n); !ok {
+				return nil, false
+			}
+		}
+
+		return result, true
+	case "-":
+		if len(args) == 1 {
+			return subtractIntegers(0, args[0])
+		}
+
+		if len(args) != 2 {
+			return nil, false
+		}
+
+		return subtractIntegers(args[0], args[1])
+	case "*":
+		result := int64(1)
+		for _, n := range args {
+			if result, ok = multiplyIntegers(result, n); !ok {
+				return nil, false
+			}
+		}
+
+		return result, true
+	case "%":
+		if len(args) != 2 || args[1] == 0 {
+			return nil, false
+		}
+
+		return args[0] % args[1], true
+	case "min", "max":
+		result := args[0]
+		for _, n := range args[1:] {
+			if operator == "min" && n < result || operator == "max" && n > result {
+				result = n
+			}
+		}
+
+		return result, true
+	case "abs":
+		if len(args) != 1 {
+			return nil, false
+		}
+
+		if args[0] < 0 {
+			return subtractIntegers(0, args[0])
+		}
+
+		return args[0], true
+	}
+
+	return nil, false
+}
+
+func addIntegers(a, b int64) (int64, bool) {
+	sum := a + b
+
+	return sum, (sum > a) == (b > 0)
+}
+
+func subtractIntegers(a, b int64) (interface{}, bool) {
+	difference := a - b
+	if (difference < a) != (b > 0) {
+		return nil, false
+	}
+
+	return difference, true
+}
+
+func multiplyIntegers(a, b int64) (int64, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+
+	product := a * b
+	if product/b != a || (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64) {
+		return 0, false
+	}
+
+	return product, true
+}
@@ -0,0 +1,207 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntegers(t *testing.T) {
+	data := `{"id": 9007199254740993, "count": 41, "price": 2.5, "items": [3, 1, 2]}`
+
+	// results are compared as strings, as decoding them would round them
+	scenarios := map[string]struct {
+		Options  []Option
+		Rule     string
+		Expected string
+	}{
+		"sum":                {nil, `{"+": [9007199254740993, 1]}`, `9007199254740994`},
+		"rounded by default": {nil, `{"+": [9007199254740993, 1]}`, `9007199254740992`},
+		"ids":                {nil, `{"var": "id"}`, `9007199254740993`},
+		"counters":           {nil, `{"+": [{"var": "count"}, 1]}`, `42`},
+		"difference":         {nil, `{"-": [{"var": "id"}, 2]}`, `9007199254740991`},
+		"negation":           {nil, `{"-": {"var": "id"}}`, `-9007199254740993`},
+		"product":            {nil, `{"*": [{"var": "id"}, 1, 1]}`, `9007199254740993`},
+		"remainder":          {nil, `{"%": [{"var": "id"}, 10]}`, `3`},
+		"min":                {nil, `{"min": [{"var": "id"}, 9007199254740995]}`, `9007199254740993`},
+		"max":                {nil, `{"max": [-3, -2]}`, `-2`},
+		"abs":                {nil, `{"abs": -9007199254740993}`, `9007199254740993`},
+		"with counts":        {nil, `{"+": [{"var": "id"}, {"count": {"var": "items"}}]}`, `9007199254740996`},
+		"reduce":             {nil, `{"reduce": [{"var": "items"}, {"+": [{"var": "accumulator"}, {"var": "current"}]}, 9007199254740993]}`, `9007199254740999`},
+		"floats":             {nil, `{"+": [{"var": "price"}, 1]}`, `3.5`},
+		"division":           {nil, `{"/": [{"var": "count"}, 2]}`, `20.5`},
+		"overflow":           {nil, `{"*": [9223372036854775807, 2]}`, `18446744073709552000`},
+		"equality":           {nil, `{"==": [{"var": "id"}, 9007199254740992]}`, `false`},
+		"strict equality":    {nil, `{"===": [{"var": "count"}, 41.0]}`, `true`},
+		"ordering":           {nil, `{"<": [9007199254740992, {"var": "id"}]}`, `true`},
+		"equality of floats": {nil, `{"==": [{"var": "id"}, 9007199254740992.0]}`, `false`},
+		"ordering of floats": {nil, `{"<": [9007199254740992.0, {"var": "id"}]}`, `true`},
+		"fractions":          {nil, `{">": [{"var": "count"}, 40.5]}`, `true`},
+		"large floats":       {nil, `{"<": [{"var": "id"}, 1e300]}`, `true`},
+		"sum of a list":      {nil, `{"sum": [[9007199254740993, 1]]}`, `9007199254740994`},
+		"sum of the data":    {nil, `{"sum": {"var": "items"}}`, `6`},
+		"sort":               {nil, `{"sort": [[9007199254740993, 9007199254740992]]}`, `[9007199254740992,9007199254740993]`},
+		"strings":            {nil, `{"cat": ["#", {"var": "id"}]}`, `"#9007199254740993"`},
+		"javascript":         {[]Option{WithCoercion(CompatJS)}, `{"+": [9007199254740993, 1]}`, `9007199254740992`},
+		"round floats":       {[]Option{WithOutputTransformers(RoundFloats(0))}, `{"merge": [{"var": "id"}, {"var": "price"}]}`, `[9007199254740993,3]`},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			options := scenario.Options
+			if name != "rounded by default" {
+				options = append(options, WithIntegers())
+			}
+
+			engine, err := NewEngine(options...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var result bytes.Buffer
+
+			err = engine.Apply(strings.NewReader(scenario.Rule), strings.NewReader(data), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, scenario.Expected+"\n", result.String())
+		})
+	}
+}
+
+func TestIntegersWithInterfaces(t *testing.T) {
+	engine, err := NewEngine(WithIntegers())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := map[string]struct {
+		Rule     interface{}
+		Expected interface{}
+	}{
+		"sum":      {map[string]interface{}{"+": []interface{}{int64(1), 2.0}}, int64(3)},
+		"floats":   {map[string]interface{}{"+": []interface{}{int64(1), 0.5}}, 1.5},
+		"division": {map[string]interface{}{"/": []interface{}{int64(4), int64(2)}}, 2.0},
+		"variable": {map[string]interface{}{"var": "id"}, int64(7)},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			result, err := engine.ApplyInterface(scenario.Rule, map[string]interface{}{"id": int64(7)})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, scenario.Expected, result)
+		})
+	}
+}
+
+func TestIntegersWithKeyOrder(t *testing.T) {
+	engine, err := NewEngine(WithIntegers(), WithKeyOrder())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := engine.ApplyRaw([]byte(`{"var": ""}`), []byte(`{"b": 9007199254740993, "a": 1.50}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, `{"b":9007199254740993,"a":1.5}`, string(bytes.TrimSpace(result)))
+
+	_, err = engine.ApplyRaw([]byte(`{"var": ""}`), []byte(`1e400`))
+	assert.Error(t, err)
+}
+
+func TestIntegersWithCompiledRules(t *testing.T) {
+	engine, err := NewEngine(WithIntegers())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := []byte(`{"id": 9007199254740992}`)
+
+	rules := map[string]string{
+		"data":     `{"==": [{"var": "id"}, 9007199254740993]}`,
+		"literals": `{"==": [9007199254740993, "9007199254740993"]}`,
+		"sum":      `{"==": [{"+": [{"var": "id"}, 1]}, 9007199254740993]}`,
+		"unknown":  `{"==": [{"var": {"cat": ["i", "d"]}}, 9007199254740992]}`,
+	}
+
+	for name, rule := range rules {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var expected bytes.Buffer
+
+			err := engine.Apply(strings.NewReader(rule), bytes.NewReader(data), &expected)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			compiled, err := engine.Compile(strings.NewReader(rule))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			result, err := engine.ApplyBool(compiled, data)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, expected.String(), fmt.Sprintf("%v\n", result))
+
+			results, err := engine.ApplyAll(map[string]*Rule{name: compiled}, data)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, result, results[name])
+
+			encoded, err := compiled.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			decoded := new(Rule)
+			if err := decoded.UnmarshalBinary(encoded); err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, compiled.tree, decoded.tree)
+			assert.True(t, decoded.integers)
+		})
+	}
+}
+
+func TestIntegersWithRulesOfOtherEngines(t *testing.T) {
+	engine, err := NewEngine(WithIntegers())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	floats, err := Compile(strings.NewReader(`{"var": "id"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	integers, err := engine.Compile(strings.NewReader(`{"var": "id"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = engine.ApplyBool(floats, []byte(`{}`))
+	assert.Error(t, err)
+
+	_, err = engine.ApplyAll(map[string]*Rule{"id": floats}, []byte(`{}`))
+	assert.Error(t, err)
+
+	_, err = ApplyBool(integers, []byte(`{}`))
+	assert.Error(t, err)
+
+	_, err = NewRouter(Route{Label: "id", Rule: integers})
+	assert.Error(t, err)
+}
@@ -7,28 +7,10 @@ import (
 	"io"
 	"math"
 	"reflect"
-	"sort"
+	"strconv"
 	"strings"
-
-	"github.com/mitchellh/copystructure"
 )
 
-func between(operator string, values []interface{}, data interface{}) interface{} {
-	a := values[0]
-	b := values[1]
-	c := values[2]
-
-	if operator == "<" {
-		return less(a, b) && less(b, c)
-	}
-
-	if operator == "<=" {
-		return (less(a, b) || equals(a, b)) && (less(b, c) || equals(b, c))
-	}
-
-	return false
-}
-
 func unary(operator string, value interface{}) interface{} {
 	if operator == "+" || operator == "*" || operator == "/" {
 		return toNumber(value)
@@ -61,15 +43,7 @@ func _and(values []interface{}) interface{} {
 	isBoolExpression := true
 
 	for _, value := range values {
-		if isSlice(value) {
-			return value
-		}
-
-		if isBool(value) && !value.(bool) {
-			return false
-		}
-
-		if isString(value) && toString(value) == "" {
+		if stopsAnd(value) {
 			return value
 		}
 
@@ -93,6 +67,12 @@ func _and(values []interface{}) interface{} {
 	return v
 }
 
+// stopsAnd tells if and returns a value as soon as it gets it: lists,
+// false and empty strings
+func stopsAnd(value interface{}) bool {
+	return isSlice(value) || isBool(value) && !value.(bool) || isString(value) && toString(value) == ""
+}
+
 func _or(values []interface{}) interface{} {
 	for _, value := range values {
 		if isTrue(value) {
@@ -116,48 +96,45 @@ func _inRange(value interface{}, values interface{}) bool {
 	return toString(value) >= toString(i) && toString(j) >= toString(value)
 }
 
-// Expect values to be in alphabetical ascending order
-func _inSorted(value interface{}, values interface{}) bool {
-	valuesSlice := values.([]interface{})
-
-	findElement := func(i int) bool {
-		element := valuesSlice[i]
+// _in tells if a value is an element of a list, a substring of a string
+// or a property name of an object. Lists and objects are found in lists by
+// deep equality, while lists of two elements in a list hold ranges for the
+// other values: {"in": [5, [[1, 10], [20, 30]]]} is true.
+func _in(value interface{}, values interface{}) bool {
+	if object, ok := structObject(values); ok {
+		values = object
+	}
 
-		if isSlice(valuesSlice[i]) {
-			sliceElement := valuesSlice[i].([]interface{})
-			start := sliceElement[0]
-			end := sliceElement[1]
+	switch {
+	case isString(values):
+		if !isString(value) && !isNumber(value) {
+			return false
+		}
 
-			return (toString(start) <= toString(value) && toString(end) >= toString(value)) || toString(end) > toString(value)
+		return strings.Contains(values.(string), toString(value))
+	case isMap(values):
+		if !isString(value) && !isNumber(value) {
+			return false
 		}
 
-		return toString(element) >= toString(value)
-	}
+		_, ok := values.(map[string]interface{})[toString(value)]
 
-	i := sort.Search(len(valuesSlice), findElement)
-	if i >= len(valuesSlice) {
+		return ok
+	case !isSlice(values):
 		return false
 	}
 
-	if isSlice(valuesSlice[i]) {
-		sliceElement := valuesSlice[i].([]interface{})
-		start := sliceElement[0]
-		end := sliceElement[1]
-
-		return toString(start) <= toString(value) && toString(end) >= toString(value)
-	}
-
-	return toString(valuesSlice[i]) == toString(value)
-}
+	for _, element := range values.([]interface{}) {
+		if isSlice(value) || isMap(value) {
+			if deepEquals(value, element) {
+				return true
+			}
 
-func _in(value interface{}, values interface{}) bool {
-	if isString(values) {
-		return strings.Contains(values.(string), value.(string))
-	}
+			continue
+		}
 
-	for _, element := range values.([]interface{}) {
 		if isSlice(element) {
-			if _inRange(value, element) {
+			if len(element.([]interface{})) == 2 && (isNumber(value) || isString(value)) && _inRange(value, element) {
 				return true
 			}
 
@@ -165,7 +142,7 @@ func _in(value interface{}, values interface{}) bool {
 		}
 
 		if isNumber(value) {
-			if toNumber(element) == value {
+			if (isNumber(element) || isString(element)) && toNumber(element) == value {
 				return true
 			}
 
@@ -180,13 +157,6 @@ func _in(value interface{}, values interface{}) bool {
 	return false
 }
 
-func mod(a interface{}, b interface{}) interface{} {
-	_a := toNumber(a)
-	_b := toNumber(b)
-
-	return math.Mod(_a, _b)
-}
-
 func abs(a interface{}) interface{} {
 	_a := toNumber(a)
 
@@ -268,22 +238,6 @@ func mult(values interface{}) interface{} {
 	return sum
 }
 
-func div(values interface{}) interface{} {
-	var sum float64
-
-	for _, n := range values.([]interface{}) {
-		if sum == 0 {
-			sum = toNumber(n)
-
-			continue
-		}
-
-		sum /= toNumber(n)
-	}
-
-	return sum
-}
-
 func substr(values interface{}) interface{} {
 	rp := reflect.ValueOf(values)
 	parsed := values.([]interface{})
@@ -342,9 +296,18 @@ func merge(values interface{}, level int8) interface{} {
 	return result
 }
 
-func conditional(values, data interface{}) interface{} {
+// holds tells if the evaluated condition of an if holds
+func holds(condition, data interface{}) bool {
+	if isMap(condition) {
+		condition = getVar(condition, data, AutoPaths)
+	}
+
+	return isTrue(condition)
+}
+
+func conditional(values, data interface{}) (interface{}, int) {
 	if isPrimitive(values) {
-		return values
+		return values, -1
 	}
 
 	rp := reflect.ValueOf(values)
@@ -352,51 +315,69 @@ func conditional(values, data interface{}) interface{} {
 	length := rp.Len()
 
 	if length == 0 {
-		return nil
+		return nil, -1
 	}
 
 	parsed := values.([]interface{})
 
 	for i := 0; i < length-1; i = i + 2 {
-		v := parsed[i]
-		if isMap(v) {
-			v = getVar(parsed[i], data)
-		}
-
-		if isTrue(v) {
-			return parsed[i+1]
+		if holds(parsed[i], data) {
+			return parsed[i+1], i + 1
 		}
 	}
 
 	if length%2 == 1 {
-		return parsed[length-1]
+		return parsed[length-1], length - 1
 	}
 
-	return nil
+	return nil, -1
 }
 
-func setProperty(value, data interface{}) interface{} {
-	_value := value.([]interface{})
+// switchCase evaluates the result of the first case whose value equals
+// the key, or the default when there's no such case:
+// {"switch": [key, [[case, result], [case, result]], default]}.
+// Strings and numbers are compared like "==" does, other values must be
+// identical. Only the key, the cases up to the matching one and the
+// chosen result are evaluated.
+func (ev *evaluator) switchCase(values, data interface{}) interface{} {
+	parsed := toSlice(values)
+	if len(parsed) < 2 || len(parsed) > 3 || !isSlice(parsed[1]) {
+		return nil
+	}
+
+	key := ev.parseValues(parsed[0], data)
+
+	for i, branch := range parsed[1].([]interface{}) {
+		if !isSlice(branch) || len(branch.([]interface{})) != 2 {
+			continue
+		}
 
-	object := _value[0]
+		_branch := branch.([]interface{})
+		if caseMatches(key, ev.parseValues(_branch[0], data)) {
+			ev.chose(fmt.Sprintf("1/%d/1", i))
 
-	if !isMap(object) {
-		return object
+			return ev.parseValues(_branch[1], data)
+		}
 	}
 
-	property := _value[1].(string)
-	modified, err := copystructure.Copy(object)
-	if err != nil {
-		panic(err)
+	if len(parsed) == 3 {
+		ev.chose("2")
+
+		return ev.parseValues(parsed[2], data)
 	}
 
-	_modified := modified.(map[string]interface{})
-	_modified[property] = parseValues(_value[2], data)
+	return nil
+}
 
-	return interface{}(_modified)
+func caseMatches(key, value interface{}) bool {
+	if (isString(key) || isNumber(key)) && (isString(value) || isNumber(value)) {
+		return equals(key, value)
+	}
+
+	return deepEquals(key, value)
 }
 
-func missing(values, data interface{}) interface{} {
+func (ev *evaluator) missing(values, data interface{}) interface{} {
 	if isString(values) {
 		values = []interface{}{values}
 	}
@@ -404,7 +385,7 @@ func missing(values, data interface{}) interface{} {
 	missing := make([]interface{}, 0)
 
 	for _, _var := range values.([]interface{}) {
-		_value := getVar(_var, data)
+		_value := ev.variable(_var, data)
 
 		if _value == nil {
 			missing = append(missing, _var)
@@ -414,7 +395,7 @@ func missing(values, data interface{}) interface{} {
 	return missing
 }
 
-func missingSome(values, data interface{}) interface{} {
+func (ev *evaluator) missingSome(values, data interface{}) interface{} {
 	parsed := values.([]interface{})
 	number := int(toNumber(parsed[0]))
 	vars := parsed[1]
@@ -423,7 +404,7 @@ func missingSome(values, data interface{}) interface{} {
 	found := make([]interface{}, 0)
 
 	for _, _var := range vars.([]interface{}) {
-		_value := getVar(_var, data)
+		_value := ev.variable(_var, data)
 
 		if _value == nil {
 			missing = append(missing, _var)
@@ -439,110 +420,191 @@ func missingSome(values, data interface{}) interface{} {
 	return make([]interface{}, 0)
 }
 
-func all(values, data interface{}) interface{} {
+func (ev *evaluator) all(values, data interface{}) interface{} {
 	parsed := values.([]interface{})
 
 	var subject interface{}
 
 	if isMap(parsed[0]) {
-		subject = apply(parsed[0], data)
+		subject = ev.apply(parsed[0], data)
 	}
 
 	if isSlice(parsed[0]) {
 		subject = parsed[0]
 	}
 
-	if !isTrue(subject) {
+	if object, ok := structObject(subject); ok {
+		subject = object
+	}
+
+	keyed := isMap(subject)
+	if keyed {
+		subject = entries(subject.(map[string]interface{}))
+	}
+
+	if !ev.truthy(subject) {
 		return false
 	}
 
-	conditions := solveVars(parsed[1], data)
+	conditions := ev.solveVars(parsed[1], data)
 
-	for _, value := range subject.([]interface{}) {
-		v := apply(conditions, value)
+	defer ev.iteration(data)()
 
-		if !isTrue(v) {
-			return false
-		}
-	}
+	result := true
+
+	ev.each(conditions, source(subject), keyed, func(_, v interface{}) bool {
+		result = ev.truthy(v)
 
-	return true
+		return result
+	})
+
+	return result
 }
 
-func none(values, data interface{}) interface{} {
+func (ev *evaluator) none(values, data interface{}) interface{} {
 	parsed := values.([]interface{})
 
 	var subject interface{}
 
 	if isMap(parsed[0]) {
-		subject = apply(parsed[0], data)
+		subject = ev.apply(parsed[0], data)
 	}
 
 	if isSlice(parsed[0]) {
 		subject = parsed[0]
 	}
 
-	if !isTrue(subject) {
+	if object, ok := structObject(subject); ok {
+		subject = object
+	}
+
+	keyed := isMap(subject)
+	if keyed {
+		subject = entries(subject.(map[string]interface{}))
+	}
+
+	if !ev.truthy(subject) {
 		return true
 	}
 
-	conditions := solveVars(parsed[1], data)
+	conditions := ev.solveVars(parsed[1], data)
 
-	for _, value := range subject.([]interface{}) {
-		v := apply(conditions, value)
+	defer ev.iteration(data)()
 
-		if isTrue(v) {
-			return false
-		}
-	}
+	result := true
 
-	return true
+	ev.each(conditions, source(subject), keyed, func(_, v interface{}) bool {
+		result = !ev.truthy(v)
+
+		return result
+	})
+
+	return result
 }
 
-func some(values, data interface{}) interface{} {
+func (ev *evaluator) some(values, data interface{}) interface{} {
 	parsed := values.([]interface{})
 
 	var subject interface{}
 
 	if isMap(parsed[0]) {
-		subject = apply(parsed[0], data)
+		subject = ev.apply(parsed[0], data)
 	}
 
 	if isSlice(parsed[0]) {
 		subject = parsed[0]
 	}
 
-	if !isTrue(subject) {
+	if object, ok := structObject(subject); ok {
+		subject = object
+	}
+
+	keyed := isMap(subject)
+	if keyed {
+		subject = entries(subject.(map[string]interface{}))
+	}
+
+	if !ev.truthy(subject) {
 		return false
 	}
 
-	conditions := solveVars(parsed[1], data)
+	conditions := ev.solveVars(parsed[1], data)
 
-	for _, value := range subject.([]interface{}) {
-		v := apply(conditions, value)
+	defer ev.iteration(data)()
 
-		if isTrue(v) {
-			return true
+	result := false
+
+	ev.each(conditions, source(subject), keyed, func(_, v interface{}) bool {
+		result = ev.truthy(v)
+
+		return !result
+	})
+
+	return result
+}
+
+func (ev *evaluator) operation(operator string, values, data interface{}) interface{} {
+	if ev.engine.coercion != SpecCoercion {
+		if result, ok := ev.coerce(operator, values); ok {
+			return result
 		}
 	}
 
-	return false
-}
+	if arithmeticOperators[operator] || operator == "max" || operator == "min" {
+		ev.coerced(operator, values)
+	}
+
+	if ev.engine.integers && integerOperators[operator] {
+		if result, ok := integerArithmetic(operator, values); ok {
+			return result
+		}
+	}
 
-func operation(operator string, values, data interface{}) interface{} {
 	if operator == "missing" {
-		return missing(values, data)
+		return ev.missing(values, data)
 	}
 	if operator == "missing_some" {
-		return missingSome(values, data)
+		return ev.missingSome(values, data)
 	}
 
 	if operator == "var" {
-		return getVar(values, data)
+		return ev.variable(values, data)
+	}
+
+	if operator == "let" {
+		return ev.let(values, data)
+	}
+
+	if operator == "def" {
+		return ev.def(values, data)
+	}
+
+	if operator == "call" {
+		return ev.callFunction(values)
+	}
+
+	if operator == "template" {
+		return ev.template(values, data)
 	}
 
 	if operator == "set" {
-		return setProperty(values, data)
+		return ev.set(values)
+	}
+
+	if operator == "unset" {
+		return ev.unset(values)
+	}
+
+	if operator == "rename" {
+		return ev.rename(values)
+	}
+
+	if operator == "merge_objects" {
+		return mergeObjects(values)
+	}
+
+	if operator == "rule" {
+		return ev.reference(values, data)
 	}
 
 	if operator == "cat" {
@@ -558,7 +620,188 @@ func operation(operator string, values, data interface{}) interface{} {
 	}
 
 	if operator == "if" {
-		return conditional(values, data)
+		result, branch := conditional(values, data)
+		if branch >= 0 {
+			ev.chose(strconv.Itoa(branch))
+		}
+
+		return result
+	}
+
+	if operator == "match" || operator == "regex" {
+		return ev.match(values)
+	}
+
+	if operator == "now" {
+		return ev.now()
+	}
+
+	if operator == "random" {
+		return ev.engine.random()
+	}
+
+	if operator == "sample_pct" {
+		return ev.sample(values)
+	}
+
+	if operator == "date_before" {
+		return ev.engine.dates.dateBefore(values)
+	}
+
+	if operator == "date_after" {
+		return ev.engine.dates.dateAfter(values)
+	}
+
+	if operator == "date_between" {
+		return ev.engine.dates.dateBetween(values)
+	}
+
+	if operator == "date_add" {
+		return ev.engine.dates.dateAdd(values)
+	}
+
+	if operator == "date_diff" {
+		return ev.engine.dates.dateDiff(values)
+	}
+
+	if operator == "date_start_of" {
+		return ev.engine.dates.dateStartOf(values)
+	}
+
+	if operator == "date_same" {
+		return ev.engine.dates.dateSame(values)
+	}
+
+	if identifierOperators[operator] {
+		return identifier(operator, values)
+	}
+
+	if _, ok := hashOperators[operator]; ok {
+		return hashValue(operator, values)
+	}
+
+	if operator == "pow" {
+		return pow(values)
+	}
+
+	if operator == "sqrt" {
+		return sqrt(values)
+	}
+
+	if operator == "round" {
+		return round(values)
+	}
+
+	if operator == "format_number" {
+		return formatNumber(values)
+	}
+
+	if operator == "floor" {
+		return floor(values)
+	}
+
+	if operator == "ceil" {
+		return ceil(values)
+	}
+
+	if operator == "sum" {
+		return aggregateSum(values)
+	}
+
+	if operator == "avg" {
+		return average(values)
+	}
+
+	if operator == "count" {
+		return count(values)
+	}
+
+	if operator == "median" {
+		return median(values)
+	}
+
+	if operator == "sort" {
+		return ev.sortValues(values)
+	}
+
+	if operator == "unique" {
+		return ev.unique(values)
+	}
+
+	if operator == "reverse" {
+		return ev.reverse(values)
+	}
+
+	if operator == "slice" {
+		return ev.slice(values)
+	}
+
+	if operator == "flatten" {
+		return ev.flatten(values)
+	}
+
+	if operator == "index_of" {
+		return index(values)
+	}
+
+	if operator == "keys" {
+		return keys(values)
+	}
+
+	if operator == "values" {
+		return objectValues(values)
+	}
+
+	if operator == "pick" {
+		return pick(values)
+	}
+
+	if operator == "omit" {
+		return omit(values)
+	}
+
+	if operator == "has" {
+		return ev.has(values)
+	}
+
+	if operator == "intersection" {
+		return intersection(values)
+	}
+
+	if operator == "union" {
+		return union(values)
+	}
+
+	if operator == "difference" {
+		return difference(values)
+	}
+
+	if operator == "zip" {
+		return zip(values)
+	}
+
+	if operator == "product" {
+		return ev.product(values)
+	}
+
+	if operator == "between" {
+		return ev.between(values)
+	}
+
+	if operator == "in_range" {
+		return ev.inRange(values)
+	}
+
+	if operator == "overlaps" {
+		return ev.overlaps(values)
+	}
+
+	if operator == "contains_range" {
+		return ev.containsRange(values)
+	}
+
+	if semverOperators[operator] {
+		return semver(operator, values)
 	}
 
 	if isPrimitive(values) {
@@ -593,7 +836,7 @@ func operation(operator string, values, data interface{}) interface{} {
 	}
 
 	if operator == "/" {
-		return div(values)
+		return ev.div(values)
 	}
 
 	if operator == "and" {
@@ -606,9 +849,13 @@ func operation(operator string, values, data interface{}) interface{} {
 
 	if operator == "?:" {
 		if parsed[0].(bool) {
+			ev.chose("1")
+
 			return parsed[1]
 		}
 
+		ev.chose("2")
+
 		return parsed[2]
 	}
 
@@ -617,31 +864,19 @@ func operation(operator string, values, data interface{}) interface{} {
 	}
 
 	if operator == "in_sorted" {
-		return _inSorted(parsed[0], parsed[1])
+		return inSorted(parsed)
 	}
 
 	if operator == "%" {
-		return mod(parsed[0], parsed[1])
+		return ev.mod(parsed[0], parsed[1])
 	}
 
 	if rp.Len() == 3 {
-		return between(operator, parsed, data)
-	}
-
-	if operator == "<" {
-		return less(parsed[0], parsed[1])
-	}
-
-	if operator == ">" {
-		return less(parsed[1], parsed[0])
-	}
-
-	if operator == "<=" {
-		return less(parsed[0], parsed[1]) || equals(parsed[0], parsed[1])
+		return ev.chained(operator, parsed)
 	}
 
-	if operator == ">=" {
-		return less(parsed[1], parsed[0]) || equals(parsed[0], parsed[1])
+	if operator == "<" || operator == ">" || operator == "<=" || operator == ">=" {
+		return ev.ordered(operator, parsed[0], parsed[1])
 	}
 
 	if operator == "===" {
@@ -649,144 +884,200 @@ func operation(operator string, values, data interface{}) interface{} {
 	}
 
 	if operator == "!=" {
-		return !equals(parsed[0], parsed[1])
+		ev.compared(operator, parsed[0], parsed[1])
+
+		return !ev.equals(parsed[0], parsed[1])
 	}
 
 	if operator == "!==" {
 		return !hardEquals(parsed[0], parsed[1])
 	}
 
-	return equals(parsed[0], parsed[1])
+	ev.compared(operator, parsed[0], parsed[1])
+
+	return ev.equals(parsed[0], parsed[1])
 }
 
-func parseValues(values, data interface{}) interface{} {
+func (ev *evaluator) parseValues(values, data interface{}) interface{} {
 	if values == nil || isPrimitive(values) {
 		return values
 	}
 
+	// DataSources are only read element by element by iterations, which
+	// evaluate their list with apply
 	if isMap(values) {
-		return apply(values, data)
+		return materialize(ev.apply(values, data))
 	}
 
-	parsed := make([]interface{}, 0)
+	list := values.([]interface{})
+	box, parsed := ev.arena.boxed(len(list))
 
-	for _, value := range values.([]interface{}) {
+	for i, value := range list {
 		if isMap(value) {
-			parsed = append(parsed, apply(value, data))
+			parsed[i] = materialize(ev.apply(value, data))
 		} else {
-			parsed = append(parsed, value)
+			parsed[i] = value
 		}
 	}
 
-	return parsed
+	return box
 }
 
-func apply(rules, data interface{}) interface{} {
-	for operator, values := range rules.(map[string]interface{}) {
-		if operator == "filter" {
-			return filter(values, data)
-		}
+func (ev *evaluator) apply(rules, data interface{}) interface{} {
+	if ev.tracing {
+		return ev.traceRule(rules, data)
+	}
 
-		if operator == "map" {
-			return _map(values, data)
-		}
+	if ev.coverage != nil {
+		return ev.cover(rules, data)
+	}
 
-		if operator == "reduce" {
-			return reduce(values, data)
-		}
+	if ev.iterating > 0 {
+		return ev.memoize(rules, data)
+	}
+
+	if ev.keys != nil {
+		return ev.share(rules, data)
+	}
+
+	return ev.applyRule(rules, data)
+}
+
+// lazyOperators evaluate their own arguments, the others get them
+// already evaluated
+var lazyOperators = map[string]bool{
+	"filter":     true,
+	"map":        true,
+	"reduce":     true,
+	"all":        true,
+	"none":       true,
+	"some":       true,
+	"map_obj":    true,
+	"filter_obj": true,
+	"groupby":    true,
+	"switch":     true,
+	"log":        true,
+	"let":        true,
+	"def":        true,
+}
+
+func isLazyOperator(operator string) bool {
+	return lazyOperators[operator] || isTypeOperator(operator) || isCastOperator(operator)
+}
 
-		if operator == "all" {
-			return all(values, data)
+func (ev *evaluator) applyRule(rules, data interface{}) interface{} {
+	for operator, values := range rules.(map[string]interface{}) {
+		if ev.engine.instrumentation != nil {
+			ev.engine.instrumentation.OperatorEvaluated(ev.ctx, operator)
 		}
 
-		if operator == "none" {
-			return none(values, data)
+		if ev.budget != nil {
+			defer ev.enter(rules)()
 		}
 
-		if operator == "some" {
-			return some(values, data)
+		if !isLazyOperator(operator) {
+			if ev.reaching(operator, values) {
+				values = ev.reach(operator, values.([]interface{}), data)
+			} else if isMap(values) {
+				values = listArgument(operator, ev.parseValues(values, data))
+			} else {
+				values = ev.parseValues(values, data)
+			}
+
+			if ev.tracing {
+				ev.current.Values = values
+			}
 		}
-		return operation(operator, parseValues(values, data), data)
+
+		return ev.invoke(operator, values, data)
 	}
 
 	// an empty-map rule should return an empty-map
 	return make(map[string]interface{})
 }
 
-// Apply read the rule and it's data from io.Reader, executes it
-// and write back a JSON into an io.Writer result
-func Apply(rule, data io.Reader, result io.Writer) error {
-	if rule == nil {
-		return fmt.Errorf("error Apply-ing nil rule")
+// invoke calls an operator with its evaluated arguments, through the
+// middlewares of the engine and within its limits
+func (ev *evaluator) invoke(operator string, values, data interface{}) interface{} {
+	if len(ev.engine.middlewares) > 0 {
+		return ev.limit(operator, ev.finite(operator, ev.callWithMiddlewares(operator, values, data)))
 	}
-	if data == nil {
-		// best effort, nil data is likely no-data needed
-		data = strings.NewReader("{}")
+
+	return ev.limit(operator, ev.finite(operator, ev.call(operator, values, data)))
+}
+
+func (ev *evaluator) call(operator string, values, data interface{}) interface{} {
+	ev.allow(operator)
+
+	if operator == "filter" {
+		return ev.filter(values, data)
 	}
-	var _rule interface{}
-	var _data interface{}
 
-	decoderRule := json.NewDecoder(rule)
-	err := decoderRule.Decode(&_rule)
-	if err != nil {
-		return fmt.Errorf("error parsing rule: %w", err)
+	if operator == "map" {
+		return ev._map(values, data)
 	}
 
-	decoderData := json.NewDecoder(data)
-	err = decoderData.Decode(&_data)
-	if err != nil {
-		return fmt.Errorf("error parsing data %w", err)
+	if operator == "reduce" {
+		return ev.reduce(values, data)
 	}
 
-	encoder := json.NewEncoder(result)
-	switch r := _rule.(type) {
-	case map[string]interface{}:
-		return encoder.Encode(apply(r, _data))
-	default:
-		return encoder.Encode(r)
+	if operator == "all" {
+		return ev.all(values, data)
 	}
-}
 
-func ApplyRaw(rule, data json.RawMessage) (json.RawMessage, error) {
-	var _rule interface{}
-	var _data interface{}
+	if operator == "none" {
+		return ev.none(values, data)
+	}
 
-	err := json.Unmarshal(rule, &_rule)
-	if err != nil {
-		return nil, err
+	if operator == "some" {
+		return ev.some(values, data)
 	}
 
-	err = json.Unmarshal(data, &_data)
-	if err != nil {
-		return nil, err
+	if operator == "map_obj" {
+		return ev.mapObject(values, data)
 	}
 
-	var result interface{}
+	if operator == "filter_obj" {
+		return ev.filterObject(values, data)
+	}
 
-	if isMap(_rule) {
-		result = apply(_rule, _data)
-	} else {
-		result = _rule
+	if operator == "groupby" {
+		return ev.groupBy(values, data)
 	}
 
-	var output json.RawMessage
+	if operator == "switch" {
+		return ev.switchCase(values, data)
+	}
 
-	output, err = json.Marshal(&result)
-	if err != nil {
-		return nil, err
+	if isTypeOperator(operator) {
+		return ev.inspectType(operator, values, data)
 	}
 
-	return output, nil
-}
+	if isCastOperator(operator) {
+		return ev.cast(operator, values, data)
+	}
 
-func ApplyInterface(rule, data interface{}) (interface{}, error) {
-	var result interface{}
+	if operator == "log" {
+		return ev.log(values, data)
+	}
 
-	if isMap(rule) {
-		result = apply(rule, data)
-	} else {
-		result = rule
+	if ev.engine.logger != nil && operator != "var" && !isOperator(operator) {
+		ev.warn("jsonlogic unknown operator", "operator", operator)
 	}
 
-	return result, nil
+	return ev.operation(operator, values, data)
+}
+
+// Apply read the rule and it's data from io.Reader, executes it
+// and write back a JSON into an io.Writer result
+func Apply(rule, data io.Reader, result io.Writer) error {
+	return defaultEngine.Apply(rule, data, result)
+}
+
+func ApplyRaw(rule, data json.RawMessage) (json.RawMessage, error) {
+	return defaultEngine.ApplyRaw(rule, data)
+}
+
+func ApplyInterface(rule, data interface{}) (interface{}, error) {
+	return defaultEngine.ApplyInterface(rule, data)
 }
@@ -269,14 +269,15 @@ func TestInSortedOperator(t *testing.T) {
 			{"in_sorted": [
 				{"var": ".age"},
 				[
+					2,
 					11.00,
 					[12, 14],
 					[13, 18],
-					2,
-					"20",
+					20,
 					[32, 38],
+					"18",
 					"a",
-					["b", "d"]					
+					["b", "d"]
 				]
 			]}
 		]
@@ -381,6 +382,48 @@ func TestNoneWithLists(t *testing.T) {
 	assert.JSONEq(t, "true", result.String())
 }
 
+func TestQuantifiersWithObjects(t *testing.T) {
+	data := `{
+		"users": {
+			"u1": {"name": "Ana", "active": true},
+			"u2": {"name": "Rui", "active": false}
+		},
+		"stock": {"apples": 3, "pears": 0},
+		"key": "outer",
+		"empty": {}
+	}`
+
+	scenarios := map[string]struct {
+		Rule     string
+		Expected string
+	}{
+		"some values":         {`{"some": [{"var": "users"}, {"var": ".value.active"}]}`, `true`},
+		"all values":          {`{"all": [{"var": "users"}, {"var": ".value.active"}]}`, `false`},
+		"none values":         {`{"none": [{"var": "stock"}, {"<": [{"var": ".value"}, 0]}]}`, `true`},
+		"keys":                {`{"some": [{"var": "users"}, {"==": [{"var": ".key"}, "u2"]}]}`, `true`},
+		"keys and values":     {`{"all": [{"var": "stock"}, {"or": [{"==": [{"var": ".key"}, "pears"]}, {">": [{"var": ".value"}, 0]}]}]}`, `true`},
+		"entries":             {`{"some": [{"var": "stock"}, {"==": [{"var": ".value"}, 0]}]}`, `true`},
+		"outer data":          {`{"some": [{"var": "stock"}, {"==": [{"var": "key"}, "outer"]}]}`, `true`},
+		"empty object, all":   {`{"all": [{"var": "empty"}, true]}`, `false`},
+		"empty object, some":  {`{"some": [{"var": "empty"}, true]}`, `false`},
+		"empty object, none":  {`{"none": [{"var": "empty"}, true]}`, `true`},
+		"lists still iterate": {`{"all": [{"merge": [1, 2]}, {">": [{"var": ""}, 0]}]}`, `true`},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var result bytes.Buffer
+
+			err := Apply(strings.NewReader(scenario.Rule), strings.NewReader(data), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, result.String())
+		})
+	}
+}
+
 func TestInOperatorWorksWithMaps(t *testing.T) {
 	rule := strings.NewReader(`{
 		"some": [
@@ -413,6 +456,52 @@ func TestInOperatorWorksWithMaps(t *testing.T) {
 	assert.JSONEq(t, "true", result.String())
 }
 
+func TestInOperator(t *testing.T) {
+	data := `{
+		"user": {"name": "Ana", "roles": ["admin"], "nickname": null},
+		"allowed": [{"id": 1}, {"id": 2}],
+		"pairs": [[1, 2], [3, 4]],
+		"codes": ["1", 2, null, true]
+	}`
+
+	scenarios := map[string]struct {
+		Rule     string
+		Expected string
+	}{
+		"an element":                  {`{"in": ["admin", {"var": "user.roles"}]}`, `true`},
+		"a substring":                 {`{"in": ["An", {"var": "user.name"}]}`, `true`},
+		"a number in a string":        {`{"in": [2, "v2.0"]}`, `true`},
+		"an object in a list":         {`{"in": [{"var": "allowed.1"}, {"var": "allowed"}]}`, `true`},
+		"an object missing":           {`{"in": [{"var": "user"}, {"var": "allowed"}]}`, `false`},
+		"a list in a list":            {`{"in": [{"merge": [3, 4]}, {"var": "pairs"}]}`, `true`},
+		"a list which isn't there":    {`{"in": [{"merge": [2, 3]}, {"var": "pairs"}]}`, `false`},
+		"a number within a range":     {`{"in": [2.5, {"var": "pairs"}]}`, `false`},
+		"a number in a range":         {`{"in": [3.5, {"var": "pairs"}]}`, `true`},
+		"a key":                       {`{"in": ["name", {"var": "user"}]}`, `true`},
+		"a key with a null value":     {`{"in": ["nickname", {"var": "user"}]}`, `true`},
+		"a missing key":               {`{"in": ["email", {"var": "user"}]}`, `false`},
+		"a value isn't a key":         {`{"in": ["Ana", {"var": "user"}]}`, `false`},
+		"a number among strings":      {`{"in": [1, {"var": "codes"}]}`, `true`},
+		"a number among other values": {`{"in": [3, {"var": "codes"}]}`, `false`},
+		"null":                        {`{"in": [null, {"var": "codes"}]}`, `true`},
+		"missing data":                {`{"in": ["a", {"var": "missing"}]}`, `false`},
+		"a list in a string":          {`{"in": [{"var": "user.roles"}, "admin"]}`, `false`},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var result bytes.Buffer
+
+			err := Apply(strings.NewReader(scenario.Rule), strings.NewReader(data), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, result.String())
+		})
+	}
+}
+
 func TestJSONLogicValidator(t *testing.T) {
 	scenarios := map[string]struct {
 		IsValid bool
@@ -497,3 +586,56 @@ func TestMergeArrayOfArrays(t *testing.T) {
 
 	assert.JSONEq(t, expectedResult, result.String())
 }
+
+func TestSwitchOperator(t *testing.T) {
+	rule := `{
+		"switch": [
+			{"var": "tier"},
+			[
+				["gold", {"*": [{"var": "price"}, 0.8]}],
+				["silver", {"*": [{"var": "price"}, 0.9]}],
+				[1, "numeric tier"],
+				[null, "no tier"]
+			],
+			{"var": "price"}
+		]
+	}`
+
+	scenarios := map[string]struct {
+		Data     string
+		Expected string
+	}{
+		"first case":           {`{"tier": "gold", "price": 100}`, `80`},
+		"second case":          {`{"tier": "silver", "price": 100}`, `90`},
+		"numbers like ==":      {`{"tier": "1", "price": 100}`, `"numeric tier"`},
+		"null case":            {`{"price": 100}`, `"no tier"`},
+		"default":              {`{"tier": "bronze", "price": 100}`, `100`},
+		"default for booleans": {`{"tier": true, "price": 100}`, `100`},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var result bytes.Buffer
+
+			err := Apply(strings.NewReader(rule), strings.NewReader(scenario.Data), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, result.String())
+		})
+	}
+}
+
+func TestSwitchWithoutDefault(t *testing.T) {
+	rule := strings.NewReader(`{"switch": [{"var": "tier"}, [["gold", 1]]]}`)
+	data := strings.NewReader(`{"tier": "silver"}`)
+
+	var result bytes.Buffer
+	err := Apply(rule, data, &result)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.JSONEq(t, "null", result.String())
+}
@@ -0,0 +1,240 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+)
+
+// WithKeyOrder makes Apply, ApplyRaw, ApplyCompiled, ApplyWithTrace and
+// ApplyYAML write the objects of the data and of the rules in their results
+// with their keys in the order they were read, instead of sorting them, so results passing
+// them through are byte for byte the same as the input, for signing or
+// diffing them. Objects made by operators, like set or merge_objects,
+// still have their keys sorted. These methods don't use the cache of such
+// engines, whose results are copies.
+func WithKeyOrder() Option {
+	return func(e *Engine) error {
+		e.keyOrder = true
+
+		return nil
+	}
+}
+
+// keyOrder holds the keys of the decoded objects in the order they were
+// read, keyed by the address of the objects
+type keyOrder map[uintptr][]string
+
+// newKeyOrder returns an empty keyOrder if the engine keeps the order of
+// keys, or nil otherwise
+func (e *Engine) newKeyOrder() keyOrder {
+	if !e.keyOrder {
+		return nil
+	}
+
+	return keyOrder{}
+}
+
+// readJSON is like the function readJSON, recording the order of the keys
+// of the objects read unless o is nil
+func (o keyOrder) readJSON(r io.Reader, v *interface{}) error {
+	if o == nil {
+		return readJSON(r, v)
+	}
+
+	value, err := o.read(json.NewDecoder(r))
+	if err != nil {
+		return err
+	}
+
+	*v = value
+
+	return nil
+}
+
+// unmarshal is like json.Unmarshal, recording the order of the keys of the
+// objects read unless o is nil
+func (o keyOrder) unmarshal(raw []byte, v *interface{}) error {
+	if o == nil {
+		return json.Unmarshal(raw, v)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+
+	value, err := o.read(decoder)
+	if err != nil {
+		return err
+	}
+
+	if _, err := decoder.Token(); err != io.EOF {
+		if err == nil {
+			err = errors.New("invalid data after top-level value")
+		}
+
+		return err
+	}
+
+	*v = value
+
+	return nil
+}
+
+// read decodes a value from its tokens, as json.Unmarshal would, recording
+// the order of the keys of the objects read unless o is nil
+func (o keyOrder) read(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch token {
+	case json.Delim('{'):
+		object := make(map[string]interface{})
+
+		var keys []string
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+
+			value, err := o.read(decoder)
+			if err != nil {
+				return nil, err
+			}
+
+			// like json.Unmarshal, the last of duplicated keys wins
+			if _, found := object[key.(string)]; !found {
+				keys = append(keys, key.(string))
+			}
+
+			object[key.(string)] = value
+		}
+
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+
+		if o != nil {
+			o[reflect.ValueOf(object).Pointer()] = keys
+		}
+
+		return object, nil
+	case json.Delim('['):
+		list := []interface{}{}
+
+		for decoder.More() {
+			value, err := o.read(decoder)
+			if err != nil {
+				return nil, err
+			}
+
+			list = append(list, value)
+		}
+
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+
+		return list, nil
+	}
+
+	// numbers are only json.Numbers for engines keeping integers, see
+	// WithIntegers
+	if n, ok := token.(json.Number); ok {
+		return readNumber(n)
+	}
+
+	return token, nil
+}
+
+// orderedObject is an object encoded with its keys in a given order
+type orderedObject struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+// MarshalJSON writes the object without escaping HTML characters, which
+// the encoder writing the result does if it has to
+func (o orderedObject) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+
+	buffer.WriteByte('{')
+
+	for i, key := range o.keys {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+
+		if err := encoder.Encode(key); err != nil {
+			return nil, err
+		}
+
+		buffer.WriteByte(':')
+
+		if err := encoder.Encode(o.values[key]); err != nil {
+			return nil, err
+		}
+	}
+
+	buffer.WriteByte('}')
+
+	return buffer.Bytes(), nil
+}
+
+// wrap replaces the objects of a result whose keys order is known by
+// orderedObjects, copying the objects and lists holding them
+func (o keyOrder) wrap(value interface{}) interface{} {
+	if o == nil {
+		return value
+	}
+
+	switch value := value.(type) {
+	case map[string]interface{}:
+		values := make(map[string]interface{}, len(value))
+		for key, v := range value {
+			values[key] = o.wrap(v)
+		}
+
+		keys, found := o[reflect.ValueOf(value).Pointer()]
+		if !found {
+			return values
+		}
+
+		return orderedObject{keys: keys, values: values}
+	case []interface{}:
+		list := make([]interface{}, len(value))
+		for i, v := range value {
+			list[i] = o.wrap(v)
+		}
+
+		return list
+	}
+
+	return value
+}
+
+// evaluateIn is like evaluateWith, skipping the cache when the order of
+// keys is kept, and auditing the evaluation if the engine has an audit log
+func (e *Engine) evaluateIn(ctx context.Context, order keyOrder, a *arena, rule, data interface{}) (interface{}, error) {
+	if e.audit != nil {
+		return e.audited(ctx, rule, data)
+	}
+
+	if order == nil {
+		return e.evaluateWith(ctx, a, rule, data)
+	}
+
+	ev := e.acquire()
+	ev.ctx = ctx
+	ev.arena = a
+	defer release(ev)
+
+	return ev.run(rule, data)
+}
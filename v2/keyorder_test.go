@@ -0,0 +1,106 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyOrder(t *testing.T) {
+	scenarios := map[string]struct {
+		Rule     string
+		Data     string
+		Expected string
+	}{
+		"passthrough":         {`{"var": ""}`, `{"z": 1, "a": 2, "m": 3}`, `{"z":1,"a":2,"m":3}`},
+		"nested":              {`{"var": "payload"}`, `{"payload": {"b": {"y": 1, "x": 2}, "a": [{"d": 1, "c": 2}]}}`, `{"b":{"y":1,"x":2},"a":[{"d":1,"c":2}]}`},
+		"filtered list":       {`{"filter": [{"var": "items"}, {">": [{"var": "n"}, 1]}]}`, `{"items": [{"n": 1, "id": "a"}, {"n": 2, "id": "b"}]}`, `[{"n":2,"id":"b"}]`},
+		"object made":         {`{"set": [{"var": ""}, "c", 3]}`, `{"b": 1, "a": 2}`, `{"a":2,"b":1,"c":3}`},
+		"inside object made":  {`{"set": [{}, "data", {"var": ""}]}`, `{"b": 1, "a": 2}`, `{"data":{"b":1,"a":2}}`},
+		"duplicated keys":     {`{"var": ""}`, `{"b": 1, "a": 2, "b": 3}`, `{"b":3,"a":2}`},
+		"empty object":        {`{"var": "a"}`, `{"a": {}}`, `{}`},
+		"html":                {`{"var": ""}`, `{"<b>": "&", "a": 1}`, `{"\u003cb\u003e":"\u0026","a":1}`},
+		"not an object":       {`{"var": "a"}`, `{"a": [1, "b", null]}`, `[1,"b",null]`},
+		"literal of the rule": {`{"if": [true, {"var": ""}, 0]}`, `{"y": true, "x": false}`, `{"y":true,"x":false}`},
+	}
+
+	engine, err := NewEngine(WithKeyOrder())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var result bytes.Buffer
+
+			err := engine.Apply(strings.NewReader(scenario.Rule), strings.NewReader(scenario.Data), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, scenario.Expected+"\n", result.String())
+
+			raw, err := engine.ApplyRaw([]byte(scenario.Rule), []byte(scenario.Data))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, scenario.Expected, string(raw))
+		})
+	}
+}
+
+func TestKeyOrderWithOtherOptions(t *testing.T) {
+	engine, err := NewEngine(WithKeyOrder(), WithIndent("", " "), WithoutHTMLEscaping(), WithCache(&testCache{values: make(map[interface{}]interface{})}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		var result bytes.Buffer
+
+		err = engine.Apply(strings.NewReader(`{"var": ""}`), strings.NewReader(`{"b": "<", "a": {"d": 1, "c": 2}}`), &result)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "{\n \"b\": \"<\",\n \"a\": {\n  \"d\": 1,\n  \"c\": 2\n }\n}\n", result.String())
+	}
+
+	var result bytes.Buffer
+
+	_, err = engine.ApplyWithTrace(strings.NewReader(`{"var": ""}`), strings.NewReader(`{"b": 1, "a": 2}`), &result)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "{\n \"b\": 1,\n \"a\": 2\n}\n", result.String())
+
+	result.Reset()
+
+	err = engine.ApplyYAML(strings.NewReader(`var: ""`), strings.NewReader(`{"b": 1, "a": 2}`), &result)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "{\n \"b\": 1,\n \"a\": 2\n}\n", result.String())
+}
+
+func TestKeyOrderInvalidJSON(t *testing.T) {
+	engine, err := NewEngine(WithKeyOrder())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = engine.ApplyRaw([]byte(`{"var": ""}`), []byte(`{"a": 1} {"b": 2}`))
+	assert.Error(t, err)
+
+	_, err = engine.ApplyRaw([]byte(`{"var": ""}`), []byte(`{"a": `))
+	assert.Error(t, err)
+
+	err = engine.Apply(strings.NewReader(`{"var": [}`), nil, &bytes.Buffer{})
+	assert.Error(t, err)
+}
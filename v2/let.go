@@ -0,0 +1,160 @@
+package jsonlogic
+
+import (
+	"strings"
+)
+
+// letBody is the key of the expression a let evaluates with its bindings
+const letBody = "in"
+
+// letArgs reads the arguments of let: an object of bindings, named values,
+// and the expression using them under the "in" key. Names can't be empty
+// or contain dots.
+func letArgs(values interface{}) (map[string]interface{}, interface{}, bool) {
+	args, ok := values.(map[string]interface{})
+	if !ok {
+		// a list around the object, like the arguments of other operators
+		parsed := toSlice(values)
+		if len(parsed) != 1 || !isMap(parsed[0]) {
+			return nil, nil, false
+		}
+
+		args = parsed[0].(map[string]interface{})
+	}
+
+	body, ok := args[letBody]
+	if !ok {
+		return nil, nil, false
+	}
+
+	bindings := make(map[string]interface{}, len(args)-1)
+	for name, value := range args {
+		if name == letBody {
+			continue
+		}
+
+		if name == "" || strings.Contains(name, ".") {
+			return nil, nil, false
+		}
+
+		bindings[name] = value
+	}
+
+	return bindings, body, true
+}
+
+// isBound tells if a var path starts with one of the names bound by a let
+func isBound(path string, bindings map[string]interface{}) bool {
+	_, ok := bindings[strings.SplitN(path, ".", 2)[0]]
+
+	return ok
+}
+
+// letOperands returns the bindings and the body of a let as a list of
+// expressions, for the functions going through the expressions of rules
+func letOperands(values interface{}) interface{} {
+	bindings, body, ok := letArgs(values)
+	if !ok {
+		return values
+	}
+
+	operands := make([]interface{}, 0, len(bindings)+1)
+	for _, value := range bindings {
+		operands = append(operands, value)
+	}
+
+	return append(operands, body)
+}
+
+// let evaluates an expression with named values, which var reads before
+// the data: {"let": {"minAge": {"+": [{"var": "base"}, 3]}, "in":
+// {">=": [{"var": "age"}, {"var": "minAge"}]}}}. The values are evaluated
+// once, against the data, so they can't refer to one another: nested lets
+// can. Names shadow those of the data and of enclosing lets. It returns
+// null when the arguments are invalid.
+func (ev *evaluator) let(values, data interface{}) interface{} {
+	bindings, body, ok := letArgs(values)
+	if !ok {
+		return nil
+	}
+
+	scope := make(map[string]interface{}, len(bindings))
+	for name, value := range bindings {
+		scope[name] = ev.parseValues(value, data)
+	}
+
+	ev.scopes = append(ev.scopes[:len(ev.scopes):len(ev.scopes)], scope)
+	defer func() {
+		ev.scopes = ev.scopes[:len(ev.scopes)-1]
+	}()
+
+	return ev.parseValues(body, data)
+}
+
+// bound looks up the first part of a var path in the scopes of the lets
+// being evaluated, the innermost first, returning the value bound to its
+// name and the rest of the path
+func (ev *evaluator) bound(path string) (interface{}, string, bool) {
+	if len(ev.scopes) == 0 || path == "" || strings.HasPrefix(path, ".") {
+		return nil, "", false
+	}
+
+	name, rest := path, ""
+	if i := strings.IndexByte(path, '.'); i >= 0 {
+		name, rest = path[:i], path[i+1:]
+	}
+
+	for i := len(ev.scopes) - 1; i >= 0; i-- {
+		if value, ok := ev.scopes[i][name]; ok {
+			return value, rest, true
+		}
+	}
+
+	return nil, "", false
+}
+
+// boundVariable reads a var path starting with a name bound by a let,
+// telling if it does
+func (ev *evaluator) boundVariable(values interface{}) (interface{}, bool) {
+	path, fallback := varArgs(values)
+
+	value, rest, ok := ev.bound(path)
+	if !ok {
+		return nil, false
+	}
+
+	value = getVar(rest, value, DotPaths)
+	if value == nil {
+		return fallback, true
+	}
+
+	return value, true
+}
+
+// unsolved is bound to the names of the lets whose values aren't known yet
+type unsolved struct{}
+
+// solveLet replaces in a let the vars of iteration predicates reading the
+// data around the iteration, as solveVars does, except those reading the
+// names it binds
+func (ev *evaluator) solveLet(values, data interface{}) interface{} {
+	bindings, body, ok := letArgs(values)
+	if !ok {
+		return ev.solveVars(values, data)
+	}
+
+	solved := make(map[string]interface{}, len(bindings)+1)
+
+	// the names are bound to no value yet while the body is solved
+	scope := make(map[string]interface{}, len(bindings))
+	for name, value := range bindings {
+		solved[name] = ev.solveVars(value, data)
+		scope[name] = unsolved{}
+	}
+
+	ev.scopes = append(ev.scopes[:len(ev.scopes):len(ev.scopes)], scope)
+	solved[letBody] = ev.solveVars(body, data)
+	ev.scopes = ev.scopes[:len(ev.scopes)-1]
+
+	return solved
+}
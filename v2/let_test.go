@@ -0,0 +1,132 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLet(t *testing.T) {
+	data := `{"age": 20, "base": 15, "limit": 2, "items": [1, 2, 3], "user": {"name": "Ana"}}`
+
+	scenarios := map[string]struct {
+		Rule     string
+		Expected string
+	}{
+		"binding": {
+			Rule:     `{"let": {"minAge": {"+": [{"var": "base"}, 3]}, "in": {">=": [{"var": "age"}, {"var": "minAge"}]}}}`,
+			Expected: `true`,
+		},
+		"several bindings": {
+			Rule:     `{"let": {"a": 1, "b": {"var": "limit"}, "in": {"+": [{"var": "a"}, {"var": "b"}]}}}`,
+			Expected: `3`,
+		},
+		"in a list": {
+			Rule:     `{"let": [{"a": 1, "in": {"var": "a"}}]}`,
+			Expected: `1`,
+		},
+		"paths into values": {
+			Rule:     `{"let": {"u": {"var": "user"}, "in": {"cat": ["hi ", {"var": "u.name"}, {"var": ["u.title", "!"]}]}}}`,
+			Expected: `"hi Ana!"`,
+		},
+		"shadows the data": {
+			Rule:     `{"let": {"age": 1, "in": {"var": "age"}}}`,
+			Expected: `1`,
+		},
+		"null values": {
+			Rule:     `{"let": {"age": null, "in": {"var": ["age", "none"]}}}`,
+			Expected: `"none"`,
+		},
+		"nested lets": {
+			Rule:     `{"let": {"a": 2, "in": {"let": {"b": {"*": [{"var": "a"}, 10]}, "a": 5, "in": [{"var": "a"}, {"var": "b"}]}}}}`,
+			Expected: `[5, 20]`,
+		},
+		"bindings see the data, not one another": {
+			Rule:     `{"let": {"limit": 10, "double": {"*": [{"var": "limit"}, 2]}, "in": {"var": "double"}}}`,
+			Expected: `4`,
+		},
+		"in iterations": {
+			Rule:     `{"let": {"min": {"var": "limit"}, "in": {"filter": [{"var": "items"}, {">=": [{"var": ""}, {"var": "min"}]}]}}}`,
+			Expected: `[2, 3]`,
+		},
+		"around iterations": {
+			Rule:     `{"map": [{"var": "items"}, {"let": {"limit": {"*": [{"var": ""}, 10]}, "in": {"+": [{"var": "limit"}, {"var": "base"}]}}}]}`,
+			Expected: `[25, 35, 45]`,
+		},
+		"whole data": {
+			Rule:     `{"let": {"a": 1, "in": {"var": "limit"}}}`,
+			Expected: `2`,
+		},
+		"without body": {
+			Rule:     `{"let": {"a": 1}}`,
+			Expected: `null`,
+		},
+		"invalid name": {
+			Rule:     `{"let": {"a.b": 1, "in": 1}}`,
+			Expected: `null`,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var result bytes.Buffer
+
+			err := Apply(strings.NewReader(scenario.Rule), strings.NewReader(data), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, result.String())
+		})
+	}
+}
+
+func TestLetWithParallelism(t *testing.T) {
+	engine, err := NewEngine(WithParallelism(4, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rule := `{"let": {"step": {"var": "step"}, "in": {"map": [{"var": "items"}, {"let": {"x": {"*": [{"var": ""}, {"var": "step"}]}, "in": {"+": [{"var": "x"}, 1]}}}]}}}`
+
+	var result bytes.Buffer
+
+	err = engine.Apply(strings.NewReader(rule), strings.NewReader(`{"step": 10, "items": [1, 2, 3, 4, 5, 6]}`), &result)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.JSONEq(t, `[11, 21, 31, 41, 51, 61]`, result.String())
+}
+
+func TestLetAnalysis(t *testing.T) {
+	rule := `{"let": {"min": {"var": "base"}, "in": {">=": [{"var": "age"}, {"var": "min"}]}}}`
+
+	assert.True(t, IsValid(strings.NewReader(rule)))
+	assert.False(t, IsValid(strings.NewReader(`{"let": {"min": 1}}`)))
+	assert.False(t, IsValid(strings.NewReader(`{"let": {"min": {"nope": 1}, "in": 1}}`)))
+
+	warnings, err := Lint(strings.NewReader(rule))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Empty(t, warnings)
+
+	compiled, err := Compile(strings.NewReader(rule))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, compiled.complete)
+
+	result, err := ApplyBool(compiled, []byte(`{"age": 20, "base": 18, "min": 99}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, result)
+}
@@ -0,0 +1,68 @@
+package jsonlogic
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrLimitExceeded is returned, wrapped, when a rule produces a list or a
+// result larger than the limits of the engine
+var ErrLimitExceeded = errors.New("limit exceeded")
+
+// WithMaxListLength makes the engine fail evaluations with
+// ErrLimitExceeded as soon as an operator gives a list of more than n
+// elements, like a map over a large list or a merge of many lists, instead
+// of going on with it. map, filter and flatten fail while building their
+// lists, so they never hold more than n elements, and product before
+// building anything. The lists of the data read by var aren't limited.
+func WithMaxListLength(n int) Option {
+	return func(e *Engine) error {
+		if n <= 0 {
+			return fmt.Errorf("max list length must be positive, got %d", n)
+		}
+
+		e.maxListLength = n
+
+		return nil
+	}
+}
+
+// WithMaxResultSize makes the methods writing results as JSON, like Apply
+// and ApplyRaw, fail with ErrLimitExceeded instead of writing results of
+// more than n bytes. ApplyInterface, which doesn't encode its results,
+// isn't limited.
+func WithMaxResultSize(n int) Option {
+	return func(e *Engine) error {
+		if n <= 0 {
+			return fmt.Errorf("max result size must be positive, got %d", n)
+		}
+
+		e.maxResultSize = n
+
+		return nil
+	}
+}
+
+// grow fails the evaluation when an operator building a list is about to
+// give it more than the max list length of the engine, before the list is
+// built any further
+func (ev *evaluator) grow(operator string, length int) {
+	if max := ev.engine.maxListLength; max > 0 && length > max {
+		ev.fail(fmt.Errorf("%w: %s gave more than %d elements", ErrLimitExceeded, operator, max))
+	}
+}
+
+// limit applies the max list length of the engine to the result of an
+// operator
+func (ev *evaluator) limit(operator string, result interface{}) interface{} {
+	if ev.engine.maxListLength == 0 || operator == "var" {
+		return result
+	}
+
+	list, ok := result.([]interface{})
+	if ok && len(list) > ev.engine.maxListLength {
+		ev.fail(fmt.Errorf("%w: %s gave %d elements, more than %d", ErrLimitExceeded, operator, len(list), ev.engine.maxListLength))
+	}
+
+	return result
+}
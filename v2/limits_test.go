@@ -0,0 +1,134 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaxListLength(t *testing.T) {
+	scenarios := map[string]struct {
+		Rule     string
+		Expected string
+		Err      error
+	}{
+		"within the limit":  {`{"map": [{"var": "short"}, {"*": [{"var": ""}, 2]}]}`, `[2,4,6]`, nil},
+		"map over the data": {`{"map": [{"var": "long"}, {"*": [{"var": ""}, 2]}]}`, ``, ErrLimitExceeded},
+		"merge":             {`{"merge": [{"var": "short"}, {"var": "short"}]}`, ``, ErrLimitExceeded},
+		"product":           {`{"product": [{"var": "short"}, {"var": "short"}]}`, ``, ErrLimitExceeded},
+		"nested":            {`{"count": {"merge": [{"var": "short"}, {"var": "short"}]}}`, ``, ErrLimitExceeded},
+		"data read by var":  {`{"var": "long"}`, `[1,2,3,4,5,6,7]`, nil},
+		"filtered":          {`{"filter": [{"var": "long"}, {">": [{"var": ""}, 5]}]}`, `[6,7]`, nil},
+		"filter":            {`{"filter": [{"var": "long"}, true]}`, ``, ErrLimitExceeded},
+		"flatten":           {`{"flatten": {"var": "nested"}}`, ``, ErrLimitExceeded},
+	}
+
+	data := `{"short": [1, 2, 3], "long": [1, 2, 3, 4, 5, 6, 7], "nested": [[1, 2, 3], [4, 5, 6]]}`
+
+	engine, err := NewEngine(WithMaxListLength(5))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parallel, err := NewEngine(WithMaxListLength(5), WithParallelism(2, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			for _, e := range []*Engine{engine, parallel} {
+				var result bytes.Buffer
+
+				err := e.Apply(strings.NewReader(scenario.Rule), strings.NewReader(data), &result)
+				if scenario.Err != nil {
+					assert.True(t, errors.Is(err, scenario.Err))
+
+					continue
+				}
+
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				assert.JSONEq(t, scenario.Expected, result.String())
+			}
+		})
+	}
+}
+
+func TestMaxResultSize(t *testing.T) {
+	engine, err := NewEngine(WithMaxResultSize(10))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var result bytes.Buffer
+
+	err = engine.Apply(strings.NewReader(`{"cat": ["abc", "defg"]}`), nil, &result)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "\"abcdefg\"\n", result.String())
+
+	result.Reset()
+
+	err = engine.Apply(strings.NewReader(`{"cat": ["abc", "defghi"]}`), nil, &result)
+	assert.True(t, errors.Is(err, ErrLimitExceeded))
+	assert.Empty(t, result.String())
+
+	_, err = engine.ApplyRaw([]byte(`{"merge": [[1, 2, 3], [4, 5, 6]]}`), []byte(`{}`))
+	assert.True(t, errors.Is(err, ErrLimitExceeded))
+
+	_, err = engine.ApplyInterface(map[string]interface{}{"merge": []interface{}{1.0, 2.0, 3.0, 4.0, 5.0, 6.0}}, nil)
+	assert.NoError(t, err)
+}
+
+func TestMaxListLengthWhileBuilding(t *testing.T) {
+	engine, err := NewEngine(WithMaxListLength(100))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	thousand := make([]interface{}, 1000)
+	for i := range thousand {
+		thousand[i] = float64(i)
+	}
+
+	data := map[string]interface{}{"thousand": thousand, "lists": []interface{}{thousand, thousand}}
+
+	// the lists these rules would build hold millions of elements
+	rules := map[string]string{
+		"map":     `{"map": [{"product": [{"var": "thousand"}, {"var": "thousand"}]}, 1]}`,
+		"product": `{"product": [{"var": "thousand"}, {"var": "thousand"}, {"var": "thousand"}]}`,
+		"flatten": `{"flatten": {"var": "lists"}}`,
+		// 1000^7 combinations, more than an int counts
+		"product of many lists": `{"product": [` + strings.Repeat(`{"var": "thousand"}, `, 6) + `{"var": "thousand"}]}`,
+	}
+
+	for name, rule := range rules {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var tree interface{}
+			if err := readJSON(strings.NewReader(rule), &tree); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := engine.ApplyInterface(tree, data)
+			assert.True(t, errors.Is(err, ErrLimitExceeded))
+			assert.Contains(t, err.Error(), "more than 100 elements")
+		})
+	}
+}
+
+func TestInvalidLimits(t *testing.T) {
+	_, err := NewEngine(WithMaxListLength(0))
+	assert.Error(t, err)
+
+	_, err = NewEngine(WithMaxResultSize(-1))
+	assert.Error(t, err)
+}
@@ -0,0 +1,229 @@
+package jsonlogic
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// maxLintNesting is how deep expressions can be nested before Lint
+// considers the rule too hard to read
+const maxLintNesting = 12
+
+// LintWarning describes a suspicious construct found by Lint
+type LintWarning struct {
+	// Path locates the expression in the rule as a JSON pointer, like
+	// "/and/1/==" for the second argument of an "and" being a "=="
+	Path string `json:"path"`
+
+	// Check identifies the kind of problem, see Lint
+	Check   string `json:"check"`
+	Message string `json:"message"`
+}
+
+// deprecatedOperators maps operators that shouldn't be used in new rules
+// to their replacement
+var deprecatedOperators = map[string]string{
+	"?:": "if",
+}
+
+// comparisonOperators are the operators comparing their arguments
+var comparisonOperators = map[string]bool{
+	"==":  true,
+	"===": true,
+	"!=":  true,
+	"!==": true,
+	"<":   true,
+	"<=":  true,
+	">":   true,
+	">=":  true,
+	// between compares its first 3 arguments, the fourth is the notation
+	// of the bounds
+	"between": true,
+}
+
+// Lint reads a rule and looks for constructs that are valid but likely
+// mistakes. It reports, identified by their check:
+//
+//   - "unknown-operator": operators this package doesn't implement
+//   - "deprecated-operator": operators with a better replacement
+//   - "incompatible-comparison": comparisons between literals of different
+//     types, or ordering of values that have no order, like booleans
+//   - "constant-condition": conditions of "if", "and", "or" and "!" that
+//     don't depend on the data, so they always take the same branch
+//   - "unused-default": defaults of "var" that are never used
+//   - "excessive-nesting": expressions nested too deep to be readable
+//
+// An error is returned only when the rule can't be read.
+func Lint(rule io.Reader) ([]LintWarning, error) {
+	var _rule interface{}
+
+	err := json.NewDecoder(rule).Decode(&_rule)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing rule: %w", err)
+	}
+
+	l := &linter{warnings: make([]LintWarning, 0)}
+	l.lint(_rule, "", 0)
+
+	return l.warnings, nil
+}
+
+type linter struct {
+	warnings []LintWarning
+}
+
+func (l *linter) warn(path, check, format string, args ...interface{}) {
+	l.warnings = append(l.warnings, LintWarning{
+		Path:    path,
+		Check:   check,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// pointerToken escapes a JSON pointer token as defined by RFC 6901
+func pointerToken(token string) string {
+	return strings.Replace(strings.Replace(token, "~", "~0", -1), "/", "~1", -1)
+}
+
+// isLiteral tells if a value of a rule doesn't depend on the data
+func isLiteral(value interface{}) bool {
+	if isMap(value) {
+		return false
+	}
+
+	if isSlice(value) {
+		for _, element := range value.([]interface{}) {
+			if !isLiteral(element) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func (l *linter) lint(rule interface{}, path string, depth int) {
+	if isSlice(rule) {
+		for i, value := range rule.([]interface{}) {
+			l.lint(value, path+"/"+strconv.Itoa(i), depth)
+		}
+
+		return
+	}
+
+	if !isMap(rule) {
+		return
+	}
+
+	if depth == maxLintNesting {
+		l.warn(path, "excessive-nesting", "expressions are nested more than %d levels deep", maxLintNesting)
+	}
+
+	for operator, values := range rule.(map[string]interface{}) {
+		_path := path + "/" + pointerToken(operator)
+
+		l.lintOperator(operator, values, _path)
+
+		if bindings, body, ok := letArgs(values); operator == "let" && ok {
+			for name, value := range bindings {
+				l.lint(value, _path+"/"+pointerToken(name), depth+1)
+			}
+
+			l.lint(body, _path+"/"+letBody, depth+1)
+
+			continue
+		}
+
+		if definitions, body, ok := defArgs(values); operator == "def" && ok {
+			for name, definition := range definitions {
+				l.lint(definition.body, _path+"/"+pointerToken(name)+"/1", depth+1)
+			}
+
+			l.lint(body, _path+"/"+letBody, depth+1)
+
+			continue
+		}
+
+		l.lint(values, _path, depth+1)
+	}
+}
+
+func (l *linter) lintOperator(operator string, values interface{}, path string) {
+	if operator != "var" && !isOperator(operator) {
+		l.warn(path, "unknown-operator", "unknown operator %q", operator)
+
+		return
+	}
+
+	if replacement, ok := deprecatedOperators[operator]; ok {
+		l.warn(path, "deprecated-operator", "%q is deprecated, use %q instead", operator, replacement)
+	}
+
+	args := toSlice(values)
+
+	switch {
+	case operator == "var":
+		l.lintVar(values, path)
+	case comparisonOperators[operator]:
+		l.lintComparison(operator, args, path)
+	case operator == "if" || operator == "?:":
+		for i := 0; i < len(args)-1; i += 2 {
+			if isLiteral(args[i]) {
+				l.warn(path+"/"+strconv.Itoa(i), "constant-condition", "condition is always %t", isTrue(args[i]))
+			}
+		}
+	case operator == "and" || operator == "or" || operator == "!" || operator == "!!":
+		for i, arg := range args {
+			if isLiteral(arg) {
+				l.warn(path+"/"+strconv.Itoa(i), "constant-condition", "operand is always %t", isTrue(arg))
+			}
+		}
+	}
+}
+
+func (l *linter) lintVar(values interface{}, path string) {
+	if !isSlice(values) || len(values.([]interface{})) != 2 {
+		return
+	}
+
+	parsed := values.([]interface{})
+
+	if parsed[1] == nil {
+		l.warn(path, "unused-default", "null is already the default value of var")
+	} else if parsed[0] == nil || parsed[0] == "" {
+		l.warn(path, "unused-default", "var of the whole data never uses its default")
+	}
+}
+
+func (l *linter) lintComparison(operator string, args []interface{}, path string) {
+	ordering := operator != "==" && operator != "===" && operator != "!=" && operator != "!=="
+
+	if operator == "between" && len(args) > 3 {
+		args = args[:3]
+	}
+
+	literals := make([]interface{}, 0, len(args))
+	for i, arg := range args {
+		if !isLiteral(arg) {
+			continue
+		}
+
+		if ordering && !isNumber(arg) && !isString(arg) {
+			l.warn(path+"/"+strconv.Itoa(i), "incompatible-comparison", "%s has no order", typeOf(arg))
+		}
+
+		literals = append(literals, arg)
+	}
+
+	for i := 1; i < len(literals); i++ {
+		a, b := typeOf(literals[0]), typeOf(literals[i])
+		if a != b {
+			l.warn(path, "incompatible-comparison", "comparing %s with %s", a, b)
+
+			return
+		}
+	}
+}
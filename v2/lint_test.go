@@ -0,0 +1,110 @@
+package jsonlogic
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLint(t *testing.T) {
+	scenarios := map[string]struct {
+		Rule     string
+		Expected []LintWarning
+	}{
+		"clean rule": {
+			Rule:     `{"if": [{">=": [{"var": "age"}, 18]}, "adult", "minor"]}`,
+			Expected: []LintWarning{},
+		},
+		"unknown operator": {
+			Rule: `{"and": [{"equals": [{"var": "a"}, 1]}]}`,
+			Expected: []LintWarning{
+				{Path: "/and/0/equals", Check: "unknown-operator", Message: `unknown operator "equals"`},
+			},
+		},
+		"deprecated operator": {
+			Rule: `{"?:": [{"var": "a"}, 1, 2]}`,
+			Expected: []LintWarning{
+				{Path: "/?:", Check: "deprecated-operator", Message: `"?:" is deprecated, use "if" instead`},
+			},
+		},
+		"comparison of different types": {
+			Rule: `{"==": [1, "1"]}`,
+			Expected: []LintWarning{
+				{Path: "/==", Check: "incompatible-comparison", Message: "comparing number with string"},
+			},
+		},
+		"ordering of booleans": {
+			Rule: `{"<": [{"var": "a"}, true]}`,
+			Expected: []LintWarning{
+				{Path: "/</1", Check: "incompatible-comparison", Message: "boolean has no order"},
+			},
+		},
+		"between different types": {
+			Rule: `{"between": [{"var": "a"}, 1, "9", "[)"]}`,
+			Expected: []LintWarning{
+				{Path: "/between", Check: "incompatible-comparison", Message: "comparing number with string"},
+			},
+		},
+		"constant condition": {
+			Rule: `{"if": [true, {"var": "a"}, {"var": "b"}]}`,
+			Expected: []LintWarning{
+				{Path: "/if/0", Check: "constant-condition", Message: "condition is always true"},
+			},
+		},
+		"constant operand": {
+			Rule: `{"or": [{"var": "a"}, []]}`,
+			Expected: []LintWarning{
+				{Path: "/or/1", Check: "constant-condition", Message: "operand is always false"},
+			},
+		},
+		"null default": {
+			Rule: `{"var": ["a", null]}`,
+			Expected: []LintWarning{
+				{Path: "/var", Check: "unused-default", Message: "null is already the default value of var"},
+			},
+		},
+		"default of the whole data": {
+			Rule: `{"var": ["", 1]}`,
+			Expected: []LintWarning{
+				{Path: "/var", Check: "unused-default", Message: "var of the whole data never uses its default"},
+			},
+		},
+		"escaped paths": {
+			Rule: `{"in": [{"var": "a"}, {"a/b": []}]}`,
+			Expected: []LintWarning{
+				{Path: "/in/1/a~1b", Check: "unknown-operator", Message: `unknown operator "a/b"`},
+			},
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			warnings, err := Lint(strings.NewReader(scenario.Rule))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, scenario.Expected, warnings)
+		})
+	}
+}
+
+func TestLintExcessiveNesting(t *testing.T) {
+	rule := strings.Repeat(`{"!": `, maxLintNesting+2) + `{"var": "a"}` + strings.Repeat(`}`, maxLintNesting+2)
+
+	warnings, err := Lint(strings.NewReader(rule))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, warnings, 1)
+	assert.Equal(t, "excessive-nesting", warnings[0].Check)
+	assert.Equal(t, strings.Repeat("/!", maxLintNesting), warnings[0].Path)
+}
+
+func TestLintInvalidRule(t *testing.T) {
+	_, err := Lint(strings.NewReader(`{`))
+	assert.Error(t, err)
+}
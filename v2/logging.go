@@ -0,0 +1,45 @@
+package jsonlogic
+
+import (
+	"fmt"
+)
+
+// Logger receives the values logged by the "log" operator and the warnings
+// about suspicious evaluations, like unknown operators, values that can't
+// be cast or implicit coercions of values which aren't numbers. Arguments after the message are key-value pairs. It is
+// satisfied by *slog.Logger; other loggers, like logr's, need a small adapter.
+type Logger interface {
+	Info(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+}
+
+// WithLogger sends the output of the "log" operator and the evaluation
+// warnings to logger. Without a logger they are discarded.
+func WithLogger(logger Logger) Option {
+	return func(e *Engine) error {
+		if logger == nil {
+			return fmt.Errorf("logger must not be nil")
+		}
+
+		e.logger = logger
+
+		return nil
+	}
+}
+
+// log implements the "log" operator, logging its argument and returning it
+func (ev *evaluator) log(values, data interface{}) interface{} {
+	value := ev.argument(values, data)
+
+	if ev.engine.logger != nil {
+		ev.engine.logger.Info("jsonlogic log", "value", value)
+	}
+
+	return value
+}
+
+func (ev *evaluator) warn(msg string, args ...interface{}) {
+	if ev.engine.logger != nil {
+		ev.engine.logger.Warn(msg, args...)
+	}
+}
@@ -0,0 +1,134 @@
+package jsonlogic
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingLogger struct {
+	infos    []string
+	warnings []string
+}
+
+func (l *recordingLogger) Info(msg string, args ...interface{}) {
+	l.infos = append(l.infos, strings.TrimSpace(fmt.Sprintln(append([]interface{}{msg}, args...)...)))
+}
+
+func (l *recordingLogger) Warn(msg string, args ...interface{}) {
+	l.warnings = append(l.warnings, strings.TrimSpace(fmt.Sprintln(append([]interface{}{msg}, args...)...)))
+}
+
+func TestLogOperator(t *testing.T) {
+	logger := &recordingLogger{}
+
+	engine, err := NewEngine(WithLogger(logger))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := engine.ApplyRaw([]byte(`{"+": [{"log": {"var": "a"}}, {"log": [2]}]}`), []byte(`{"a": 1}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.JSONEq(t, `3`, string(result))
+	assert.Equal(t, []string{"jsonlogic log value 1", "jsonlogic log value 2"}, logger.infos)
+	assert.Empty(t, logger.warnings)
+}
+
+func TestLogOperatorWithoutLogger(t *testing.T) {
+	result, err := ApplyRaw([]byte(`{"log": {"var": "a"}}`), []byte(`{"a": [1, 2]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.JSONEq(t, `[1, 2]`, string(result))
+}
+
+func TestWarnings(t *testing.T) {
+	logger := &recordingLogger{}
+
+	engine, err := NewEngine(WithLogger(logger))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = engine.ApplyRaw([]byte(`{"and": [
+		{"equals": [1, 1]},
+		{"to_number": "abc"}
+	]}`), []byte(`{}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, logger.warnings, 2)
+	assert.Contains(t, logger.warnings[0], "unknown operator")
+	assert.Contains(t, logger.warnings[1], "cast failed")
+}
+
+func TestCoercionWarnings(t *testing.T) {
+	scenarios := map[string]struct {
+		Rule     string
+		Expected []string
+	}{
+		"text in a sum": {
+			Rule:     `{"+": ["abc", 1]}`,
+			Expected: []string{"jsonlogic implicit coercion operator + value abc"},
+		},
+		"text negated": {
+			Rule:     `{"-": "abc"}`,
+			Expected: []string{"jsonlogic implicit coercion operator - value abc"},
+		},
+		"list in a product": {
+			Rule:     `{"*": [{"var": "list"}, 2]}`,
+			Expected: []string{"jsonlogic implicit coercion operator * value [1]"},
+		},
+		"text in max": {
+			Rule:     `{"max": [1, "abc"]}`,
+			Expected: []string{"jsonlogic implicit coercion operator max value abc"},
+		},
+		"number compared with text": {
+			Rule:     `{"<": [1, "abc"]}`,
+			Expected: []string{"jsonlogic implicit coercion operator < values [1 abc]"},
+		},
+		"number equal to text": {
+			Rule:     `{"==": ["abc", 1]}`,
+			Expected: []string{"jsonlogic implicit coercion operator == values [abc 1]"},
+		},
+		"numbers in strings": {
+			Rule: `{"and": [{"*": ["2", 3]}, {"==": ["1", 1]}, {"<": [1, "2"]}]}`,
+		},
+		"null and booleans": {
+			Rule: `{"and": [{"+": [{"var": "missing"}, true, 1]}, {"==": [null, 0]}]}`,
+		},
+		"strings compared": {
+			Rule: `{"<": ["a", "b"]}`,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			logger := &recordingLogger{}
+
+			engine, err := NewEngine(WithLogger(logger))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = engine.ApplyRaw([]byte(scenario.Rule), []byte(`{"list": [1]}`))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, scenario.Expected, logger.warnings)
+		})
+	}
+}
+
+func TestLoggerMustNotBeNil(t *testing.T) {
+	_, err := NewEngine(WithLogger(nil))
+	assert.Error(t, err)
+}
@@ -0,0 +1,340 @@
+// Package logic builds JSON Logic rules from Go code:
+//
+//	rule := logic.If(logic.Var("age").Gte(18), "adult", "minor")
+//
+// Rules can be marshaled to JSON, or given to jsonlogic.ApplyInterface
+// through their Interface method. Arguments can be other rules or plain Go
+// values, which are used as literals.
+package logic
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// Rule is a JSON Logic expression
+type Rule struct {
+	value interface{}
+}
+
+// Value returns a literal
+func Value(literal interface{}) Rule {
+	return Rule{value: value(literal)}
+}
+
+// Op returns an expression using any operator, including the ones this
+// package has no function for
+func Op(operator string, args ...interface{}) Rule {
+	return Rule{value: map[string]interface{}{operator: values(args)}}
+}
+
+// unaryOp returns an expression using an operator with a single argument,
+// written without a list around it
+func unaryOp(operator string, arg interface{}) Rule {
+	return Rule{value: map[string]interface{}{operator: value(arg)}}
+}
+
+func value(arg interface{}) interface{} {
+	if rule, ok := arg.(Rule); ok {
+		return rule.value
+	}
+
+	return normalize(arg)
+}
+
+// normalize converts a Go value to the form of decoded JSON, which is the
+// one expected by the evaluation: float64 numbers, []interface{} lists and
+// map[string]interface{} objects
+func normalize(arg interface{}) interface{} {
+	if arg == nil {
+		return nil
+	}
+
+	v := reflect.ValueOf(arg)
+
+	switch v.Kind() {
+	case reflect.Bool, reflect.String:
+		return v.Interface()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.Slice, reflect.Array:
+		list := make([]interface{}, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			list = append(list, value(v.Index(i).Interface()))
+		}
+
+		return list
+	case reflect.Map:
+		if v.Type().Key().Kind() == reflect.String {
+			object := make(map[string]interface{}, v.Len())
+			for _, key := range v.MapKeys() {
+				object[key.String()] = value(v.MapIndex(key).Interface())
+			}
+
+			return object
+		}
+	}
+
+	// anything else takes the form it has in JSON
+	encoded, err := json.Marshal(arg)
+	if err != nil {
+		return arg
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		return arg
+	}
+
+	return decoded
+}
+
+func values(args []interface{}) []interface{} {
+	list := make([]interface{}, 0, len(args))
+	for _, arg := range args {
+		list = append(list, value(arg))
+	}
+
+	return list
+}
+
+// Interface returns the rule in the form of decoded JSON, as expected by
+// jsonlogic.ApplyInterface
+func (r Rule) Interface() interface{} {
+	return r.value
+}
+
+// MarshalJSON writes the rule as JSON
+func (r Rule) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.value)
+}
+
+// String returns the rule as JSON
+func (r Rule) String() string {
+	rule, err := json.Marshal(r.value)
+	if err != nil {
+		return err.Error()
+	}
+
+	return string(rule)
+}
+
+// Var reads a value from the data
+func Var(path string) Rule {
+	return unaryOp("var", path)
+}
+
+// VarOr reads a value from the data, using fallback when it is missing
+func VarOr(path string, fallback interface{}) Rule {
+	return Op("var", path, fallback)
+}
+
+// Missing lists which of the paths are missing from the data
+func Missing(paths ...string) Rule {
+	args := make([]interface{}, 0, len(paths))
+	for _, path := range paths {
+		args = append(args, path)
+	}
+
+	return Op("missing", args...)
+}
+
+// MissingSome lists the missing paths when less than need are present
+func MissingSome(need int, paths ...string) Rule {
+	return Op("missing_some", need, paths)
+}
+
+// If returns then when cond is truthy, otherwise. More conditions can be
+// chained with ElseIf.
+func If(cond, then, otherwise interface{}) Rule {
+	return Op("if", cond, then, otherwise)
+}
+
+// ElseIf adds a condition to an If expression, checked when the previous
+// ones are falsy
+func (r Rule) ElseIf(cond, then interface{}) Rule {
+	rule, ok := r.value.(map[string]interface{})
+	if !ok {
+		return r
+	}
+
+	args, ok := rule["if"].([]interface{})
+	if !ok || len(args)%2 == 0 {
+		return r
+	}
+
+	chained := make([]interface{}, 0, len(args)+2)
+	chained = append(chained, args[:len(args)-1]...)
+	chained = append(chained, value(cond), value(then), args[len(args)-1])
+
+	return Rule{value: map[string]interface{}{"if": chained}}
+}
+
+// And returns the first falsy argument, or the last one
+func And(args ...interface{}) Rule {
+	return Op("and", args...)
+}
+
+// Or returns the first truthy argument, or the last one
+func Or(args ...interface{}) Rule {
+	return Op("or", args...)
+}
+
+// Not negates the truthiness of arg
+func Not(arg interface{}) Rule {
+	return unaryOp("!", arg)
+}
+
+// Truthy converts arg to a boolean
+func Truthy(arg interface{}) Rule {
+	return unaryOp("!!", arg)
+}
+
+// Cat concatenates the arguments as strings
+func Cat(args ...interface{}) Rule {
+	return Op("cat", args...)
+}
+
+// Min returns the smallest argument
+func Min(args ...interface{}) Rule {
+	return Op("min", args...)
+}
+
+// Max returns the biggest argument
+func Max(args ...interface{}) Rule {
+	return Op("max", args...)
+}
+
+// Merge flattens the arguments into a single list
+func Merge(args ...interface{}) Rule {
+	return Op("merge", args...)
+}
+
+// Map applies rule to every element of list
+func Map(list, rule interface{}) Rule {
+	return Op("map", list, rule)
+}
+
+// Filter keeps the elements of list for which rule is truthy
+func Filter(list, rule interface{}) Rule {
+	return Op("filter", list, rule)
+}
+
+// Reduce combines the elements of list with rule, starting from initial
+func Reduce(list, rule, initial interface{}) Rule {
+	return Op("reduce", list, rule, initial)
+}
+
+// All tells if rule is truthy for every element of list
+func All(list, rule interface{}) Rule {
+	return Op("all", list, rule)
+}
+
+// Some tells if rule is truthy for at least an element of list
+func Some(list, rule interface{}) Rule {
+	return Op("some", list, rule)
+}
+
+// None tells if rule is falsy for every element of list
+func None(list, rule interface{}) Rule {
+	return Op("none", list, rule)
+}
+
+// Eq compares with == (loose equality)
+func (r Rule) Eq(other interface{}) Rule {
+	return Op("==", r, other)
+}
+
+// Ne compares with != (loose inequality)
+func (r Rule) Ne(other interface{}) Rule {
+	return Op("!=", r, other)
+}
+
+// StrictEq compares with === (strict equality)
+func (r Rule) StrictEq(other interface{}) Rule {
+	return Op("===", r, other)
+}
+
+// StrictNe compares with !== (strict inequality)
+func (r Rule) StrictNe(other interface{}) Rule {
+	return Op("!==", r, other)
+}
+
+// Gt compares with >
+func (r Rule) Gt(other interface{}) Rule {
+	return Op(">", r, other)
+}
+
+// Gte compares with >=
+func (r Rule) Gte(other interface{}) Rule {
+	return Op(">=", r, other)
+}
+
+// Lt compares with <
+func (r Rule) Lt(other interface{}) Rule {
+	return Op("<", r, other)
+}
+
+// Lte compares with <=
+func (r Rule) Lte(other interface{}) Rule {
+	return Op("<=", r, other)
+}
+
+// Between tells if low < r < high
+func (r Rule) Between(low, high interface{}) Rule {
+	return Op("<", low, r, high)
+}
+
+// BetweenInclusive tells if low <= r <= high
+func (r Rule) BetweenInclusive(low, high interface{}) Rule {
+	return Op("<=", low, r, high)
+}
+
+// In tells if r is an element of a list, or a substring of a string
+func (r Rule) In(list interface{}) Rule {
+	return Op("in", r, list)
+}
+
+// And combines r and other with and
+func (r Rule) And(other interface{}) Rule {
+	return And(r, other)
+}
+
+// Or combines r and other with or
+func (r Rule) Or(other interface{}) Rule {
+	return Or(r, other)
+}
+
+// Not negates r
+func (r Rule) Not() Rule {
+	return Not(r)
+}
+
+// Add sums r and other
+func (r Rule) Add(other interface{}) Rule {
+	return Op("+", r, other)
+}
+
+// Sub subtracts other from r
+func (r Rule) Sub(other interface{}) Rule {
+	return Op("-", r, other)
+}
+
+// Mul multiplies r by other
+func (r Rule) Mul(other interface{}) Rule {
+	return Op("*", r, other)
+}
+
+// Div divides r by other
+func (r Rule) Div(other interface{}) Rule {
+	return Op("/", r, other)
+}
+
+// Mod returns the remainder of the division of r by other
+func (r Rule) Mod(other interface{}) Rule {
+	return Op("%", r, other)
+}
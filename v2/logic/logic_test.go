@@ -0,0 +1,90 @@
+package logic
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/bewica/jsonlogic/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder(t *testing.T) {
+	scenarios := map[string]struct {
+		Rule     Rule
+		Expected string
+	}{
+		"comparison": {
+			Rule:     Var("age").Gte(18),
+			Expected: `{">=": [{"var": "age"}, 18]}`,
+		},
+		"if": {
+			Rule:     If(Var("age").Gte(18), true, false),
+			Expected: `{"if": [{">=": [{"var": "age"}, 18]}, true, false]}`,
+		},
+		"else if": {
+			Rule:     If(Var("age").Lt(13), "child", "adult").ElseIf(Var("age").Lt(18), "teen"),
+			Expected: `{"if": [{"<": [{"var": "age"}, 13]}, "child", {"<": [{"var": "age"}, 18]}, "teen", "adult"]}`,
+		},
+		"logic": {
+			Rule:     And(Var("active"), Not(Var("banned")).Or(Var("admin"))),
+			Expected: `{"and": [{"var": "active"}, {"or": [{"!": {"var": "banned"}}, {"var": "admin"}]}]}`,
+		},
+		"arithmetic": {
+			Rule:     Var("price").Mul(Var("quantity")).Sub(5),
+			Expected: `{"-": [{"*": [{"var": "price"}, {"var": "quantity"}]}, 5]}`,
+		},
+		"between": {
+			Rule:     Var("temp").BetweenInclusive(0, 100),
+			Expected: `{"<=": [0, {"var": "temp"}, 100]}`,
+		},
+		"lists": {
+			Rule:     Var("role").In([]string{"admin", "owner"}),
+			Expected: `{"in": [{"var": "role"}, ["admin", "owner"]]}`,
+		},
+		"iterators": {
+			Rule:     Filter(Var("users"), Var(".age").Gte(18)),
+			Expected: `{"filter": [{"var": "users"}, {">=": [{"var": ".age"}, 18]}]}`,
+		},
+		"missing": {
+			Rule:     MissingSome(1, "email", "phone"),
+			Expected: `{"missing_some": [1, ["email", "phone"]]}`,
+		},
+		"any operator": {
+			Rule:     Op("match", Var("sku"), "^[A-Z]+$"),
+			Expected: `{"match": [{"var": "sku"}, "^[A-Z]+$"]}`,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			rule, err := json.Marshal(scenario.Rule)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, string(rule))
+			assert.JSONEq(t, scenario.Expected, scenario.Rule.String())
+		})
+	}
+}
+
+func TestBuiltRulesCanBeApplied(t *testing.T) {
+	rule := If(Var("age").Lt(18), "minor", "adult").ElseIf(Var("age").Gte(65), "senior")
+
+	data := map[string]interface{}{"age": float64(70)}
+
+	result, err := jsonlogic.ApplyInterface(rule.Interface(), data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "senior", result)
+
+	result, err = jsonlogic.ApplyInterface(Var("role").In([]string{"admin"}).Interface(), map[string]interface{}{"role": "admin"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, true, result)
+}
@@ -0,0 +1,110 @@
+package jsonlogic
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+)
+
+// numericArgs reads the arguments of a math operator, which can be given
+// either as a single value or as a list with between min and max values
+func numericArgs(values interface{}, min, max int) ([]float64, bool) {
+	parsed := toSlice(values)
+	if len(parsed) < min || len(parsed) > max {
+		return nil, false
+	}
+
+	numbers := make([]float64, len(parsed))
+	for i, value := range parsed {
+		if !isNumber(value) && !isString(value) {
+			return nil, false
+		}
+
+		numbers[i] = toNumber(value)
+	}
+
+	return numbers, true
+}
+
+func pow(values interface{}) interface{} {
+	args, ok := numericArgs(values, 2, 2)
+	if !ok {
+		return nil
+	}
+
+	return math.Pow(args[0], args[1])
+}
+
+func sqrt(values interface{}) interface{} {
+	args, ok := numericArgs(values, 1, 1)
+	if !ok {
+		return nil
+	}
+
+	return math.Sqrt(args[0])
+}
+
+func floor(values interface{}) interface{} {
+	args, ok := numericArgs(values, 1, 1)
+	if !ok {
+		return nil
+	}
+
+	return math.Floor(args[0])
+}
+
+func ceil(values interface{}) interface{} {
+	args, ok := numericArgs(values, 1, 1)
+	if !ok {
+		return nil
+	}
+
+	return math.Ceil(args[0])
+}
+
+// round rounds half away from zero to the given number of decimal places
+// (none by default): {"round": [2.675, 2]} is 2.68. Rounding works on the
+// shortest decimal representation of the number, so values like 1.005
+// round the way people writing pricing rules expect.
+func round(values interface{}) interface{} {
+	args, ok := numericArgs(values, 1, 2)
+	if !ok {
+		return nil
+	}
+
+	if len(args) == 1 {
+		return math.Round(args[0])
+	}
+
+	if math.IsNaN(args[0]) || math.IsInf(args[0], 0) {
+		return nil
+	}
+
+	places := int64(args[1])
+	if places < 0 || places > 15 {
+		return nil
+	}
+
+	return roundPlaces(args[0], places)
+}
+
+// roundPlaces rounds a finite number half away from zero to between 0 and
+// 15 decimal places
+func roundPlaces(x float64, places int64) float64 {
+	n, _ := new(big.Rat).SetString(strconv.FormatFloat(x, 'f', -1, 64))
+	scale := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(places), nil))
+	n.Mul(n, scale)
+
+	// half away from zero: truncate |n| + 1/2
+	half := big.NewRat(1, 2)
+	if n.Sign() < 0 {
+		n.Sub(n, half)
+	} else {
+		n.Add(n, half)
+	}
+
+	truncated := new(big.Int).Quo(n.Num(), n.Denom())
+	result, _ := new(big.Rat).SetFrac(truncated, scale.Num()).Float64()
+
+	return result
+}
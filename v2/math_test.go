@@ -0,0 +1,46 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMathOperators(t *testing.T) {
+	scenarios := map[string]struct {
+		Rule     string
+		Expected string
+	}{
+		"pow":                          {`{"pow": [2, 10]}`, `1024`},
+		"pow with fractional exponent": {`{"pow": [9, 0.5]}`, `3`},
+		"sqrt":                         {`{"sqrt": 16}`, `4`},
+		"sqrt of a list":               {`{"sqrt": [2.25]}`, `1.5`},
+		"sqrt of a negative number":    {`{"sqrt": -1}`, `null`},
+		"floor":                        {`{"floor": -1.5}`, `-2`},
+		"ceil":                         {`{"ceil": "1.2"}`, `2`},
+		"round":                        {`{"round": 2.5}`, `3`},
+		"round negative half":          {`{"round": -2.5}`, `-3`},
+		"round to decimal places":      {`{"round": [2.675, 2]}`, `2.68`},
+		"round binary artifacts":       {`{"round": [1.005, 2]}`, `1.01`},
+		"round negative decimals":      {`{"round": [-1.005, 2]}`, `-1.01`},
+		"round computed prices":        {`{"round": [{"*": [19.99, 0.15]}, 2]}`, `3`},
+		"missing values":               {`{"sqrt": {"var": "missing"}}`, `null`},
+		"too many arguments":           {`{"floor": [1, 2]}`, `null`},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var result bytes.Buffer
+
+			err := Apply(strings.NewReader(scenario.Rule), strings.NewReader(`{}`), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, result.String())
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package jsonlogic
+
+import (
+	"reflect"
+	"strings"
+)
+
+// memoEntry is the result of a closed expression. The expression is kept
+// so its address, which keys the entry, can't be reused during the
+// evaluation.
+type memoEntry struct {
+	rule   interface{}
+	result interface{}
+}
+
+// memoize evaluates rule once per evaluation when it is closed, that is,
+// when it doesn't depend on the data. Iterations evaluate their predicate
+// for every element, so without it, closed expressions of predicates, like
+// the minimum of a list of the data, would be evaluated again and again.
+// Reused results skip middlewares and instrumentation.
+func (ev *evaluator) memoize(rule, data interface{}) interface{} {
+	key := reflect.ValueOf(rule).Pointer()
+
+	if entry, ok := ev.memo[key]; ok {
+		return entry.result
+	}
+
+	if !isClosed(rule) {
+		return ev.applyRule(rule, data)
+	}
+
+	result := ev.applyRule(rule, data)
+
+	if ev.memo == nil {
+		ev.memo = make(map[uintptr]memoEntry)
+	}
+
+	ev.memo[key] = memoEntry{rule: rule, result: result}
+
+	return result
+}
+
+// iteration marks the start of an iteration evaluated against data, in
+// which closed expressions are memoized and $parent reads data, returning
+// the function marking its end, which gives the iteration around back its
+// position
+func (ev *evaluator) iteration(data interface{}) func() {
+	ev.iterating++
+
+	// the slice is shared with the workers of parallel iterations, so it
+	// is copied instead of appended to in place
+	previous := ev.parents
+	ev.parents = append(ev.parents[:len(ev.parents):len(ev.parents)], data)
+
+	index, key := ev.index, ev.key
+
+	return func() {
+		ev.iterating--
+		ev.parents = previous
+		ev.index, ev.key = index, key
+	}
+}
+
+// isClosed tells if the result of rule only depends on the rule itself:
+// it has no var reading the data, except the ones relative to the elements
+// of its own iterations, and no impure operator
+func isClosed(rule interface{}) bool {
+	if isSlice(rule) {
+		for _, value := range rule.([]interface{}) {
+			if !isClosed(value) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	if !isMap(rule) {
+		return true
+	}
+
+	for operator, values := range rule.(map[string]interface{}) {
+		if operator == "var" || operator == "missing" || operator == "missing_some" || operator == "template" || operator == "call" || operator == "set" || impureOperators[operator] {
+			return false
+		}
+
+		if operator == "let" {
+			return isClosed(letOperands(values))
+		}
+
+		if operator == "def" {
+			return isClosed(defOperands(values))
+		}
+
+		parsed := toSlice(values)
+		if !iteratorOperators[operator] || len(parsed) < 2 {
+			return isClosed(values)
+		}
+
+		if !isClosed(parsed[0]) || !isClosed(parsed[2:]) {
+			return false
+		}
+
+		if operator == "reduce" {
+			// the predicate of reduce only reads current and accumulator
+			return isPure(parsed[1])
+		}
+
+		return isLocal(parsed[1])
+	}
+
+	return true
+}
+
+// isLocal tells if the predicate of an iteration only reads the element
+func isLocal(rule interface{}) bool {
+	if isSlice(rule) {
+		for _, value := range rule.([]interface{}) {
+			if !isLocal(value) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	if !isMap(rule) {
+		return true
+	}
+
+	for operator, values := range rule.(map[string]interface{}) {
+		if operator == "var" {
+			path, _ := varArgs(values)
+			if path != "" && !strings.HasPrefix(path, ".") {
+				return false
+			}
+		}
+
+		if operator == "missing" || operator == "missing_some" || operator == "template" || operator == "call" || operator == "set" || impureOperators[operator] {
+			return false
+		}
+
+		if operator == "let" {
+			return isLocal(letOperands(values))
+		}
+
+		if operator == "def" {
+			return isLocal(defOperands(values))
+		}
+
+		return isLocal(values)
+	}
+
+	return true
+}
@@ -0,0 +1,74 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoization(t *testing.T) {
+	scenarios := map[string]struct {
+		Rule        string
+		Data        string
+		Expected    string
+		Evaluations int
+	}{
+		"closed expression in a predicate": {
+			Rule: `{"filter": [
+				{"var": "people"},
+				{"==": [{"var": ".age"}, {"min": {"map": [{"var": "people"}, {"var": ".age"}]}}]}
+			]}`,
+			Data:        `{"people": [{"age": 18}, {"age": 20}, {"age": 18}, {"age": 30}]}`,
+			Expected:    `[{"age": 18}, {"age": 18}]`,
+			Evaluations: 1,
+		},
+		"expression reading the element": {
+			Rule: `{"map": [
+				{"var": "people"},
+				{"min": [{"var": ".age"}, 19]}
+			]}`,
+			Data:        `{"people": [{"age": 18}, {"age": 20}, {"age": 18}, {"age": 30}]}`,
+			Expected:    `[18, 19, 18, 19]`,
+			Evaluations: 4,
+		},
+		"nested iteration reading the outer element": {
+			Rule: `{"map": [
+				{"var": "people"},
+				{"min": {"filter": [[10, 20, 30], {">": [{"var": ""}, {"var": "age"}]}]}}
+			]}`,
+			Data:        `{"people": [{"age": 15}, {"age": 25}]}`,
+			Expected:    `[20, 30]`,
+			Evaluations: 2,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			evaluations := 0
+
+			engine, err := NewEngine(WithMiddlewares(func(operator string, args []interface{}, next Evaluator) (interface{}, error) {
+				if operator == "min" {
+					evaluations++
+				}
+
+				return next(operator, args)
+			}))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var result bytes.Buffer
+
+			err = engine.Apply(strings.NewReader(scenario.Rule), strings.NewReader(scenario.Data), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, result.String())
+			assert.Equal(t, scenario.Evaluations, evaluations)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package jsonlogic
+
+// Evaluator evaluates an operator with the given arguments
+type Evaluator func(operator string, args []interface{}) (interface{}, error)
+
+// Middleware wraps the evaluation of every operator of a rule. It can
+// inspect or replace the arguments before calling next, change the result
+// or the error it returns, or skip next altogether.
+//
+// Operators usually get their arguments already evaluated, but the ones
+// that evaluate their own arguments, like "filter", "map", "reduce",
+// "all", "none", "some", "map_obj", "filter_obj", "groupby", "switch",
+// the type testing and the cast operators, get them as they are written in
+// the rule.
+//
+// Arguments may be parts of the rule or of the data, which middlewares
+// must not modify: they can replace them with copies instead.
+type Middleware func(operator string, args []interface{}, next Evaluator) (interface{}, error)
+
+// WithMiddlewares creates an Engine using the given middlewares, see Use
+func WithMiddlewares(middlewares ...Middleware) Option {
+	return func(e *Engine) error {
+		e.Use(middlewares...)
+
+		return nil
+	}
+}
+
+// Use adds middlewares wrapping the evaluation of operators. The first
+// middleware added is the outermost one. Use is not safe to call while the
+// engine is evaluating rules.
+func (e *Engine) Use(middlewares ...Middleware) {
+	e.middlewares = append(e.middlewares, middlewares...)
+}
+
+func (ev *evaluator) callWithMiddlewares(operator string, values, data interface{}) interface{} {
+	// operators taking a single argument may get it without a list around
+	// it, which must be preserved to keep their behavior
+	args, wrapped := []interface{}{values}, true
+	if isSlice(values) {
+		args, wrapped = values.([]interface{}), false
+	}
+
+	next := func(operator string, args []interface{}) (result interface{}, err error) {
+		defer recoverFailure(&err)
+
+		var values interface{} = args
+		if wrapped && len(args) == 1 {
+			values = args[0]
+		}
+
+		return ev.call(operator, values, data), nil
+	}
+
+	middlewares := ev.engine.middlewares
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		middleware, inner := middlewares[i], next
+		next = func(operator string, args []interface{}) (interface{}, error) {
+			return middleware(operator, args, inner)
+		}
+	}
+
+	result, err := next(operator, args)
+	if err != nil {
+		ev.fail(err)
+	}
+
+	return result
+}
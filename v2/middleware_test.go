@@ -0,0 +1,143 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddlewaresWrapOperators(t *testing.T) {
+	engine, err := NewEngine()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var calls []string
+
+	engine.Use(func(operator string, args []interface{}, next Evaluator) (interface{}, error) {
+		calls = append(calls, "outer:"+operator)
+
+		return next(operator, args)
+	})
+
+	engine.Use(func(operator string, args []interface{}, next Evaluator) (interface{}, error) {
+		calls = append(calls, "inner:"+operator)
+
+		return next(operator, args)
+	})
+
+	var result bytes.Buffer
+
+	err = engine.Apply(strings.NewReader(`{"==": [{"var": "a"}, 1]}`), strings.NewReader(`{"a": 1}`), &result)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.JSONEq(t, `true`, result.String())
+	assert.Equal(t, []string{"outer:var", "inner:var", "outer:==", "inner:=="}, calls)
+}
+
+func TestMiddlewaresCanChangeArgumentsAndResults(t *testing.T) {
+	// sanitize strings before comparing them and negate "!" results
+	engine, err := NewEngine(WithMiddlewares(func(operator string, args []interface{}, next Evaluator) (interface{}, error) {
+		if operator == "==" {
+			for i, arg := range args {
+				if s, ok := arg.(string); ok {
+					args[i] = strings.ToLower(strings.TrimSpace(s))
+				}
+			}
+		}
+
+		return next(operator, args)
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var result bytes.Buffer
+
+	err = engine.Apply(strings.NewReader(`{"==": [{"var": "country"}, "gb"]}`), strings.NewReader(`{"country": " GB "}`), &result)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.JSONEq(t, `true`, result.String())
+}
+
+func TestMiddlewaresKeepSingleArguments(t *testing.T) {
+	engine, err := NewEngine(WithMiddlewares(func(operator string, args []interface{}, next Evaluator) (interface{}, error) {
+		return next(operator, args)
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := engine.ApplyRaw([]byte(`{"cat": [{"var": null}, "y"]}`), []byte(`"x"`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.JSONEq(t, `"xy"`, string(result))
+}
+
+func TestMiddlewaresGetRawArgumentsOfLazyOperators(t *testing.T) {
+	var args []interface{}
+
+	engine, err := NewEngine(WithMiddlewares(func(operator string, _args []interface{}, next Evaluator) (interface{}, error) {
+		if operator == "filter" {
+			args = _args
+		}
+
+		return next(operator, _args)
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = engine.ApplyRaw([]byte(`{"filter": [{"var": "a"}, true]}`), []byte(`{"a": [1]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []interface{}{map[string]interface{}{"var": "a"}, true}, args)
+}
+
+func TestMiddlewaresErrors(t *testing.T) {
+	denied := errors.New("denied")
+
+	engine, err := NewEngine(WithMiddlewares(func(operator string, args []interface{}, next Evaluator) (interface{}, error) {
+		if operator == "var" && args[0] == "secret" {
+			return nil, denied
+		}
+
+		return next(operator, args)
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = engine.ApplyRaw([]byte(`{"!": {"var": "secret"}}`), []byte(`{"secret": 1}`))
+	assert.True(t, errors.Is(err, denied))
+
+	// errors raised by operators reach the middlewares
+	var seen error
+
+	strict, err := NewEngine(WithStrictCasts(), WithMiddlewares(func(operator string, args []interface{}, next Evaluator) (interface{}, error) {
+		result, err := next(operator, args)
+		if err != nil {
+			seen = err
+		}
+
+		return result, err
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = strict.ApplyRaw([]byte(`{"to_number": "abc"}`), []byte(`{}`))
+	assert.True(t, errors.Is(err, ErrInvalidCast))
+	assert.True(t, errors.Is(seen, ErrInvalidCast))
+}
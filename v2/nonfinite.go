@@ -0,0 +1,73 @@
+package jsonlogic
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrNonFinite is returned, wrapped, by engines using NonFiniteToError
+// when an operator gives NaN or an infinity
+var ErrNonFinite = errors.New("non-finite number")
+
+// NonFinitePolicy is what happens to the NaN and infinities given by
+// operators, which can't be written as JSON
+type NonFinitePolicy int
+
+const (
+	// NonFiniteToNull makes them null. It is the default.
+	NonFiniteToNull NonFinitePolicy = iota
+
+	// NonFiniteToError fails the evaluation with ErrNonFinite
+	NonFiniteToError
+
+	// ClampNonFinite replaces infinities with the largest or smallest
+	// float64. NaN, which is neither, becomes null.
+	ClampNonFinite
+
+	// KeepNonFinite leaves them as they are, for ApplyInterface: encoding
+	// them as JSON fails
+	KeepNonFinite
+)
+
+// WithNonFinitePolicy chooses what happens to the NaN and infinities given
+// by operators. It applies to every operator, extensions included.
+func WithNonFinitePolicy(policy NonFinitePolicy) Option {
+	return func(e *Engine) error {
+		if policy < NonFiniteToNull || policy > KeepNonFinite {
+			return fmt.Errorf("unknown non-finite policy %d", policy)
+		}
+
+		e.nonFinitePolicy = policy
+
+		return nil
+	}
+}
+
+// finite applies the non-finite policy of the engine to the result of an
+// operator
+func (ev *evaluator) finite(operator string, result interface{}) interface{} {
+	n, ok := result.(float64)
+	if !ok || !(math.IsNaN(n) || math.IsInf(n, 0)) {
+		return result
+	}
+
+	switch ev.engine.nonFinitePolicy {
+	case KeepNonFinite:
+		return result
+	case NonFiniteToError:
+		ev.fail(fmt.Errorf("%w: %s gave %v", ErrNonFinite, operator, n))
+	case ClampNonFinite:
+		if math.IsInf(n, 1) {
+			return math.MaxFloat64
+		}
+
+		if math.IsInf(n, -1) {
+			return -math.MaxFloat64
+		}
+	}
+
+	ev.warn("jsonlogic non-finite number", "operator", operator, "value", n)
+
+	return nil
+}
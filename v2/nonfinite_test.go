@@ -0,0 +1,116 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNonFiniteToNull(t *testing.T) {
+	scenarios := map[string]struct {
+		Rule     string
+		Data     string
+		Expected string
+	}{
+		"overflowing product":   {`{"*": [1e308, 10]}`, `{}`, `null`},
+		"overflowing sum":       {`{"+": [1e308, 1e308]}`, `{}`, `null`},
+		"overflowing power":     {`{"pow": [10, 400]}`, `{}`, `null`},
+		"square root":           {`{"sqrt": -4}`, `{}`, `null`},
+		"infinite string":       {`{"+": [{"var": "n"}, 1]}`, `{"n": "Infinity"}`, `null`},
+		"nested":                {`{"cat": ["x", {"*": [1e308, 10]}]}`, `{}`, `"x"`},
+		"within a comparison":   {`{"==": [{"*": [1e308, 10]}, null]}`, `{}`, `true`},
+		"finite results remain": {`{"*": [2, 3]}`, `{}`, `6`},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var result bytes.Buffer
+
+			err := Apply(strings.NewReader(scenario.Rule), strings.NewReader(scenario.Data), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, result.String())
+		})
+	}
+}
+
+func TestNonFinitePolicies(t *testing.T) {
+	scenarios := map[string]struct {
+		Policy   NonFinitePolicy
+		Rule     string
+		Expected interface{}
+		Err      error
+	}{
+		"error":              {NonFiniteToError, `{"*": [1e308, 10]}`, nil, ErrNonFinite},
+		"error without them": {NonFiniteToError, `{"*": [2, 3]}`, 6.0, nil},
+		"clamp":              {ClampNonFinite, `{"*": [1e308, 10]}`, math.MaxFloat64, nil},
+		"clamp negative":     {ClampNonFinite, `{"*": [-1e308, 10]}`, -math.MaxFloat64, nil},
+		"clamp NaN":          {ClampNonFinite, `{"sqrt": -1}`, nil, nil},
+		"keep":               {KeepNonFinite, `{"pow": [10, 400]}`, math.Inf(1), nil},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			engine, err := NewEngine(WithNonFinitePolicy(scenario.Policy))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var rule interface{}
+			if err := json.Unmarshal([]byte(scenario.Rule), &rule); err != nil {
+				t.Fatal(err)
+			}
+
+			result, err := engine.ApplyInterface(rule, nil)
+			if scenario.Err != nil {
+				assert.True(t, errors.Is(err, scenario.Err))
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, scenario.Expected, result)
+		})
+	}
+}
+
+func TestNonFiniteWithDivisionPolicy(t *testing.T) {
+	engine, err := NewEngine(WithDivisionPolicy(DivideToInfinity), WithNonFinitePolicy(ClampNonFinite))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := map[string]struct {
+		Rule     string
+		Expected string
+	}{
+		"infinity": {`{"/": [-1, 0]}`, `-1.7976931348623157e+308`},
+		"NaN":      {`{"%": [1, 0]}`, `null`},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var result bytes.Buffer
+
+			err := engine.Apply(strings.NewReader(scenario.Rule), strings.NewReader(`{}`), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, result.String())
+		})
+	}
+}
+
+func TestWithNonFinitePolicy(t *testing.T) {
+	_, err := NewEngine(WithNonFinitePolicy(NonFinitePolicy(4)))
+	assert.Error(t, err)
+}
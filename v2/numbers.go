@@ -0,0 +1,93 @@
+package jsonlogic
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// formatNumber writes a number for people to read, with a number of
+// decimal places, the separators of a locale given as a BCP 47 tag
+// ("en" by default), and thousands separators unless told otherwise:
+// {"format_number": [1234.5, 2, "de"]} is "1.234,50". Without decimal
+// places, the number keeps those of its shortest representation. It
+// returns null when the arguments are invalid.
+func formatNumber(values interface{}) interface{} {
+	parsed := toSlice(values)
+	if len(parsed) < 1 || len(parsed) > 4 {
+		return nil
+	}
+
+	if isBool(parsed[0]) {
+		return nil
+	}
+
+	// numbers given as strings are read as by to_number
+	n, ok := castToNumber(parsed[0])
+	if !ok {
+		return nil
+	}
+
+	x := toNumber(n)
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return nil
+	}
+
+	places := int64(-1)
+	if len(parsed) > 1 && parsed[1] != nil {
+		if !isNumber(parsed[1]) {
+			return nil
+		}
+
+		places = int64(toNumber(parsed[1]))
+		if places < 0 || places > 15 {
+			return nil
+		}
+	}
+
+	tag := language.English
+	if len(parsed) > 2 && parsed[2] != nil {
+		if !isString(parsed[2]) {
+			return nil
+		}
+
+		var err error
+
+		tag, err = language.Parse(parsed[2].(string))
+		if err != nil {
+			return nil
+		}
+	}
+
+	separators := true
+	if len(parsed) > 3 {
+		if !isBool(parsed[3]) {
+			return nil
+		}
+
+		separators = parsed[3].(bool)
+	}
+
+	if places < 0 {
+		shortest := strconv.FormatFloat(x, 'f', -1, 64)
+
+		places = 0
+		if dot := strings.IndexByte(shortest, '.'); dot >= 0 {
+			places = int64(len(shortest) - dot - 1)
+		}
+	} else {
+		// rounded as by round, since the formatter rounds half to even
+		x = roundPlaces(x, places)
+	}
+
+	options := []number.Option{number.Scale(int(places))}
+	if !separators {
+		options = append(options, number.NoSeparator())
+	}
+
+	return message.NewPrinter(tag).Sprint(number.Decimal(x, options...))
+}
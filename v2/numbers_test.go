@@ -0,0 +1,47 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatNumber(t *testing.T) {
+	scenarios := map[string]struct {
+		Rule     string
+		Expected string
+	}{
+		"shortest representation":    {`{"format_number": 1234.5}`, `"1,234.5"`},
+		"integer":                    {`{"format_number": [1234567]}`, `"1,234,567"`},
+		"decimal places":             {`{"format_number": [1234.5, 2]}`, `"1,234.50"`},
+		"rounds half away from zero": {`{"format_number": [0.125, 2]}`, `"0.13"`},
+		"negative number":            {`{"format_number": [-1234.5, 0]}`, `"-1,235"`},
+		"numeric string":             {`{"format_number": ["1234", 1]}`, `"1,234.0"`},
+		"german locale":              {`{"format_number": [1234.5, 2, "de"]}`, `"1.234,50"`},
+		"french locale":              {`{"format_number": [1234.5, 2, "fr"]}`, `"1\u00a0234,50"`},
+		"indian grouping":            {`{"format_number": [1234567, 0, "hi-IN"]}`, `"12,34,567"`},
+		"default places with locale": {`{"format_number": [1234.5, null, "de"]}`, `"1.234,5"`},
+		"without separators":         {`{"format_number": [1234.5, 2, "en", false]}`, `"1234.50"`},
+		"in a reason":                {`{"cat": ["amount ", {"format_number": [1234.5, 2]}, " is too high"]}`, `"amount 1,234.50 is too high"`},
+		"invalid number":             {`{"format_number": ["abc", 2]}`, `null`},
+		"invalid places":             {`{"format_number": [1, -1]}`, `null`},
+		"invalid locale":             {`{"format_number": [1, 2, "not a locale"]}`, `null`},
+		"invalid separators":         {`{"format_number": [1, 2, "en", "no"]}`, `null`},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var result bytes.Buffer
+
+			err := Apply(strings.NewReader(scenario.Rule), strings.NewReader(`{}`), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, result.String())
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package jsonlogic
+
+import (
+	"sort"
+	"strings"
+)
+
+// objectArgs splits the arguments of an object operator into the object it
+// works on and the remaining arguments: {"op": [object, args...]}. A bare
+// object, like {"op": {"var": "x"}} resolves to, is accepted too. Structs
+// are read as objects, see objectOf.
+func objectArgs(values interface{}) (map[string]interface{}, []interface{}) {
+	if object, ok := objectOf(values); ok {
+		return object, nil
+	}
+
+	parsed := toSlice(values)
+	if len(parsed) > 0 {
+		if object, ok := objectOf(parsed[0]); ok {
+			return object, parsed[1:]
+		}
+	}
+
+	return nil, nil
+}
+
+// objectOf reads a value as an object: objects, and the structs of data
+// given as Go values, whose fields are read like var reads them
+func objectOf(value interface{}) (map[string]interface{}, bool) {
+	if object, ok := value.(map[string]interface{}); ok {
+		return object, true
+	}
+
+	return structObject(value)
+}
+
+// propertyNames reads the names given to pick and omit, either as
+// separate arguments or as a single list
+func propertyNames(args []interface{}) []string {
+	if len(args) == 1 && isSlice(args[0]) {
+		args = args[0].([]interface{})
+	}
+
+	names := make([]string, 0, len(args))
+	for _, arg := range args {
+		if isString(arg) || isNumber(arg) {
+			names = append(names, toString(arg))
+		}
+	}
+
+	return names
+}
+
+func sortedKeys(object map[string]interface{}) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// entries returns the properties of an object as {"key": name, "value":
+// value} objects, in alphabetical order of their names, for all, some and
+// none to iterate over them
+func entries(object map[string]interface{}) []interface{} {
+	result := make([]interface{}, 0, len(object))
+	for _, key := range sortedKeys(object) {
+		result = append(result, map[string]interface{}{"key": key, "value": object[key]})
+	}
+
+	return result
+}
+
+// eachEntry evaluates the rule given as second argument for every entry of
+// the object given as first one, calling yield with its name, its value and
+// the result
+func (ev *evaluator) eachEntry(values, data interface{}, yield func(key string, value, result interface{})) {
+	parsed := toSlice(values)
+	if len(parsed) < 2 {
+		return
+	}
+
+	object, ok := objectOf(ev.parseValues(parsed[0], data))
+	if !ok {
+		return
+	}
+
+	logic := ev.solveVars(parsed[1], data)
+
+	defer ev.iteration(data)()
+
+	ev.each(logic, listSource(entries(object)), true, func(entry, result interface{}) bool {
+		_entry := entry.(map[string]interface{})
+		yield(_entry["key"].(string), _entry["value"], result)
+
+		return true
+	})
+}
+
+// mapObject replaces the values of an object with the results of a rule
+// applied to its entries: {"map_obj": [{"var": "prices"}, {"*": [{"var":
+// ".value"}, 2]}]}. Anything but an object gives an empty object.
+func (ev *evaluator) mapObject(values, data interface{}) interface{} {
+	result := make(map[string]interface{})
+
+	ev.eachEntry(values, data, func(key string, _, v interface{}) {
+		result[key] = v
+	})
+
+	return result
+}
+
+// filterObject keeps the properties of an object whose entries pass a rule:
+// {"filter_obj": [{"var": "user"}, {"!=": [{"var": ".value"}, null]}]}.
+// Anything but an object gives an empty object.
+func (ev *evaluator) filterObject(values, data interface{}) interface{} {
+	result := make(map[string]interface{})
+
+	ev.eachEntry(values, data, func(key string, value, v interface{}) {
+		if ev.truthy(v) {
+			result[key] = value
+		}
+	})
+
+	return result
+}
+
+// keys returns the property names of an object in alphabetical order
+func keys(values interface{}) interface{} {
+	object, _ := objectArgs(values)
+
+	result := make([]interface{}, 0, len(object))
+	for _, key := range sortedKeys(object) {
+		result = append(result, key)
+	}
+
+	return result
+}
+
+// objectValues returns the values of an object, in the order of its keys
+func objectValues(values interface{}) interface{} {
+	object, _ := objectArgs(values)
+
+	result := make([]interface{}, 0, len(object))
+	for _, key := range sortedKeys(object) {
+		result = append(result, object[key])
+	}
+
+	return result
+}
+
+// pick returns a new object with only the given properties:
+// {"pick": [object, "name", "age"]}
+func pick(values interface{}) interface{} {
+	object, args := objectArgs(values)
+
+	result := make(map[string]interface{})
+	for _, name := range propertyNames(args) {
+		if value, ok := object[name]; ok {
+			result[name] = value
+		}
+	}
+
+	return result
+}
+
+// omit returns a new object without the given properties:
+// {"omit": [object, "password"]}
+func omit(values interface{}) interface{} {
+	object, args := objectArgs(values)
+
+	result := make(map[string]interface{}, len(object))
+	for key, value := range object {
+		result[key] = value
+	}
+
+	for _, name := range propertyNames(args) {
+		delete(result, name)
+	}
+
+	return result
+}
+
+// has tells if an object has a property, even when its value is null:
+// {"has": [object, "user.email"]}. Paths are read like those of var, so
+// JSON pointers reach the properties whose names contain dots.
+func (ev *evaluator) has(values interface{}) interface{} {
+	object, args := objectArgs(values)
+	if object == nil || len(args) != 1 || !isString(args[0]) {
+		return false
+	}
+
+	path := args[0].(string)
+
+	parts := strings.Split(path, ".")
+	if isPointer(path, ev.engine.pathSyntax) {
+		var ok bool
+
+		// the empty pointer is the object itself, not one of its properties
+		parts, ok = splitPath(path, ev.engine.pathSyntax)
+		if !ok || len(parts) == 0 {
+			return false
+		}
+	}
+
+	for i, part := range parts {
+		value, ok := object[part]
+		if !ok {
+			return false
+		}
+
+		if i == len(parts)-1 {
+			break
+		}
+
+		if !isMap(value) {
+			return false
+		}
+
+		object = value.(map[string]interface{})
+	}
+
+	return true
+}
@@ -0,0 +1,144 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObjectOperators(t *testing.T) {
+	data := `{
+		"user": {
+			"name": "Jane",
+			"age": 33,
+			"password": "secret",
+			"nickname": null,
+			"address": {"city": "Lisbon"}
+		}
+	}`
+
+	scenarios := map[string]struct {
+		Rule     string
+		Expected string
+	}{
+		"keys":                      {`{"keys": {"var": "user.address"}}`, `["city"]`},
+		"keys are sorted":           {`{"keys": [{"var": "user"}]}`, `["address", "age", "name", "nickname", "password"]`},
+		"keys of something else":    {`{"keys": {"var": "user.name"}}`, `[]`},
+		"values":                    {`{"values": {"pick": [{"var": "user"}, "name", "age"]}}`, `[33, "Jane"]`},
+		"pick":                      {`{"pick": [{"var": "user"}, "name", "missing"]}`, `{"name": "Jane"}`},
+		"pick a list of properties": {`{"pick": [{"var": "user"}, ["name", "age"]]}`, `{"name": "Jane", "age": 33}`},
+		"omit": {
+			`{"omit": [{"var": "user"}, "password", "address"]}`,
+			`{"name": "Jane", "age": 33, "nickname": null}`,
+		},
+		"has a property":            {`{"has": [{"var": "user"}, "name"]}`, `true`},
+		"has a null property":       {`{"has": [{"var": "user"}, "nickname"]}`, `true`},
+		"has a missing property":    {`{"has": [{"var": "user"}, "email"]}`, `false`},
+		"has a nested property":     {`{"has": [{"var": ""}, "user.address.city"]}`, `true`},
+		"has a missing nested one":  {`{"has": [{"var": ""}, "user.name.first"]}`, `false`},
+		"has on something else":     {`{"has": [{"var": "user.age"}, "name"]}`, `false`},
+		"omit does not change data": {`{"and": [{"!": {"has": [{"omit": [{"var": "user"}, "password"]}, "password"]}}, {"has": [{"var": "user"}, "password"]}]}`, `true`},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var result bytes.Buffer
+
+			err := Apply(strings.NewReader(scenario.Rule), strings.NewReader(data), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, result.String())
+		})
+	}
+}
+
+func TestHasPaths(t *testing.T) {
+	data := `{"user": {"versions": {"v1.2": null}, "a/b": 1}}`
+
+	auto, err := NewEngine()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dots, err := NewEngine(WithPathSyntax(DotPaths))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pointers, err := NewEngine(WithPathSyntax(PointerPaths))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := map[string]struct {
+		Engine   *Engine
+		Rule     string
+		Expected string
+	}{
+		"pointer":                        {auto, `{"has": [{"var": ""}, "/user/versions/v1.2"]}`, `true`},
+		"missing pointer":                {auto, `{"has": [{"var": ""}, "/user/versions/v1.3"]}`, `false`},
+		"escaped pointer":                {auto, `{"has": [{"var": "user"}, "/a~1b"]}`, `true`},
+		"empty pointer":                  {pointers, `{"has": [{"var": ""}, ""]}`, `false`},
+		"dot path through a dotted name": {auto, `{"has": [{"var": ""}, "user.versions.v1.2"]}`, `false`},
+		"pointer with pointer paths":     {pointers, `{"has": [{"var": "/user"}, "/versions/v1.2"]}`, `true`},
+		"dot path with pointer paths":    {pointers, `{"has": [{"var": "/user"}, "versions"]}`, `false`},
+		"slash with dot paths":           {dots, `{"has": [{"var": "user"}, "a/b"]}`, `true`},
+		"pointer with dot paths":         {dots, `{"has": [{"var": ""}, "/user/versions/v1.2"]}`, `false`},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var result bytes.Buffer
+
+			err := scenario.Engine.Apply(strings.NewReader(scenario.Rule), strings.NewReader(data), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, result.String())
+		})
+	}
+}
+
+func TestObjectIterations(t *testing.T) {
+	data := `{
+		"user": {"name": "Jane", "nickname": null, "email": null, "x_id": 7, "x_team": "blue"},
+		"prices": {"apple": 1.5, "pear": 2},
+		"rate": 2
+	}`
+
+	scenarios := map[string]struct {
+		Rule     string
+		Expected string
+	}{
+		"drop null fields":        {`{"filter_obj": [{"var": "user"}, {"!=": [{"var": ".value"}, null]}]}`, `{"name": "Jane", "x_id": 7, "x_team": "blue"}`},
+		"keep keys with a prefix": {`{"filter_obj": [{"var": "user"}, {"match": [{"var": ".key"}, "^x_"]}]}`, `{"x_id": 7, "x_team": "blue"}`},
+		"map values":              {`{"map_obj": [{"var": "prices"}, {"*": [{"var": ".value"}, {"var": "rate"}]}]}`, `{"apple": 3, "pear": 4}`},
+		"map to keys":             {`{"map_obj": [{"var": "prices"}, {"cat": [{"var": ".key"}, "s"]}]}`, `{"apple": "apples", "pear": "pears"}`},
+		"map to nulls":            {`{"map_obj": [{"var": "prices"}, null]}`, `{"apple": null, "pear": null}`},
+		"chained":                 {`{"map_obj": [{"filter_obj": [{"var": "prices"}, {">": [{"var": ".value"}, 1.5]}]}, {"var": ".key"}]}`, `{"pear": "pear"}`},
+		"filter something else":   {`{"filter_obj": [{"var": "rate"}, true]}`, `{}`},
+		"map something missing":   {`{"map_obj": [{"var": "missing"}, true]}`, `{}`},
+		"without a rule":          {`{"map_obj": [{"var": "prices"}]}`, `{}`},
+		"data is left unchanged":  {`{"and": [{"filter_obj": [{"var": "user"}, false]}, {"has": [{"var": "user"}, "name"]}]}`, `true`},
+		"objects in quantifiers":  {`{"all": [{"map_obj": [{"var": "prices"}, {">": [{"var": ".value"}, 1]}]}, {"var": ".value"}]}`, `true`},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var result bytes.Buffer
+
+			err := Apply(strings.NewReader(scenario.Rule), strings.NewReader(data), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, result.String())
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package jsonlogic
+
+import "sort"
+
+// OperatorInfo describes an operator an engine evaluates, for tools
+// building rules to offer the operators of the engine they target
+type OperatorInfo struct {
+	Name string `json:"name"`
+
+	// MinArgs and MaxArgs bound the number of arguments of the operator,
+	// MaxArgs being -1 when there's no limit
+	MinArgs int `json:"min_args"`
+	MaxArgs int `json:"max_args"`
+
+	// Categories group operators by what they work on, like "comparison",
+	// "array" or "date"
+	Categories []string `json:"categories"`
+
+	// Standard tells if the operator is part of the JSON Logic
+	// specification, rather than an extension of this package or of the
+	// engine
+	Standard bool `json:"standard"`
+}
+
+// operatorInfos describes the operators of this package
+var operatorInfos = map[string]OperatorInfo{
+	"var":          {MinArgs: 0, MaxArgs: 2, Categories: []string{"data"}, Standard: true},
+	"missing":      {MinArgs: 0, MaxArgs: -1, Categories: []string{"data"}, Standard: true},
+	"missing_some": {MinArgs: 2, MaxArgs: 2, Categories: []string{"data"}, Standard: true},
+	"if":           {MinArgs: 0, MaxArgs: -1, Categories: []string{"logic"}, Standard: true},
+	"?:":           {MinArgs: 3, MaxArgs: 3, Categories: []string{"logic"}, Standard: true},
+	"==":           {MinArgs: 2, MaxArgs: 2, Categories: []string{"comparison"}, Standard: true},
+	"===":          {MinArgs: 2, MaxArgs: 2, Categories: []string{"comparison"}, Standard: true},
+	"!=":           {MinArgs: 2, MaxArgs: 2, Categories: []string{"comparison"}, Standard: true},
+	"!==":          {MinArgs: 2, MaxArgs: 2, Categories: []string{"comparison"}, Standard: true},
+	"!":            {MinArgs: 1, MaxArgs: 1, Categories: []string{"logic"}, Standard: true},
+	"!!":           {MinArgs: 1, MaxArgs: 1, Categories: []string{"logic"}, Standard: true},
+	"or":           {MinArgs: 1, MaxArgs: -1, Categories: []string{"logic"}, Standard: true},
+	"and":          {MinArgs: 1, MaxArgs: -1, Categories: []string{"logic"}, Standard: true},
+	">":            {MinArgs: 2, MaxArgs: 3, Categories: []string{"comparison"}, Standard: true},
+	">=":           {MinArgs: 2, MaxArgs: 3, Categories: []string{"comparison"}, Standard: true},
+	"<":            {MinArgs: 2, MaxArgs: 3, Categories: []string{"comparison"}, Standard: true},
+	"<=":           {MinArgs: 2, MaxArgs: 3, Categories: []string{"comparison"}, Standard: true},
+	"max":          {MinArgs: 1, MaxArgs: -1, Categories: []string{"arithmetic"}, Standard: true},
+	"min":          {MinArgs: 1, MaxArgs: -1, Categories: []string{"arithmetic"}, Standard: true},
+	"+":            {MinArgs: 1, MaxArgs: -1, Categories: []string{"arithmetic"}, Standard: true},
+	"-":            {MinArgs: 1, MaxArgs: 2, Categories: []string{"arithmetic"}, Standard: true},
+	"*":            {MinArgs: 1, MaxArgs: -1, Categories: []string{"arithmetic"}, Standard: true},
+	"/":            {MinArgs: 2, MaxArgs: -1, Categories: []string{"arithmetic"}, Standard: true},
+	"%":            {MinArgs: 2, MaxArgs: 2, Categories: []string{"arithmetic"}, Standard: true},
+	"map":          {MinArgs: 2, MaxArgs: 2, Categories: []string{"array", "iterator"}, Standard: true},
+	"filter":       {MinArgs: 2, MaxArgs: 2, Categories: []string{"array", "iterator"}, Standard: true},
+	"reduce":       {MinArgs: 3, MaxArgs: 3, Categories: []string{"array", "iterator"}, Standard: true},
+	"all":          {MinArgs: 2, MaxArgs: 2, Categories: []string{"array", "iterator"}, Standard: true},
+	"none":         {MinArgs: 2, MaxArgs: 2, Categories: []string{"array", "iterator"}, Standard: true},
+	"some":         {MinArgs: 2, MaxArgs: 2, Categories: []string{"array", "iterator"}, Standard: true},
+	"merge":        {MinArgs: 0, MaxArgs: -1, Categories: []string{"array"}, Standard: true},
+	"in":           {MinArgs: 2, MaxArgs: 2, Categories: []string{"array", "string"}, Standard: true},
+	"cat":          {MinArgs: 0, MaxArgs: -1, Categories: []string{"string"}, Standard: true},
+	"substr":       {MinArgs: 2, MaxArgs: 3, Categories: []string{"string"}, Standard: true},
+	"log":          {MinArgs: 1, MaxArgs: 1, Categories: []string{"debug"}, Standard: true},
+
+	"abs":              {MinArgs: 1, MaxArgs: 1, Categories: []string{"arithmetic"}},
+	"pow":              {MinArgs: 2, MaxArgs: 2, Categories: []string{"arithmetic"}},
+	"sqrt":             {MinArgs: 1, MaxArgs: 1, Categories: []string{"arithmetic"}},
+	"round":            {MinArgs: 1, MaxArgs: 2, Categories: []string{"arithmetic"}},
+	"format_number":    {MinArgs: 1, MaxArgs: 4, Categories: []string{"string"}},
+	"template":         {MinArgs: 1, MaxArgs: -1, Categories: []string{"string", "data"}},
+	"let":              {MinArgs: 1, MaxArgs: 1, Categories: []string{"data"}},
+	"def":              {MinArgs: 1, MaxArgs: 1, Categories: []string{"function"}},
+	"call":             {MinArgs: 1, MaxArgs: -1, Categories: []string{"function"}},
+	"floor":            {MinArgs: 1, MaxArgs: 1, Categories: []string{"arithmetic"}},
+	"ceil":             {MinArgs: 1, MaxArgs: 1, Categories: []string{"arithmetic"}},
+	"in_sorted":        {MinArgs: 2, MaxArgs: 3, Categories: []string{"array", "range"}},
+	"between":          {MinArgs: 3, MaxArgs: 4, Categories: []string{"comparison", "range"}},
+	"in_range":         {MinArgs: 2, MaxArgs: 3, Categories: []string{"range"}},
+	"overlaps":         {MinArgs: 2, MaxArgs: 3, Categories: []string{"range"}},
+	"contains_range":   {MinArgs: 2, MaxArgs: 3, Categories: []string{"range"}},
+	"semver_gte":       {MinArgs: 2, MaxArgs: 2, Categories: []string{"comparison", "version"}},
+	"semver_lt":        {MinArgs: 2, MaxArgs: 2, Categories: []string{"comparison", "version"}},
+	"semver_satisfies": {MinArgs: 2, MaxArgs: 2, Categories: []string{"version"}},
+	"is_uuid":          {MinArgs: 1, MaxArgs: 2, Categories: []string{"identifier"}},
+	"uuid_equals":      {MinArgs: 2, MaxArgs: 2, Categories: []string{"identifier", "comparison"}},
+	"is_ulid":          {MinArgs: 1, MaxArgs: 1, Categories: []string{"identifier"}},
+	"ulid_time":        {MinArgs: 1, MaxArgs: 1, Categories: []string{"identifier", "date"}},
+	"ulid_compare":     {MinArgs: 2, MaxArgs: 2, Categories: []string{"identifier", "comparison"}},
+	"hash_sha256":      {MinArgs: 1, MaxArgs: 2, Categories: []string{"hash"}},
+	"hash_md5":         {MinArgs: 1, MaxArgs: 2, Categories: []string{"hash"}},
+	"hash_fnv":         {MinArgs: 1, MaxArgs: 2, Categories: []string{"hash"}},
+	"set":              {MinArgs: 3, MaxArgs: -1, Categories: []string{"object"}},
+	"unset":            {MinArgs: 2, MaxArgs: -1, Categories: []string{"object"}},
+	"rename":           {MinArgs: 3, MaxArgs: -1, Categories: []string{"object"}},
+	"merge_objects":    {MinArgs: 0, MaxArgs: -1, Categories: []string{"object"}},
+	"match":            {MinArgs: 2, MaxArgs: 2, Categories: []string{"string"}},
+	"regex":            {MinArgs: 2, MaxArgs: 2, Categories: []string{"string"}},
+	"sum":              {MinArgs: 1, MaxArgs: 1, Categories: []string{"array", "aggregate"}},
+	"avg":              {MinArgs: 1, MaxArgs: 1, Categories: []string{"array", "aggregate"}},
+	"count":            {MinArgs: 1, MaxArgs: 1, Categories: []string{"array", "aggregate"}},
+	"median":           {MinArgs: 1, MaxArgs: 1, Categories: []string{"array", "aggregate"}},
+	"sort":             {MinArgs: 1, MaxArgs: 2, Categories: []string{"array"}},
+	"unique":           {MinArgs: 1, MaxArgs: 1, Categories: []string{"array"}},
+	"reverse":          {MinArgs: 1, MaxArgs: 1, Categories: []string{"array"}},
+	"slice":            {MinArgs: 2, MaxArgs: 3, Categories: []string{"array"}},
+	"flatten":          {MinArgs: 1, MaxArgs: 2, Categories: []string{"array"}},
+	"index_of":         {MinArgs: 2, MaxArgs: 2, Categories: []string{"array"}},
+	"keys":             {MinArgs: 1, MaxArgs: 1, Categories: []string{"object"}},
+	"values":           {MinArgs: 1, MaxArgs: 1, Categories: []string{"object"}},
+	"pick":             {MinArgs: 2, MaxArgs: -1, Categories: []string{"object"}},
+	"omit":             {MinArgs: 2, MaxArgs: -1, Categories: []string{"object"}},
+	"has":              {MinArgs: 2, MaxArgs: 2, Categories: []string{"object"}},
+	"intersection":     {MinArgs: 1, MaxArgs: -1, Categories: []string{"array", "set"}},
+	"union":            {MinArgs: 1, MaxArgs: -1, Categories: []string{"array", "set"}},
+	"difference":       {MinArgs: 1, MaxArgs: -1, Categories: []string{"array", "set"}},
+	"zip":              {MinArgs: 1, MaxArgs: -1, Categories: []string{"array"}},
+	"product":          {MinArgs: 1, MaxArgs: -1, Categories: []string{"array"}},
+	"map_obj":          {MinArgs: 2, MaxArgs: 2, Categories: []string{"object", "iterator"}},
+	"filter_obj":       {MinArgs: 2, MaxArgs: 2, Categories: []string{"object", "iterator"}},
+	"groupby":          {MinArgs: 2, MaxArgs: 2, Categories: []string{"array", "iterator"}},
+	"switch":           {MinArgs: 2, MaxArgs: 3, Categories: []string{"logic"}},
+	"typeof":           {MinArgs: 1, MaxArgs: 1, Categories: []string{"type"}},
+	"is_null":          {MinArgs: 1, MaxArgs: 1, Categories: []string{"type"}},
+	"is_bool":          {MinArgs: 1, MaxArgs: 1, Categories: []string{"type"}},
+	"is_number":        {MinArgs: 1, MaxArgs: 1, Categories: []string{"type"}},
+	"is_string":        {MinArgs: 1, MaxArgs: 1, Categories: []string{"type"}},
+	"is_array":         {MinArgs: 1, MaxArgs: 1, Categories: []string{"type"}},
+	"is_object":        {MinArgs: 1, MaxArgs: 1, Categories: []string{"type"}},
+	"to_number":        {MinArgs: 1, MaxArgs: 1, Categories: []string{"type"}},
+	"to_string":        {MinArgs: 1, MaxArgs: 1, Categories: []string{"type"}},
+	"to_bool":          {MinArgs: 1, MaxArgs: 1, Categories: []string{"type"}},
+	"rule":             {MinArgs: 1, MaxArgs: 1, Categories: []string{"rule"}},
+	"now":              {MinArgs: 0, MaxArgs: 0, Categories: []string{"date"}},
+	"date_before":      {MinArgs: 2, MaxArgs: 2, Categories: []string{"date", "comparison"}},
+	"date_after":       {MinArgs: 2, MaxArgs: 2, Categories: []string{"date", "comparison"}},
+	"date_between":     {MinArgs: 3, MaxArgs: 3, Categories: []string{"date", "comparison"}},
+	"date_add":         {MinArgs: 2, MaxArgs: 3, Categories: []string{"date"}},
+	"date_diff":        {MinArgs: 2, MaxArgs: 3, Categories: []string{"date"}},
+	"date_start_of":    {MinArgs: 2, MaxArgs: 2, Categories: []string{"date"}},
+	"date_same":        {MinArgs: 3, MaxArgs: 3, Categories: []string{"date", "comparison"}},
+	"random":           {MinArgs: 0, MaxArgs: 0, Categories: []string{"random"}},
+	"sample_pct":       {MinArgs: 1, MaxArgs: 1, Categories: []string{"random"}},
+}
+
+// WithOperatorInfo declares the operators evaluated by the middlewares of
+// an engine, for Operators to list them. Middlewares don't tell which
+// operators they evaluate, so extension packages export the descriptions
+// of theirs:
+//
+//	engine, err := jsonlogic.NewEngine(
+//		jsonlogic.WithMiddlewares(geo.Operators),
+//		jsonlogic.WithOperatorInfo(geo.Info...),
+//	)
+//
+// Declared operators are never standard, and replace the operators of this
+// package with the same name.
+func WithOperatorInfo(operators ...OperatorInfo) Option {
+	return func(e *Engine) error {
+		if e.extensions == nil {
+			e.extensions = make(map[string]OperatorInfo)
+		}
+
+		for _, operator := range operators {
+			operator.Standard = false
+			e.extensions[operator.Name] = operator
+		}
+
+		return nil
+	}
+}
+
+// Operators returns the operators of this package, including var, and the
+// operators declared with WithOperatorInfo, ordered by their name. Engines
+// pinned to an operator set only return the operators of this package in
+// the set.
+func (e *Engine) Operators() []OperatorInfo {
+	infos := make([]OperatorInfo, 0, len(operatorInfos)+len(e.extensions))
+
+	for name, info := range operatorInfos {
+		if _, ok := e.extensions[name]; ok {
+			continue
+		}
+
+		if e.allowed != nil && !e.allowed[name] {
+			continue
+		}
+
+		info.Name = name
+		info.Categories = append([]string(nil), info.Categories...)
+		infos = append(infos, info)
+	}
+
+	for _, info := range e.extensions {
+		info.Categories = append([]string(nil), info.Categories...)
+		infos = append(infos, info)
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Name < infos[j].Name
+	})
+
+	return infos
+}
+
+// Operators returns the operators of engines without options
+func Operators() []OperatorInfo {
+	return defaultEngine.Operators()
+}
@@ -0,0 +1,50 @@
+package jsonlogic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOperatorInfosCoverOperators(t *testing.T) {
+	for _, operator := range append([]string{"var"}, operators...) {
+		_, ok := operatorInfos[operator]
+		assert.True(t, ok, "%s is not described", operator)
+	}
+
+	for name := range operatorInfos {
+		assert.True(t, name == "var" || isOperator(name), "%s is not an operator", name)
+	}
+}
+
+func TestOperators(t *testing.T) {
+	engine, err := NewEngine(WithOperatorInfo(
+		OperatorInfo{Name: "geo_within", MinArgs: 2, MaxArgs: 2, Categories: []string{"geo"}, Standard: true},
+		OperatorInfo{Name: "round", MinArgs: 1, MaxArgs: 3, Categories: []string{"arithmetic"}},
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	infos := engine.Operators()
+	assert.Len(t, infos, len(operatorInfos)+1)
+
+	byName := make(map[string]OperatorInfo)
+	for i, info := range infos {
+		if i > 0 {
+			assert.True(t, infos[i-1].Name < info.Name)
+		}
+
+		byName[info.Name] = info
+	}
+
+	assert.Equal(t, OperatorInfo{Name: "some", MinArgs: 2, MaxArgs: 2, Categories: []string{"array", "iterator"}, Standard: true}, byName["some"])
+	assert.Equal(t, OperatorInfo{Name: "between", MinArgs: 3, MaxArgs: 4, Categories: []string{"comparison", "range"}}, byName["between"])
+	assert.Equal(t, OperatorInfo{Name: "geo_within", MinArgs: 2, MaxArgs: 2, Categories: []string{"geo"}}, byName["geo_within"])
+	assert.Equal(t, 3, byName["round"].MaxArgs)
+
+	infos[0].Categories[0] = "changed"
+	assert.NotEqual(t, "changed", engine.Operators()[0].Categories[0])
+
+	assert.Len(t, Operators(), len(operatorInfos))
+}
@@ -0,0 +1,95 @@
+package jsonlogic
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrOperatorNotAllowed is returned, wrapped, when a rule uses an operator
+// missing from the operator set an engine is pinned to
+var ErrOperatorNotAllowed = errors.New("operator not allowed")
+
+// coreOperators are the operators of the JSON Logic specification
+var coreOperators = []string{
+	"var", "missing", "missing_some", "if", "?:", "==", "===", "!=", "!==",
+	"!", "!!", "or", "and", ">", ">=", "<", "<=", "max", "min", "+", "-", "*",
+	"/", "%", "map", "filter", "reduce", "all", "none", "some", "merge", "in",
+	"cat", "substr", "log",
+}
+
+// operatorSets are the versions of the operators engines can be pinned to
+// with WithOperatorSet. They only choose the operators rules may use:
+// core-1 and ext-2024 don't change, while ext-2026, the current version,
+// gets the operators added to the package.
+var operatorSets = map[string]map[string]bool{
+	// core-1 is the JSON Logic specification
+	"core-1": operatorSet(coreOperators),
+
+	// ext-2024 adds the extensions of this package
+	"ext-2024": operatorSet(coreOperators, extensions2024),
+
+	// ext-2026 adds the operators on days, weeks, months and years,
+	// format_number, template, let, def and call, and the operators added
+	// since
+	"ext-2026": operatorSet(coreOperators, extensions2024, []string{
+		"date_start_of", "date_same", "format_number", "template", "let",
+		"def", "call", "regex",
+	}),
+}
+
+// extensions2024 are the extensions of ext-2024
+var extensions2024 = []string{
+	"in_sorted", "abs", "set", "match", "now", "date_before", "date_after",
+	"date_between", "date_add", "date_diff", "pow", "sqrt", "round", "floor",
+	"ceil", "sum", "avg", "count", "median", "sort", "unique", "reverse",
+	"slice", "flatten", "index_of", "keys", "values", "pick", "omit", "has",
+	"intersection", "union", "difference", "switch", "typeof", "is_null",
+	"is_bool", "is_number", "is_string", "is_array", "is_object", "to_number",
+	"to_string", "to_bool", "rule", "map_obj", "filter_obj", "groupby", "zip",
+	"product", "between", "in_range", "overlaps", "contains_range",
+	"semver_gte", "semver_lt", "semver_satisfies", "is_uuid", "uuid_equals",
+	"is_ulid", "ulid_time", "ulid_compare", "hash_sha256", "hash_md5",
+	"hash_fnv", "random", "sample_pct", "unset", "rename", "merge_objects",
+}
+
+func operatorSet(lists ...[]string) map[string]bool {
+	set := make(map[string]bool)
+	for _, list := range lists {
+		for _, operator := range list {
+			set[operator] = true
+		}
+	}
+
+	return set
+}
+
+// WithOperatorSet restricts the operators an engine evaluates to a version
+// of them, "core-1" for the operators of the JSON Logic specification,
+// "ext-2024" for those and the extensions of this package as of 2024, or
+// "ext-2026" for all the extensions, including the ones added since.
+// Evaluating an operator out of the set fails with ErrOperatorNotAllowed,
+// except for the operators evaluated by middlewares. Unknown versions are
+// an error. Sets only choose the operators rules may use, not how they are
+// evaluated: fixes and changes of behavior of the operators apply to all
+// the sets.
+func WithOperatorSet(version string) Option {
+	return func(e *Engine) error {
+		set, ok := operatorSets[version]
+		if !ok {
+			return fmt.Errorf("unknown operator set %q", version)
+		}
+
+		e.operatorSet = version
+		e.allowed = set
+
+		return nil
+	}
+}
+
+// allow fails the evaluation of operators out of the operator set of the
+// engine, if any
+func (ev *evaluator) allow(operator string) {
+	if ev.engine.allowed != nil && !ev.engine.allowed[operator] {
+		ev.fail(fmt.Errorf("%w: %q is not in operator set %s", ErrOperatorNotAllowed, operator, ev.engine.operatorSet))
+	}
+}
@@ -0,0 +1,90 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithOperatorSet(t *testing.T) {
+	upper := func(operator string, args []interface{}, next Evaluator) (interface{}, error) {
+		if operator == "upper" {
+			return strings.ToUpper(args[0].(string)), nil
+		}
+
+		return next(operator, args)
+	}
+
+	scenarios := map[string]struct {
+		Set      string
+		Rule     string
+		Expected string
+		Err      bool
+	}{
+		"core operator":              {"core-1", `{"==": [{"var": "a"}, 1]}`, `true`, false},
+		"extension out of core":      {"core-1", `{"abs": -1}`, ``, true},
+		"nested extension":           {"core-1", `{"if": [true, {"sort": [3, 1]}, 0]}`, ``, true},
+		"unknown operator":           {"core-1", `{"nope": [1, 1]}`, ``, true},
+		"extension in ext-2024":      {"ext-2024", `{"abs": -1}`, `1`, false},
+		"middleware operator":        {"core-1", `{"upper": "a"}`, `"A"`, false},
+		"unknown operator in ext":    {"ext-2024", `{"nope": [1, 1]}`, ``, true},
+		"iteration in core":          {"core-1", `{"map": [[1, 2], {"+": [{"var": ""}, 1]}]}`, `[2, 3]`, false},
+		"extension within iteration": {"core-1", `{"map": [[1, 2], {"abs": {"var": ""}}]}`, ``, true},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			engine, err := NewEngine(WithOperatorSet(scenario.Set), WithMiddlewares(upper))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var result bytes.Buffer
+
+			err = engine.Apply(strings.NewReader(scenario.Rule), strings.NewReader(`{"a": 1}`), &result)
+			if scenario.Err {
+				assert.True(t, errors.Is(err, ErrOperatorNotAllowed), "%v", err)
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, result.String())
+		})
+	}
+}
+
+func TestWithOperatorSetUnknownVersion(t *testing.T) {
+	_, err := NewEngine(WithOperatorSet("core-0"))
+	assert.EqualError(t, err, `unknown operator set "core-0"`)
+}
+
+func TestOperatorSetsAreOperators(t *testing.T) {
+	for version, set := range operatorSets {
+		for operator := range set {
+			assert.True(t, operator == "var" || isOperator(operator), "%s in %s is not an operator", operator, version)
+		}
+	}
+
+	assert.Len(t, operatorSets["ext-2026"], len(operators)+1)
+}
+
+func TestOperatorsOfOperatorSet(t *testing.T) {
+	engine, err := NewEngine(WithOperatorSet("core-1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, info := range engine.Operators() {
+		assert.True(t, info.Standard, info.Name)
+	}
+
+	assert.Len(t, engine.Operators(), len(coreOperators))
+}
@@ -0,0 +1,88 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// outputFormat is the way results are written as JSON. Its zero value is
+// the format of json.Encoder: compact, escaping HTML characters and ending
+// with a newline.
+type outputFormat struct {
+	prefix, indent    string
+	keepHTML          bool
+	noTrailingNewline bool
+}
+
+// WithIndent makes the engine write its results indented, every line
+// starting with prefix and followed by copies of indent according to its
+// nesting, as with json.MarshalIndent
+func WithIndent(prefix, indent string) Option {
+	return func(e *Engine) error {
+		e.output.prefix = prefix
+		e.output.indent = indent
+
+		return nil
+	}
+}
+
+// WithoutHTMLEscaping makes the engine write the characters <, > and & of
+// strings as they are, instead of escaping them as \u003c, \u003e and
+// \u0026 for the results to be embedded in HTML
+func WithoutHTMLEscaping() Option {
+	return func(e *Engine) error {
+		e.output.keepHTML = true
+
+		return nil
+	}
+}
+
+// WithoutTrailingNewline makes the engine write its results without the
+// newline json.Encoder ends them with. ApplyRaw never adds one.
+func WithoutTrailingNewline() Option {
+	return func(e *Engine) error {
+		e.output.noTrailingNewline = true
+
+		return nil
+	}
+}
+
+// marshal encodes a result as JSON in the output format of the engine,
+// ending with a newline or not
+func (e *Engine) marshal(output interface{}, newline bool) ([]byte, error) {
+	var buffer bytes.Buffer
+
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetIndent(e.output.prefix, e.output.indent)
+	encoder.SetEscapeHTML(!e.output.keepHTML)
+
+	if err := encoder.Encode(output); err != nil {
+		return nil, err
+	}
+
+	// the newline ending the encoded result doesn't count
+	encoded := buffer.Bytes()
+	if size := len(encoded) - 1; e.maxResultSize > 0 && size > e.maxResultSize {
+		return nil, fmt.Errorf("%w: result of %d bytes, more than %d", ErrLimitExceeded, size, e.maxResultSize)
+	}
+
+	if !newline {
+		encoded = bytes.TrimSuffix(encoded, []byte("\n"))
+	}
+
+	return encoded, nil
+}
+
+// write writes a result as JSON in the output format of the engine
+func (e *Engine) write(result io.Writer, output interface{}) error {
+	encoded, err := e.marshal(output, !e.output.noTrailingNewline)
+	if err != nil {
+		return err
+	}
+
+	_, err = result.Write(encoded)
+
+	return err
+}
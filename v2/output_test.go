@@ -0,0 +1,78 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutputFormat(t *testing.T) {
+	scenarios := map[string]struct {
+		Options  []Option
+		Expected string
+	}{
+		"default":             {nil, "{\"html\":\"\\u003cb\\u003e\\u0026\",\"list\":[1,2]}\n"},
+		"indented":            {[]Option{WithIndent("", "  ")}, "{\n  \"html\": \"\\u003cb\\u003e\\u0026\",\n  \"list\": [\n    1,\n    2\n  ]\n}\n"},
+		"prefixed":            {[]Option{WithIndent("> ", "\t")}, "{\n> \t\"html\": \"\\u003cb\\u003e\\u0026\",\n> \t\"list\": [\n> \t\t1,\n> \t\t2\n> \t]\n> }\n"},
+		"without escaping":    {[]Option{WithoutHTMLEscaping()}, "{\"html\":\"<b>&\",\"list\":[1,2]}\n"},
+		"without newline":     {[]Option{WithoutTrailingNewline()}, "{\"html\":\"\\u003cb\\u003e\\u0026\",\"list\":[1,2]}"},
+		"all options":         {[]Option{WithIndent("", " "), WithoutHTMLEscaping(), WithoutTrailingNewline()}, "{\n \"html\": \"<b>&\",\n \"list\": [\n  1,\n  2\n ]\n}"},
+		"indenting no option": {[]Option{WithIndent("", "")}, "{\"html\":\"\\u003cb\\u003e\\u0026\",\"list\":[1,2]}\n"},
+	}
+
+	rule := `{"merge_objects": [{"var": ""}]}`
+	data := `{"html": "<b>&", "list": [1, 2]}`
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			engine, err := NewEngine(scenario.Options...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var result bytes.Buffer
+
+			err = engine.Apply(strings.NewReader(rule), strings.NewReader(data), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, scenario.Expected, result.String())
+
+			raw, err := engine.ApplyRaw([]byte(rule), []byte(data))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, strings.TrimSuffix(scenario.Expected, "\n"), string(raw))
+		})
+	}
+}
+
+func TestOutputFormatOfOtherMethods(t *testing.T) {
+	engine, err := NewEngine(WithoutHTMLEscaping(), WithoutTrailingNewline())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var result bytes.Buffer
+
+	_, err = engine.ApplyWithTrace(strings.NewReader(`{"cat": ["<", ">"]}`), nil, &result)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, `"<>"`, result.String())
+
+	result.Reset()
+
+	err = engine.ApplyYAML(strings.NewReader(`cat: ["<", ">"]`), nil, &result)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, `"<>"`, result.String())
+}
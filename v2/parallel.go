@@ -0,0 +1,153 @@
+package jsonlogic
+
+import (
+	"fmt"
+	"sync"
+)
+
+// WithParallelism evaluates the predicates of map, filter, all, some and
+// none concurrently for lists of at least minLength elements, using up to
+// workers goroutines shared by all the evaluations of the engine. Results
+// keep the order of the lists. Middlewares, instrumentation and loggers
+// must then be safe for concurrent use. Traced evaluations always run
+// sequentially.
+func WithParallelism(workers, minLength int) Option {
+	return func(e *Engine) error {
+		if workers < 1 {
+			return fmt.Errorf("workers must be positive, got %d", workers)
+		}
+
+		if minLength < 1 {
+			return fmt.Errorf("minLength must be positive, got %d", minLength)
+		}
+
+		e.workers = make(chan struct{}, workers)
+		e.minParallelLength = minLength
+
+		return nil
+	}
+}
+
+// each evaluates rule for every element of list, giving the results in
+// order to yield until it returns false. The elements of keyed lists are
+// the entries of an object. Only decoded lists are split between workers.
+func (ev *evaluator) each(rule interface{}, list DataSource, keyed bool, yield func(value, result interface{}) bool) {
+	if ev.budget != nil {
+		ev.spend(list.Len())
+	}
+
+	decoded, ok := list.(listSource)
+
+	if !ok || ev.engine.workers == nil || ev.tracing || ev.coverage != nil || ev.worker || len(decoded) < ev.engine.minParallelLength {
+		for i := 0; i < list.Len(); i++ {
+			value := element(list, i)
+
+			ev.at(i, value, keyed)
+			if !yield(value, ev.parseValues(rule, value)) {
+				return
+			}
+		}
+
+		return
+	}
+
+	results := ev.parallel(rule, decoded, keyed)
+
+	for i, value := range decoded {
+		if !yield(value, results[i]) {
+			return
+		}
+	}
+}
+
+// parallel evaluates rule for every element of list, splitting it in
+// chunks evaluated by the workers available, and by the calling goroutine
+// when there are none
+func (ev *evaluator) parallel(rule interface{}, list []interface{}, keyed bool) []interface{} {
+	results := make([]interface{}, len(list))
+
+	chunks := cap(ev.engine.workers) + 1
+	size := (len(list) + chunks - 1) / chunks
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		failure interface{}
+		failed  bool
+	)
+
+	// the workers share the time of the evaluation
+	started := ev.time()
+
+	run := func(start, end int) {
+		defer func() {
+			if r := recover(); r != nil {
+				mu.Lock()
+				if !failed {
+					failure, failed = r, true
+				}
+				mu.Unlock()
+			}
+		}()
+
+		// workers have their own state, and don't split their work again
+		worker := &evaluator{
+			engine:     ev.engine,
+			ctx:        ev.ctx,
+			prefetched: ev.prefetched,
+			iterating:  ev.iterating,
+			parents:    ev.parents,
+			worker:     true,
+			references: ev.references,
+			budget:     ev.budget,
+			rule:       ev.rule,
+			expression: ev.expression,
+			origins:    ev.copyOrigins(),
+			started:    started,
+			scopes:     ev.scopes,
+			functions:  ev.functions,
+			calling:    ev.calling,
+		}
+
+		for i := start; i < end; i++ {
+			worker.at(i, list[i], keyed)
+			results[i] = worker.parseValues(rule, list[i])
+		}
+	}
+
+	inline := make([][2]int, 0)
+
+	for start := 0; start < len(list); start += size {
+		end := start + size
+		if end > len(list) {
+			end = len(list)
+		}
+
+		select {
+		case ev.engine.workers <- struct{}{}:
+			wg.Add(1)
+			go func(start, end int) {
+				defer func() {
+					<-ev.engine.workers
+					wg.Done()
+				}()
+
+				run(start, end)
+			}(start, end)
+		default:
+			inline = append(inline, [2]int{start, end})
+		}
+	}
+
+	for _, chunk := range inline {
+		run(chunk[0], chunk[1])
+	}
+
+	wg.Wait()
+
+	if failed {
+		panic(failure)
+	}
+
+	return results
+}
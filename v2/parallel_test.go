@@ -0,0 +1,76 @@
+package jsonlogic
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithParallelism(t *testing.T) {
+	numbers := make([]interface{}, 0, 1000)
+	for i := 0; i < 1000; i++ {
+		numbers = append(numbers, float64(i))
+	}
+
+	data := map[string]interface{}{"numbers": numbers, "limit": float64(500)}
+
+	engine, err := NewEngine(WithParallelism(4, 10))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := map[string]string{
+		"map":        `{"map": [{"var": "numbers"}, {"*": [{"var": ""}, 2]}]}`,
+		"filter":     `{"filter": [{"var": "numbers"}, {"==": [{"%": [{"var": ""}, 7]}, 0]}]}`,
+		"all":        `{"all": [{"var": "numbers"}, {"<": [{"var": ""}, {"var": "limit"}]}]}`,
+		"some":       `{"some": [{"var": "numbers"}, {">": [{"var": ""}, {"var": "limit"}]}]}`,
+		"none":       `{"none": [{"var": "numbers"}, {">": [{"var": ""}, 5000]}]}`,
+		"nested":     `{"map": [{"var": "numbers"}, {"max": {"map": [[1, 2, 3], {"+": [{"var": ""}, 1]}]}}]}`,
+		"small list": `{"map": [[1, 2, 3], {"+": [{"var": ""}, 1]}]}`,
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var rule interface{}
+			if err := json.Unmarshal([]byte(scenario), &rule); err != nil {
+				t.Fatal(err)
+			}
+
+			expected, err := ApplyInterface(rule, data)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			result, err := engine.ApplyInterface(rule, data)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, expected, result)
+		})
+	}
+}
+
+func TestWithParallelismFailures(t *testing.T) {
+	engine, err := NewEngine(WithParallelism(2, 2), WithStrictCasts())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rule := map[string]interface{}{"map": []interface{}{
+		[]interface{}{"1", "2", "x", "4"},
+		map[string]interface{}{"to_number": map[string]interface{}{"var": ""}},
+	}}
+
+	_, err = engine.ApplyInterface(rule, nil)
+	assert.True(t, errors.Is(err, ErrInvalidCast))
+
+	_, err = NewEngine(WithParallelism(0, 10))
+	assert.Error(t, err)
+
+	_, err = NewEngine(WithParallelism(2, 0))
+	assert.Error(t, err)
+}
@@ -0,0 +1,123 @@
+package jsonlogic
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrMissingParam is returned when a template uses a parameter it is not
+// given, and which has no default
+var ErrMissingParam = errors.New("missing parameter")
+
+// Instantiate reads a rule template and writes the rule obtained by
+// replacing its placeholders, {"param": "name"}, by the values of params.
+// Placeholders can have a default, used for the parameters missing from
+// params: {"param": ["name", default]}. Values are inserted as they are
+// encoded in JSON, so objects are read as expressions and parameters can
+// also be sub-rules.
+func Instantiate(rule io.Reader, params map[string]interface{}, result io.Writer) error {
+	var template interface{}
+
+	err := readJSON(rule, &template)
+	if err != nil {
+		return fmt.Errorf("error parsing rule: %w", err)
+	}
+
+	instance, err := InstantiateInterface(template, params)
+	if err != nil {
+		return err
+	}
+
+	encoded, err := encodeCanonical(instance, "")
+	if err != nil {
+		return err
+	}
+
+	_, err = result.Write(encoded)
+
+	return err
+}
+
+// InstantiateInterface is like Instantiate, but works with an already
+// decoded template, which is left untouched
+func InstantiateInterface(rule interface{}, params map[string]interface{}) (interface{}, error) {
+	values := make(map[string]interface{}, len(params))
+	for name, value := range params {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding parameter %q: %w", name, err)
+		}
+
+		var decoded interface{}
+		if err := json.Unmarshal(encoded, &decoded); err != nil {
+			return nil, fmt.Errorf("error encoding parameter %q: %w", name, err)
+		}
+
+		values[name] = decoded
+	}
+
+	return instantiate(rule, values)
+}
+
+func instantiate(rule interface{}, params map[string]interface{}) (interface{}, error) {
+	if isSlice(rule) {
+		list := make([]interface{}, 0, len(rule.([]interface{})))
+		for _, value := range rule.([]interface{}) {
+			instance, err := instantiate(value, params)
+			if err != nil {
+				return nil, err
+			}
+
+			list = append(list, instance)
+		}
+
+		return list, nil
+	}
+
+	if !isMap(rule) {
+		return rule, nil
+	}
+
+	object := rule.(map[string]interface{})
+	if values, ok := object["param"]; ok && len(object) == 1 {
+		return param(values, params)
+	}
+
+	instance := make(map[string]interface{}, len(object))
+	for key, values := range object {
+		value, err := instantiate(values, params)
+		if err != nil {
+			return nil, err
+		}
+
+		instance[key] = value
+	}
+
+	return instance, nil
+}
+
+// param returns the value of a placeholder: {"param": name} or
+// {"param": [name, default]}
+func param(values interface{}, params map[string]interface{}) (interface{}, error) {
+	name := values
+	parsed, hasList := values.([]interface{})
+	if hasList && len(parsed) > 0 {
+		name = parsed[0]
+	}
+
+	if !isString(name) {
+		return nil, fmt.Errorf("parameter names must be strings, got %v", name)
+	}
+
+	if value, ok := params[name.(string)]; ok {
+		return value, nil
+	}
+
+	if hasList && len(parsed) > 1 {
+		return instantiate(parsed[1], params)
+	}
+
+	return nil, fmt.Errorf("%w %q", ErrMissingParam, name)
+}
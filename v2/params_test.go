@@ -0,0 +1,97 @@
+package jsonlogic
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstantiate(t *testing.T) {
+	scenarios := map[string]struct {
+		rule     string
+		params   map[string]interface{}
+		expected string
+	}{
+		"number": {
+			rule:     `{">": [{"var": "total"}, {"param": "threshold"}]}`,
+			params:   map[string]interface{}{"threshold": 100},
+			expected: `{">": [{"var": "total"}, 100]}`,
+		},
+		"list argument": {
+			rule:     `{"in": [{"var": "country"}, {"param": ["countries"]}]}`,
+			params:   map[string]interface{}{"countries": []string{"PT", "BR"}},
+			expected: `{"in": [{"var": "country"}, ["PT", "BR"]]}`,
+		},
+		"default": {
+			rule:     `{"<": [{"var": "age"}, {"param": ["max_age", 65]}]}`,
+			params:   map[string]interface{}{},
+			expected: `{"<": [{"var": "age"}, 65]}`,
+		},
+		"default overridden": {
+			rule:     `{"<": [{"var": "age"}, {"param": ["max_age", 65]}]}`,
+			params:   map[string]interface{}{"max_age": 70},
+			expected: `{"<": [{"var": "age"}, 70]}`,
+		},
+		"sub-rule": {
+			rule:     `{"and": [{"var": "active"}, {"param": "extra"}]}`,
+			params:   map[string]interface{}{"extra": map[string]interface{}{"==": []interface{}{map[string]interface{}{"var": "plan"}, "gold"}}},
+			expected: `{"and": [{"var": "active"}, {"==": [{"var": "plan"}, "gold"]}]}`,
+		},
+		"several placeholders": {
+			rule:     `{"if": [{"param": "condition"}, {"param": "then"}, {"param": "then"}]}`,
+			params:   map[string]interface{}{"condition": true, "then": "yes"},
+			expected: `{"if": [true, "yes", "yes"]}`,
+		},
+		"no placeholders": {
+			rule:     `{"var": "a"}`,
+			expected: `{"var": "a"}`,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var result strings.Builder
+
+			err := Instantiate(strings.NewReader(scenario.rule), scenario.params, &result)
+			assert.NoError(t, err)
+			assert.JSONEq(t, scenario.expected, result.String())
+		})
+	}
+}
+
+func TestInstantiateInterfaceKeepsTemplate(t *testing.T) {
+	template := map[string]interface{}{"==": []interface{}{map[string]interface{}{"var": "a"}, map[string]interface{}{"param": "value"}}}
+
+	first, err := InstantiateInterface(template, map[string]interface{}{"value": 1})
+	assert.NoError(t, err)
+
+	second, err := InstantiateInterface(template, map[string]interface{}{"value": 2})
+	assert.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{"==": []interface{}{map[string]interface{}{"var": "a"}, 1.0}}, first)
+	assert.Equal(t, map[string]interface{}{"==": []interface{}{map[string]interface{}{"var": "a"}, 2.0}}, second)
+	assert.Equal(t, map[string]interface{}{"param": "value"}, template["=="].([]interface{})[1])
+
+	result, err := ApplyInterface(first, map[string]interface{}{"a": 1.0})
+	assert.NoError(t, err)
+	assert.Equal(t, true, result)
+}
+
+func TestInstantiateErrors(t *testing.T) {
+	var result strings.Builder
+
+	err := Instantiate(strings.NewReader(`{"==": [{"param": "missing"}, 1]}`), nil, &result)
+	assert.True(t, errors.Is(err, ErrMissingParam))
+
+	err = Instantiate(strings.NewReader(`{"==": [{"param": 1}, 1]}`), nil, &result)
+	assert.Error(t, err)
+
+	err = Instantiate(strings.NewReader(`{"==": [`), nil, &result)
+	assert.Error(t, err)
+
+	_, err = InstantiateInterface(map[string]interface{}{"param": "f"}, map[string]interface{}{"f": func() {}})
+	assert.Error(t, err)
+}
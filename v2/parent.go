@@ -0,0 +1,58 @@
+package jsonlogic
+
+import (
+	"strings"
+)
+
+// parentPrefix is the prefix of the var paths reading the data the
+// iterations being evaluated are evaluated against
+const parentPrefix = "$parent"
+
+// parentPath splits a var path into the number of $parent prefixes it
+// starts with and the path following them: "$parent.$parent.name" is two
+// levels up, and "name" there.
+func parentPath(path string) (int, string) {
+	levels := 0
+
+	for path == parentPrefix || strings.HasPrefix(path, parentPrefix+".") {
+		levels++
+		path = strings.TrimPrefix(strings.TrimPrefix(path, parentPrefix), ".")
+	}
+
+	return levels, path
+}
+
+// isParentPath tells if a var path starts with the $parent prefix
+func isParentPath(path string) bool {
+	levels, _ := parentPath(path)
+
+	return levels > 0
+}
+
+// parent reads the var paths starting with the $parent prefix, telling if
+// values is such a path. In the predicate of an iteration, $parent is the
+// data the iteration is evaluated against, which is the element of the
+// iteration around it when they are nested: {"filter": [{"var": "orders"},
+// {"some": [{"var": ".items"}, {"==": [{"var": ".sku"}, {"var":
+// "$parent.sku"}]}]}]} compares the skus of the items to the sku of their
+// order, and $parent.$parent reads the data of the outer filter. Paths
+// after the prefixes are dot paths, and they read null out of iterations.
+func (ev *evaluator) parent(values interface{}) (interface{}, bool) {
+	path, fallback := varArgs(values)
+
+	levels, path := parentPath(path)
+	if levels == 0 {
+		return nil, false
+	}
+
+	if levels > len(ev.parents) {
+		return fallback, true
+	}
+
+	value := getVar(path, ev.parents[len(ev.parents)-levels], DotPaths)
+	if value == nil {
+		return fallback, true
+	}
+
+	return value, true
+}
@@ -0,0 +1,128 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParent(t *testing.T) {
+	data := `{
+		"sku": "root",
+		"bonus": 10,
+		"orders": [
+			{"id": 1, "sku": "a", "items": [{"sku": "a"}, {"sku": "b"}]},
+			{"id": 2, "sku": "c", "items": [{"sku": "a"}]}
+		],
+		"items": [1, 2, 3]
+	}`
+
+	scenarios := map[string]struct {
+		Rule     string
+		Expected string
+	}{
+		"element of the enclosing iteration": {
+			Rule:     `{"filter": [{"var": "orders"}, {"some": [{"var": ".items"}, {"==": [{"var": ".sku"}, {"var": "$parent.sku"}]}]}]}`,
+			Expected: `[{"id": 1, "sku": "a", "items": [{"sku": "a"}, {"sku": "b"}]}]`,
+		},
+		"data of the outer iteration": {
+			Rule:     `{"map": [{"var": "orders"}, {"map": [{"var": ".items"}, {"cat": [{"var": "$parent.$parent.sku"}, "/", {"var": "$parent.id"}, "/", {"var": ".sku"}]}]}]}`,
+			Expected: `[["root/1/a", "root/1/b"], ["root/2/a"]]`,
+		},
+		"data of a single iteration": {
+			Rule:     `{"map": [{"var": "items"}, {"+": [{"var": ""}, {"var": "$parent.bonus"}]}]}`,
+			Expected: `[11, 12, 13]`,
+		},
+		"whole data": {
+			Rule:     `{"map": [{"var": "orders"}, {"all": [{"var": ".items"}, {"var": "$parent"}]}]}`,
+			Expected: `[true, true]`,
+		},
+		"reduce": {
+			Rule:     `{"reduce": [{"var": "items"}, {"+": [{"var": "accumulator"}, {"var": "current"}, {"var": "$parent.bonus"}]}, 0]}`,
+			Expected: `36`,
+		},
+		"template": {
+			Rule:     `{"map": [{"var": "orders"}, {"map": [{"var": ".items"}, {"template": ["{$parent.id}:{sku}"]}]}]}`,
+			Expected: `[["1:a", "1:b"], ["2:a"]]`,
+		},
+		"out of iterations": {
+			Rule:     `{"cat": [{"var": ["$parent.sku", "none"]}, {"var": ["$parent", "!"]}]}`,
+			Expected: `"none!"`,
+		},
+		"more levels than iterations": {
+			Rule:     `{"map": [{"var": "items"}, {"var": ["$parent.$parent.bonus", 0]}]}`,
+			Expected: `[0, 0, 0]`,
+		},
+		"missing paths": {
+			Rule:     `{"map": [{"var": "items"}, {"var": ["$parent.nope", "none"]}]}`,
+			Expected: `["none", "none", "none"]`,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var result bytes.Buffer
+
+			err := Apply(strings.NewReader(scenario.Rule), strings.NewReader(data), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, result.String())
+		})
+	}
+}
+
+func TestParentWithParallelism(t *testing.T) {
+	engine, err := NewEngine(WithParallelism(4, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rule := `{"map": [{"var": "groups"}, {"map": [{"var": ".values"}, {"*": [{"var": ""}, {"var": "$parent.factor"}]}]}]}`
+	data := `{"groups": [{"factor": 2, "values": [1, 2, 3, 4]}, {"factor": 10, "values": [1, 2, 3, 4]}]}`
+
+	var result bytes.Buffer
+
+	err = engine.Apply(strings.NewReader(rule), strings.NewReader(data), &result)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.JSONEq(t, `[[2, 4, 6, 8], [10, 20, 30, 40]]`, result.String())
+}
+
+func TestParentAnalysis(t *testing.T) {
+	rule := `{"some": [{"var": "items"}, {"==": [{"var": ""}, {"var": "$parent.wanted"}]}]}`
+
+	compiled, err := Compile(strings.NewReader(rule))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.False(t, compiled.complete)
+
+	result, err := ApplyBool(compiled, []byte(`{"items": [1, 2, 3], "wanted": 2}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, result)
+
+	compiled, err = Compile(strings.NewReader(`{"reduce": [{"var": "items"}, {"+": [{"var": "current"}, {"var": "$parent.bonus"}]}, 0]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.False(t, compiled.complete)
+
+	warnings, err := Lint(strings.NewReader(rule))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Empty(t, warnings)
+}
@@ -0,0 +1,136 @@
+package jsonlogic
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PathSyntax is the way the paths of var, missing, missing_some and has
+// are written
+type PathSyntax int
+
+const (
+	// AutoPaths reads the paths starting with a slash as JSON pointers,
+	// and the others as dot paths. It is the default.
+	AutoPaths PathSyntax = iota
+
+	// DotPaths reads paths as property names and indexes separated by
+	// dots: "items.0.name". Names containing dots can't be reached.
+	DotPaths
+
+	// PointerPaths reads paths as JSON pointers, as defined by RFC 6901:
+	// "/items/0/name", where "~1" stands for "/" and "~0" for "~". The
+	// empty path is the whole data; other paths not starting with a slash
+	// are never found.
+	PointerPaths
+)
+
+// WithPathSyntax chooses how var, missing, missing_some and has read
+// their paths. Paths relative to the element of an iteration, starting with a
+// dot, are always dot paths.
+func WithPathSyntax(syntax PathSyntax) Option {
+	return func(e *Engine) error {
+		if syntax < AutoPaths || syntax > PointerPaths {
+			return fmt.Errorf("unknown path syntax %d", syntax)
+		}
+
+		e.pathSyntax = syntax
+
+		return nil
+	}
+}
+
+// VarConvention is the way var tells the element of an iteration from the
+// data around it in the predicates of iterations
+type VarConvention int
+
+const (
+	// DottedElement reads the paths starting with a dot from the element,
+	// and the others from the data around the iteration, then from the
+	// element when they are missing there: {"var": ".age"} is the age of
+	// the element. It is the default.
+	DottedElement VarConvention = iota
+
+	// PlainElement reads every path from the element, like json-logic-js:
+	// {"var": "age"} is the age of the element, and the data around the
+	// iteration is only read with $parent. Paths starting with a dot read
+	// the element too, so rules written for either convention run.
+	PlainElement
+)
+
+// WithVarConvention chooses how var reads paths in the predicates of
+// iterations, so rules written for json-logic-js, which only see the
+// element there, run unmodified with PlainElement.
+func WithVarConvention(convention VarConvention) Option {
+	return func(e *Engine) error {
+		if convention < DottedElement || convention > PlainElement {
+			return fmt.Errorf("unknown var convention %d", convention)
+		}
+
+		e.varConvention = convention
+
+		return nil
+	}
+}
+
+// isPointer tells if a path is a JSON pointer
+func isPointer(path string, syntax PathSyntax) bool {
+	switch syntax {
+	case DotPaths:
+		return false
+	case PointerPaths:
+		return !strings.HasPrefix(path, ".")
+	}
+
+	return strings.HasPrefix(path, "/")
+}
+
+// splitPath returns the properties and indexes a path goes through, and
+// false when it isn't valid
+func splitPath(path string, syntax PathSyntax) ([]string, bool) {
+	return appendPath(nil, path, syntax)
+}
+
+// appendPath is like splitPath, appending the parts of the path to parts
+func appendPath(parts []string, path string, syntax PathSyntax) ([]string, bool) {
+	pointer := isPointer(path, syntax)
+	separator := "."
+
+	if pointer {
+		if path == "" {
+			return parts, true
+		}
+
+		if !strings.HasPrefix(path, "/") {
+			return nil, false
+		}
+
+		path, separator = path[1:], "/"
+	}
+
+	if parts == nil {
+		parts = make([]string, 0, strings.Count(path, separator)+1)
+	}
+
+	for {
+		i := strings.Index(path, separator)
+
+		part := path
+		if i >= 0 {
+			part = path[:i]
+		}
+
+		// empty tokens of pointers are properties, dots are collapsed
+		if pointer {
+			parts = append(parts, strings.Replace(strings.Replace(part, "~1", "/", -1), "~0", "~", -1))
+		} else if part != "" {
+			parts = append(parts, part)
+		}
+
+		if i < 0 {
+			return parts, true
+		}
+
+		path = path[i+1:]
+	}
+}
@@ -0,0 +1,144 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPathSyntax(t *testing.T) {
+	data := `{"items": [{"name": "a"}, {"name": "b"}], "a.b": {"c/d": 1, "~e": 2}, "/x": 3, "a": {"b": 4}}`
+
+	scenarios := map[string]struct {
+		Syntax   PathSyntax
+		Rule     string
+		Expected string
+	}{
+		"auto pointer":               {AutoPaths, `{"var": "/items/1/name"}`, `"b"`},
+		"auto dots":                  {AutoPaths, `{"var": "a.b"}`, `4`},
+		"escaped tokens":             {AutoPaths, `{"merge": [{"var": "/a.b/c~1d"}, {"var": "/a.b/~0e"}]}`, `[1, 2]`},
+		"negative index":             {AutoPaths, `{"var": "/items/-1/name"}`, `"b"`},
+		"missing pointer":            {AutoPaths, `{"var": ["/items/2/name", "none"]}`, `"none"`},
+		"missing":                    {AutoPaths, `{"missing": ["/a.b/c~1d", "/a.b/d"]}`, `["/a.b/d"]`},
+		"dots":                       {DotPaths, `{"merge": [{"var": "/x"}, {"var": "a.b"}]}`, `[3, 4]`},
+		"pointers":                   {PointerPaths, `{"var": "/a/b"}`, `4`},
+		"dots with pointers":         {PointerPaths, `{"var": ["a.b", "none"]}`, `"none"`},
+		"whole data with pointers":   {PointerPaths, `{"var": ""}`, data},
+		"pointers in iterations":     {PointerPaths, `{"map": [{"var": "/items"}, {"var": ".name"}]}`, `["a", "b"]`},
+		"missing_some with pointers": {PointerPaths, `{"missing_some": [1, ["/a", "/y"]]}`, `[]`},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			engine, err := NewEngine(WithPathSyntax(scenario.Syntax))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var result bytes.Buffer
+
+			err = engine.Apply(strings.NewReader(scenario.Rule), strings.NewReader(data), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, result.String())
+		})
+	}
+}
+
+func TestPathSyntaxWithCompiledRules(t *testing.T) {
+	rule, err := Compile(strings.NewReader(`{"==": [{"var": "/a.b/c"}, 1]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := []byte(`{"a.b": {"c": 1}, "a": {"b": {"c": 2}}}`)
+
+	result, err := ApplyBool(rule, data)
+	assert.NoError(t, err)
+	assert.True(t, result)
+
+	engine, err := NewEngine(WithPathSyntax(DotPaths))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err = engine.ApplyBool(rule, data)
+	assert.NoError(t, err)
+	assert.False(t, result)
+}
+
+func TestWithPathSyntax(t *testing.T) {
+	_, err := NewEngine(WithPathSyntax(PathSyntax(7)))
+	assert.Error(t, err)
+}
+
+func TestVarConvention(t *testing.T) {
+	data := `{"age": 99, "minimum": 18, "users": [{"name": "a", "age": 20}, {"name": "b", "age": 12}]}`
+
+	scenarios := map[string]struct {
+		Convention VarConvention
+		Rule       string
+		Expected   string
+	}{
+		"dotted element":                {DottedElement, `{"filter": [{"var": "users"}, {">=": [{"var": ".age"}, 18]}]}`, `[{"name": "a", "age": 20}]`},
+		"dotted reads data first":       {DottedElement, `{"map": [{"var": "users"}, {"var": "age"}]}`, `[99, 99]`},
+		"dotted falls back":             {DottedElement, `{"map": [{"var": "users"}, {"var": "name"}]}`, `["a", "b"]`},
+		"plain element":                 {PlainElement, `{"map": [{"var": "users"}, {"var": "age"}]}`, `[20, 12]`},
+		"plain with dots":               {PlainElement, `{"filter": [{"var": "users"}, {">=": [{"var": ".age"}, 18]}]}`, `[{"name": "a", "age": 20}]`},
+		"plain reads data with $parent": {PlainElement, `{"filter": [{"var": "users"}, {">=": [{"var": "age"}, {"var": "$parent.minimum"}]}]}`, `[{"name": "a", "age": 20}]`},
+		"plain misses data":             {PlainElement, `{"map": [{"var": "users"}, {"var": ["minimum", "none"]}]}`, `["none", "none"]`},
+		"plain out of iterations":       {PlainElement, `{"var": "age"}`, `99`},
+		"plain in reduce":               {PlainElement, `{"reduce": [{"var": "users"}, {"+": [{"var": "accumulator"}, {"var": "current.age"}]}, 0]}`, `32`},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			engine, err := NewEngine(WithVarConvention(scenario.Convention))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var result bytes.Buffer
+
+			err = engine.Apply(strings.NewReader(scenario.Rule), strings.NewReader(data), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, result.String())
+		})
+	}
+}
+
+func TestVarConventionWithResolver(t *testing.T) {
+	engine, err := NewEngine(
+		WithVarConvention(PlainElement),
+		WithVarResolver(VarResolverFunc(func(path string) (interface{}, bool) {
+			return "resolved", true
+		})),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var result bytes.Buffer
+
+	rule := `{"merge": [{"var": "name"}, {"map": [{"var": "items"}, {"var": ["name", "none"]}]}]}`
+
+	err = engine.Apply(strings.NewReader(rule), strings.NewReader(`{"items": [1, 2]}`), &result)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.JSONEq(t, `["resolved", "none", "none"]`, result.String())
+}
+
+func TestWithVarConvention(t *testing.T) {
+	_, err := NewEngine(WithVarConvention(VarConvention(7)))
+	assert.Error(t, err)
+}
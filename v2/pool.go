@@ -0,0 +1,72 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"sync"
+)
+
+// maxPooledBuffer bounds the size of the buffers kept for reuse, so a few
+// big documents don't pin their memory
+const maxPooledBuffer = 64 << 10
+
+var buffers = sync.Pool{
+	New: func() interface{} {
+		return new(bytes.Buffer)
+	},
+}
+
+var evaluators = sync.Pool{
+	New: func() interface{} {
+		return new(evaluator)
+	},
+}
+
+func getBuffer() *bytes.Buffer {
+	return buffers.Get().(*bytes.Buffer)
+}
+
+func putBuffer(b *bytes.Buffer) {
+	if b.Cap() > maxPooledBuffer {
+		return
+	}
+
+	b.Reset()
+	buffers.Put(b)
+}
+
+// acquire returns an evaluator from the pool, to be given back with
+// release once the evaluation ends. Traced evaluations, whose trace
+// outlives them, use evaluator instead.
+func (e *Engine) acquire() *evaluator {
+	ev := evaluators.Get().(*evaluator)
+	ev.engine = e
+
+	return ev
+}
+
+func release(ev *evaluator) {
+	*ev = evaluator{}
+	evaluators.Put(ev)
+}
+
+// readJSON decodes the first JSON value of r into v, reading it through a
+// pooled buffer instead of the buffer of a new json.Decoder
+func readJSON(r io.Reader, v *interface{}) error {
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	if _, err := buf.ReadFrom(r); err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(buf.Bytes(), v); err != nil {
+		// like a decoder, ignore what follows the first value
+		*v = nil
+
+		return json.NewDecoder(bytes.NewReader(buf.Bytes())).Decode(v)
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadJSON(t *testing.T) {
+	var value interface{}
+
+	err := readJSON(strings.NewReader(`{"a": [1, "b"]}`), &value)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"a": []interface{}{float64(1), "b"}}, value)
+
+	// like json.Decoder, only the first value is read
+	err = readJSON(strings.NewReader(`{"a": 1} {"b": 2}`), &value)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"a": float64(1)}, value)
+
+	err = readJSON(strings.NewReader(``), &value)
+	assert.Equal(t, io.EOF, err)
+
+	err = readJSON(strings.NewReader(`{"a": `), &value)
+	assert.Error(t, err)
+}
+
+func TestPooledEvaluatorsAreReset(t *testing.T) {
+	rule := `{"filter": [{"var": "list"}, {">": [{"var": ""}, {"min": [3, 4]}]}]}`
+
+	for i := 0; i < 3; i++ {
+		var result bytes.Buffer
+
+		err := Apply(strings.NewReader(rule), strings.NewReader(`{"list": [1, 5, 9]}`), &result)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.JSONEq(t, `[5, 9]`, result.String())
+	}
+
+	ev := defaultEngine.acquire()
+	assert.Nil(t, ev.memo)
+	assert.Equal(t, 0, ev.iterating)
+	release(ev)
+}
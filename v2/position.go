@@ -0,0 +1,51 @@
+package jsonlogic
+
+const (
+	// indexName and keyName are the var paths reading the position of the
+	// element the innermost iteration is evaluating
+	indexName = "$index"
+	keyName   = "$key"
+)
+
+// isPositionPath tells if a var path reads the position of an element
+func isPositionPath(path string) bool {
+	return path == indexName || path == keyName
+}
+
+// at sets the position of the element of list an iteration evaluates. The
+// elements of keyed lists are the entries of an object.
+func (ev *evaluator) at(index int, element interface{}, keyed bool) {
+	ev.index, ev.key = index, nil
+
+	if keyed {
+		ev.key = element.(map[string]interface{})["key"]
+	}
+}
+
+// position reads the $index and $key var paths, telling if values is one
+// of them. In the predicate of an iteration, $index is the position of the
+// element, counted from 0: {"all": [{"var": "items"}, {"or": [{">=":
+// [{"var": "$index"}, 3]}, {"var": ".approved"}]}]} tells if the first
+// three items are approved. $key is the name of the entry in iterations
+// over objects. Out of iterations, and for $key in iterations over lists,
+// they are null.
+func (ev *evaluator) position(values interface{}) (interface{}, bool) {
+	path, fallback := varArgs(values)
+	if !isPositionPath(path) {
+		return nil, false
+	}
+
+	if len(ev.parents) == 0 {
+		return fallback, true
+	}
+
+	if path == indexName {
+		return float64(ev.index), true
+	}
+
+	if ev.key == nil {
+		return fallback, true
+	}
+
+	return ev.key, true
+}
@@ -0,0 +1,109 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPosition(t *testing.T) {
+	data := `{
+		"items": [
+			{"approved": true},
+			{"approved": true},
+			{"approved": true},
+			{"approved": false}
+		],
+		"numbers": [10, 20, 30],
+		"limits": {"a": 1, "b": 5},
+		"groups": [[1, 2], [3]]
+	}`
+
+	scenarios := map[string]struct {
+		Rule     string
+		Expected string
+	}{
+		"first elements": {
+			Rule:     `{"all": [{"var": "items"}, {"or": [{">=": [{"var": "$index"}, 3]}, {"var": ".approved"}]}]}`,
+			Expected: `true`,
+		},
+		"map": {
+			Rule:     `{"map": [{"var": "numbers"}, {"+": [{"var": ""}, {"var": "$index"}]}]}`,
+			Expected: `[10, 21, 32]`,
+		},
+		"filter": {
+			Rule:     `{"filter": [{"var": "numbers"}, {"!=": [{"var": "$index"}, 1]}]}`,
+			Expected: `[10, 30]`,
+		},
+		"some": {
+			Rule:     `{"some": [{"var": "items"}, {"and": [{"<": [{"var": "$index"}, 3]}, {"!": {"var": ".approved"}}]}]}`,
+			Expected: `false`,
+		},
+		"none": {
+			Rule:     `{"none": [{"var": "numbers"}, {">": [{"var": "$index"}, 2]}]}`,
+			Expected: `true`,
+		},
+		"reduce": {
+			Rule:     `{"reduce": [{"var": "numbers"}, {"+": [{"var": "accumulator"}, {"*": [{"var": "current"}, {"var": "$index"}]}]}, 0]}`,
+			Expected: `80`,
+		},
+		"keys of objects": {
+			Rule:     `{"map_obj": [{"var": "limits"}, {"cat": [{"var": "$key"}, {"var": "$index"}]}]}`,
+			Expected: `{"a": "a0", "b": "b1"}`,
+		},
+		"keys in some": {
+			Rule:     `{"some": [{"var": "limits"}, {"==": [{"var": "$key"}, "b"]}]}`,
+			Expected: `true`,
+		},
+		"keys of lists": {
+			Rule:     `{"map": [{"var": "numbers"}, {"var": ["$key", "none"]}]}`,
+			Expected: `["none", "none", "none"]`,
+		},
+		"innermost iteration": {
+			Rule:     `{"map": [{"var": "groups"}, {"merge": [{"map": [{"var": ""}, {"var": "$index"}]}, {"var": "$index"}]}]}`,
+			Expected: `[[0, 1, 0], [0, 1]]`,
+		},
+		"out of iterations": {
+			Rule:     `{"cat": [{"var": ["$index", "none"]}, {"var": ["$key", "!"]}]}`,
+			Expected: `"none!"`,
+		},
+		"template": {
+			Rule:     `{"map": [{"var": "numbers"}, {"template": ["{$index}:{1}", {"var": ""}]}]}`,
+			Expected: `["0:10", "1:20", "2:30"]`,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var result bytes.Buffer
+
+			err := Apply(strings.NewReader(scenario.Rule), strings.NewReader(data), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, result.String())
+		})
+	}
+}
+
+func TestPositionWithParallelism(t *testing.T) {
+	engine, err := NewEngine(WithParallelism(4, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rule := `{"map": [{"var": "values"}, {"*": [{"var": ""}, {"var": "$index"}]}]}`
+
+	var result bytes.Buffer
+
+	err = engine.Apply(strings.NewReader(rule), strings.NewReader(`{"values": [1, 1, 1, 1, 1, 1, 1, 1]}`), &result)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.JSONEq(t, `[0, 1, 2, 3, 4, 5, 6, 7]`, result.String())
+}
@@ -0,0 +1,174 @@
+package jsonlogic
+
+import (
+	"strings"
+)
+
+// opcode is the operation of an instruction of a program
+type opcode uint8
+
+const (
+	// opConst pushes value
+	opConst opcode = iota
+
+	// opVar pushes the value var gives for the path value, split into
+	// parts
+	opVar
+
+	// opCall calls operator with the argc values on top of the stack in a
+	// list when list is set, with the value on top of the stack when
+	// single is, and with value otherwise, and pushes its result
+	opCall
+
+	// opApply evaluates value, an expression the program doesn't compile,
+	// like the lazy operators evaluating their own arguments, and pushes
+	// its result
+	opApply
+)
+
+type instruction struct {
+	op       opcode
+	operator string
+	argc     int
+	list     bool
+	single   bool
+	value    interface{}
+	parts    []string
+}
+
+// program is a rule flattened into instructions run on a stack, in the
+// order in which evaluating the rule evaluates its expressions, which
+// saves reading the operators and sorting their arguments at every
+// evaluation
+type program []instruction
+
+// compileProgram compiles a rule into a program, nil when the rule isn't
+// an expression
+func compileProgram(rule interface{}) program {
+	expression, ok := rule.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	var p program
+	p.expression(expression)
+
+	return p
+}
+
+func (p *program) expression(expression map[string]interface{}) {
+	operator, values, ok := soleOperator(expression)
+	if !ok || isLazyOperator(operator) {
+		*p = append(*p, instruction{op: opApply, value: expression})
+
+		return
+	}
+
+	switch args := values.(type) {
+	case map[string]interface{}:
+		p.expression(args)
+		*p = append(*p, instruction{op: opCall, operator: operator, single: true})
+
+		return
+	case []interface{}:
+		for _, arg := range args {
+			if nested, ok := arg.(map[string]interface{}); ok {
+				p.expression(nested)
+			} else {
+				*p = append(*p, instruction{op: opConst, value: arg})
+			}
+		}
+
+		*p = append(*p, instruction{op: opCall, operator: operator, argc: len(args), list: true})
+
+		return
+	}
+
+	if values != nil && !isPrimitive(values) {
+		*p = append(*p, instruction{op: opApply, value: expression})
+
+		return
+	}
+
+	// paths of pointers and of the variables starting with $ are left to
+	// var
+	if path, ok := values.(string); ok && operator == "var" && !strings.HasPrefix(path, "/") && !strings.HasPrefix(path, "$") {
+		parts, _ := splitPath(path, DotPaths)
+		*p = append(*p, instruction{op: opVar, operator: operator, value: values, parts: parts})
+
+		return
+	}
+
+	*p = append(*p, instruction{op: opCall, operator: operator, value: values})
+}
+
+// soleOperator returns the operator of an expression and its arguments,
+// false when the expression doesn't have exactly one operator
+func soleOperator(expression map[string]interface{}) (string, interface{}, bool) {
+	if len(expression) != 1 {
+		return "", nil, false
+	}
+
+	for operator, values := range expression {
+		return operator, values, true
+	}
+
+	return "", nil, false
+}
+
+// compiled tells if the programs of rules can be run instead of the rules,
+// which is when nothing needs to see the expressions being evaluated, and
+// no resolver needs branches to be evaluated only when reached
+func (ev *evaluator) compiled() bool {
+	return ev.budget == nil && ev.engine.instrumentation == nil && !ev.tracing && ev.coverage == nil && ev.keys == nil && ev.engine.resolver == nil
+}
+
+// exec runs a program against data, giving the result evaluating the rule
+// it was compiled from gives
+func (ev *evaluator) exec(p program, data interface{}) interface{} {
+	var buffer [16]interface{}
+	stack := buffer[:0]
+
+	// var reads data directly, unless something else may give the value
+	direct := ev.engine.resolver == nil && len(ev.engine.middlewares) == 0 && ev.engine.pathSyntax != PointerPaths
+
+	for i := range p {
+		in := &p[i]
+
+		switch in.op {
+		case opConst:
+			stack = append(stack, in.value)
+		case opApply:
+			stack = append(stack, materialize(ev.apply(in.value, data)))
+		case opVar:
+			if !direct {
+				stack = append(stack, materialize(ev.invoke(in.operator, in.value, data)))
+
+				continue
+			}
+
+			ev.allow(in.operator)
+
+			value, _ := lookup(data, in.parts)
+			stack = append(stack, materialize(ev.finite(in.operator, value)))
+		case opCall:
+			values := in.value
+
+			switch {
+			case in.single:
+				values = listArgument(in.operator, stack[len(stack)-1])
+				stack = stack[:len(stack)-1]
+			case in.list:
+				// operators may keep their arguments, which get their own list
+				box, args := ev.arena.boxed(in.argc)
+				copy(args, stack[len(stack)-in.argc:])
+				stack = stack[:len(stack)-in.argc]
+				values = box
+			}
+
+			stack = append(stack, materialize(ev.invoke(in.operator, values, data)))
+		}
+	}
+
+	return stack[0]
+}
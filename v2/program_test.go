@@ -0,0 +1,153 @@
+package jsonlogic
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgram(t *testing.T) {
+	data := `{"user": {"age": 30, "name": "ana", "tags": ["a", "b"]}, "items": [{"price": 5}, {"price": 12}], "a/b": 1, "zero": 0, "lists": [[1], [2]]}`
+
+	rules := map[string]string{
+		"comparisons":     `{"and": [{">=": [{"var": "user.age"}, 18]}, {"==": [{"var": "user.name"}, "ana"]}]}`,
+		"arithmetic":      `{"+": [{"*": [{"var": "user.age"}, 2]}, {"-": [10, {"var": "items.1.price"}]}, 1.5]}`,
+		"missing vars":    `{"cat": [{"var": "user.email"}, {"var": ["user.email", "none"]}, {"var": "user.tags.-1"}]}`,
+		"whole data":      `{"var": ""}`,
+		"single argument": `{"!": {"var": "zero"}}`,
+		"no arguments":    `{"merge": []}`,
+		"literal lists":   `{"merge": [[1, {"var": "user.age"}], [{"var": "user.name"}]]}`,
+		"lazy operators":  `{"if": [{"some": [{"var": "items"}, {">": [{"var": ".price"}, 10]}]}, {"map": [{"var": "items"}, {"var": ".price"}]}, "none"]}`,
+		"let":             `{"let": {"x": {"var": "user.age"}, "in": {"+": [{"var": "x"}, 1]}}}`,
+		"pointers":        `{"+": [{"var": "/a~1b"}, {"var": "/items/0/price"}]}`,
+		"positions":       `{"var": ["$index", "none"]}`,
+		"empty rule":      `{}`,
+		"several keys":    `{"==": [1, 1], "!=": [1, 1]}`,
+		"errors":          `{"/": [1, {"var": "zero"}]}`,
+		"list arguments":  `{"reverse": {"var": "lists"}}`,
+	}
+
+	engines := map[string][]Option{
+		"default":   nil,
+		"pointers":  {WithPathSyntax(PointerPaths)},
+		"strict":    {WithCoercion(StrictCoercion), WithDivisionPolicy(DivideToError)},
+		"budget":    {WithStepBudget(100)},
+		"arena":     {WithArena()},
+		"operators": {WithOperatorSet("core-1")},
+		"middleware": {WithMiddlewares(func(operator string, args []interface{}, next Evaluator) (interface{}, error) {
+			if operator == "var" {
+				return "intercepted", nil
+			}
+
+			return next(operator, args)
+		})},
+	}
+
+	var _data interface{}
+	if err := readJSON(strings.NewReader(data), &_data); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, rule := range rules {
+		for engine, options := range engines {
+			t.Run(fmt.Sprintf("SCENARIO:%s with %s", name, engine), func(t *testing.T) {
+				e, err := NewEngine(options...)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				compiled, err := Compile(strings.NewReader(rule))
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				expected := outcome(func() (interface{}, error) {
+					return e.evaluate(compiled.tree, _data)
+				})
+
+				// several keys are evaluated in no given order
+				if name == "several keys" {
+					assert.Equal(t, program{{op: opApply, value: compiled.tree}}, compiled.program)
+
+					return
+				}
+
+				a := e.newArena()
+				defer a.release()
+
+				assert.Equal(t, expected, outcome(func() (interface{}, error) {
+					return e.evaluateRule(context.Background(), a, compiled, _data)
+				}))
+			})
+		}
+	}
+}
+
+// outcome returns the result of an evaluation, its error or the panic it
+// raised, the way they are compared
+func outcome(evaluate func() (interface{}, error)) (result []interface{}) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = []interface{}{"panic", fmt.Sprint(r)}
+		}
+	}()
+
+	value, err := evaluate()
+
+	return []interface{}{value, err}
+}
+
+func TestCompileProgram(t *testing.T) {
+	var rule interface{}
+	if err := readJSON(strings.NewReader(`{"if": [{"==": [{"var": "a.b"}, 1]}, {"!": {"var": "c"}}, {"map": [[1], {"var": ""}]}]}`), &rule); err != nil {
+		t.Fatal(err)
+	}
+
+	args := rule.(map[string]interface{})["if"].([]interface{})
+
+	assert.Equal(t, program{
+		{op: opVar, operator: "var", value: "a.b", parts: []string{"a", "b"}},
+		{op: opConst, value: 1.0},
+		{op: opCall, operator: "==", argc: 2, list: true},
+		{op: opVar, operator: "var", value: "c", parts: []string{"c"}},
+		{op: opCall, operator: "!", single: true},
+		{op: opApply, value: args[2]},
+		{op: opCall, operator: "if", argc: 3, list: true},
+	}, compileProgram(rule))
+
+	assert.Nil(t, compileProgram([]interface{}{1.0}))
+}
+
+func TestProgramAllocations(t *testing.T) {
+	rule, err := Compile(strings.NewReader(`{"and": [{">=": [{"var": "user.age"}, 18]}, {"in": [{"var": "user.country"}, ["PT", "ES"]]}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var data interface{}
+	if err := readJSON(strings.NewReader(`{"user": {"age": 30, "country": "PT"}}`), &data); err != nil {
+		t.Fatal(err)
+	}
+
+	engine, err := NewEngine(WithArena())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the arena gives the lists of arguments, in the interfaces operators
+	// get them in, and the path of vars are split when compiling
+	a := new(arena)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		defer a.reset()
+
+		if result, err := engine.evaluateRule(context.Background(), a, rule, data); err != nil || result != true {
+			t.Fatal(result, err)
+		}
+	})
+
+	assert.Equal(t, 0.0, allocs)
+}
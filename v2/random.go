@@ -0,0 +1,53 @@
+package jsonlogic
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+)
+
+// WithRandom replaces the source of the random numbers of random and
+// sample_pct, which must return numbers in [0, 1) like rand.Float64, and be
+// safe for concurrent use when the engine is. It's mostly useful to write
+// deterministic tests.
+func WithRandom(random func() float64) Option {
+	return func(e *Engine) error {
+		if random == nil {
+			return fmt.Errorf("random source must not be nil")
+		}
+
+		e.random = random
+
+		return nil
+	}
+}
+
+// WithRandomSeed makes random and sample_pct draw their numbers from a
+// source seeded with seed, so the numbers of an engine are the same from
+// one run to the other when it evaluates rules in the same order
+func WithRandomSeed(seed int64) Option {
+	return func(e *Engine) error {
+		var mutex sync.Mutex
+		source := rand.New(rand.NewSource(seed))
+
+		e.random = func() float64 {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			return source.Float64()
+		}
+
+		return nil
+	}
+}
+
+// sample tells if a draw falls within a percentage of cases:
+// {"sample_pct": 10} is true for about 10% of the evaluations
+func (ev *evaluator) sample(values interface{}) interface{} {
+	parsed := toSlice(values)
+	if len(parsed) != 1 || !isNumber(parsed[0]) {
+		return nil
+	}
+
+	return ev.engine.random()*100 < toNumber(parsed[0])
+}
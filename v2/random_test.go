@@ -0,0 +1,100 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func fixedRandom(value float64) func() float64 {
+	return func() float64 {
+		return value
+	}
+}
+
+func TestRandomOperators(t *testing.T) {
+	scenarios := map[string]struct {
+		Random   float64
+		Rule     string
+		Expected string
+	}{
+		"random":                {0.25, `{"random": []}`, `0.25`},
+		"in the sample":         {0.2, `{"sample_pct": 25}`, `true`},
+		"out of the sample":     {0.3, `{"sample_pct": 25}`, `false`},
+		"at the limit":          {0.25, `{"sample_pct": 25}`, `false`},
+		"nobody":                {0, `{"sample_pct": 0}`, `false`},
+		"everybody":             {0.999, `{"sample_pct": 100}`, `true`},
+		"percentage from data":  {0.4, `{"sample_pct": {"var": "rollout"}}`, `true`},
+		"not a percentage":      {0.1, `{"sample_pct": "ten"}`, `null`},
+		"splitting traffic":     {0.7, `{"if": [{"<": [{"random": []}, 0.5]}, "A", "B"]}`, `"B"`},
+		"drawn in an iteration": {0.1, `{"all": [{"var": "items"}, {"sample_pct": 50}]}`, `true`},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			engine, err := NewEngine(WithRandom(fixedRandom(scenario.Random)))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var result bytes.Buffer
+
+			err = engine.Apply(strings.NewReader(scenario.Rule), strings.NewReader(`{"rollout": 50, "items": [1, 2]}`), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, result.String())
+		})
+	}
+}
+
+func TestRandomIsDrawnForEveryEvaluation(t *testing.T) {
+	draws := []float64{0.1, 0.9, 0.6}
+	engine, err := NewEngine(WithRandom(func() float64 {
+		draw := draws[0]
+		draws = draws[1:]
+
+		return draw
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := engine.ApplyInterface(map[string]interface{}{
+		"filter": []interface{}{map[string]interface{}{"var": "items"}, map[string]interface{}{"sample_pct": 50.0}},
+	}, map[string]interface{}{"items": []interface{}{1.0, 2.0, 3.0}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []interface{}{1.0}, result)
+}
+
+func TestWithRandomSeed(t *testing.T) {
+	draw := func() interface{} {
+		engine, err := NewEngine(WithRandomSeed(42))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := engine.ApplyInterface(map[string]interface{}{"random": []interface{}{}}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return result
+	}
+
+	first := draw()
+	assert.Equal(t, first, draw())
+	assert.True(t, first.(float64) >= 0 && first.(float64) < 1)
+}
+
+func TestWithRandom(t *testing.T) {
+	_, err := NewEngine(WithRandom(nil))
+	assert.Error(t, err)
+}
@@ -0,0 +1,150 @@
+package jsonlogic
+
+// rangeOperators are the operators working on [low, high] ranges
+var rangeOperators = map[string]bool{
+	"in_range":       true,
+	"overlaps":       true,
+	"contains_range": true,
+}
+
+// interval is a [low, high] pair with the notation of its bounds
+type interval struct {
+	low, high interface{}
+	bounds    string
+}
+
+// rangeArgs reads the arguments of the range operators: two operands and
+// the notation of the bounds of their ranges, "[]" by default
+func rangeArgs(values interface{}) (interface{}, interface{}, string, bool) {
+	parsed := toSlice(values)
+	if len(parsed) < 2 || len(parsed) > 3 {
+		return nil, nil, "", false
+	}
+
+	bounds := "[]"
+	if len(parsed) == 3 {
+		notation, ok := parsed[2].(string)
+		if !ok {
+			return nil, nil, "", false
+		}
+
+		bounds = notation
+	}
+
+	if _, ok := intervals[bounds]; !ok {
+		return nil, nil, "", false
+	}
+
+	return parsed[0], parsed[1], bounds, true
+}
+
+// toInterval reads a [low, high] pair of numbers or strings, whose low
+// bound can't be above its high bound
+func (ev *evaluator) toInterval(value interface{}, bounds string) (interval, bool) {
+	pair, ok := value.([]interface{})
+	if !ok || len(pair) != 2 {
+		return interval{}, false
+	}
+
+	for _, bound := range pair {
+		if !isNumber(bound) && !isString(bound) {
+			return interval{}, false
+		}
+	}
+
+	if ev.less(pair[1], pair[0]) {
+		return interval{}, false
+	}
+
+	return interval{low: pair[0], high: pair[1], bounds: bounds}, true
+}
+
+// contains tells if a value is within an interval
+func (ev *evaluator) contains(i interval, value interface{}) bool {
+	operators := intervals[i.bounds]
+
+	return ev.ordered(operators[0], value, i.low) && ev.ordered(operators[1], value, i.high)
+}
+
+// inRange tells if a value is within a range, or within any of a list of
+// ranges: {"in_range": [{"var": "age"}, [[0, 12], [65, 120]], "[)"]}.
+// Malformed ranges or an unknown notation give null.
+func (ev *evaluator) inRange(values interface{}) interface{} {
+	value, ranges, bounds, ok := rangeArgs(values)
+	if !ok {
+		return nil
+	}
+
+	list, ok := ranges.([]interface{})
+	if !ok {
+		return nil
+	}
+
+	if len(list) > 0 && !isSlice(list[0]) {
+		list = []interface{}{list}
+	}
+
+	found := false
+	for _, r := range list {
+		i, ok := ev.toInterval(r, bounds)
+		if !ok {
+			return nil
+		}
+
+		if !found && ev.contains(i, value) {
+			found = true
+		}
+	}
+
+	return found
+}
+
+// overlaps tells if two ranges share a value:
+// {"overlaps": [{"var": "shift"}, ["09:00", "17:00"], "[)"]}. The notation
+// applies to both ranges.
+func (ev *evaluator) overlaps(values interface{}) interface{} {
+	a, b, bounds, ok := rangeArgs(values)
+	if !ok {
+		return nil
+	}
+
+	first, ok := ev.toInterval(a, bounds)
+	if !ok {
+		return nil
+	}
+
+	second, ok := ev.toInterval(b, bounds)
+	if !ok {
+		return nil
+	}
+
+	// Ranges sharing only a bound overlap when it's inclusive on both
+	operator := "<"
+	if bounds == "[]" {
+		operator = "<="
+	}
+
+	return ev.ordered(operator, first.low, second.high) && ev.ordered(operator, second.low, first.high)
+}
+
+// containsRange tells if a range holds all the values of another:
+// {"contains_range": [[0, 100], {"merge": [{"var": "min"}, {"var": "max"}]}]}.
+// The notation applies to both ranges.
+func (ev *evaluator) containsRange(values interface{}) interface{} {
+	a, b, bounds, ok := rangeArgs(values)
+	if !ok {
+		return nil
+	}
+
+	outer, ok := ev.toInterval(a, bounds)
+	if !ok {
+		return nil
+	}
+
+	inner, ok := ev.toInterval(b, bounds)
+	if !ok {
+		return nil
+	}
+
+	return ev.ordered("<=", outer.low, inner.low) && ev.ordered("<=", inner.high, outer.high)
+}
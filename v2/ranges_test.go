@@ -0,0 +1,65 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRanges(t *testing.T) {
+	scenarios := map[string]struct {
+		Rule     string
+		Expected string
+	}{
+		"in range":                 {`{"in_range": [{"var": "age"}, [18, 65]]}`, `true`},
+		"out of range":             {`{"in_range": [70, [18, 65]]}`, `false`},
+		"inclusive by default":     {`{"in_range": [65, [18, 65]]}`, `true`},
+		"half open":                {`{"in_range": [65, [18, 65], "[)"]}`, `false`},
+		"exclusive":                {`{"in_range": [18, [18, 65], "()"]}`, `false`},
+		"list of ranges":           {`{"in_range": [70, [[0, 12], [65, 120]]]}`, `true`},
+		"outside a list of ranges": {`{"in_range": [30, [[0, 12], [65, 120]]]}`, `false`},
+		"ranges from the data":     {`{"in_range": [{"var": "age"}, {"var": "ranges"}]}`, `true`},
+		"strings":                  {`{"in_range": ["2024-05-01", ["2024-01-01", "2024-12-31"]]}`, `true`},
+		"empty list of ranges":     {`{"in_range": [1, {"var": "none"}]}`, `false`},
+		"inverted range":           {`{"in_range": [1, [2, 0]]}`, `null`},
+		"malformed range":          {`{"in_range": [1, [0, 1, 2]]}`, `null`},
+		"unknown notation":         {`{"in_range": [1, [0, 2], "[["]}`, `null`},
+		"not a range":              {`{"in_range": [1, 2]}`, `null`},
+
+		"overlapping":               {`{"overlaps": [[1, 5], [4, 10]]}`, `true`},
+		"apart":                     {`{"overlaps": [[1, 3], [4, 10]]}`, `false`},
+		"inside":                    {`{"overlaps": [[1, 10], [4, 5]]}`, `true`},
+		"sharing a bound":           {`{"overlaps": [[1, 4], [4, 10]]}`, `true`},
+		"sharing an excluded bound": {`{"overlaps": [[1, 4], [4, 10], "[)"]}`, `false`},
+		"overlapping half open":     {`{"overlaps": [[1, 5], [4, 10], "[)"]}`, `true`},
+		"overlapping hours":         {`{"overlaps": [{"var": "shift"}, ["09:00", "17:00"], "[)"]}`, `true`},
+		"overlapping computed":      {`{"overlaps": [{"merge": [{"var": "age"}, {"+": [{"var": "age"}, 5]}]}, [34, 40]]}`, `true`},
+		"unevaluated bounds":        {`{"overlaps": [[{"var": "age"}, 40], [34, 40]]}`, `null`},
+		"overlapping malformed":     {`{"overlaps": [[1, 5], 4]}`, `null`},
+
+		"contains":             {`{"contains_range": [[0, 100], [10, 20]]}`, `true`},
+		"contains itself":      {`{"contains_range": [[0, 100], [0, 100]]}`, `true`},
+		"contains exclusively": {`{"contains_range": [[0, 100], [0, 100], "()"]}`, `true`},
+		"partly contains":      {`{"contains_range": [[0, 100], [50, 150]]}`, `false`},
+		"contains from data":   {`{"contains_range": [[0, 100], {"var": "ranges.0"}]}`, `true`},
+		"contains malformed":   {`{"contains_range": [[0, 100]]}`, `null`},
+	}
+
+	data := `{"age": 30, "ranges": [[20, 40], [50, 60]], "none": [], "shift": ["16:00", "22:00"]}`
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var result bytes.Buffer
+
+			err := Apply(strings.NewReader(scenario.Rule), strings.NewReader(data), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, result.String())
+		})
+	}
+}
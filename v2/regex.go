@@ -0,0 +1,133 @@
+package jsonlogic
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sync"
+)
+
+const (
+	// maxPatternLength bounds the size of the patterns accepted by "match"
+	maxPatternLength = 1024
+
+	// maxCachedPatterns bounds how many compiled patterns engines keep
+	// around by default
+	maxCachedPatterns = 256
+)
+
+// WithMaxCachedPatterns bounds how many of the patterns "match" compiles
+// the engine keeps around for the next evaluations, 256 by default. Each
+// engine has its own patterns, so rules with many computed patterns don't
+// evict those of the other engines.
+func WithMaxCachedPatterns(n int) Option {
+	return func(e *Engine) error {
+		if n <= 0 {
+			return fmt.Errorf("max cached patterns must be positive, got %d", n)
+		}
+
+		e.patterns = newPatternCache(n)
+
+		return nil
+	}
+}
+
+// patternCache keeps the compiled patterns of an engine, up to size
+type patternCache struct {
+	mu       sync.RWMutex
+	size     int
+	patterns map[string]*regexp.Regexp
+}
+
+func newPatternCache(size int) *patternCache {
+	return &patternCache{size: size, patterns: make(map[string]*regexp.Regexp)}
+}
+
+// compile returns the compiled form of pattern, reusing a previous
+// compilation when possible
+func (c *patternCache) compile(pattern string) (*regexp.Regexp, error) {
+	c.mu.RLock()
+	re, ok := c.patterns[pattern]
+	c.mu.RUnlock()
+
+	if ok {
+		return re, nil
+	}
+
+	re, err := compilePattern(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	if len(c.patterns) >= c.size {
+		// rules tend to use a handful of patterns, so there is no point
+		// in tracking usage: just start over
+		c.patterns = make(map[string]*regexp.Regexp)
+	}
+	c.patterns[pattern] = re
+	c.mu.Unlock()
+
+	return re, nil
+}
+
+// compilePattern compiles a pattern of "match". Patterns follow the RE2
+// syntax, which runs in linear time on the input and so is safe to use
+// with untrusted rules.
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if len(pattern) > maxPatternLength {
+		return nil, fmt.Errorf("pattern is longer than %d bytes", maxPatternLength)
+	}
+
+	return regexp.Compile(pattern)
+}
+
+// ErrInvalidPattern is returned, wrapped, by the evaluations of match with
+// a pattern which isn't valid RE2 or is too long
+var ErrInvalidPattern = errors.New("invalid pattern")
+
+// match tests a string against a RE2 pattern: {"match": [value, pattern]},
+// or {"regex": [value, pattern]}. Non-string values never match, and
+// invalid patterns fail the evaluation with ErrInvalidPattern.
+func (ev *evaluator) match(values interface{}) interface{} {
+	if !isSlice(values) {
+		return false
+	}
+
+	parsed := values.([]interface{})
+	if len(parsed) != 2 || !isString(parsed[1]) {
+		return false
+	}
+
+	subject := parsed[0]
+	if !isString(subject) && !isNumber(subject) {
+		return false
+	}
+
+	re, err := ev.engine.patterns.compile(parsed[1].(string))
+	if err != nil {
+		ev.fail(fmt.Errorf("%w: %q: %v", ErrInvalidPattern, parsed[1], err))
+	}
+
+	return re.MatchString(toString(subject))
+}
+
+func isValidPattern(values interface{}) bool {
+	if !isSlice(values) {
+		return false
+	}
+
+	parsed := values.([]interface{})
+	if len(parsed) != 2 {
+		return false
+	}
+
+	if !isString(parsed[1]) {
+		// computed patterns can only be checked at evaluation time
+		return true
+	}
+
+	_, err := compilePattern(parsed[1].(string))
+
+	return err == nil
+}
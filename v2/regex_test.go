@@ -0,0 +1,143 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchOperator(t *testing.T) {
+	scenarios := map[string]struct {
+		Rule     string
+		Data     string
+		Expected string
+	}{
+		"matching email": {
+			Rule:     `{"match": [{"var": "email"}, "^[^@\\s]+@[^@\\s]+\\.[a-z]+$"]}`,
+			Data:     `{"email": "jane@example.com"}`,
+			Expected: `true`,
+		},
+		"non matching email": {
+			Rule:     `{"match": [{"var": "email"}, "^[^@\\s]+@[^@\\s]+\\.[a-z]+$"]}`,
+			Data:     `{"email": "jane.example.com"}`,
+			Expected: `false`,
+		},
+		"case insensitive flag": {
+			Rule:     `{"match": ["SKU-001", "(?i)^sku-[0-9]{3}$"]}`,
+			Data:     `{}`,
+			Expected: `true`,
+		},
+		"numbers are matched by their string form": {
+			Rule:     `{"match": [{"var": "zip"}, "^[0-9]{5}$"]}`,
+			Data:     `{"zip": 12345}`,
+			Expected: `true`,
+		},
+		"missing value never matches": {
+			Rule:     `{"match": [{"var": "zip"}, ".*"]}`,
+			Data:     `{}`,
+			Expected: `false`,
+		},
+		"regex is an alias of match": {
+			Rule:     `{"regex": [{"var": "sku"}, "^SKU-[0-9]+$"]}`,
+			Data:     `{"sku": "SKU-001"}`,
+			Expected: `true`,
+		},
+		"regex without a match": {
+			Rule:     `{"regex": [{"var": "sku"}, "^SKU-[0-9]+$"]}`,
+			Data:     `{"sku": "sku-001"}`,
+			Expected: `false`,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var result bytes.Buffer
+
+			err := Apply(strings.NewReader(scenario.Rule), strings.NewReader(scenario.Data), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, result.String())
+		})
+	}
+}
+
+func TestMatchOperatorInvalidPattern(t *testing.T) {
+	scenarios := map[string]struct {
+		Rule string
+		Data string
+	}{
+		"literal pattern": {
+			Rule: `{"match": ["abc", "(abc"]}`,
+			Data: `{}`,
+		},
+		"pattern from the data": {
+			Rule: `{"regex": ["abc", {"var": "pattern"}]}`,
+			Data: `{"pattern": "[a-"}`,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var result bytes.Buffer
+
+			err := Apply(strings.NewReader(scenario.Rule), strings.NewReader(scenario.Data), &result)
+
+			assert.True(t, errors.Is(err, ErrInvalidPattern), "unexpected error: %v", err)
+		})
+	}
+}
+
+func TestMatchOperatorValidation(t *testing.T) {
+	assert.True(t, IsValid(strings.NewReader(`{"match": [{"var": "sku"}, "^[A-Z]+$"]}`)))
+	assert.False(t, IsValid(strings.NewReader(`{"match": [{"var": "sku"}, "(abc"]}`)))
+	assert.False(t, IsValid(strings.NewReader(`{"match": [{"var": "sku"}]}`)))
+	assert.True(t, IsValid(strings.NewReader(`{"regex": [{"var": "sku"}, "^[A-Z]+$"]}`)))
+	assert.False(t, IsValid(strings.NewReader(`{"regex": [{"var": "sku"}, "(abc"]}`)))
+}
+
+func TestPatternCacheReusesCompiledPatterns(t *testing.T) {
+	patterns := newPatternCache(maxCachedPatterns)
+
+	first, err := patterns.compile("^a+$")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := patterns.compile("^a+$")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, first == second)
+
+	_, err = patterns.compile(strings.Repeat("a", maxPatternLength+1))
+	assert.Error(t, err)
+}
+
+func TestMaxCachedPatterns(t *testing.T) {
+	engine, err := NewEngine(WithMaxCachedPatterns(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, pattern := range []string{"^a", "^b", "^c", "^d", "^e"} {
+		result, err := engine.ApplyInterface(map[string]interface{}{"match": []interface{}{"abc", pattern}}, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, pattern == "^a", result)
+
+		assert.True(t, len(engine.patterns.patterns) <= 2)
+	}
+
+	// the patterns of an engine aren't those of the others
+	_, ok := defaultEngine.patterns.patterns["^e"]
+	assert.False(t, ok)
+
+	_, err = NewEngine(WithMaxCachedPatterns(0))
+	assert.Error(t, err)
+}
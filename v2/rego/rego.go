@@ -0,0 +1,852 @@
+// Package rego translates JSON Logic rules to Rego policies, the language
+// of Open Policy Agent, so policies authored in JSON Logic can be enforced
+// by OPA:
+//
+//	policy, err := rego.Translate(strings.NewReader(`{">=": [{"var": "age"}, 18]}`), "authz", "allow")
+//	// package authz
+//	//
+//	// import rego.v1
+//	//
+//	// default allow := false
+//	//
+//	// allow if {
+//	// 	input.age >= 18
+//	// }
+//
+// The data is the input document of the policy. Rules whose result is a
+// boolean become a rule defaulting to false, the others a rule holding
+// their result, which is undefined when the data misses a field the rule
+// reads without default.
+//
+// Rego has no truthiness where JSON Logic converts values as needed, so
+// only rules whose meaning is the same in both languages are translated:
+//
+//   - == and != compare like === and !==, without conversions
+//   - the arguments of !, and, or and the conditions of if must be
+//     booleans
+//   - in checks the elements of lists, or substrings when the list is a
+//     string literal
+//   - merge concatenates lists only
+//
+// Operators without an equivalent, like reduce or missing, make the
+// translation fail. Expressions which can't be written inline, like or,
+// become helper rules named after the translated rule.
+package rego
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// expr is a translated term; compound ones are wrapped in parentheses
+// when used as operands
+type expr struct {
+	code     string
+	compound bool
+}
+
+func (e expr) operand() string {
+	if e.compound {
+		return "(" + e.code + ")"
+	}
+
+	return e.code
+}
+
+type translator struct {
+	name string
+
+	// scopes are the names of the variables holding the data, followed by
+	// the ones holding the elements of the iterations being translated
+	scopes []string
+
+	// helpers are the rules written for the expressions which can't be
+	// inlined, numbered by their position
+	helpers []string
+}
+
+// Translate reads a rule and returns the equivalent Rego policy, declaring
+// the package pkg and the rule name
+func Translate(rule io.Reader, pkg, name string) (string, error) {
+	var _rule interface{}
+
+	err := json.NewDecoder(rule).Decode(&_rule)
+	if err != nil {
+		return "", fmt.Errorf("error parsing rule: %w", err)
+	}
+
+	for _, part := range strings.Split(pkg, ".") {
+		if !isIdentifier(part) {
+			return "", fmt.Errorf("invalid package %q", pkg)
+		}
+	}
+
+	if !isIdentifier(name) {
+		return "", fmt.Errorf("invalid rule name %q", name)
+	}
+
+	t := &translator{name: name, scopes: []string{"input"}}
+
+	var main string
+
+	if operator, values, ok := ifOperator(_rule); ok {
+		main, err = t.branches(name, values, "/"+pointerToken(operator))
+		if err != nil {
+			return "", err
+		}
+	} else if isCondition(_rule) {
+		alternatives, err := t.alternatives(_rule, "")
+		if err != nil {
+			return "", err
+		}
+
+		main = "default " + name + " := false\n\n" + definitions(name, alternatives)
+	} else {
+		translated, err := t.term(_rule, "")
+		if err != nil {
+			return "", err
+		}
+
+		main = name + " := " + translated.code
+	}
+
+	policy := "package " + pkg + "\n\nimport rego.v1\n\n" + main + "\n"
+	for _, helper := range t.helpers {
+		policy += "\n" + helper + "\n"
+	}
+
+	return policy, nil
+}
+
+// conditionOperators are the operators whose result is a boolean
+var conditionOperators = map[string]bool{
+	"==": true, "===": true, "!=": true, "!==": true,
+	"<": true, "<=": true, ">": true, ">=": true,
+	"and": true, "or": true, "!": true, "!!": true,
+	"in": true, "match": true, "regex": true, "all": true, "some": true, "none": true,
+}
+
+// ifOperator returns the operator and the arguments of rules using if
+func ifOperator(rule interface{}) (string, interface{}, bool) {
+	operation, ok := rule.(map[string]interface{})
+	if !ok || len(operation) != 1 {
+		return "", nil, false
+	}
+
+	for _, operator := range []string{"if", "?:"} {
+		if values, ok := operation[operator]; ok {
+			return operator, values, true
+		}
+	}
+
+	return "", nil, false
+}
+
+func isCondition(rule interface{}) bool {
+	if _, ok := rule.(bool); ok {
+		return true
+	}
+
+	operation, ok := rule.(map[string]interface{})
+	if !ok || len(operation) != 1 {
+		return false
+	}
+
+	for operator := range operation {
+		return conditionOperators[operator]
+	}
+
+	return false
+}
+
+// body writes the statements of a rule, one per line
+func body(statements []string) string {
+	lines := make([]string, 0, len(statements))
+	for _, statement := range statements {
+		lines = append(lines, "\t"+strings.Replace(statement, "\n", "\n\t", -1))
+	}
+
+	return "{\n" + strings.Join(lines, "\n") + "\n}"
+}
+
+// helper reserves a helper rule, returning its head and how to refer to
+// it. Inside iterations, helpers are functions of the elements.
+func (t *translator) helper() (int, string) {
+	t.helpers = append(t.helpers, "")
+	index := len(t.helpers) - 1
+
+	head := t.name + "_" + strconv.Itoa(index+1)
+	if len(t.scopes) > 1 {
+		head += "(" + strings.Join(t.scopes[1:], ", ") + ")"
+	}
+
+	return index, head
+}
+
+// boolean writes a helper rule holding whether the statements are true,
+// for conditions used as values
+func (t *translator) boolean(rule interface{}, path string) (expr, error) {
+	index, head := t.helper()
+
+	statements, err := t.condition(rule, path)
+	if err != nil {
+		return expr{}, err
+	}
+
+	t.helpers[index] = head + " := true if " + body(statements) + " else := false"
+
+	return expr{code: head}, nil
+}
+
+// definitions writes a rule true when any of the lists of statements is,
+// with a definition for each
+func definitions(head string, alternatives [][]string) string {
+	definitions := make([]string, 0, len(alternatives))
+	for _, statements := range alternatives {
+		definitions = append(definitions, head+" if "+body(statements))
+	}
+
+	return strings.Join(definitions, "\n\n")
+}
+
+// alternatives translates a rule to lists of statements, the rule being
+// true when all the statements of any list are. There is more than one
+// list for or, Rego rules being true when any of their definitions is.
+func (t *translator) alternatives(rule interface{}, path string) ([][]string, error) {
+	if operation, ok := rule.(map[string]interface{}); ok && len(operation) == 1 {
+		if values, ok := operation["or"]; ok {
+			return t.disjunction(values, path+"/or")
+		}
+	}
+
+	statements, err := t.condition(rule, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return [][]string{statements}, nil
+}
+
+// arguments returns the arguments of an operator, given as a list or
+// alone, with their paths
+func arguments(values interface{}, path string) ([]interface{}, []string) {
+	list, ok := values.([]interface{})
+	if !ok {
+		return []interface{}{values}, []string{path}
+	}
+
+	paths := make([]string, 0, len(list))
+	for i := range list {
+		paths = append(paths, path+"/"+strconv.Itoa(i))
+	}
+
+	return list, paths
+}
+
+func (t *translator) terms(values interface{}, path string) ([]expr, error) {
+	list, paths := arguments(values, path)
+
+	terms := make([]expr, 0, len(list))
+	for i, value := range list {
+		term, err := t.term(value, paths[i])
+		if err != nil {
+			return nil, err
+		}
+
+		terms = append(terms, term)
+	}
+
+	return terms, nil
+}
+
+var comparisons = map[string]string{
+	"==":  "==",
+	"===": "==",
+	"!=":  "!=",
+	"!==": "!=",
+	"<":   "<",
+	"<=":  "<=",
+	">":   ">",
+	">=":  ">=",
+}
+
+// condition translates a rule to statements all true when it is
+func (t *translator) condition(rule interface{}, path string) ([]string, error) {
+	operation, ok := rule.(map[string]interface{})
+	if !ok || len(operation) != 1 {
+		term, err := t.term(rule, path)
+		if err != nil {
+			return nil, err
+		}
+
+		return []string{term.code}, nil
+	}
+
+	for operator, values := range operation {
+		_path := path + "/" + pointerToken(operator)
+
+		switch operator {
+		case "and":
+			return t.and(values, _path)
+		case "or":
+			return t.or(values, _path)
+		case "!":
+			return t.not(values, _path)
+		case "!!":
+			list, paths := arguments(values, _path)
+			if len(list) != 1 {
+				return nil, fmt.Errorf("%s: !! must have 1 argument, got %d", location(_path), len(list))
+			}
+
+			return t.condition(list[0], paths[0])
+		case "in":
+			return t.in(values, _path)
+		case "some", "all", "none":
+			return t.quantifier(operator, values, _path)
+		}
+
+		if comparisons[operator] == "" {
+			break
+		}
+
+		args, err := t.terms(values, _path)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case len(args) == 2:
+			return []string{args[0].operand() + " " + comparisons[operator] + " " + args[1].operand()}, nil
+		case (operator == "<" || operator == "<=" || operator == ">" || operator == ">=") && len(args) == 3:
+			// between
+			return []string{
+				args[0].operand() + " " + operator + " " + args[1].operand(),
+				args[1].operand() + " " + operator + " " + args[2].operand(),
+			}, nil
+		}
+
+		return nil, fmt.Errorf("%s: operator %q with %d arguments has no Rego equivalent", location(_path), operator, len(args))
+	}
+
+	term, err := t.term(rule, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{term.code}, nil
+}
+
+func (t *translator) and(values interface{}, path string) ([]string, error) {
+	list, paths := arguments(values, path)
+
+	statements := make([]string, 0, len(list))
+	for i, value := range list {
+		conditions, err := t.condition(value, paths[i])
+		if err != nil {
+			return nil, err
+		}
+
+		statements = append(statements, conditions...)
+	}
+
+	return statements, nil
+}
+
+// disjunction translates the arguments of or to lists of statements
+func (t *translator) disjunction(values interface{}, path string) ([][]string, error) {
+	list, paths := arguments(values, path)
+
+	alternatives := make([][]string, 0, len(list))
+	for i, value := range list {
+		statements, err := t.condition(value, paths[i])
+		if err != nil {
+			return nil, err
+		}
+
+		alternatives = append(alternatives, statements)
+	}
+
+	return alternatives, nil
+}
+
+// or writes a helper rule with a definition per argument
+func (t *translator) or(values interface{}, path string) ([]string, error) {
+	list, paths := arguments(values, path)
+	if len(list) == 1 {
+		return t.condition(list[0], paths[0])
+	}
+
+	index, head := t.helper()
+
+	alternatives, err := t.disjunction(values, path)
+	if err != nil {
+		return nil, err
+	}
+
+	t.helpers[index] = definitions(head, alternatives)
+
+	return []string{head}, nil
+}
+
+func (t *translator) not(values interface{}, path string) ([]string, error) {
+	list, paths := arguments(values, path)
+	if len(list) != 1 {
+		return nil, fmt.Errorf("%s: ! must have 1 argument, got %d", location(path), len(list))
+	}
+
+	return t.negation(list[0], paths[0])
+}
+
+// negation negates a condition, through a helper rule when it doesn't
+// fit a single statement
+func (t *translator) negation(rule interface{}, path string) ([]string, error) {
+	statements, err := t.condition(rule, path)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(statements) == 1 && isSimple(statements[0]) {
+		return []string{"not " + statements[0]}, nil
+	}
+
+	index, head := t.helper()
+
+	t.helpers[index] = definitions(head, [][]string{statements})
+
+	return []string{"not " + head}, nil
+}
+
+// isSimple tells if a statement can be negated with not
+func isSimple(statement string) bool {
+	return !strings.Contains(statement, "\n") &&
+		!strings.HasPrefix(statement, "not ") &&
+		!strings.HasPrefix(statement, "some ") &&
+		!strings.HasPrefix(statement, "every ")
+}
+
+func (t *translator) in(values interface{}, path string) ([]string, error) {
+	args, err := t.terms(values, path)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(args) != 2 {
+		return nil, fmt.Errorf("%s: in must have 2 arguments, got %d", location(path), len(args))
+	}
+
+	if list, ok := values.([]interface{}); ok {
+		if _, ok := list[1].(string); ok {
+			return []string{"contains(" + args[1].code + ", " + args[0].code + ")"}, nil
+		}
+	}
+
+	return []string{args[0].operand() + " in " + args[1].operand()}, nil
+}
+
+// element returns the name of the variable holding the elements of an
+// iteration starting at the current depth
+func (t *translator) element() string {
+	element := "x"
+	if depth := len(t.scopes) - 1; depth > 0 {
+		element += strconv.Itoa(depth)
+	}
+
+	return element
+}
+
+// iterated translates the list and the rule of an iteration, the rule
+// being translated by predicate with the element in scope
+func (t *translator) iterated(operator string, values interface{}, path string, predicate func(interface{}, string) error) (expr, string, error) {
+	list, ok := values.([]interface{})
+	if !ok || len(list) != 2 {
+		return expr{}, "", fmt.Errorf("%s: %s must have a list and a rule", location(path), operator)
+	}
+
+	subject, err := t.term(list[0], path+"/0")
+	if err != nil {
+		return expr{}, "", err
+	}
+
+	element := t.element()
+
+	t.scopes = append(t.scopes, element)
+	err = predicate(list[1], path+"/1")
+	t.scopes = t.scopes[:len(t.scopes)-1]
+
+	return subject, element, err
+}
+
+// quantifier translates some and none to a helper rule looking for an
+// element, which keeps its variable out of the statements around it, and
+// all to every
+func (t *translator) quantifier(operator string, values interface{}, path string) ([]string, error) {
+	var statements []string
+
+	condition := func(rule interface{}, path string) (err error) {
+		statements, err = t.condition(rule, path)
+
+		return err
+	}
+
+	if operator == "all" {
+		subject, element, err := t.iterated(operator, values, path, condition)
+		if err != nil {
+			return nil, err
+		}
+
+		return []string{"every " + element + " in " + subject.operand() + " " + body(statements)}, nil
+	}
+
+	index, head := t.helper()
+
+	subject, element, err := t.iterated(operator, values, path, condition)
+	if err != nil {
+		return nil, err
+	}
+
+	found := append([]string{"some " + element + " in " + subject.operand()}, statements...)
+	t.helpers[index] = definitions(head, [][]string{found})
+
+	if operator == "none" {
+		return []string{"not " + head}, nil
+	}
+
+	return []string{head}, nil
+}
+
+// term translates a rule to a Rego term
+func (t *translator) term(rule interface{}, path string) (expr, error) {
+	switch value := rule.(type) {
+	case nil:
+		return expr{code: "null"}, nil
+	case bool:
+		return expr{code: strconv.FormatBool(value)}, nil
+	case float64:
+		if math.IsInf(value, 0) || math.IsNaN(value) {
+			return expr{}, fmt.Errorf("%s: %v has no Rego equivalent", location(path), value)
+		}
+
+		return expr{code: strconv.FormatFloat(value, 'g', -1, 64)}, nil
+	case string:
+		return expr{code: quote(value)}, nil
+	case []interface{}:
+		elements := make([]string, 0, len(value))
+		for i, element := range value {
+			translated, err := t.term(element, path+"/"+strconv.Itoa(i))
+			if err != nil {
+				return expr{}, err
+			}
+
+			elements = append(elements, translated.code)
+		}
+
+		return expr{code: "[" + strings.Join(elements, ", ") + "]"}, nil
+	case map[string]interface{}:
+		if len(value) != 1 {
+			return expr{}, fmt.Errorf("%s: rules must have a single operator, got %d", location(path), len(value))
+		}
+
+		for operator, args := range value {
+			if conditionOperators[operator] && operator != "match" && operator != "regex" {
+				return t.boolean(rule, path)
+			}
+
+			return t.operation(operator, args, path+"/"+pointerToken(operator))
+		}
+	}
+
+	return expr{}, fmt.Errorf("%s: unsupported value %v", location(path), rule)
+}
+
+var arithmeticOperators = map[string]string{
+	"+": "+",
+	"-": "-",
+	"*": "*",
+	"/": "/",
+	"%": "%",
+}
+
+var functions = map[string]string{
+	"min": "min",
+	"max": "max",
+}
+
+func (t *translator) operation(operator string, values interface{}, path string) (expr, error) {
+	switch operator {
+	case "var":
+		return t.variable(values, path)
+	case "filter", "map":
+		return t.comprehension(operator, values, path)
+	case "if", "?:":
+		return t.conditional(values, path)
+	}
+
+	args, err := t.terms(values, path)
+	if err != nil {
+		return expr{}, err
+	}
+
+	switch {
+	case (operator == "+" || operator == "*") && len(args) > 1:
+		return chain(args, operator), nil
+	case arithmeticOperators[operator] != "" && len(args) == 2:
+		return chain(args, operator), nil
+	case operator == "+" && len(args) == 1:
+		return expr{code: "to_number(" + args[0].code + ")"}, nil
+	case operator == "-" && len(args) == 1:
+		return expr{code: "0 - " + args[0].operand(), compound: true}, nil
+	case functions[operator] != "" && len(args) > 0:
+		return expr{code: functions[operator] + "(" + list(args) + ")"}, nil
+	case operator == "cat" && len(args) > 0:
+		return expr{code: "sprintf(" + quote(strings.Repeat("%v", len(args))) + ", " + list(args) + ")"}, nil
+	case operator == "merge" && len(args) > 0:
+		merged := args[0]
+		for _, arg := range args[1:] {
+			merged = expr{code: "array.concat(" + merged.code + ", " + arg.code + ")"}
+		}
+
+		return merged, nil
+	case (operator == "match" || operator == "regex") && len(args) == 2:
+		return expr{code: "regex.match(" + args[1].code + ", " + args[0].code + ")"}, nil
+	}
+
+	return expr{}, fmt.Errorf("%s: operator %q with %d arguments has no Rego equivalent", location(path), operator, len(args))
+}
+
+func chain(args []expr, operator string) expr {
+	operands := make([]string, 0, len(args))
+	for _, arg := range args {
+		operands = append(operands, arg.operand())
+	}
+
+	return expr{code: strings.Join(operands, " "+operator+" "), compound: true}
+}
+
+func list(args []expr) string {
+	elements := make([]string, 0, len(args))
+	for _, arg := range args {
+		elements = append(elements, arg.code)
+	}
+
+	return "[" + strings.Join(elements, ", ") + "]"
+}
+
+// conditional translates if to a helper rule
+func (t *translator) conditional(values interface{}, path string) (expr, error) {
+	index, head := t.helper()
+
+	definition, err := t.branches(head, values, path)
+	if err != nil {
+		return expr{}, err
+	}
+
+	t.helpers[index] = definition
+
+	return expr{code: head}, nil
+}
+
+// branches writes the definition of the rule head holding the result of
+// if, with an else branch per condition
+func (t *translator) branches(head string, values interface{}, path string) (string, error) {
+	list, paths := arguments(values, path)
+	if len(list) < 3 || len(list)%2 == 0 {
+		return "", fmt.Errorf("%s: if must have conditions with their results, and a last result", location(path))
+	}
+
+	definition := head
+	for i := 0; i < len(list)-1; i += 2 {
+		statements, err := t.condition(list[i], paths[i])
+		if err != nil {
+			return "", err
+		}
+
+		result, err := t.term(list[i+1], paths[i+1])
+		if err != nil {
+			return "", err
+		}
+
+		if i > 0 {
+			definition += " else"
+		}
+
+		definition += " := " + result.code + " if " + body(statements)
+	}
+
+	otherwise, err := t.term(list[len(list)-1], paths[len(list)-1])
+	if err != nil {
+		return "", err
+	}
+
+	return definition + " else := " + otherwise.code, nil
+}
+
+// comprehension translates filter and map to array comprehensions
+func (t *translator) comprehension(operator string, values interface{}, path string) (expr, error) {
+	var result string
+	var statements []string
+
+	predicate := func(rule interface{}, path string) error {
+		if operator == "filter" {
+			var err error
+			statements, err = t.condition(rule, path)
+
+			return err
+		}
+
+		translated, err := t.term(rule, path)
+		result = translated.code
+
+		return err
+	}
+
+	subject, element, err := t.iterated(operator, values, path, predicate)
+	if err != nil {
+		return expr{}, err
+	}
+
+	if operator == "filter" {
+		result = element
+	}
+
+	statements = append([]string{"some " + element + " in " + subject.operand()}, statements...)
+
+	return expr{code: "[" + result + " | " + strings.Join(statements, "; ") + "]"}, nil
+}
+
+// variable translates var to a reference to the input, or to the element
+// of the iteration being translated. Defaults are read with object.get.
+func (t *translator) variable(values interface{}, path string) (expr, error) {
+	name := values
+	var fallback interface{}
+	hasFallback := false
+
+	if list, ok := values.([]interface{}); ok {
+		if len(list) == 0 {
+			return expr{code: t.scopes[len(t.scopes)-1]}, nil
+		}
+
+		name = list[0]
+		if len(list) > 1 {
+			fallback, hasFallback = list[1], true
+		}
+	}
+
+	if number, ok := name.(float64); ok {
+		name = strconv.FormatFloat(number, 'f', -1, 64)
+	}
+
+	_name, ok := name.(string)
+	if !ok {
+		return expr{}, fmt.Errorf("%s: computed paths have no Rego equivalent", location(path))
+	}
+
+	scope := t.scopes[len(t.scopes)-1]
+	reference := scope
+	keys := make([]string, 0)
+
+	for _, part := range pathParts(_name) {
+		index, err := strconv.Atoi(part)
+		if err == nil && index < 0 {
+			return expr{}, fmt.Errorf("%s: negative indexes have no Rego equivalent", location(path))
+		}
+
+		if err == nil {
+			if hasFallback {
+				return expr{}, fmt.Errorf("%s: defaults of paths with indexes have no Rego equivalent", location(path))
+			}
+
+			reference += "[" + strconv.Itoa(index) + "]"
+
+			continue
+		}
+
+		if isIdentifier(part) {
+			reference += "." + part
+		} else {
+			reference += "[" + quote(part) + "]"
+		}
+
+		keys = append(keys, quote(part))
+	}
+
+	if !hasFallback || len(keys) == 0 {
+		return expr{code: reference}, nil
+	}
+
+	translated, err := t.term(fallback, path+"/1")
+	if err != nil {
+		return expr{}, err
+	}
+
+	return expr{code: "object.get(" + scope + ", [" + strings.Join(keys, ", ") + "], " + translated.code + ")"}, nil
+}
+
+// quote writes a Rego string, whose syntax is the one of JSON
+func quote(value string) string {
+	var buffer bytes.Buffer
+
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+
+	if err := encoder.Encode(value); err != nil {
+		panic(err)
+	}
+
+	return strings.TrimSuffix(buffer.String(), "\n")
+}
+
+var identifier = regexp.MustCompile(`^[_a-zA-Z][_a-zA-Z0-9]*$`)
+
+// reserved are the keywords of Rego, which can't be used as names
+var reserved = map[string]bool{
+	"as": true, "contains": true, "default": true, "else": true,
+	"every": true, "false": true, "if": true, "import": true, "in": true,
+	"not": true, "null": true, "package": true, "some": true, "true": true,
+	"with": true,
+}
+
+func isIdentifier(name string) bool {
+	return identifier.MatchString(name) && !reserved[name]
+}
+
+// pathParts splits a var path into the properties and indexes it goes
+// through. Paths starting with a slash are JSON pointers, the others are
+// separated by dots.
+func pathParts(path string) []string {
+	if !strings.HasPrefix(path, "/") {
+		parts := make([]string, 0)
+		for _, part := range strings.Split(path, ".") {
+			if part != "" {
+				parts = append(parts, part)
+			}
+		}
+
+		return parts
+	}
+
+	parts := strings.Split(path[1:], "/")
+	for i, part := range parts {
+		parts[i] = strings.Replace(strings.Replace(part, "~1", "/", -1), "~0", "~", -1)
+	}
+
+	return parts
+}
+
+// pointerToken escapes a JSON pointer token as defined by RFC 6901
+func pointerToken(token string) string {
+	return strings.Replace(strings.Replace(token, "~", "~0", -1), "/", "~1", -1)
+}
+
+func location(path string) string {
+	if path == "" {
+		return "/"
+	}
+
+	return path
+}
@@ -0,0 +1,333 @@
+package rego
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslate(t *testing.T) {
+	scenarios := map[string]struct {
+		rule     string
+		expected string
+	}{
+		"comparison": {
+			rule: `{">=": [{"var": "age"}, 18]}`,
+			expected: `default allow := false
+
+allow if {
+	input.age >= 18
+}
+`,
+		},
+		"strict equality": {
+			rule: `{"===": [{"var": "user.name"}, "ana"]}`,
+			expected: `default allow := false
+
+allow if {
+	input.user.name == "ana"
+}
+`,
+		},
+		"between": {
+			rule: `{"<": [0, {"var": "temp"}, 100]}`,
+			expected: `default allow := false
+
+allow if {
+	0 < input.temp
+	input.temp < 100
+}
+`,
+		},
+		"descending between": {
+			rule: `{">": [100, {"var": "temp"}, 0]}`,
+			expected: `default allow := false
+
+allow if {
+	100 > input.temp
+	input.temp > 0
+}
+`,
+		},
+		"logic": {
+			rule: `{"and": [{"==": [{"var": "a"}, 1]}, {"or": [{"var": "b"}, {"!": {"var": "c"}}]}]}`,
+			expected: `default allow := false
+
+allow if {
+	input.a == 1
+	allow_1
+}
+
+allow_1 if {
+	input.b
+}
+
+allow_1 if {
+	not input.c
+}
+`,
+		},
+		"disjunction": {
+			rule: `{"or": [{"var": "admin"}, {"and": [{"in": [{"var": "role"}, ["owner", "editor"]]}, {"!": {"var": "banned"}}]}]}`,
+			expected: `default allow := false
+
+allow if {
+	input.admin
+}
+
+allow if {
+	input.role in ["owner", "editor"]
+	not input.banned
+}
+`,
+		},
+		"negated conjunction": {
+			rule: `{"!": {"and": [{"var": "a"}, {"var": "b"}]}}`,
+			expected: `default allow := false
+
+allow if {
+	not allow_1
+}
+
+allow_1 if {
+	input.a
+	input.b
+}
+`,
+		},
+		"conditions": {
+			rule: `{"if": [{"<": [{"var": "t"}, 0]}, "ice", {"<": [{"var": "t"}, 100]}, "water", "steam"]}`,
+			expected: `allow := "ice" if {
+	input.t < 0
+} else := "water" if {
+	input.t < 100
+} else := "steam"
+`,
+		},
+		"nested conditions": {
+			rule: `{"cat": ["it is ", {"if": [{"var": "hot"}, "hot", "cold"]}]}`,
+			expected: `allow := sprintf("%v%v", ["it is ", allow_1])
+
+allow_1 := "hot" if {
+	input.hot
+} else := "cold"
+`,
+		},
+		"condition as value": {
+			rule: `{"==": [{"var": "a"}, {">": [{"var": "b"}, 1]}]}`,
+			expected: `default allow := false
+
+allow if {
+	input.a == allow_1
+}
+
+allow_1 := true if {
+	input.b > 1
+} else := false
+`,
+		},
+		"arithmetic": {
+			rule: `{"+": [{"*": [{"var": "price"}, {"var": "qty"}]}, 1.5, {"-": {"var": "discount"}}]}`,
+			expected: `allow := (input.price * input.qty) + 1.5 + (0 - input.discount)
+`,
+		},
+		"functions": {
+			rule: `{"max": [{"var": "a"}, {"min": [1, 2]}, {"%": [{"var": "n"}, 2]}]}`,
+			expected: `allow := max([input.a, min([1, 2]), input.n % 2])
+`,
+		},
+		"in a list": {
+			rule: `{"in": [{"var": "country"}, ["PT", "BR"]]}`,
+			expected: `default allow := false
+
+allow if {
+	input.country in ["PT", "BR"]
+}
+`,
+		},
+		"in a string": {
+			rule: `{"in": ["@", "a@b"]}`,
+			expected: `default allow := false
+
+allow if {
+	contains("a@b", "@")
+}
+`,
+		},
+		"match": {
+			rule: `{"match": [{"var": "email"}, "@example\\.com$"]}`,
+			expected: `default allow := false
+
+allow if {
+	regex.match("@example\\.com$", input.email)
+}
+`,
+		},
+		"regex": {
+			rule: `{"regex": [{"var": "sku"}, "^SKU-"]}`,
+			expected: `default allow := false
+
+allow if {
+	regex.match("^SKU-", input.sku)
+}
+`,
+		},
+		"some": {
+			rule: `{"and": [{"some": [{"var": "a"}, {"var": "ok"}]}, {"some": [{"var": "b"}, {">": [{"var": "price"}, 100]}]}]}`,
+			expected: `default allow := false
+
+allow if {
+	allow_1
+	allow_2
+}
+
+allow_1 if {
+	some x in input.a
+	x.ok
+}
+
+allow_2 if {
+	some x in input.b
+	x.price > 100
+}
+`,
+		},
+		"nested iterations": {
+			rule: `{"all": [{"var": "orders"}, {"none": [{"var": "lines"}, {"==": [{"var": ""}, "refund"]}]}]}`,
+			expected: `default allow := false
+
+allow if {
+	every x in input.orders {
+		not allow_1(x)
+	}
+}
+
+allow_1(x) if {
+	some x1 in x.lines
+	x1 == "refund"
+}
+`,
+		},
+		"map and filter": {
+			rule: `{"map": [{"filter": [{"var": "l"}, {">": [{"var": ""}, 0]}]}, {"*": [{"var": ""}, 2]}]}`,
+			expected: `allow := [x * 2 | some x in [x | some x in input.l; x > 0]]
+`,
+		},
+		"merge": {
+			rule: `{"merge": [[1], {"var": "l"}, [2]]}`,
+			expected: `allow := array.concat(array.concat([1], input.l), [2])
+`,
+		},
+		"default": {
+			rule: `{"var": ["user.plan", "free"]}`,
+			expected: `allow := object.get(input, ["user", "plan"], "free")
+`,
+		},
+		"indexes and keys": {
+			rule: `{"var": "items.0.first-name"}`,
+			expected: `allow := input.items[0]["first-name"]
+`,
+		},
+		"keywords": {
+			rule: `{"var": "some.in"}`,
+			expected: `allow := input["some"]["in"]
+`,
+		},
+		"pointer": {
+			rule: `{"var": "/a.b/0/c~1d"}`,
+			expected: `allow := input["a.b"][0]["c/d"]
+`,
+		},
+		"whole data": {
+			rule: `{"var": ""}`,
+			expected: `allow := input
+`,
+		},
+		"literals": {
+			rule: `[null, true, 1e21, 0.5, "a\"b<"]`,
+			expected: `allow := [null, true, 1e+21, 0.5, "a\"b<"]
+`,
+		},
+		"literal condition": {
+			rule: `true`,
+			expected: `default allow := false
+
+allow if {
+	true
+}
+`,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			policy, err := Translate(strings.NewReader(scenario.rule), "authz", "allow")
+			assert.NoError(t, err)
+			assert.Equal(t, "package authz\n\nimport rego.v1\n\n"+scenario.expected, policy)
+		})
+	}
+}
+
+func TestTranslateUnsupported(t *testing.T) {
+	scenarios := map[string]struct {
+		rule     string
+		expected string
+	}{
+		"operator": {
+			rule:     `{"and": [true, {"reduce": [[1], {"+": [{"var": "current"}, {"var": "accumulator"}]}, 0]}]}`,
+			expected: `/and/1/reduce: operator "reduce" with 3 arguments has no Rego equivalent`,
+		},
+		"computed path": {
+			rule:     `{"var": {"cat": ["a", "b"]}}`,
+			expected: `/var: computed paths have no Rego equivalent`,
+		},
+		"negative index": {
+			rule:     `{"var": "items.-1"}`,
+			expected: `/var: negative indexes have no Rego equivalent`,
+		},
+		"default with index": {
+			rule:     `{"var": ["items.0", 1]}`,
+			expected: `/var: defaults of paths with indexes have no Rego equivalent`,
+		},
+		"invalid if": {
+			rule:     `{"if": [true, 1]}`,
+			expected: `/if: if must have conditions with their results, and a last result`,
+		},
+		"invalid negation": {
+			rule:     `{"!": [true, false]}`,
+			expected: `/!: ! must have 1 argument, got 2`,
+		},
+		"invalid iteration": {
+			rule:     `{"or": [false, {"some": [[1]]}]}`,
+			expected: `/or/1/some: some must have a list and a rule`,
+		},
+		"several operators": {
+			rule:     `{"var": "a", "cat": ["b"]}`,
+			expected: `/: rules must have a single operator, got 2`,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			_, err := Translate(strings.NewReader(scenario.rule), "authz", "allow")
+			assert.EqualError(t, err, scenario.expected)
+		})
+	}
+}
+
+func TestTranslateErrors(t *testing.T) {
+	_, err := Translate(strings.NewReader(`{`), "authz", "allow")
+	assert.Error(t, err)
+
+	_, err = Translate(strings.NewReader(`true`), "authz.default", "allow")
+	assert.Error(t, err)
+
+	_, err = Translate(strings.NewReader(`true`), "authz", "in")
+	assert.Error(t, err)
+
+	policy, err := Translate(strings.NewReader(`true`), "policies.authz", "allow")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(policy, "package policies.authz\n"))
+}
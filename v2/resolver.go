@@ -0,0 +1,368 @@
+package jsonlogic
+
+import (
+	"fmt"
+	"strings"
+)
+
+// VarResolver provides the values of var paths missing from the data, so
+// they can be fetched as the rule needs them, from a feature store, the
+// environment or a database, instead of being gathered up front. Values
+// can be decoded JSON or any Go value accepted as data.
+type VarResolver interface {
+	Resolve(path string) (interface{}, bool)
+}
+
+// VarResolverFunc adapts a function to a VarResolver
+type VarResolverFunc func(path string) (interface{}, bool)
+
+// Resolve calls f(path)
+func (f VarResolverFunc) Resolve(path string) (interface{}, bool) {
+	return f(path)
+}
+
+// WithVarResolver makes var, missing and missing_some ask resolver for the
+// paths not found in the data. Paths relative to the element of an
+// iteration, starting with a dot, are never resolved. So that the paths of
+// the branches not taken aren't resolved, if, ?:, and and or evaluate their
+// operands as they reach them on these engines, instead of all of them
+// first: the branches not taken and the operands after a short circuit are
+// never evaluated.
+func WithVarResolver(resolver VarResolver) Option {
+	return func(e *Engine) error {
+		if resolver == nil {
+			return fmt.Errorf("resolver must not be nil")
+		}
+
+		e.resolver = resolver
+
+		return nil
+	}
+}
+
+// BatchVarResolver is a VarResolver able to resolve many paths at once.
+// Before evaluating a rule, the paths it always reads which are missing
+// from the data are resolved with a single call to ResolveAll; Resolve is
+// left for the paths known only during the evaluation, like computed ones,
+// and for those read only when reached, like the branches of if and the
+// operands of and and or after the first. Paths missing from the returned
+// map are considered missing.
+type BatchVarResolver interface {
+	VarResolver
+	ResolveAll(paths []string) map[string]interface{}
+}
+
+// prefetch resolves at once the paths used by rule missing from data
+func (ev *evaluator) prefetch(resolver BatchVarResolver, rule, data interface{}) {
+	paths := make([]string, 0)
+	for _, path := range collectVars(rule, nil, make(map[string]bool)) {
+		if getVar(path, data, ev.engine.pathSyntax) == nil {
+			paths = append(paths, path)
+		}
+	}
+
+	if len(paths) == 0 {
+		return
+	}
+
+	values := resolver.ResolveAll(paths)
+
+	ev.prefetched = make(map[string]interface{}, len(paths))
+	for _, path := range paths {
+		ev.prefetched[path] = fromGoData(values[path])
+	}
+}
+
+// branchingOperators are the operators which don't need all their operands
+// for their result: conditions, and the operators which short circuit
+var branchingOperators = map[string]bool{
+	"if":  true,
+	"?:":  true,
+	"and": true,
+	"or":  true,
+}
+
+// reaching tells if an operator evaluates its operands as it reaches them,
+// which branching operators do on engines with a resolver
+func (ev *evaluator) reaching(operator string, values interface{}) bool {
+	if ev.engine.resolver == nil || !branchingOperators[operator] {
+		return false
+	}
+
+	_, ok := values.([]interface{})
+
+	return ok
+}
+
+// reach evaluates the operands of a branching operator in order, up to
+// those deciding its result, and leaves the others null: the operator
+// gives the same result as with all of them evaluated
+func (ev *evaluator) reach(operator string, operands []interface{}, data interface{}) interface{} {
+	box, values := ev.arena.boxed(len(operands))
+
+	evaluate := func(i int) interface{} {
+		if isMap(operands[i]) {
+			values[i] = materialize(ev.apply(operands[i], data))
+		} else {
+			values[i] = operands[i]
+		}
+
+		return values[i]
+	}
+
+	compat := ev.engine.coercion == CompatJS
+
+	switch {
+	case operator == "and" || operator == "or":
+		for i := range operands {
+			value := evaluate(i)
+
+			if compat && ev.truthy(value) == (operator == "or") {
+				break
+			}
+
+			if !compat && (operator == "and" && stopsAnd(value) || operator == "or" && isTrue(value)) {
+				break
+			}
+		}
+	case operator == "?:" && !compat:
+		if len(operands) != 3 {
+			for i := range operands {
+				evaluate(i)
+			}
+
+			break
+		}
+
+		switch evaluate(0) {
+		case true:
+			evaluate(1)
+		case false:
+			evaluate(2)
+		}
+	default:
+		i := 0
+		for ; i < len(operands)-1; i += 2 {
+			condition := evaluate(i)
+
+			if compat && ev.truthy(condition) || !compat && holds(condition, data) {
+				evaluate(i + 1)
+
+				return box
+			}
+		}
+
+		if i < len(operands) {
+			evaluate(i)
+		}
+	}
+
+	return box
+}
+
+// collectVars appends to paths the var paths a rule always reads from the
+// data, in the order they appear. Paths relative to the element of an
+// iteration or to the data around it, computed paths, and the expressions
+// evaluated only when chosen or reached, like the branches of if and the
+// results of switch, are left out.
+func collectVars(rule interface{}, paths []string, seen map[string]bool) []string {
+	add := func(path interface{}) {
+		name, _ := varArgs(path)
+		if name == "" || strings.HasPrefix(name, ".") || isParentPath(name) || isPositionPath(name) || seen[name] {
+			return
+		}
+
+		seen[name] = true
+		paths = append(paths, name)
+	}
+
+	if isSlice(rule) {
+		for _, value := range rule.([]interface{}) {
+			paths = collectVars(value, paths, seen)
+		}
+
+		return paths
+	}
+
+	if !isMap(rule) {
+		return paths
+	}
+
+	for operator, values := range rule.(map[string]interface{}) {
+		parsed := toSlice(values)
+
+		switch operator {
+		case "var":
+			add(values)
+		case "missing":
+			for _, name := range parsed {
+				add(name)
+			}
+		case "missing_some":
+			if len(parsed) == 2 && isSlice(parsed[1]) {
+				for _, name := range parsed[1].([]interface{}) {
+					add(name)
+				}
+			}
+		case "let":
+			if bindings, body, ok := letArgs(values); ok {
+				for _, value := range bindings {
+					paths = collectVars(value, paths, seen)
+				}
+
+				for _, path := range collectVars(body, nil, make(map[string]bool)) {
+					if !isBound(path, bindings) {
+						add(path)
+					}
+				}
+			}
+
+			continue
+		case "def":
+			if definitions, body, ok := defArgs(values); ok {
+				paths = collectVars(body, paths, seen)
+
+				for _, definition := range definitions {
+					for _, path := range collectVars(definition.body, nil, make(map[string]bool)) {
+						if !isBound(path, definition.paramNames()) {
+							add(path)
+						}
+					}
+				}
+			}
+
+			continue
+		case "template":
+			if len(parsed) > 0 && isString(parsed[0]) {
+				names, _ := templatePaths(parsed[0].(string))
+				for _, name := range names {
+					add(name)
+				}
+			}
+		case "if", "?:", "and", "or":
+			// the first operand is the only one always evaluated: the
+			// others are branches, or follow a short circuit
+			if len(parsed) > 0 {
+				paths = collectVars(parsed[0], paths, seen)
+			}
+
+			continue
+		case "switch":
+			paths = collectVars(parsed[0], paths, seen)
+			if len(parsed) > 1 && isSlice(parsed[1]) {
+				for _, c := range parsed[1].([]interface{}) {
+					if isSlice(c) && len(c.([]interface{})) > 0 {
+						paths = collectVars(c.([]interface{})[0], paths, seen)
+					}
+				}
+			}
+
+			continue
+		case "reduce":
+			if len(parsed) < 2 {
+				break
+			}
+
+			paths = collectVars(parsed[0], paths, seen)
+			for _, path := range collectVars(parsed[1], nil, make(map[string]bool)) {
+				// current and accumulator are given by reduce itself
+				head := strings.SplitN(path, ".", 2)[0]
+				if head != "current" && head != "accumulator" {
+					add(path)
+				}
+			}
+
+			for _, value := range parsed[2:] {
+				paths = collectVars(value, paths, seen)
+			}
+
+			continue
+		}
+
+		paths = collectVars(values, paths, seen)
+	}
+
+	return paths
+}
+
+// variable implements var: values is a path or a list with a path and a
+// default, looked up in data and then through the resolver
+func (ev *evaluator) variable(values, data interface{}) interface{} {
+	if len(ev.scopes) > 0 {
+		if value, ok := ev.boundVariable(values); ok {
+			return value
+		}
+	}
+
+	if ev.engine.env != nil {
+		if value, ok := ev.environment(values); ok {
+			return value
+		}
+	}
+
+	if value, ok := ev.parent(values); ok {
+		return value
+	}
+
+	if value, ok := ev.position(values); ok {
+		return value
+	}
+
+	if ev.engine.resolver == nil || ev.engine.varConvention == PlainElement && len(ev.parents) > 0 {
+		// the predicates of iterations only read the element
+		return readVar(values, data, ev.engine.pathSyntax, ev.arena.path())
+	}
+
+	path, fallback := varArgs(values)
+	if path == "" {
+		return data
+	}
+
+	if value := readVar(path, data, ev.engine.pathSyntax, ev.arena.path()); value != nil {
+		return value
+	}
+
+	if value, ok := ev.prefetched[path]; ok {
+		if value != nil {
+			return value
+		}
+
+		return fallback
+	}
+
+	if !strings.HasPrefix(path, ".") {
+		if value, ok := ev.engine.resolver.Resolve(path); ok && value != nil {
+			return fromGoData(value)
+		}
+	}
+
+	return fallback
+}
+
+// varArgs returns the path and the default of a var
+func varArgs(values interface{}) (string, interface{}) {
+	var fallback interface{}
+
+	if isSlice(values) {
+		parsed := values.([]interface{})
+		if len(parsed) == 0 {
+			return "", nil
+		}
+
+		if len(parsed) > 1 {
+			fallback = parsed[1]
+		}
+
+		values = parsed[0]
+	}
+
+	if isNumber(values) {
+		return toString(values), fallback
+	}
+
+	if isString(values) {
+		return values.(string), fallback
+	}
+
+	return "", fallback
+}
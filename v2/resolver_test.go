@@ -0,0 +1,224 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVarResolver(t *testing.T) {
+	resolved := make([]string, 0)
+
+	features := map[string]interface{}{
+		"user.score":   720,
+		"user.country": "PT",
+		"limits":       []int{100, 500},
+	}
+
+	engine, err := NewEngine(WithVarResolver(VarResolverFunc(func(path string) (interface{}, bool) {
+		resolved = append(resolved, path)
+
+		value, ok := features[path]
+
+		return value, ok
+	})))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := map[string]struct {
+		Rule     string
+		Data     string
+		Expected string
+		Resolved []string
+	}{
+		"resolved value": {
+			Rule:     `{">": [{"var": "user.score"}, 700]}`,
+			Data:     `{}`,
+			Expected: `true`,
+			Resolved: []string{"user.score"},
+		},
+		"data comes first": {
+			Rule:     `{"var": "user.country"}`,
+			Data:     `{"user": {"country": "BR"}}`,
+			Expected: `"BR"`,
+			Resolved: []string{},
+		},
+		"default when not resolved": {
+			Rule:     `{"var": ["user.age", 18]}`,
+			Data:     `{}`,
+			Expected: `18`,
+			Resolved: []string{"user.age"},
+		},
+		"missing": {
+			Rule:     `{"missing": ["user.score", "user.age"]}`,
+			Data:     `{}`,
+			Expected: `["user.age"]`,
+			Resolved: []string{"user.score", "user.age"},
+		},
+		"iterations": {
+			Rule:     `{"filter": [{"var": "limits"}, {">": [{"var": ""}, {"var": "threshold"}]}]}`,
+			Data:     `{"threshold": 200}`,
+			Expected: `[500]`,
+			Resolved: []string{"limits"},
+		},
+		"paths relative to the element are not resolved": {
+			Rule:     `{"map": [[{"a": 1}, {}], {"var": [".b", 0]}]}`,
+			Data:     `{}`,
+			Expected: `[0, 0]`,
+			Resolved: []string{},
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			resolved = resolved[:0]
+
+			var result bytes.Buffer
+
+			err := engine.Apply(strings.NewReader(scenario.Rule), strings.NewReader(scenario.Data), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, result.String())
+			assert.Equal(t, scenario.Resolved, resolved)
+		})
+	}
+}
+
+func TestWithVarResolverRequiresResolver(t *testing.T) {
+	_, err := NewEngine(WithVarResolver(nil))
+	assert.Error(t, err)
+}
+
+type testBatchResolver struct {
+	values  map[string]interface{}
+	batches [][]string
+	single  []string
+}
+
+func (r *testBatchResolver) Resolve(path string) (interface{}, bool) {
+	r.single = append(r.single, path)
+
+	value, ok := r.values[path]
+
+	return value, ok
+}
+
+func (r *testBatchResolver) ResolveAll(paths []string) map[string]interface{} {
+	r.batches = append(r.batches, paths)
+
+	values := make(map[string]interface{})
+	for _, path := range paths {
+		if value, ok := r.values[path]; ok {
+			values[path] = value
+		}
+	}
+
+	return values
+}
+
+func TestBatchVarResolver(t *testing.T) {
+	scenarios := map[string]struct {
+		Rule     string
+		Data     string
+		Expected string
+		Batches  [][]string
+		Single   []string
+	}{
+		"paths are resolved at once": {
+			Rule:     `{"cat": [{"var": "country"}, {"var": "active"}, {"var": "score"}]}`,
+			Data:     `{"active": "-"}`,
+			Expected: `"PT-720"`,
+			Batches:  [][]string{{"country", "score"}},
+		},
+		"operands of and after the first are resolved when reached": {
+			Rule:     `{"and": [{">": [{"var": "score"}, 700]}, {"==": [{"var": "country"}, "PT"]}, {"var": "active"}]}`,
+			Data:     `{"active": true}`,
+			Expected: `true`,
+			Batches:  [][]string{{"score"}},
+			Single:   []string{"country"},
+		},
+		"operands of or after a short circuit are never resolved": {
+			Rule:     `{"or": [{"==": [{"var": "country"}, "PT"]}, {"var": "age"}]}`,
+			Data:     `{}`,
+			Expected: `true`,
+			Batches:  [][]string{{"country"}},
+		},
+		"branches of if are resolved when chosen": {
+			Rule:     `{"if": [{"var": "vip"}, {"var": "age"}, {"<": [{"var": "score"}, 700]}, "low", {"var": "country"}]}`,
+			Data:     `{"vip": false}`,
+			Expected: `"PT"`,
+			Single:   []string{"score", "country"},
+		},
+		"branches of the ternary operator are resolved when chosen": {
+			Rule:     `{"?:": [{">": [{"var": "score"}, 700]}, {"var": "country"}, {"var": "age"}]}`,
+			Data:     `{}`,
+			Expected: `"PT"`,
+			Batches:  [][]string{{"score"}},
+			Single:   []string{"country"},
+		},
+		"paths read around a condition are resolved at once": {
+			Rule:     `{"cat": [{"var": "country"}, {"if": [{">": [{"var": "score"}, 700]}, {"var": "age"}, "-"]}]}`,
+			Data:     `{}`,
+			Expected: `"PT"`,
+			Batches:  [][]string{{"country", "score"}},
+			Single:   []string{"age"},
+		},
+		"missing paths are not resolved again": {
+			Rule:     `{"var": ["age", 18]}`,
+			Data:     `{}`,
+			Expected: `18`,
+			Batches:  [][]string{{"age"}},
+		},
+		"computed paths are resolved one by one": {
+			Rule:     `{"var": {"cat": ["coun", "try"]}}`,
+			Data:     `{}`,
+			Expected: `"PT"`,
+			Single:   []string{"country"},
+		},
+		"results of switch are resolved when chosen": {
+			Rule:     `{"switch": [{"var": "country"}, [["PT", {"var": "score"}], ["BR", {"var": "age"}]], {"var": "other"}]}`,
+			Data:     `{}`,
+			Expected: `720`,
+			Batches:  [][]string{{"country"}},
+			Single:   []string{"score"},
+		},
+		"values given by reduce": {
+			Rule:     `{"reduce": [{"var": "limits"}, {"+": [{"var": "current"}, {"var": "accumulator"}]}, 0]}`,
+			Data:     `{}`,
+			Expected: `600`,
+			Batches:  [][]string{{"limits"}},
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			resolver := &testBatchResolver{values: map[string]interface{}{
+				"score":   720,
+				"country": "PT",
+				"limits":  []int{100, 500},
+			}}
+
+			engine, err := NewEngine(WithVarResolver(resolver))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var result bytes.Buffer
+
+			err = engine.Apply(strings.NewReader(scenario.Rule), strings.NewReader(scenario.Data), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, result.String())
+			assert.Equal(t, scenario.Batches, resolver.batches)
+			assert.Equal(t, scenario.Single, resolver.single)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package jsonlogic
+
+import (
+	"fmt"
+)
+
+// Route is a rule with the label it routes the data matching it to
+type Route struct {
+	Label string
+	Rule  *Rule
+}
+
+// Router routes data to the label of the first rule it matches, like
+// events to the queue handling them
+type Router struct {
+	engine *Engine
+	routes []Route
+	rules  []*Rule
+}
+
+// NewRouter returns a Router checking the routes in order. See
+// Engine.NewRouter.
+func NewRouter(routes ...Route) (*Router, error) {
+	return defaultEngine.NewRouter(routes...)
+}
+
+// NewRouter returns a Router checking the routes in order, with the
+// engine. The rules are applied to the data as with ApplyAll: the data is
+// decoded once, and the expressions they have in common are evaluated
+// once.
+func (e *Engine) NewRouter(routes ...Route) (*Router, error) {
+	rules := make([]*Rule, 0, len(routes))
+	for _, route := range routes {
+		if route.Rule == nil {
+			return nil, fmt.Errorf("route %q has no rule", route.Label)
+		}
+
+		if err := e.checkRule(route.Rule); err != nil {
+			return nil, fmt.Errorf("route %q: %w", route.Label, err)
+		}
+
+		rules = append(rules, route.Rule)
+	}
+
+	return &Router{engine: e, routes: append([]Route(nil), routes...), rules: rules}, nil
+}
+
+// Evaluate returns the label of the first route whose rule is truthy for
+// the JSON data, false when there is none
+func (r *Router) Evaluate(data []byte) (string, bool, error) {
+	labels, err := r.evaluate(data, true)
+	if err != nil || len(labels) == 0 {
+		return "", false, err
+	}
+
+	return labels[0], true, nil
+}
+
+// EvaluateAll returns the labels of all the routes whose rule is truthy
+// for the JSON data, in order
+func (r *Router) EvaluateAll(data []byte) ([]string, error) {
+	return r.evaluate(data, false)
+}
+
+func (r *Router) evaluate(data []byte, first bool) ([]string, error) {
+	_data, err := r.engine.decodeShared(r.rules, data)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing data %w", err)
+	}
+
+	labels := make([]string, 0)
+
+	failed, err := r.engine.applyEach(r.rules, _data, func(i int, result interface{}) bool {
+		if !isTrue(result) {
+			return true
+		}
+
+		labels = append(labels, r.routes[i].Label)
+
+		return !first
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error applying the rule of route %q: %w", r.routes[failed].Label, err)
+	}
+
+	return labels, nil
+}
@@ -0,0 +1,104 @@
+package jsonlogic
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func routes(t *testing.T) []Route {
+	compile := func(rule string) *Rule {
+		compiled, err := Compile(strings.NewReader(rule))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return compiled
+	}
+
+	return []Route{
+		{Label: "fraud", Rule: compile(`{">": [{"var": "amount"}, 10000]}`)},
+		{Label: "refunds", Rule: compile(`{"==": [{"var": "type"}, "refund"]}`)},
+		{Label: "payments", Rule: compile(`{"in": [{"var": "type"}, ["payment", "refund"]]}`)},
+	}
+}
+
+func TestRouter(t *testing.T) {
+	router, err := NewRouter(routes(t)...)
+	assert.NoError(t, err)
+
+	scenarios := map[string]struct {
+		data     string
+		label    string
+		matched  bool
+		expected []string
+	}{
+		"first route": {
+			data:     `{"amount": 20000, "type": "payment"}`,
+			label:    "fraud",
+			matched:  true,
+			expected: []string{"fraud", "payments"},
+		},
+		"later route": {
+			data:     `{"amount": 10, "type": "refund"}`,
+			label:    "refunds",
+			matched:  true,
+			expected: []string{"refunds", "payments"},
+		},
+		"no route": {
+			data:     `{"amount": 10, "type": "signup"}`,
+			expected: []string{},
+		},
+		"no data": {
+			expected: []string{},
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			label, matched, err := router.Evaluate([]byte(scenario.data))
+			assert.NoError(t, err)
+			assert.Equal(t, scenario.label, label)
+			assert.Equal(t, scenario.matched, matched)
+
+			labels, err := router.EvaluateAll([]byte(scenario.data))
+			assert.NoError(t, err)
+			assert.Equal(t, scenario.expected, labels)
+		})
+	}
+}
+
+func TestRouterStopsAtFirstMatch(t *testing.T) {
+	engine, err := NewEngine(WithStrictCasts())
+	assert.NoError(t, err)
+
+	broken, err := Compile(strings.NewReader(`{"to_number": "abc"}`))
+	assert.NoError(t, err)
+
+	router, err := engine.NewRouter(append(routes(t), Route{Label: "broken", Rule: broken})...)
+	assert.NoError(t, err)
+
+	label, _, err := router.Evaluate([]byte(`{"type": "payment"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "payments", label)
+
+	_, _, err = router.Evaluate([]byte(`{"type": "signup"}`))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `"broken"`)
+
+	_, err = router.EvaluateAll([]byte(`{"type": "payment"}`))
+	assert.Error(t, err)
+}
+
+func TestRouterErrors(t *testing.T) {
+	_, err := NewRouter(Route{Label: "empty"})
+	assert.Error(t, err)
+
+	router, err := NewRouter(routes(t)...)
+	assert.NoError(t, err)
+
+	_, _, err = router.Evaluate([]byte(`{"amount": `))
+	assert.Error(t, err)
+}
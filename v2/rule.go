@@ -0,0 +1,431 @@
+package jsonlogic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// Rule is a rule decoded once, to be applied many times. Rules never
+// change once compiled, so a Rule can be applied by any number of
+// goroutines at once, with any engines, without locking: evaluations only
+// read it, and keep their state in their own evaluators.
+type Rule struct {
+	tree    interface{}
+	program program
+
+	// paths are the var paths the rule reads from the data, when they can
+	// all be known before the evaluation
+	paths    *pathNode
+	read     []string
+	complete bool
+
+	// integers tells if the whole numbers of the rule were read as
+	// integers, see WithIntegers
+	integers bool
+
+	// order holds the order of the keys of the objects of the rule, when
+	// compiled by an engine created WithKeyOrder
+	order keyOrder
+
+	// keys identify the expressions whose results can be shared with
+	// other rules applied to the same data, see ApplyAll
+	keysOnce sync.Once
+	keys     map[uintptr]expressionKey
+}
+
+// Compile reads a rule to be applied many times. See Engine.Compile.
+func Compile(rule io.Reader) (*Rule, error) {
+	return defaultEngine.Compile(rule)
+}
+
+// Compile reads a rule to be applied many times, reading its numbers the
+// way the engine reads them, and the order of its keys when it keeps them.
+// Engines only apply the rules compiled by engines reading numbers the
+// same way, see WithIntegers.
+func (e *Engine) Compile(rule io.Reader) (*Rule, error) {
+	var tree interface{}
+
+	order := e.newKeyOrder()
+
+	err := e.readJSON(order, rule, &tree)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing rule: %w", err)
+	}
+
+	compiled := compile(tree, e.keepsIntegers())
+	compiled.order = order
+
+	return compiled, nil
+}
+
+func compile(tree interface{}, integers bool) *Rule {
+	paths, complete := dataPaths(tree, make([]string, 0))
+
+	compiled := new(Rule)
+	compiled.reset(tree, paths, complete, integers)
+
+	return compiled
+}
+
+// reset makes r the rule compiled from tree, which reads paths from the
+// data when complete and has integers when integers is set
+func (r *Rule) reset(tree interface{}, paths []string, complete, integers bool) {
+	*r = Rule{tree: tree, program: compileProgram(tree), complete: complete, integers: integers}
+	if complete {
+		r.paths = newPathTree(paths)
+		r.read = paths
+	}
+}
+
+// ApplyBool applies a compiled rule to JSON data, returning the
+// truthiness of the result. See Engine.ApplyBool.
+func ApplyBool(rule *Rule, data []byte) (bool, error) {
+	return defaultEngine.ApplyBool(rule, data)
+}
+
+// ApplyBool applies a compiled rule to JSON data, returning the
+// truthiness of the result, which is the way rules used as conditions are
+// read. When all the var paths of the rule are known before the
+// evaluation, only the values they point to are decoded from the data:
+// the rest is scanned without being allocated.
+func (e *Engine) ApplyBool(rule *Rule, data []byte) (bool, error) {
+	if err := e.checkRule(rule); err != nil {
+		return false, err
+	}
+
+	_data, err := e.decodeData(rule, data)
+	if err != nil {
+		return false, fmt.Errorf("error parsing data %w", err)
+	}
+
+	a := e.newArena()
+	defer a.release()
+
+	result, err := e.evaluateRule(context.Background(), a, rule, _data)
+	if err != nil {
+		return false, err
+	}
+
+	return isTrue(result), nil
+}
+
+// ApplyCompiled applies a compiled rule to JSON data, returning its result
+// as JSON. See Engine.ApplyCompiled.
+func ApplyCompiled(rule *Rule, data json.RawMessage) (json.RawMessage, error) {
+	return defaultEngine.ApplyCompiled(rule, data)
+}
+
+// ApplyCompiled is like ApplyRaw, with a compiled rule: given the same rule
+// and data, it returns the same bytes, passed through the output
+// transformers, with the keys of objects in order on engines created
+// WithKeyOrder, and within the bounds of WithMaxResultSize. The keys of the
+// objects of rules read back by UnmarshalBinary are sorted.
+func (e *Engine) ApplyCompiled(rule *Rule, data json.RawMessage) (json.RawMessage, error) {
+	return e.ApplyCompiledWithContext(context.Background(), rule, data)
+}
+
+// ApplyCompiledWithContext is like ApplyCompiled, giving ctx to the
+// instrumentation of the engine, see WithInstrumentation
+func (e *Engine) ApplyCompiledWithContext(ctx context.Context, rule *Rule, data json.RawMessage) (json.RawMessage, error) {
+	if err := e.checkRule(rule); err != nil {
+		return nil, err
+	}
+
+	order := e.newKeyOrder()
+	if order != nil {
+		for key, keys := range rule.order {
+			order[key] = keys
+		}
+	}
+
+	var _data interface{}
+	var err error
+
+	if order == nil {
+		_data, err = e.decodeData(rule, data)
+	} else if len(data) > 0 {
+		err = e.unmarshal(order, data, &_data)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error parsing data %w", err)
+	}
+
+	a := e.newArena()
+	defer a.release()
+
+	var result interface{}
+	if order != nil || e.audit != nil {
+		result, err = e.evaluateIn(ctx, order, a, rule.tree, _data)
+	} else {
+		result, err = e.evaluateRule(ctx, a, rule, _data)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	result, err = e.prepare(order, result)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.marshal(result, false)
+}
+
+// evaluateRule is like evaluateWith, running the program of a compiled rule
+// instead of walking the rule when it can
+func (e *Engine) evaluateRule(ctx context.Context, a *arena, rule *Rule, data interface{}) (interface{}, error) {
+	if e.cache != nil || rule.program == nil {
+		return e.evaluateWith(ctx, a, rule.tree, data)
+	}
+
+	ev := e.acquire()
+	ev.ctx = ctx
+	ev.arena = a
+	defer release(ev)
+
+	return ev.runRule(rule, data)
+}
+
+// runRule is run, running the program of a compiled rule instead of
+// walking the rule when it can
+func (ev *evaluator) runRule(rule *Rule, data interface{}) (interface{}, error) {
+	if rule.program == nil {
+		return ev.run(rule.tree, data)
+	}
+
+	var result interface{}
+
+	err := ev.execute(rule.tree, data, func() {
+		if ev.compiled() {
+			result = ev.exec(rule.program, data)
+		} else {
+			result = materialize(ev.apply(rule.tree, data))
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// decodeData decodes the parts of data read by the rule, or all of it
+// when they aren't known
+func (e *Engine) decodeData(r *Rule, data []byte) (interface{}, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	if r.complete && json.Valid(data) {
+		if extracted, ok := e.extract(data, r.paths); ok {
+			return extracted, nil
+		}
+	}
+
+	var _data interface{}
+
+	err := e.unmarshal(nil, data, &_data)
+
+	return _data, err
+}
+
+// dataPaths appends to paths the var paths rule reads from the data,
+// telling if they are all known: computed paths and vars reading the
+// whole data make the list incomplete, and so do the paths reading the
+// data around iterations with $parent. Paths relative to the elements of
+// iterations are left out, and so are the paths of the predicates of
+// reduce, which read current and accumulator.
+func dataPaths(rule interface{}, paths []string) ([]string, bool) {
+	return collectDataPaths(rule, paths, false)
+}
+
+func collectDataPaths(rule interface{}, paths []string, local bool) ([]string, bool) {
+	if isSlice(rule) {
+		complete := true
+		for _, value := range rule.([]interface{}) {
+			var ok bool
+			paths, ok = collectDataPaths(value, paths, local)
+			complete = complete && ok
+		}
+
+		return paths, complete
+	}
+
+	if !isMap(rule) {
+		return paths, true
+	}
+
+	for operator, values := range rule.(map[string]interface{}) {
+		parsed := toSlice(values)
+
+		switch operator {
+		case "rule":
+			// the paths of named rules are only known to the registry
+			return paths, false
+		case "var":
+			if isMap(values) || (isSlice(values) && len(parsed) > 0 && !isString(parsed[0]) && !isNumber(parsed[0])) {
+				// computed path
+				return paths, false
+			}
+
+			complete := true
+			if len(parsed) > 1 {
+				// defaults can be expressions too
+				paths, complete = collectDataPaths(parsed[1:], paths, local)
+			}
+
+			path, _ := varArgs(values)
+			if path == "" || strings.HasPrefix(path, ".") {
+				// the whole data, or the element of an iteration
+				return paths, complete && local
+			}
+
+			if strings.HasPrefix(path, "/") {
+				// JSON pointers, unless the engine reads dot paths
+				return paths, false
+			}
+
+			if isParentPath(path) {
+				// the data around an iteration, which depends on how
+				// deep it is
+				return paths, false
+			}
+
+			if isPositionPath(path) {
+				// given by the iteration itself
+				return paths, complete
+			}
+
+			return append(paths, path), complete
+		case "missing", "missing_some":
+			if local {
+				// they read the element of the iteration
+				return paths, true
+			}
+
+			names := parsed
+			if operator == "missing_some" {
+				if len(parsed) != 2 || !isSlice(parsed[1]) {
+					return paths, false
+				}
+
+				names = parsed[1].([]interface{})
+			}
+
+			for _, name := range names {
+				if !isString(name) || strings.HasPrefix(name.(string), "/") {
+					return paths, false
+				}
+
+				paths = append(paths, name.(string))
+			}
+
+			return paths, true
+		case "let":
+			bindings, body, ok := letArgs(values)
+			if !ok {
+				return paths, true
+			}
+
+			complete := true
+			for _, value := range bindings {
+				paths, ok = collectDataPaths(value, paths, local)
+				complete = complete && ok
+			}
+
+			// the paths of the body starting with a bound name don't read
+			// the data
+			read, ok := collectDataPaths(body, nil, local)
+			for _, path := range read {
+				if !isBound(path, bindings) {
+					paths = append(paths, path)
+				}
+			}
+
+			return paths, complete && ok
+		case "def":
+			definitions, body, ok := defArgs(values)
+			if !ok {
+				return paths, true
+			}
+
+			paths, complete := collectDataPaths(body, paths, local)
+
+			// the bodies of the functions read their parameters and the
+			// data around the def
+			for _, definition := range definitions {
+				read, ok := collectDataPaths(definition.body, nil, local)
+				complete = complete && ok
+
+				for _, path := range read {
+					if !isBound(path, definition.paramNames()) {
+						paths = append(paths, path)
+					}
+				}
+			}
+
+			return paths, complete
+		case "template":
+			if len(parsed) == 0 || !isString(parsed[0]) {
+				// computed template
+				return paths, false
+			}
+
+			names, ok := templatePaths(parsed[0].(string))
+			if !ok {
+				return paths, true
+			}
+
+			var complete bool
+			paths, complete = collectDataPaths(parsed[1:], paths, local)
+
+			if local {
+				// the placeholders read the element of the iteration
+				return paths, complete
+			}
+
+			for _, name := range names {
+				if strings.HasPrefix(name, "/") || isParentPath(name) {
+					return paths, false
+				}
+			}
+
+			for _, name := range names {
+				if !isPositionPath(name) {
+					paths = append(paths, name)
+				}
+			}
+
+			return paths, complete
+		}
+
+		if iteratorOperators[operator] && len(parsed) > 1 {
+			paths, complete := collectDataPaths(parsed[0], paths, local)
+
+			var ok bool
+			if operator != "reduce" {
+				paths, ok = collectDataPaths(parsed[1], paths, true)
+			} else {
+				// only the completeness of the predicate matters, which
+				// $parent may break
+				_, ok = collectDataPaths(parsed[1], nil, true)
+			}
+
+			complete = complete && ok
+
+			rest, ok := collectDataPaths(parsed[2:], paths, local)
+
+			return rest, complete && ok
+		}
+
+		return collectDataPaths(values, paths, local)
+	}
+
+	return paths, true
+}
@@ -0,0 +1,341 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyBoolMatchesApply(t *testing.T) {
+	tests, err := ReadTestsFromFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprintf("SCENARIO:%d", i), func(t *testing.T) {
+			rule, err := Compile(bytes.NewReader(test.Rule))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var expected interface{}
+			if err := json.Unmarshal(test.Expected, &expected); err != nil {
+				t.Fatal(err)
+			}
+
+			result, err := ApplyBool(rule, test.Data)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, isTrue(expected), result, "rule %s, data %s", test.Rule, test.Data)
+		})
+	}
+}
+
+func TestApplyBool(t *testing.T) {
+	data := `{
+		"user": {"name": "Ana", "age": 34, "tags": ["vip"], "address": {"city": "Lisbon"}},
+		"items": [{"price": 10}, {"price": 25}],
+		"limit": 20,
+		"count": 3,
+		"noise": {"deep": [1, {"a": "b\"}"}], "text": "with \"escapes\" and } braces \\"},
+		"with\"quote": true,
+		"empty": null
+	}`
+
+	scenarios := map[string]struct {
+		Rule     string
+		Complete bool
+		Expected bool
+	}{
+		"nested path": {
+			Rule:     `{"==": [{"var": "user.address.city"}, "Lisbon"]}`,
+			Complete: true,
+			Expected: true,
+		},
+		"iteration": {
+			Rule:     `{"some": [{"var": "items"}, {">": [{"var": ".price"}, {"var": "limit"}]}]}`,
+			Complete: true,
+			Expected: true,
+		},
+		"index": {
+			Rule:     `{"<": [{"var": "items.1.price"}, {"var": "limit"}]}`,
+			Complete: true,
+			Expected: false,
+		},
+		"whole object": {
+			Rule:     `{"in": ["vip", {"var": "user.tags"}]}`,
+			Complete: true,
+			Expected: true,
+		},
+		"escaped key": {
+			Rule:     `{"var": "with\"quote"}`,
+			Complete: true,
+			Expected: true,
+		},
+		"path through null": {
+			Rule:     `{"var": ["empty.value", true]}`,
+			Complete: true,
+			Expected: true,
+		},
+		"path through a number": {
+			Rule:     `{"var": "count.value"}`,
+			Complete: true,
+			Expected: false,
+		},
+		"missing": {
+			Rule:     `{"!": [{"missing": ["user.name", "limit"]}]}`,
+			Complete: true,
+			Expected: true,
+		},
+		"template": {
+			Rule:     `{"==": [{"template": ["{user.name} ({1})", {"var": "count"}]}, "Ana (3)"]}`,
+			Complete: true,
+			Expected: true,
+		},
+		"computed path": {
+			Rule:     `{"var": {"cat": ["li", "mit"]}}`,
+			Complete: false,
+			Expected: true,
+		},
+		"whole data": {
+			Rule:     `{"!!": [{"var": ""}]}`,
+			Complete: false,
+			Expected: true,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			rule, err := Compile(strings.NewReader(scenario.Rule))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, scenario.Complete, rule.complete)
+
+			result, err := ApplyBool(rule, []byte(data))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, scenario.Expected, result)
+		})
+	}
+}
+
+func TestApplyBoolErrors(t *testing.T) {
+	_, err := Compile(strings.NewReader(`{`))
+	assert.Error(t, err)
+
+	rule, err := Compile(strings.NewReader(`{"var": "a"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = ApplyBool(rule, []byte(`{"a": `))
+	assert.Error(t, err)
+
+	result, err := ApplyBool(rule, nil)
+	assert.NoError(t, err)
+	assert.False(t, result)
+}
+
+func TestApplyCompiledMatchesApplyRaw(t *testing.T) {
+	tests, err := ReadTestsFromFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprintf("SCENARIO:%d", i), func(t *testing.T) {
+			rule, err := Compile(bytes.NewReader(test.Rule))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expected, err := ApplyRaw(test.Rule, test.Data)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			result, err := ApplyCompiled(rule, test.Data)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, string(expected), string(result))
+		})
+	}
+}
+
+func TestApplyCompiled(t *testing.T) {
+	scenarios := map[string]struct {
+		Options []Option
+		Rule    string
+		Data    string
+		Err     error
+	}{
+		"data": {
+			Rule: `{"var": "user"}`,
+			Data: `{"user": {"name": "Ana", "age": 34}}`,
+		},
+		"key order of the data": {
+			Options: []Option{WithKeyOrder()},
+			Rule:    `{"var": "user"}`,
+			Data:    `{"user": {"name": "Ana", "age": 34}}`,
+		},
+		"key order of the rule": {
+			Options: []Option{WithKeyOrder()},
+			Rule:    `{"merge": [[{"z": 1, "a": 2}]]}`,
+			Data:    `{}`,
+		},
+		"output transformers": {
+			Options: []Option{WithOutputTransformers(RoundFloats(1), StripNulls())},
+			Rule:    `{"var": ""}`,
+			Data:    `{"price": 1.25, "note": null}`,
+		},
+		"result size": {
+			Options: []Option{WithMaxResultSize(8)},
+			Rule:    `{"var": "name"}`,
+			Data:    `{"name": "Ana Maria"}`,
+			Err:     ErrLimitExceeded,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			engine, err := NewEngine(scenario.Options...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rule, err := engine.Compile(strings.NewReader(scenario.Rule))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expected, expectedErr := engine.ApplyRaw(json.RawMessage(scenario.Rule), json.RawMessage(scenario.Data))
+			result, err := engine.ApplyCompiled(rule, json.RawMessage(scenario.Data))
+
+			if scenario.Err != nil {
+				assert.True(t, errors.Is(expectedErr, scenario.Err), "unexpected error: %v", expectedErr)
+				assert.True(t, errors.Is(err, scenario.Err), "unexpected error: %v", err)
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.NoError(t, expectedErr)
+			assert.Equal(t, string(expected), string(result))
+		})
+	}
+}
+
+func BenchmarkApplyBool(b *testing.B) {
+	items := make([]string, 0, 200)
+	for i := 0; i < 200; i++ {
+		items = append(items, fmt.Sprintf(`{"id": %d, "name": "item %d", "tags": ["a", "b", "c"]}`, i, i))
+	}
+
+	data := []byte(fmt.Sprintf(`{"user": {"age": 34, "country": "PT"}, "items": [%s]}`, strings.Join(items, ",")))
+
+	rule, err := Compile(strings.NewReader(`{"and": [{">=": [{"var": "user.age"}, 18]}, {"==": [{"var": "user.country"}, "PT"]}]}`))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		result, err := ApplyBool(rule, data)
+		if err != nil || !result {
+			b.Fatal(result, err)
+		}
+	}
+}
+
+// TestRuleConcurrentUse applies shared rules from many goroutines at once,
+// for the race detector to check that evaluations only read them
+func TestRuleConcurrentUse(t *testing.T) {
+	sources := map[string]string{
+		"program":    `{"and": [{">=": [{"var": "user.age"}, 18]}, {"in": [{"var": "user.country"}, ["PT", "ES"]]}]}`,
+		"iterations": `{"some": [{"var": "items"}, {"and": [{">": [{"var": ".price"}, {"var": "$parent.limit"}]}, {"in": [2, {"merge": [[1], [2]]}]}]}]}`,
+		"let":        `{"let": {"limit": {"*": [{"var": "limit"}, 2]}, "in": {"<": [{"var": "limit"}, {"reduce": [{"var": "items"}, {"+": [{"var": "accumulator"}, {"var": "current.price"}]}, 0]}]}}}`,
+		"regex":      `{"match": [{"var": "user.name"}, "^A"]}`,
+		"template":   `{"==": [{"template": ["{user.name}-{user.age}", {"var": ""}]}, "Ana-34"]}`,
+		"sort":       `{"==": [{"cat": {"sort": [{"map": [{"var": "items"}, {"var": ".price"}]}]}}, "102540"]}`,
+	}
+
+	data := []byte(`{"user": {"name": "Ana", "age": 34, "country": "PT"}, "items": [{"price": 10}, {"price": 40}, {"price": 25}], "limit": 20}`)
+
+	rules := make(map[string]*Rule, len(sources))
+	expected := make(map[string]bool, len(sources))
+
+	for name, source := range sources {
+		rule, err := Compile(strings.NewReader(source))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := ApplyBool(rule, data)
+		if err != nil {
+			t.Fatal(name, err)
+		}
+
+		rules[name] = rule
+		expected[name] = result
+	}
+
+	var engines []*Engine
+	for _, options := range [][]Option{nil, {WithArena()}, {WithParallelism(4, 2)}, {WithStepBudget(1000)}} {
+		engine, err := NewEngine(options...)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		engines = append(engines, engine)
+	}
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+
+		go func(engine *Engine) {
+			defer wg.Done()
+
+			for j := 0; j < 20; j++ {
+				for name, rule := range rules {
+					result, err := engine.ApplyBool(rule, data)
+					assert.NoError(t, err)
+					assert.Equal(t, expected[name], result, name)
+				}
+
+				results, err := engine.ApplyAll(rules, data)
+				assert.NoError(t, err)
+
+				for name, result := range results {
+					assert.Equal(t, expected[name], isTrue(result), name)
+				}
+
+				_, err = rules["program"].MarshalBinary()
+				assert.NoError(t, err)
+			}
+		}(engines[i%len(engines)])
+	}
+
+	wg.Wait()
+}
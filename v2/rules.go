@@ -0,0 +1,95 @@
+package jsonlogic
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrUnknownRule is returned when {"rule": name} names a rule missing
+	// from the registry
+	ErrUnknownRule = errors.New("unknown rule")
+	// ErrRecursiveRule is returned when a named rule references itself,
+	// directly or through other named rules
+	ErrRecursiveRule = errors.New("recursive rule")
+)
+
+// RuleRegistry provides the rules referenced by name with
+// {"rule": "name"}, in the form of decoded JSON
+type RuleRegistry interface {
+	Rule(name string) (interface{}, bool)
+}
+
+// Rules is a RuleRegistry holding decoded rules by name. It can be read
+// from a JSON object with json.Unmarshal.
+type Rules map[string]interface{}
+
+// Rule returns the rule named name
+func (r Rules) Rule(name string) (interface{}, bool) {
+	rule, ok := r[name]
+
+	return rule, ok
+}
+
+// WithRuleRegistry makes {"rule": "name"} evaluate the rule registry has
+// for name, against the same data as the expression referencing it, so
+// rules can share common fragments:
+//
+//	{"and": [{"rule": "is_premium_customer"}, {">": [{"var": "total"}, 100]}]}
+//
+// Named rules can reference other named rules, but not themselves.
+func WithRuleRegistry(registry RuleRegistry) Option {
+	return func(e *Engine) error {
+		if registry == nil {
+			return fmt.Errorf("registry must not be nil")
+		}
+
+		e.registry = registry
+
+		return nil
+	}
+}
+
+// reference evaluates a named rule: {"rule": name}
+func (ev *evaluator) reference(values, data interface{}) interface{} {
+	name := values
+	if isSlice(values) && len(values.([]interface{})) > 0 {
+		name = values.([]interface{})[0]
+	}
+
+	if !isString(name) {
+		ev.fail(fmt.Errorf("%w: the name must be a string, got %v", ErrUnknownRule, name))
+	}
+
+	_name := name.(string)
+
+	var rule interface{}
+
+	ok := false
+	if ev.engine.registry != nil {
+		rule, ok = ev.engine.registry.Rule(_name)
+	}
+
+	if !ok {
+		ev.fail(fmt.Errorf("%w %q", ErrUnknownRule, _name))
+	}
+
+	for _, reference := range ev.references {
+		if reference == _name {
+			ev.fail(fmt.Errorf("%w %q", ErrRecursiveRule, _name))
+		}
+	}
+
+	if !isMap(rule) {
+		return rule
+	}
+
+	// the slice is shared with the workers of parallel iterations, so it
+	// is copied instead of appended to in place
+	ev.references = append(ev.references[:len(ev.references):len(ev.references)], _name)
+	defer func() {
+		ev.references = ev.references[:len(ev.references)-1]
+	}()
+
+	return ev.apply(rule, data)
+}
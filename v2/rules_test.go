@@ -0,0 +1,151 @@
+package jsonlogic
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuleRegistry(t *testing.T) {
+	var rules Rules
+
+	err := json.Unmarshal([]byte(`{
+		"is_adult": {">=": [{"var": "age"}, 18]},
+		"is_premium": {"and": [{"rule": "is_adult"}, {"==": [{"var": "plan"}, "premium"]}]},
+		"threshold": 100,
+		"is_expensive": {">": [{"var": ".price"}, {"rule": "threshold"}]}
+	}`), &rules)
+	assert.NoError(t, err)
+
+	engine, err := NewEngine(WithRuleRegistry(rules))
+	assert.NoError(t, err)
+
+	scenarios := map[string]struct {
+		rule     string
+		data     string
+		expected string
+	}{
+		"named rule": {
+			rule:     `{"rule": "is_adult"}`,
+			data:     `{"age": 20}`,
+			expected: `true`,
+		},
+		"list argument": {
+			rule:     `{"rule": ["is_adult"]}`,
+			data:     `{"age": 10}`,
+			expected: `false`,
+		},
+		"nested references": {
+			rule:     `{"if": [{"rule": "is_premium"}, "gold", "standard"]}`,
+			data:     `{"age": 30, "plan": "premium"}`,
+			expected: `"gold"`,
+		},
+		"literal": {
+			rule:     `{"+": [{"rule": "threshold"}, 1]}`,
+			data:     `{}`,
+			expected: `101`,
+		},
+		"inside iterations": {
+			rule:     `{"filter": [{"var": "items"}, {"rule": "is_expensive"}]}`,
+			data:     `{"items": [{"price": 50}, {"price": 150}]}`,
+			expected: `[{"price": 150}]`,
+		},
+		"computed name": {
+			rule:     `{"rule": {"cat": ["is_", "adult"]}}`,
+			data:     `{"age": 20}`,
+			expected: `true`,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var result strings.Builder
+
+			err := engine.Apply(strings.NewReader(scenario.rule), strings.NewReader(scenario.data), &result)
+			assert.NoError(t, err)
+			assert.JSONEq(t, scenario.expected, result.String())
+		})
+	}
+}
+
+func TestRuleRegistryErrors(t *testing.T) {
+	engine, err := NewEngine(WithRuleRegistry(Rules{
+		"loop":  map[string]interface{}{"!": []interface{}{map[string]interface{}{"rule": "other"}}},
+		"other": map[string]interface{}{"rule": "loop"},
+		"self":  map[string]interface{}{"or": []interface{}{false, map[string]interface{}{"rule": "self"}}},
+	}))
+	assert.NoError(t, err)
+
+	scenarios := map[string]struct {
+		rule     interface{}
+		expected error
+	}{
+		"unknown": {
+			rule:     map[string]interface{}{"rule": "nope"},
+			expected: ErrUnknownRule,
+		},
+		"not a name": {
+			rule:     map[string]interface{}{"rule": 1.0},
+			expected: ErrUnknownRule,
+		},
+		"self reference": {
+			rule:     map[string]interface{}{"rule": "self"},
+			expected: ErrRecursiveRule,
+		},
+		"cycle": {
+			rule:     map[string]interface{}{"rule": "loop"},
+			expected: ErrRecursiveRule,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			_, err := engine.ApplyInterface(scenario.rule, nil)
+			assert.True(t, errors.Is(err, scenario.expected), err)
+		})
+	}
+
+	_, err = ApplyInterface(map[string]interface{}{"rule": "any"}, nil)
+	assert.True(t, errors.Is(err, ErrUnknownRule))
+
+	_, err = NewEngine(WithRuleRegistry(nil))
+	assert.Error(t, err)
+}
+
+func TestRuleRegistryCompiled(t *testing.T) {
+	engine, err := NewEngine(WithRuleRegistry(Rules{
+		"is_adult": map[string]interface{}{">=": []interface{}{map[string]interface{}{"var": "user.age"}, 18.0}},
+	}))
+	assert.NoError(t, err)
+
+	rule, err := Compile(strings.NewReader(`{"rule": "is_adult"}`))
+	assert.NoError(t, err)
+
+	// the paths read by named rules are unknown, so the data is decoded
+	result, err := engine.ApplyBool(rule, []byte(`{"user": {"age": 20}}`))
+	assert.NoError(t, err)
+	assert.True(t, result)
+}
+
+func TestRuleRegistryParallel(t *testing.T) {
+	engine, err := NewEngine(
+		WithRuleRegistry(Rules{"even": map[string]interface{}{"==": []interface{}{map[string]interface{}{"%": []interface{}{map[string]interface{}{"var": ""}, 2.0}}, 0.0}}}),
+		WithParallelism(4, 2),
+	)
+	assert.NoError(t, err)
+
+	list := make([]interface{}, 0, 100)
+	for i := 0; i < 100; i++ {
+		list = append(list, float64(i))
+	}
+
+	result, err := engine.ApplyInterface(map[string]interface{}{
+		"filter": []interface{}{map[string]interface{}{"var": "list"}, map[string]interface{}{"rule": "even"}},
+	}, map[string]interface{}{"list": list})
+	assert.NoError(t, err)
+	assert.Len(t, result, 50)
+}
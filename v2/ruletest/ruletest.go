@@ -0,0 +1,197 @@
+// Package ruletest tests JSON Logic rules against the results expected of
+// them, for repositories of rules to check them with go test. Cases are
+// read from JSON files in the format of the tests of jsonlogic.com: a list
+// of [rule, data, expected] triples, where strings are comments naming the
+// cases following them:
+//
+//	[
+//	  "# Discounts",
+//	  [{">=": [{"var": "total"}, 100]}, {"total": 120}, true],
+//	  [{">=": [{"var": "total"}, 100]}, {"total": 80}, false]
+//	]
+//
+// Run runs the cases of a file as subtests:
+//
+//	func TestRules(t *testing.T) {
+//		ruletest.Run(t, "testdata/discounts.json")
+//	}
+//
+// Results are compared as JSON values, so 1 and 1.0 are the same, and
+// differences are reported as the changes from the expected result to the
+// actual one.
+package ruletest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bewica/jsonlogic/v2"
+)
+
+// Case is a rule applied to some data, and the result expected of it
+type Case struct {
+	// Name is the comment preceding the case, followed by its position in
+	// the file: "Discounts #2"
+	Name     string
+	Rule     json.RawMessage
+	Data     json.RawMessage
+	Expected json.RawMessage
+}
+
+// Read reads cases written as a list of [rule, data, expected] triples and
+// comments
+func Read(r io.Reader) ([]Case, error) {
+	var items []json.RawMessage
+
+	if err := json.NewDecoder(r).Decode(&items); err != nil {
+		return nil, fmt.Errorf("error parsing cases: %w", err)
+	}
+
+	cases := make([]Case, 0, len(items))
+	section := "case"
+
+	for i, item := range items {
+		var comment string
+		if err := json.Unmarshal(item, &comment); err == nil {
+			section = strings.TrimSpace(strings.TrimLeft(comment, "#"))
+
+			continue
+		}
+
+		var triple []json.RawMessage
+		if err := json.Unmarshal(item, &triple); err != nil || len(triple) != 3 {
+			return nil, fmt.Errorf("item %d: expected a comment or a [rule, data, expected] triple, got %s", i, item)
+		}
+
+		cases = append(cases, Case{
+			Name:     fmt.Sprintf("%s #%d", section, i),
+			Rule:     triple[0],
+			Data:     triple[1],
+			Expected: triple[2],
+		})
+	}
+
+	return cases, nil
+}
+
+// ReadFile reads the cases of a file, see Read
+func ReadFile(name string) ([]Case, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Read(f)
+}
+
+// Failure is a case whose rule failed or didn't give the expected result
+type Failure struct {
+	Case Case
+
+	// Result is the result the rule gave, nil when it failed with Err
+	Result json.RawMessage
+	Err    error
+
+	// Changes turn the expected result into the actual one
+	Changes []jsonlogic.Change
+}
+
+func (f *Failure) Error() string {
+	if f.Err != nil {
+		return fmt.Sprintf("%s: %s applied to %s failed: %v", f.Case.Name, compact(f.Case.Rule), compact(f.Case.Data), f.Err)
+	}
+
+	var message strings.Builder
+
+	fmt.Fprintf(&message, "%s: %s applied to %s\n\texpected %s\n\tgot      %s", f.Case.Name, compact(f.Case.Rule), compact(f.Case.Data), compact(f.Case.Expected), compact(f.Result))
+
+	for _, change := range f.Changes {
+		if change.Path == "" {
+			// the whole result differs, which the lines above tell
+			continue
+		}
+
+		fmt.Fprintf(&message, "\n\t%s", change)
+	}
+
+	return message.String()
+}
+
+func compact(raw json.RawMessage) string {
+	var b bytes.Buffer
+	if err := json.Compact(&b, raw); err != nil {
+		return string(raw)
+	}
+
+	return b.String()
+}
+
+var defaultEngine, _ = jsonlogic.NewEngine()
+
+// Check applies the rule of a case, returning a *Failure when it fails or
+// doesn't give the expected result
+func Check(c Case) error {
+	return CheckWith(defaultEngine, c)
+}
+
+// CheckWith is like Check, applying the rule with an engine
+func CheckWith(engine *jsonlogic.Engine, c Case) error {
+	result, err := engine.ApplyRaw(c.Rule, c.Data)
+	if err != nil {
+		return &Failure{Case: c, Err: err}
+	}
+
+	var expected, actual interface{}
+
+	if err := json.Unmarshal(c.Expected, &expected); err != nil {
+		return fmt.Errorf("%s: error parsing the expected result: %w", c.Name, err)
+	}
+
+	if err := json.Unmarshal(result, &actual); err != nil {
+		return &Failure{Case: c, Result: result, Err: err}
+	}
+
+	if reflect.DeepEqual(expected, actual) {
+		return nil
+	}
+
+	changes, err := jsonlogic.Diff(bytes.NewReader(c.Expected), bytes.NewReader(result))
+	if err != nil {
+		return &Failure{Case: c, Result: result, Err: err}
+	}
+
+	return &Failure{Case: c, Result: result, Changes: changes}
+}
+
+// Run checks the cases of a file, each one in a subtest of t named after
+// it
+func Run(t *testing.T, name string) {
+	RunWith(t, defaultEngine, name)
+}
+
+// RunWith is like Run, applying the rules with an engine
+func RunWith(t *testing.T, engine *jsonlogic.Engine, name string) {
+	t.Helper()
+
+	cases, err := ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			if err := CheckWith(engine, c); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
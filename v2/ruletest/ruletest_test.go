@@ -0,0 +1,102 @@
+package ruletest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bewica/jsonlogic/v2"
+)
+
+func TestRun(t *testing.T) {
+	Run(t, "testdata/rules.json")
+}
+
+func TestRead(t *testing.T) {
+	cases, err := Read(strings.NewReader(`[
+		[{"var": "a"}, {"a": 1}, 1],
+		"# Strings",
+		[{"cat": ["a", "b"]}, null, "ab"]
+	]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []Case{
+		{Name: "case #0", Rule: json.RawMessage(`{"var": "a"}`), Data: json.RawMessage(`{"a": 1}`), Expected: json.RawMessage(`1`)},
+		{Name: "Strings #2", Rule: json.RawMessage(`{"cat": ["a", "b"]}`), Data: json.RawMessage(`null`), Expected: json.RawMessage(`"ab"`)},
+	}, cases)
+}
+
+func TestReadInvalidCases(t *testing.T) {
+	scenarios := map[string]string{
+		"not a list":       `{"rule": true}`,
+		"short triple":     `[[true, {}]]`,
+		"neither":          `[1]`,
+		"invalid JSON":     `[[true, {}, true]`,
+		"comment not text": `[["# comment"], [true, {}, true]]`,
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			_, err := Read(strings.NewReader(scenario))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	scenarios := map[string]struct {
+		Case     Case
+		Expected string
+	}{
+		"passing": {
+			Case{Name: "a", Rule: json.RawMessage(`{"+": [1, 2]}`), Data: json.RawMessage(`{}`), Expected: json.RawMessage(`3.0`)},
+			``,
+		},
+		"wrong value": {
+			Case{Name: "b", Rule: json.RawMessage(`{"+": [1, 2]}`), Data: json.RawMessage(`{}`), Expected: json.RawMessage(`4`)},
+			"b: {\"+\":[1,2]} applied to {}\n\texpected 4\n\tgot      3",
+		},
+		"nested difference": {
+			Case{Name: "c", Rule: json.RawMessage(`{"var": "user"}`), Data: json.RawMessage(`{"user": {"name": "Ana", "roles": ["admin"]}}`), Expected: json.RawMessage(`{"name": "Ana", "roles": ["admin", "dev"]}`)},
+			"c: {\"var\":\"user\"} applied to {\"user\":{\"name\":\"Ana\",\"roles\":[\"admin\"]}}\n\texpected {\"name\":\"Ana\",\"roles\":[\"admin\",\"dev\"]}\n\tgot      {\"name\":\"Ana\",\"roles\":[\"admin\"]}\n\t/roles/1: removed \"dev\"",
+		},
+		"failing rule": {
+			Case{Name: "d", Rule: json.RawMessage(`{"rule": "missing"}`), Data: json.RawMessage(`{}`), Expected: json.RawMessage(`true`)},
+			"d: {\"rule\":\"missing\"} applied to {} failed: unknown rule \"missing\"",
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			err := Check(scenario.Case)
+			if scenario.Expected == "" {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			var failure *Failure
+			if assert.True(t, errors.As(err, &failure)) {
+				assert.Equal(t, scenario.Expected, failure.Error())
+			}
+		})
+	}
+}
+
+func TestCheckWith(t *testing.T) {
+	engine, err := jsonlogic.NewEngine(jsonlogic.WithRuleRegistry(jsonlogic.Rules{"adult": map[string]interface{}{
+		">=": []interface{}{map[string]interface{}{"var": "age"}, 18.0},
+	}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = CheckWith(engine, Case{Name: "a", Rule: json.RawMessage(`{"rule": "adult"}`), Data: json.RawMessage(`{"age": 20}`), Expected: json.RawMessage(`true`)})
+	assert.NoError(t, err)
+}
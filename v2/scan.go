@@ -0,0 +1,245 @@
+package jsonlogic
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// pathNode is a tree of var paths: the children of a node are the
+// properties read from the value it stands for, and leaves are read whole
+type pathNode struct {
+	leaf     bool
+	children map[string]*pathNode
+}
+
+func newPathTree(paths []string) *pathNode {
+	root := &pathNode{children: make(map[string]*pathNode)}
+
+	for _, path := range paths {
+		node := root
+		for _, part := range strings.Split(path, ".") {
+			if part == "" {
+				continue
+			}
+
+			child, ok := node.children[part]
+			if !ok {
+				child = &pathNode{children: make(map[string]*pathNode)}
+				node.children[part] = child
+			}
+
+			node = child
+		}
+
+		node.leaf = true
+	}
+
+	return root
+}
+
+// extract decodes from valid JSON data an object with only the values
+// the paths of root point to, scanning over everything else, reading
+// numbers the way the engine does. It fails when the data can't be
+// represented partially: when it isn't an object, or when a path goes
+// through a value which isn't an object.
+func (e *Engine) extract(data []byte, root *pathNode) (interface{}, bool) {
+	s := &scanner{engine: e, data: data}
+	s.skipSpace()
+
+	if s.peek() != '{' {
+		return nil, false
+	}
+
+	return s.object(root)
+}
+
+type scanner struct {
+	engine *Engine
+	data   []byte
+	pos    int
+}
+
+func (s *scanner) peek() byte {
+	if s.pos >= len(s.data) {
+		return 0
+	}
+
+	return s.data[s.pos]
+}
+
+func (s *scanner) skipSpace() {
+	for s.pos < len(s.data) {
+		switch s.data[s.pos] {
+		case ' ', '\t', '\n', '\r':
+			s.pos++
+		default:
+			return
+		}
+	}
+}
+
+// object reads the object at the current position, keeping the
+// properties of node
+func (s *scanner) object(node *pathNode) (map[string]interface{}, bool) {
+	object := make(map[string]interface{})
+
+	// {
+	s.pos++
+	s.skipSpace()
+
+	if s.peek() == '}' {
+		s.pos++
+
+		return object, true
+	}
+
+	for {
+		s.skipSpace()
+
+		start := s.pos
+		s.skipString()
+		key := s.data[start+1 : s.pos-1]
+
+		s.skipSpace()
+		// :
+		s.pos++
+		s.skipSpace()
+
+		child := s.child(node, key)
+
+		switch {
+		case child == nil:
+			s.skipValue()
+		case child.leaf:
+			start := s.pos
+			s.skipValue()
+
+			var value interface{}
+			if err := s.engine.unmarshal(nil, s.data[start:s.pos], &value); err != nil {
+				return nil, false
+			}
+
+			object[s.key(key)] = value
+		case s.peek() == '{':
+			value, ok := s.object(child)
+			if !ok {
+				return nil, false
+			}
+
+			object[s.key(key)] = value
+		case s.peek() == '[':
+			// lists are read whole, to keep the indexes
+			start := s.pos
+			s.skipValue()
+
+			var value interface{}
+			if err := s.engine.unmarshal(nil, s.data[start:s.pos], &value); err != nil {
+				return nil, false
+			}
+
+			object[s.key(key)] = value
+		case s.peek() == 'n':
+			s.skipValue()
+			object[s.key(key)] = nil
+		default:
+			// paths through other values are resolved in unexpected ways
+			return nil, false
+		}
+
+		s.skipSpace()
+
+		if s.peek() == '}' {
+			s.pos++
+
+			return object, true
+		}
+
+		// ,
+		s.pos++
+	}
+}
+
+// child returns the node of a property, whose raw key is given
+func (s *scanner) child(node *pathNode, key []byte) *pathNode {
+	if len(node.children) == 0 {
+		return nil
+	}
+
+	for _, c := range key {
+		if c == '\\' {
+			return node.children[s.key(key)]
+		}
+	}
+
+	return node.children[string(key)]
+}
+
+// key decodes a raw key
+func (s *scanner) key(raw []byte) string {
+	for _, c := range raw {
+		if c == '\\' {
+			var key string
+			if err := json.Unmarshal(append(append([]byte{'"'}, raw...), '"'), &key); err == nil {
+				return key
+			}
+
+			break
+		}
+	}
+
+	return string(raw)
+}
+
+func (s *scanner) skipString() {
+	// opening quote
+	s.pos++
+
+	for s.pos < len(s.data) {
+		switch s.data[s.pos] {
+		case '\\':
+			s.pos += 2
+		case '"':
+			s.pos++
+
+			return
+		default:
+			s.pos++
+		}
+	}
+}
+
+func (s *scanner) skipValue() {
+	switch s.peek() {
+	case '"':
+		s.skipString()
+	case '{', '[':
+		depth := 0
+		for s.pos < len(s.data) {
+			switch s.data[s.pos] {
+			case '"':
+				s.skipString()
+
+				continue
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+			}
+
+			s.pos++
+
+			if depth == 0 {
+				return
+			}
+		}
+	default:
+		for s.pos < len(s.data) {
+			switch s.data[s.pos] {
+			case ',', '}', ']', ' ', '\t', '\n', '\r':
+				return
+			}
+
+			s.pos++
+		}
+	}
+}
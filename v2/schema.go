@@ -0,0 +1,299 @@
+package jsonlogic
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// jsonSchema is the subset of JSON Schema (draft-07) understood by this
+// package: enough to describe rules and the usual data documents
+type jsonSchema struct {
+	Schema      string                 `json:"$schema,omitempty"`
+	Ref         string                 `json:"$ref,omitempty"`
+	Type        schemaTypes            `json:"type,omitempty"`
+	Enum        []interface{}          `json:"enum,omitempty"`
+	Definitions map[string]*jsonSchema `json:"definitions,omitempty"`
+
+	Properties           map[string]*jsonSchema `json:"properties,omitempty"`
+	AdditionalProperties *jsonSchema            `json:"additionalProperties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	MinProperties        *int                   `json:"minProperties,omitempty"`
+	MaxProperties        *int                   `json:"maxProperties,omitempty"`
+
+	Items *jsonSchema `json:"items,omitempty"`
+
+	AnyOf []*jsonSchema `json:"anyOf,omitempty"`
+	OneOf []*jsonSchema `json:"oneOf,omitempty"`
+
+	// never is set for the false schema, which no value matches
+	never bool
+}
+
+// UnmarshalJSON reads a schema, which can also be true or false
+func (s *jsonSchema) UnmarshalJSON(data []byte) error {
+	var b bool
+	if err := json.Unmarshal(data, &b); err == nil {
+		*s = jsonSchema{never: !b}
+
+		return nil
+	}
+
+	type plain jsonSchema
+
+	return json.Unmarshal(data, (*plain)(s))
+}
+
+// MarshalJSON writes a schema, using false for the one no value matches
+func (s *jsonSchema) MarshalJSON() ([]byte, error) {
+	if s.never {
+		return []byte("false"), nil
+	}
+
+	type plain jsonSchema
+
+	return json.Marshal((*plain)(s))
+}
+
+// schemaTypes is the "type" of a schema, one or a list of type names
+type schemaTypes []string
+
+func (t *schemaTypes) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*t = schemaTypes{name}
+
+		return nil
+	}
+
+	var names []string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return err
+	}
+
+	*t = names
+
+	return nil
+}
+
+func (t schemaTypes) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+
+	return json.Marshal([]string(t))
+}
+
+// allows tells if a value of the given JSON type matches the types
+func (t schemaTypes) allows(value interface{}) bool {
+	if len(t) == 0 {
+		return true
+	}
+
+	kind := typeOf(value)
+	for _, name := range t {
+		if name == kind {
+			return true
+		}
+
+		if name == "integer" && kind == "number" && toNumber(value) == math.Trunc(toNumber(value)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SchemaError describes why a value doesn't match a JSON Schema
+type SchemaError struct {
+	// Path locates the value in the document as a JSON pointer
+	Path    string
+	Message string
+}
+
+func (e *SchemaError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// resolve follows the references of a schema, which must point to its
+// root or to one of the definitions of its root
+func (s *jsonSchema) resolve(root *jsonSchema) (*jsonSchema, error) {
+	for s.Ref != "" {
+		if s.Ref == "#" {
+			s = root
+
+			continue
+		}
+
+		name := strings.TrimPrefix(s.Ref, "#/definitions/")
+		definition, ok := root.Definitions[name]
+		if !ok || name == s.Ref {
+			return nil, fmt.Errorf("unsupported schema reference %q", s.Ref)
+		}
+
+		s = definition
+	}
+
+	return s, nil
+}
+
+// validate checks value against the schema, returning the first mismatch
+func (s *jsonSchema) validate(value interface{}, path string, root *jsonSchema) error {
+	s, err := s.resolve(root)
+	if err != nil {
+		return err
+	}
+
+	if s.never {
+		return &SchemaError{Path: path, Message: "no value is allowed"}
+	}
+
+	if !s.Type.allows(value) {
+		return &SchemaError{Path: path, Message: fmt.Sprintf("%s is not of type %s", typeOf(value), strings.Join(s.Type, " or "))}
+	}
+
+	if len(s.Enum) > 0 && indexOf(s.Enum, value) < 0 {
+		return &SchemaError{Path: path, Message: fmt.Sprintf("%v is not one of the allowed values", value)}
+	}
+
+	if len(s.AnyOf) > 0 && s.matching(s.AnyOf, value, path, root) == 0 {
+		return &SchemaError{Path: path, Message: "does not match any of the allowed schemas"}
+	}
+
+	if len(s.OneOf) > 0 && s.matching(s.OneOf, value, path, root) != 1 {
+		return &SchemaError{Path: path, Message: "does not match exactly one of the allowed schemas"}
+	}
+
+	if isSlice(value) && s.Items != nil {
+		for i, element := range value.([]interface{}) {
+			if err := s.Items.validate(element, path+"/"+strconv.Itoa(i), root); err != nil {
+				return err
+			}
+		}
+	}
+
+	if isMap(value) {
+		return s.validateObject(value.(map[string]interface{}), path, root)
+	}
+
+	return nil
+}
+
+func (s *jsonSchema) matching(schemas []*jsonSchema, value interface{}, path string, root *jsonSchema) int {
+	count := 0
+	for _, schema := range schemas {
+		if schema.validate(value, path, root) == nil {
+			count++
+		}
+	}
+
+	return count
+}
+
+func (s *jsonSchema) validateObject(object map[string]interface{}, path string, root *jsonSchema) error {
+	if s.MinProperties != nil && len(object) < *s.MinProperties {
+		return &SchemaError{Path: path, Message: fmt.Sprintf("must have at least %d properties", *s.MinProperties)}
+	}
+
+	if s.MaxProperties != nil && len(object) > *s.MaxProperties {
+		return &SchemaError{Path: path, Message: fmt.Sprintf("must have at most %d properties", *s.MaxProperties)}
+	}
+
+	for _, name := range s.Required {
+		if _, ok := object[name]; !ok {
+			return &SchemaError{Path: path, Message: fmt.Sprintf("property %q is required", name)}
+		}
+	}
+
+	for _, name := range sortedKeys(object) {
+		_path := path + "/" + pointerToken(name)
+
+		property, ok := s.Properties[name]
+		if !ok && s.AdditionalProperties != nil {
+			if s.AdditionalProperties.never {
+				return &SchemaError{Path: _path, Message: fmt.Sprintf("property %q is not allowed", name)}
+			}
+
+			property = s.AdditionalProperties
+		}
+
+		if property == nil {
+			continue
+		}
+
+		if err := property.validate(object[name], _path, root); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+var (
+	ruleSchemaOnce sync.Once
+	ruleSchema     *jsonSchema
+)
+
+// ruleJSONSchema builds the schema of the rules supported by this package
+func ruleJSONSchema() *jsonSchema {
+	ruleSchemaOnce.Do(func() {
+		one := 1
+		expression := &jsonSchema{Ref: "#/definitions/expression"}
+
+		properties := map[string]*jsonSchema{"var": expression}
+		for _, operator := range operators {
+			properties[operator] = expression
+		}
+
+		ruleSchema = &jsonSchema{
+			Ref: "#/definitions/expression",
+			Definitions: map[string]*jsonSchema{
+				"expression": {
+					Type:                 schemaTypes{"null", "boolean", "number", "string", "array", "object"},
+					Items:                expression,
+					Properties:           properties,
+					AdditionalProperties: &jsonSchema{never: true},
+					MinProperties:        &one,
+					MaxProperties:        &one,
+				},
+			},
+		}
+	})
+
+	return ruleSchema
+}
+
+// Schema returns a JSON Schema (draft-07) describing the rules supported
+// by this package: literals, lists, and objects with a single property
+// naming one of the supported operators. It is meant for rule editors,
+// which can use it for validation and completion.
+func Schema() []byte {
+	root := *ruleJSONSchema()
+	root.Schema = "http://json-schema.org/draft-07/schema#"
+
+	schema, err := json.MarshalIndent(&root, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+
+	return schema
+}
+
+// ValidateAgainstSchema reads a rule and validates it against Schema,
+// returning a *SchemaError locating the first problem found
+func ValidateAgainstSchema(rule io.Reader) error {
+	var _rule interface{}
+
+	err := json.NewDecoder(rule).Decode(&_rule)
+	if err != nil {
+		return fmt.Errorf("error parsing rule: %w", err)
+	}
+
+	schema := ruleJSONSchema()
+
+	return schema.validate(_rule, "", schema)
+}
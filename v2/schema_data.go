@@ -0,0 +1,518 @@
+package jsonlogic
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// arithmeticOperators are the operators working on numbers
+var arithmeticOperators = map[string]bool{
+	"+":   true,
+	"-":   true,
+	"*":   true,
+	"/":   true,
+	"%":   true,
+	"abs": true,
+	"pow": true,
+}
+
+// iteratorOperators evaluate their second argument for every element of
+// the list given as the first one, or every entry of the object
+var iteratorOperators = map[string]bool{
+	"filter":     true,
+	"map":        true,
+	"reduce":     true,
+	"all":        true,
+	"none":       true,
+	"some":       true,
+	"map_obj":    true,
+	"filter_obj": true,
+	"groupby":    true,
+}
+
+// entryOperators are the iterator operators going through the entries of
+// objects, given as {"key": name, "value": value}
+var entryOperators = map[string]bool{
+	"all":        true,
+	"none":       true,
+	"some":       true,
+	"map_obj":    true,
+	"filter_obj": true,
+}
+
+// ValidateWithSchema reads a rule and a JSON Schema describing the data it
+// will be applied to, and checks the rule against it. It reports, located
+// by their path in the rule:
+//
+//   - var paths that can never exist in the data, like properties of a
+//     string or properties an object doesn't allow
+//   - var paths not declared by an object listing its properties
+//   - comparisons between values of different types, like a string field
+//     with a number
+//   - arithmetic on booleans, lists or objects
+//
+// Paths that can't be checked, because the schema doesn't describe them
+// or they are computed, are accepted. The error is returned only when the
+// rule or the schema can't be read.
+func ValidateWithSchema(rule, schema io.Reader) ([]*SchemaError, error) {
+	var _rule interface{}
+
+	err := json.NewDecoder(rule).Decode(&_rule)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing rule: %w", err)
+	}
+
+	var _schema jsonSchema
+
+	err = json.NewDecoder(schema).Decode(&_schema)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing schema: %w", err)
+	}
+
+	c := &schemaChecker{root: &_schema, problems: make([]*SchemaError, 0)}
+	c.check(_rule, "", scope{data: &_schema})
+
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	return c.problems, nil
+}
+
+// scope is what var can access: the data, inside iterators the current
+// element, which is looked up first for paths starting with a dot, and
+// the scope around them, read by $parent, and inside lets the values they
+// bind, looked up first for their names
+type scope struct {
+	data    *jsonSchema
+	element *jsonSchema
+	local   bool
+	parent  *scope
+	bound   map[string]*jsonSchema
+}
+
+type schemaChecker struct {
+	root     *jsonSchema
+	problems []*SchemaError
+	err      error
+}
+
+func (c *schemaChecker) report(path, format string, args ...interface{}) {
+	c.problems = append(c.problems, &SchemaError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (c *schemaChecker) resolve(s *jsonSchema) *jsonSchema {
+	if s == nil {
+		return nil
+	}
+
+	s, err := s.resolve(c.root)
+	if err != nil && c.err == nil {
+		c.err = err
+	}
+
+	return s
+}
+
+// kind returns the only JSON type a schema allows, if any
+func (c *schemaChecker) kind(s *jsonSchema) string {
+	s = c.resolve(s)
+	if s == nil || len(s.Type) != 1 {
+		return ""
+	}
+
+	if s.Type[0] == "integer" {
+		return "number"
+	}
+
+	return s.Type[0]
+}
+
+func (c *schemaChecker) allows(s *jsonSchema, kind string) bool {
+	if len(s.Type) == 0 {
+		return true
+	}
+
+	for _, name := range s.Type {
+		if name == kind {
+			return true
+		}
+	}
+
+	return false
+}
+
+// property returns the schema of a property of the values described by s,
+// nil when it's unknown, and an explanation when it can't exist
+func (c *schemaChecker) property(s *jsonSchema, name string) (*jsonSchema, string) {
+	s = c.resolve(s)
+	if s == nil {
+		return nil, ""
+	}
+
+	if s.never {
+		return nil, "no value is allowed"
+	}
+
+	_, err := strconv.Atoi(name)
+	if err == nil && c.allows(s, "array") {
+		return s.Items, ""
+	}
+
+	if !c.allows(s, "object") {
+		return nil, fmt.Sprintf("%s has no property %q", strings.Join(s.Type, " or "), name)
+	}
+
+	if property, ok := s.Properties[name]; ok {
+		return property, ""
+	}
+
+	if s.AdditionalProperties != nil {
+		if s.AdditionalProperties.never {
+			return nil, fmt.Sprintf("property %q is not allowed", name)
+		}
+
+		return s.AdditionalProperties, ""
+	}
+
+	if len(s.Properties) > 0 {
+		return nil, fmt.Sprintf("property %q is not declared", name)
+	}
+
+	return nil, ""
+}
+
+// lookup follows a var path from s, returning the schema of the value
+// found there and an explanation when it can't exist
+func (c *schemaChecker) lookup(s *jsonSchema, path string) (*jsonSchema, string) {
+	parts, ok := splitPath(path, AutoPaths)
+	if !ok {
+		return nil, ""
+	}
+
+	for _, part := range parts {
+		if s == nil {
+			return nil, ""
+		}
+
+		var problem string
+
+		s, problem = c.property(s, part)
+		if problem != "" {
+			return nil, problem
+		}
+	}
+
+	return s, ""
+}
+
+// variable checks a var path, returning the schema of its value
+func (c *schemaChecker) variable(values interface{}, path string, sc scope) *jsonSchema {
+	name := values
+	if isSlice(values) {
+		parsed := values.([]interface{})
+		if len(parsed) == 0 {
+			return sc.current()
+		}
+
+		name = parsed[0]
+	}
+
+	if name == nil {
+		return sc.current()
+	}
+
+	if isNumber(name) {
+		name = toString(name)
+	}
+
+	if !isString(name) {
+		return nil
+	}
+
+	_name := name.(string)
+	if _name == "" {
+		return sc.current()
+	}
+
+	if isPositionPath(_name) {
+		if !sc.local {
+			c.report(path, "%q can never exist: there is no iteration around it", _name)
+
+			return nil
+		}
+
+		if _name == indexName {
+			return &jsonSchema{Type: schemaTypes{"integer"}}
+		}
+
+		return nil
+	}
+
+	if levels, rest := parentPath(_name); levels > 0 {
+		around := &sc
+		for i := 0; i < levels && around != nil; i++ {
+			around = around.parent
+		}
+
+		if around == nil {
+			c.report(path, "%q can never exist: there are fewer iterations around it", _name)
+
+			return nil
+		}
+
+		s, problem := c.lookup(around.current(), rest)
+		if problem != "" {
+			c.report(path, "%q can never exist: %s", _name, problem)
+		}
+
+		return s
+	}
+
+	if sc.local && strings.HasPrefix(_name, ".") {
+		s, problem := c.lookup(sc.element, _name)
+		if problem != "" {
+			c.report(path, "%q can never exist: %s", _name, problem)
+		}
+
+		return s
+	}
+
+	head := strings.SplitN(_name, ".", 2)
+	if s, ok := sc.bound[head[0]]; ok {
+		if len(head) == 1 {
+			return s
+		}
+
+		s, problem := c.lookup(s, head[1])
+		if problem != "" {
+			c.report(path, "%q can never exist: %s", _name, problem)
+		}
+
+		return s
+	}
+
+	s, problem := c.lookup(sc.data, _name)
+	if problem == "" {
+		return s
+	}
+
+	if sc.local {
+		// names not found in the data are looked up in the element
+		if s, _problem := c.lookup(sc.element, _name); _problem == "" {
+			return s
+		}
+	}
+
+	c.report(path, "%q can never exist: %s", _name, problem)
+
+	return nil
+}
+
+func (sc scope) current() *jsonSchema {
+	if sc.local {
+		return sc.element
+	}
+
+	return sc.data
+}
+
+// check walks an expression of the rule, returning the schema of its value
+// when it is known
+func (c *schemaChecker) check(rule interface{}, path string, sc scope) *jsonSchema {
+	if isSlice(rule) {
+		for i, value := range rule.([]interface{}) {
+			c.check(value, path+"/"+strconv.Itoa(i), sc)
+		}
+
+		return &jsonSchema{Type: schemaTypes{"array"}}
+	}
+
+	if !isMap(rule) {
+		return &jsonSchema{Type: schemaTypes{typeOf(rule)}}
+	}
+
+	for operator, values := range rule.(map[string]interface{}) {
+		_path := path + "/" + pointerToken(operator)
+
+		if operator == "var" {
+			return c.variable(values, _path, sc)
+		}
+
+		if iteratorOperators[operator] {
+			return c.iterator(operator, toSlice(values), _path, sc)
+		}
+
+		if operator == "let" {
+			return c.let(values, _path, sc)
+		}
+
+		if operator == "def" {
+			return c.def(values, _path, sc)
+		}
+
+		args := make([]*jsonSchema, 0)
+		for i, value := range toSlice(values) {
+			argPath := _path
+			if isSlice(values) {
+				argPath += "/" + strconv.Itoa(i)
+			}
+
+			args = append(args, c.check(value, argPath, sc))
+		}
+
+		return c.operator(operator, args, _path)
+	}
+
+	return nil
+}
+
+// let checks the values bound by a let, and its body with their schemas
+func (c *schemaChecker) let(values interface{}, path string, sc scope) *jsonSchema {
+	bindings, body, ok := letArgs(values)
+	if !ok {
+		return nil
+	}
+
+	bound := make(map[string]*jsonSchema, len(sc.bound)+len(bindings))
+	for name, s := range sc.bound {
+		bound[name] = s
+	}
+
+	for name, value := range bindings {
+		bound[name] = c.resolve(c.check(value, path+"/"+pointerToken(name), sc))
+	}
+
+	sc.bound = bound
+
+	return c.check(body, path+"/"+letBody, sc)
+}
+
+// def checks the functions of a def, whose parameters have unknown
+// schemas, and its body
+func (c *schemaChecker) def(values interface{}, path string, sc scope) *jsonSchema {
+	definitions, body, ok := defArgs(values)
+	if !ok {
+		return nil
+	}
+
+	for name, definition := range definitions {
+		bound := make(map[string]*jsonSchema, len(sc.bound)+len(definition.params))
+		for name, s := range sc.bound {
+			bound[name] = s
+		}
+
+		for _, param := range definition.params {
+			bound[param] = nil
+		}
+
+		c.check(definition.body, path+"/"+pointerToken(name)+"/1", scope{data: sc.data, element: sc.element, local: sc.local, parent: sc.parent, bound: bound})
+	}
+
+	return c.check(body, path+"/"+letBody, sc)
+}
+
+func (c *schemaChecker) iterator(operator string, args []interface{}, path string, sc scope) *jsonSchema {
+	if len(args) < 2 {
+		return nil
+	}
+
+	subject := c.resolve(c.check(args[0], path+"/0", sc))
+
+	var element *jsonSchema
+	if subject != nil {
+		element = subject.Items
+	}
+
+	// the values of the entries of objects are only known for objects
+	// mapping any name to the same schema
+	isObject := subject != nil && subject.Items == nil && (subject.Properties != nil || subject.AdditionalProperties != nil)
+	if isObject && entryOperators[operator] {
+		var value *jsonSchema
+		if len(subject.Properties) == 0 && subject.AdditionalProperties != nil && !subject.AdditionalProperties.never {
+			value = subject.AdditionalProperties
+		}
+
+		element = &jsonSchema{Properties: map[string]*jsonSchema{
+			"key":   {Type: schemaTypes{"string"}},
+			"value": value,
+		}}
+	}
+
+	if operator == "reduce" {
+		element = &jsonSchema{Properties: map[string]*jsonSchema{"current": element, "accumulator": nil}}
+	}
+
+	result := c.check(args[1], path+"/1", scope{data: sc.data, element: element, local: true, parent: &sc, bound: sc.bound})
+
+	if len(args) > 2 {
+		c.check(args[2], path+"/2", sc)
+	}
+
+	switch operator {
+	case "filter", "filter_obj":
+		return subject
+	case "map_obj":
+		return &jsonSchema{Type: schemaTypes{"object"}, AdditionalProperties: result}
+	case "groupby":
+		var items *jsonSchema
+		if subject != nil {
+			items = subject.Items
+		}
+
+		return &jsonSchema{Type: schemaTypes{"object"}, AdditionalProperties: &jsonSchema{Type: schemaTypes{"array"}, Items: items}}
+	case "map":
+		return &jsonSchema{Type: schemaTypes{"array"}, Items: result}
+	case "all", "none", "some":
+		return &jsonSchema{Type: schemaTypes{"boolean"}}
+	}
+
+	return nil
+}
+
+func (c *schemaChecker) operator(operator string, args []*jsonSchema, path string) *jsonSchema {
+	switch {
+	case comparisonOperators[operator]:
+		if operator == "between" && len(args) > 3 {
+			args = args[:3]
+		}
+
+		kinds := make([]string, 0, len(args))
+		for _, arg := range args {
+			if kind := c.kind(arg); kind != "" && kind != "null" {
+				kinds = append(kinds, kind)
+			}
+		}
+
+		for i := 1; i < len(kinds); i++ {
+			if kinds[i] != kinds[0] {
+				c.report(path, "comparing %s with %s", kinds[0], kinds[i])
+
+				break
+			}
+		}
+
+		return &jsonSchema{Type: schemaTypes{"boolean"}}
+	case arithmeticOperators[operator]:
+		for _, arg := range args {
+			if kind := c.kind(arg); kind == "boolean" || kind == "array" || kind == "object" {
+				c.report(path, "arithmetic on %s", kind)
+
+				break
+			}
+		}
+
+		return &jsonSchema{Type: schemaTypes{"number"}}
+	case operator == "cat" || operator == "substr" || operator == "ulid_time" || operator == "format_number" || operator == "template":
+		return &jsonSchema{Type: schemaTypes{"string"}}
+	case operator == "is_uuid" || operator == "is_ulid" || operator == "uuid_equals":
+		return &jsonSchema{Type: schemaTypes{"boolean"}}
+	case operator == "!" || operator == "!!" || operator == "in" || rangeOperators[operator] || semverOperators[operator]:
+		return &jsonSchema{Type: schemaTypes{"boolean"}}
+	}
+
+	return nil
+}
@@ -0,0 +1,177 @@
+package jsonlogic
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateWithSchema(t *testing.T) {
+	schema := `{
+		"definitions": {
+			"person": {
+				"type": "object",
+				"properties": {
+					"name": {"type": "string"},
+					"age": {"type": "integer"},
+					"tags": {"type": "array", "items": {"type": "string"}}
+				},
+				"additionalProperties": false
+			}
+		},
+		"type": "object",
+		"properties": {
+			"user": {"$ref": "#/definitions/person"},
+			"people": {"type": "array", "items": {"$ref": "#/definitions/person"}},
+			"limit": {"type": "number"},
+			"settings": {"type": "object"},
+			"scores": {"type": "object", "additionalProperties": {"type": "number"}}
+		}
+	}`
+
+	scenarios := map[string]struct {
+		Rule     string
+		Expected []string
+	}{
+		"valid rule": {
+			Rule:     `{"and": [{">=": [{"var": "user.age"}, 18]}, {"in": ["vip", {"var": "user.tags"}]}]}`,
+			Expected: []string{},
+		},
+		"property not allowed": {
+			Rule:     `{"==": [{"var": "user.email"}, "x"]}`,
+			Expected: []string{`/==/0/var: "user.email" can never exist: property "email" is not allowed`},
+		},
+		"property not declared": {
+			Rule:     `{"var": "usr.name"}`,
+			Expected: []string{`/var: "usr.name" can never exist: property "usr" is not declared`},
+		},
+		"property of a primitive": {
+			Rule:     `{"var": "user.name.first"}`,
+			Expected: []string{`/var: "user.name.first" can never exist: string has no property "first"`},
+		},
+		"free form objects": {
+			Rule:     `{"var": "settings.anything.at.all"}`,
+			Expected: []string{},
+		},
+		"entries": {
+			Rule:     `{"some": [{"var": "scores"}, {"and": [{"==": [{"var": ".key"}, "math"]}, {"==": [{"var": ".value"}, "A"]}]}]}`,
+			Expected: []string{`/some/1/and/1/==: comparing number with string`},
+		},
+		"filtered entries": {
+			Rule:     `{"filter_obj": [{"var": "scores"}, {"==": [{"var": ".value"}, "A"]}]}`,
+			Expected: []string{`/filter_obj/1/==: comparing number with string`},
+		},
+		"groups": {
+			Rule: `{"all": [{"groupby": [{"var": "people"}, {"var": ".nam"}]}, {"==": [{"var": ".value.0.age"}, "x"]}]}`,
+			Expected: []string{
+				`/all/0/groupby/1/var: ".nam" can never exist: property "nam" is not allowed`,
+				`/all/1/==: comparing number with string`,
+			},
+		},
+		"entries of objects with properties": {
+			Rule:     `{"all": [{"var": "user"}, {"!=": [{"var": ".value"}, null]}]}`,
+			Expected: []string{},
+		},
+		"pointers": {
+			Rule:     `{"==": [{"var": "/user/tags/0"}, {"var": "/user/email"}]}`,
+			Expected: []string{`/==/1/var: "/user/email" can never exist: property "email" is not allowed`},
+		},
+		"list elements": {
+			Rule:     `{"==": [{"var": "user.tags.0"}, 1]}`,
+			Expected: []string{`/==: comparing string with number`},
+		},
+		"comparison of a string field with a number": {
+			Rule:     `{"<": [{"var": "user.name"}, 10]}`,
+			Expected: []string{`/<: comparing string with number`},
+		},
+		"comparison with computed values": {
+			Rule:     `{"==": [{"var": "limit"}, {"+": [{"var": "user.age"}, 1]}]}`,
+			Expected: []string{},
+		},
+		"arithmetic on a list": {
+			Rule:     `{"+": [{"var": "user.tags"}, 1]}`,
+			Expected: []string{`/+: arithmetic on array`},
+		},
+		"iterators use the schema of the elements": {
+			Rule: `{"filter": [{"var": "people"}, {"and": [
+				{">": [{"var": ".age"}, {"var": "limit"}]},
+				{"==": [{"var": ".name"}, 1]},
+				{"var": ".nickname"}
+			]}]}`,
+			Expected: []string{
+				`/filter/1/and/1/==: comparing string with number`,
+				`/filter/1/and/2/var: ".nickname" can never exist: property "nickname" is not allowed`,
+			},
+		},
+		"names in iterators can come from the elements": {
+			Rule:     `{"some": [{"var": "people"}, {"==": [{"var": "age"}, 18]}]}`,
+			Expected: []string{},
+		},
+		"reduce": {
+			Rule:     `{"reduce": [{"var": "people"}, {"+": [{"var": "current.age"}, {"var": "accumulator"}]}, 0]}`,
+			Expected: []string{},
+		},
+		"names bound by let": {
+			Rule: `{"let": {"u": {"var": "user"}, "in": {"and": [
+				{"==": [{"var": "u.name"}, 1]},
+				{"var": "u.nickname"}
+			]}}}`,
+			Expected: []string{
+				`/let/in/and/0/==: comparing string with number`,
+				`/let/in/and/1/var: "u.nickname" can never exist: property "nickname" is not allowed`,
+			},
+		},
+		"data around iterations": {
+			Rule: `{"map": [{"var": "people"}, {"some": [{"var": ".tags"}, {"or": [
+				{"==": [{"var": "$parent.name"}, 1]},
+				{"var": "$parent.nickname"},
+				{"<": [{"var": "$parent.$parent.limit"}, 1]},
+				{"var": "$parent.$parent.$parent.limit"}
+			]}]}]}`,
+			Expected: []string{
+				`/map/1/some/1/or/0/==: comparing string with number`,
+				`/map/1/some/1/or/1/var: "$parent.nickname" can never exist: property "nickname" is not allowed`,
+				`/map/1/some/1/or/3/var: "$parent.$parent.$parent.limit" can never exist: there are fewer iterations around it`,
+			},
+		},
+		"positions of elements": {
+			Rule: `{"and": [
+				{"all": [{"var": "people"}, {"==": [{"var": "$index"}, "first"]}]},
+				{"var": "$index"}
+			]}`,
+			Expected: []string{
+				`/and/0/all/1/==: comparing number with string`,
+				`/and/1/var: "$index" can never exist: there is no iteration around it`,
+			},
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			problems, err := ValidateWithSchema(strings.NewReader(scenario.Rule), strings.NewReader(schema))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			messages := make([]string, 0)
+			for _, problem := range problems {
+				messages = append(messages, problem.Error())
+			}
+
+			assert.Equal(t, scenario.Expected, messages)
+		})
+	}
+}
+
+func TestValidateWithSchemaErrors(t *testing.T) {
+	_, err := ValidateWithSchema(strings.NewReader(`{`), strings.NewReader(`{}`))
+	assert.Error(t, err)
+
+	_, err = ValidateWithSchema(strings.NewReader(`{}`), strings.NewReader(`[`))
+	assert.Error(t, err)
+
+	_, err = ValidateWithSchema(strings.NewReader(`{"var": "a"}`), strings.NewReader(`{"$ref": "other.json"}`))
+	assert.Error(t, err)
+}
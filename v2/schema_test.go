@@ -0,0 +1,121 @@
+package jsonlogic
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchema(t *testing.T) {
+	var schema map[string]interface{}
+
+	err := json.Unmarshal(Schema(), &schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "http://json-schema.org/draft-07/schema#", schema["$schema"])
+	assert.Equal(t, "#/definitions/expression", schema["$ref"])
+
+	expression := schema["definitions"].(map[string]interface{})["expression"].(map[string]interface{})
+	properties := expression["properties"].(map[string]interface{})
+
+	assert.Len(t, properties, len(operators)+1)
+	assert.Contains(t, properties, "var")
+	assert.Contains(t, properties, "match")
+	assert.Equal(t, false, expression["additionalProperties"])
+}
+
+func TestValidateAgainstSchema(t *testing.T) {
+	scenarios := map[string]struct {
+		Rule  string
+		Error string
+	}{
+		"valid rule": {
+			Rule: `{"filter": [{"var": "people"}, {">=": [{"var": ".age"}, 18]}]}`,
+		},
+		"literal": {
+			Rule: `[1, "a", null]`,
+		},
+		"unknown operator": {
+			Rule:  `{"and": [true, {"filt": []}]}`,
+			Error: `/and/1/filt: property "filt" is not allowed`,
+		},
+		"more than one operator": {
+			Rule:  `{"if": [{"var": "a", "==": [1, 1]}]}`,
+			Error: `/if/0: must have at most 1 properties`,
+		},
+		"empty object": {
+			Rule:  `{"!": {}}`,
+			Error: `/!: must have at least 1 properties`,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			err := ValidateAgainstSchema(strings.NewReader(scenario.Rule))
+			if scenario.Error == "" {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.EqualError(t, err, scenario.Error)
+			assert.IsType(t, &SchemaError{}, err)
+		})
+	}
+}
+
+func TestSchemaValidation(t *testing.T) {
+	var schema jsonSchema
+
+	err := json.Unmarshal([]byte(`{
+		"definitions": {"tag": {"type": "string", "enum": ["a", "b"]}},
+		"type": "object",
+		"required": ["id"],
+		"properties": {
+			"id": {"type": "integer"},
+			"tags": {"type": "array", "items": {"$ref": "#/definitions/tag"}},
+			"extra": {"anyOf": [{"type": "string"}, {"type": "null"}]},
+			"kind": {"oneOf": [{"type": "number"}, {"type": "integer"}]}
+		},
+		"additionalProperties": false
+	}`), &schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := map[string]struct {
+		Document string
+		Error    string
+	}{
+		"valid":                   {`{"id": 1, "tags": ["a"], "extra": null}`, ``},
+		"missing property":        {`{"tags": []}`, `: property "id" is required`},
+		"not an integer":          {`{"id": 1.5}`, `/id: number is not of type integer`},
+		"not in enum":             {`{"id": 1, "tags": ["a", "c"]}`, `/tags/1: c is not one of the allowed values`},
+		"no alternative matching": {`{"id": 1, "extra": 1}`, `/extra: does not match any of the allowed schemas`},
+		"many alternatives":       {`{"id": 1, "kind": 1}`, `/kind: does not match exactly one of the allowed schemas`},
+		"additional property":     {`{"id": 1, "other": 1}`, `/other: property "other" is not allowed`},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var document interface{}
+			if err := json.Unmarshal([]byte(scenario.Document), &document); err != nil {
+				t.Fatal(err)
+			}
+
+			err := schema.validate(document, "", &schema)
+			if scenario.Error == "" {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.EqualError(t, err, scenario.Error)
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package jsonlogic
+
+import (
+	"fmt"
+)
+
+// WeightedRule is a rule with the weight it adds to a score when it
+// matches
+type WeightedRule struct {
+	Name   string
+	Rule   *Rule
+	Weight float64
+}
+
+// Contribution is what a WeightedRule added to a ScoreCard
+type Contribution struct {
+	Name string `json:"name"`
+
+	// Result is the result of the rule, and Score what it added to the
+	// total
+	Result interface{} `json:"result"`
+	Score  float64     `json:"score"`
+}
+
+// ScoreCard is the score of data against weighted rules
+type ScoreCard struct {
+	Total float64 `json:"total"`
+
+	// Contributions are the contributions of the rules, in their order
+	Contributions []Contribution `json:"contributions"`
+}
+
+// Best returns the contribution adding the most to the total, the first
+// one when several do, and false when no rule added anything
+func (s *ScoreCard) Best() (Contribution, bool) {
+	best := -1
+	for i, contribution := range s.Contributions {
+		if contribution.Score > 0 && (best < 0 || contribution.Score > s.Contributions[best].Score) {
+			best = i
+		}
+	}
+
+	if best < 0 {
+		return Contribution{}, false
+	}
+
+	return s.Contributions[best], true
+}
+
+// Score scores JSON data against weighted rules. See Engine.Score.
+func Score(data []byte, rules []WeightedRule) (*ScoreCard, error) {
+	return defaultEngine.Score(data, rules)
+}
+
+// Score applies weighted rules to JSON data and sums up their weights, for
+// scores like those of fraud checks: rules giving a number add their weight
+// times that number, so they can grade their match, and the others add
+// their weight when their result is truthy. Weights may be negative. The
+// rules are applied as with ApplyAll: the data is decoded once, and the
+// expressions they have in common are evaluated once.
+func (e *Engine) Score(data []byte, rules []WeightedRule) (*ScoreCard, error) {
+	compiled := make([]*Rule, 0, len(rules))
+	for _, rule := range rules {
+		if rule.Rule == nil {
+			return nil, fmt.Errorf("rule %q is nil", rule.Name)
+		}
+
+		if err := e.checkRule(rule.Rule); err != nil {
+			return nil, fmt.Errorf("rule %q: %w", rule.Name, err)
+		}
+
+		compiled = append(compiled, rule.Rule)
+	}
+
+	_data, err := e.decodeShared(compiled, data)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing data %w", err)
+	}
+
+	card := &ScoreCard{Contributions: make([]Contribution, 0, len(rules))}
+
+	failed, err := e.applyEach(compiled, _data, func(i int, result interface{}) bool {
+		contribution := Contribution{Name: rules[i].Name, Result: result}
+
+		switch {
+		case isNumber(result):
+			contribution.Score = rules[i].Weight * toNumber(result)
+		case isTrue(result):
+			contribution.Score = rules[i].Weight
+		}
+
+		card.Total += contribution.Score
+		card.Contributions = append(card.Contributions, contribution)
+
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error applying rule %q: %w", rules[failed].Name, err)
+	}
+
+	return card, nil
+}
@@ -0,0 +1,93 @@
+package jsonlogic
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func weightedRules(t *testing.T) []WeightedRule {
+	compile := func(rule string) *Rule {
+		compiled, err := Compile(strings.NewReader(rule))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return compiled
+	}
+
+	return []WeightedRule{
+		{Name: "large amount", Rule: compile(`{">": [{"var": "amount"}, 1000]}`), Weight: 40},
+		{Name: "new account", Rule: compile(`{"<": [{"var": "account_days"}, 7]}`), Weight: 25},
+		{Name: "failed attempts", Rule: compile(`{"var": "failed_attempts"}`), Weight: 10},
+		{Name: "trusted device", Rule: compile(`{"var": "trusted_device"}`), Weight: -30},
+	}
+}
+
+func TestScore(t *testing.T) {
+	rules := weightedRules(t)
+
+	scenarios := map[string]struct {
+		data   string
+		total  float64
+		scores []float64
+		best   string
+	}{
+		"suspicious": {
+			data:   `{"amount": 5000, "account_days": 2, "failed_attempts": 3, "trusted_device": false}`,
+			total:  95,
+			scores: []float64{40, 25, 30, 0},
+			best:   "large amount",
+		},
+		"trusted": {
+			data:   `{"amount": 5000, "account_days": 300, "failed_attempts": 0, "trusted_device": true}`,
+			total:  10,
+			scores: []float64{40, 0, 0, -30},
+			best:   "large amount",
+		},
+		"graded": {
+			data:   `{"amount": 10, "account_days": 300, "failed_attempts": 5}`,
+			total:  50,
+			scores: []float64{0, 0, 50, 0},
+			best:   "failed attempts",
+		},
+		"nothing": {
+			data:   `{"amount": 10, "account_days": 300}`,
+			total:  0,
+			scores: []float64{0, 0, 0, 0},
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			card, err := Score([]byte(scenario.data), rules)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, scenario.total, card.Total)
+
+			scores := make([]float64, 0, len(card.Contributions))
+			for i, contribution := range card.Contributions {
+				assert.Equal(t, rules[i].Name, contribution.Name)
+				scores = append(scores, contribution.Score)
+			}
+
+			assert.Equal(t, scenario.scores, scores)
+
+			best, ok := card.Best()
+			assert.Equal(t, scenario.best != "", ok)
+			assert.Equal(t, scenario.best, best.Name)
+		})
+	}
+}
+
+func TestScoreErrors(t *testing.T) {
+	_, err := Score([]byte(`{}`), []WeightedRule{{Name: "empty"}})
+	assert.EqualError(t, err, `rule "empty" is nil`)
+
+	_, err = Score([]byte(`{`), weightedRules(t))
+	assert.Error(t, err)
+}
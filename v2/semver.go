@@ -0,0 +1,373 @@
+package jsonlogic
+
+import (
+	"strconv"
+	"strings"
+)
+
+// semverOperators are the operators comparing semantic versions
+var semverOperators = map[string]bool{
+	"semver_gte":       true,
+	"semver_lt":        true,
+	"semver_satisfies": true,
+}
+
+// version is a semantic version, without its build metadata which has no
+// precedence
+type version struct {
+	major, minor, patch uint64
+	prerelease          []string
+}
+
+// partialVersion is a version of which only the first parts are given, the
+// others being wildcards: 1.x has a single part, * none
+type partialVersion struct {
+	version
+	parts    int
+	wildcard bool
+}
+
+// parsePartialVersion reads versions like "1.2.3-beta.1+build", "v1.2",
+// "1.x" or "*". Only complete versions can have a pre-release.
+func parsePartialVersion(s string) (partialVersion, bool) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		s = s[:i]
+	}
+
+	var prerelease []string
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		prerelease = strings.Split(s[i+1:], ".")
+		s = s[:i]
+
+		for _, identifier := range prerelease {
+			if identifier == "" {
+				return partialVersion{}, false
+			}
+		}
+	}
+
+	fields := strings.Split(s, ".")
+	if len(fields) > 3 {
+		return partialVersion{}, false
+	}
+
+	var p partialVersion
+	numbers := []*uint64{&p.major, &p.minor, &p.patch}
+
+	for i, field := range fields {
+		if field == "x" || field == "X" || field == "*" {
+			p.wildcard = true
+
+			break
+		}
+
+		if p.parts != i {
+			// a number after a wildcard, like 1.x.3
+			return partialVersion{}, false
+		}
+
+		n, err := strconv.ParseUint(field, 10, 64)
+		if err != nil {
+			return partialVersion{}, false
+		}
+
+		*numbers[i] = n
+		p.parts++
+	}
+
+	for _, field := range fields[p.parts:] {
+		if field != "x" && field != "X" && field != "*" {
+			return partialVersion{}, false
+		}
+	}
+
+	if prerelease != nil && p.parts < 3 {
+		return partialVersion{}, false
+	}
+
+	p.prerelease = prerelease
+
+	return p, true
+}
+
+// parseVersion reads a version, whose missing minor and patch numbers are
+// 0: "1.10" is 1.10.0
+func parseVersion(value interface{}) (version, bool) {
+	s, ok := value.(string)
+	if !ok {
+		return version{}, false
+	}
+
+	p, ok := parsePartialVersion(s)
+	if !ok || p.wildcard {
+		return version{}, false
+	}
+
+	return p.version, true
+}
+
+// compareVersions orders versions by precedence, as defined by Semantic
+// Versioning 2.0.0
+func compareVersions(a, b version) int {
+	for _, pair := range [][2]uint64{{a.major, b.major}, {a.minor, b.minor}, {a.patch, b.patch}} {
+		if pair[0] != pair[1] {
+			if pair[0] < pair[1] {
+				return -1
+			}
+
+			return 1
+		}
+	}
+
+	// pre-releases come before their release
+	switch {
+	case len(a.prerelease) == 0 && len(b.prerelease) == 0:
+		return 0
+	case len(a.prerelease) == 0:
+		return 1
+	case len(b.prerelease) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(a.prerelease) && i < len(b.prerelease); i++ {
+		if c := compareIdentifiers(a.prerelease[i], b.prerelease[i]); c != 0 {
+			return c
+		}
+	}
+
+	return compareInts(len(a.prerelease), len(b.prerelease))
+}
+
+// compareIdentifiers orders the identifiers of pre-releases: numbers
+// numerically and before the others, compared as text
+func compareIdentifiers(a, b string) int {
+	m, errA := strconv.ParseUint(a, 10, 64)
+	n, errB := strconv.ParseUint(b, 10, 64)
+
+	switch {
+	case errA == nil && errB == nil:
+		if m == n {
+			return 0
+		}
+
+		if m < n {
+			return -1
+		}
+
+		return 1
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	}
+
+	return strings.Compare(a, b)
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+
+	return 0
+}
+
+// comparator is a condition on versions, like >= 1.2.0
+type comparator struct {
+	operator string
+	version  version
+}
+
+func (c comparator) matches(v version) bool {
+	order := compareVersions(v, c.version)
+
+	switch c.operator {
+	case "<":
+		return order < 0
+	case "<=":
+		return order <= 0
+	case ">":
+		return order > 0
+	case ">=":
+		return order >= 0
+	}
+
+	return order == 0
+}
+
+// next returns the lowest version above all those matching a partial
+// version: 1.3.0 for 1.2.x
+func (p partialVersion) next() version {
+	if p.parts == 1 {
+		return version{major: p.major + 1}
+	}
+
+	return version{major: p.major, minor: p.minor + 1}
+}
+
+// comparators expands a condition on a partial version, like ^1.2 or
+// <=1.x, to conditions on complete versions
+func comparators(operator string, p partialVersion) []comparator {
+	lowest := comparator{">=", p.version}
+
+	if p.parts == 0 {
+		// * matches every version, <* none
+		if operator == "<" || operator == ">" {
+			return []comparator{{"<", version{}}}
+		}
+
+		return nil
+	}
+
+	switch operator {
+	case "", "=":
+		if p.parts == 3 {
+			return []comparator{{"=", p.version}}
+		}
+
+		return []comparator{lowest, {"<", p.next()}}
+	case "^":
+		switch {
+		case p.major > 0 || p.parts == 1:
+			return []comparator{lowest, {"<", version{major: p.major + 1}}}
+		case p.minor > 0 || p.parts == 2:
+			return []comparator{lowest, {"<", version{minor: p.minor + 1}}}
+		}
+
+		return []comparator{lowest, {"<", version{patch: p.patch + 1}}}
+	case "~":
+		if p.parts == 1 {
+			return []comparator{lowest, {"<", p.next()}}
+		}
+
+		return []comparator{lowest, {"<", version{major: p.major, minor: p.minor + 1}}}
+	case ">":
+		if p.parts == 3 {
+			return []comparator{{">", p.version}}
+		}
+
+		return []comparator{{">=", p.next()}}
+	case "<=":
+		if p.parts == 3 {
+			return []comparator{{"<=", p.version}}
+		}
+
+		return []comparator{{"<", p.next()}}
+	}
+
+	return []comparator{{operator, p.version}}
+}
+
+// constraintOperators are the operators of constraints, longest first
+var constraintOperators = []string{">=", "<=", ">", "<", "=", "^", "~"}
+
+// parseConstraint reads a constraint on versions, like ">=1.2.0 <2",
+// "^1.4 || ~2.1.0" or "1.x": alternatives separated by ||, each one made
+// of comparators that all have to match, separated by spaces or commas
+func parseConstraint(s string) ([][]comparator, bool) {
+	var alternatives [][]comparator
+
+	for _, alternative := range strings.Split(s, "||") {
+		fields := strings.Fields(strings.Replace(alternative, ",", " ", -1))
+
+		conditions := []comparator{}
+		for i := 0; i < len(fields); i++ {
+			field := fields[i]
+
+			operator := ""
+			for _, o := range constraintOperators {
+				if strings.HasPrefix(field, o) {
+					operator = o
+
+					break
+				}
+			}
+
+			// the version may be apart from its operator, like >= 1.2
+			field = field[len(operator):]
+			if field == "" && i+1 < len(fields) {
+				i++
+				field = fields[i]
+			}
+
+			p, ok := parsePartialVersion(field)
+			if !ok {
+				return nil, false
+			}
+
+			conditions = append(conditions, comparators(operator, p)...)
+		}
+
+		alternatives = append(alternatives, conditions)
+	}
+
+	return alternatives, true
+}
+
+// satisfies tells if a version matches all the comparators of any of the
+// alternatives of a constraint
+func satisfies(v version, alternatives [][]comparator) bool {
+	for _, alternative := range alternatives {
+		matches := true
+		for _, c := range alternative {
+			if !c.matches(v) {
+				matches = false
+
+				break
+			}
+		}
+
+		if matches {
+			return true
+		}
+	}
+
+	return false
+}
+
+// semver evaluates the operators comparing semantic versions given as
+// strings, like "1.10.0" or "v2.0.0-rc.1": {"semver_gte": [{"var":
+// "client.version"}, "1.9"]}, {"semver_satisfies": [{"var":
+// "client.version"}, "^1.4 || >=2.1"]}. Invalid versions or constraints give
+// null.
+func semver(operator string, values interface{}) interface{} {
+	parsed := toSlice(values)
+	if len(parsed) != 2 {
+		return nil
+	}
+
+	v, ok := parseVersion(parsed[0])
+	if !ok {
+		return nil
+	}
+
+	if operator == "semver_satisfies" {
+		s, ok := parsed[1].(string)
+		if !ok {
+			return nil
+		}
+
+		constraint, ok := parseConstraint(s)
+		if !ok {
+			return nil
+		}
+
+		return satisfies(v, constraint)
+	}
+
+	other, ok := parseVersion(parsed[1])
+	if !ok {
+		return nil
+	}
+
+	if operator == "semver_gte" {
+		return compareVersions(v, other) >= 0
+	}
+
+	return compareVersions(v, other) < 0
+}
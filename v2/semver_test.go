@@ -0,0 +1,79 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSemverComparisons(t *testing.T) {
+	scenarios := map[string]struct {
+		Rule     string
+		Expected string
+	}{
+		"numerically":              {`{"semver_gte": ["1.10.0", "1.9.0"]}`, `true`},
+		"lower":                    {`{"semver_lt": ["1.9.0", "1.10.0"]}`, `true`},
+		"equal":                    {`{"semver_gte": ["1.2.3", "1.2.3"]}`, `true`},
+		"not lower when equal":     {`{"semver_lt": ["1.2.3", "1.2.3"]}`, `false`},
+		"from the data":            {`{"semver_gte": [{"var": "client.version"}, "2.0.0"]}`, `true`},
+		"prefix":                   {`{"semver_gte": ["v2.0.0", "2.0.0"]}`, `true`},
+		"partial":                  {`{"semver_gte": ["1.10", "1.9.5"]}`, `true`},
+		"pre-release":              {`{"semver_lt": ["2.0.0-rc.1", "2.0.0"]}`, `true`},
+		"pre-release identifiers":  {`{"semver_lt": ["1.0.0-alpha.2", "1.0.0-alpha.10"]}`, `true`},
+		"numbers before text":      {`{"semver_lt": ["1.0.0-1", "1.0.0-alpha"]}`, `true`},
+		"more identifiers":         {`{"semver_lt": ["1.0.0-alpha", "1.0.0-alpha.1"]}`, `true`},
+		"build metadata":           {`{"semver_gte": ["1.0.0+build.5", "1.0.0+build.9"]}`, `true`},
+		"pre-release with an x":    {`{"semver_lt": ["1.0.0-x", "1.0.0"]}`, `true`},
+		"invalid version":          {`{"semver_gte": ["one", "1.0.0"]}`, `null`},
+		"wildcard version":         {`{"semver_gte": ["1.x", "1.0.0"]}`, `null`},
+		"number version":           {`{"semver_gte": [2, "1.0.0"]}`, `null`},
+		"missing version":          {`{"semver_lt": [{"var": "nothing"}, "1.0.0"]}`, `null`},
+		"too many parts":           {`{"semver_lt": ["1.2.3.4", "1.0.0"]}`, `null`},
+		"empty pre-release":        {`{"semver_lt": ["1.2.3-", "1.0.0"]}`, `null`},
+		"missing second version":   {`{"semver_lt": ["1.2.3"]}`, `null`},
+		"satisfies a range":        {`{"semver_satisfies": ["1.5.0", ">=1.2.0 <2.0.0"]}`, `true`},
+		"outside a range":          {`{"semver_satisfies": ["2.0.0", ">=1.2.0 <2.0.0"]}`, `false`},
+		"comma separated":          {`{"semver_satisfies": ["1.5.0", ">=1.2.0, <2.0.0"]}`, `true`},
+		"spaced operators":         {`{"semver_satisfies": ["1.5.0", ">= 1.2 < 2"]}`, `true`},
+		"caret":                    {`{"semver_satisfies": ["1.9.9", "^1.2.3"]}`, `true`},
+		"caret major":              {`{"semver_satisfies": ["2.0.0", "^1.2.3"]}`, `false`},
+		"caret below":              {`{"semver_satisfies": ["1.2.2", "^1.2.3"]}`, `false`},
+		"caret zero":               {`{"semver_satisfies": ["0.3.0", "^0.2.3"]}`, `false`},
+		"caret zero minor":         {`{"semver_satisfies": ["0.2.9", "^0.2.3"]}`, `true`},
+		"caret zero zero":          {`{"semver_satisfies": ["0.0.4", "^0.0.3"]}`, `false`},
+		"tilde":                    {`{"semver_satisfies": ["1.2.9", "~1.2.3"]}`, `true`},
+		"tilde minor":              {`{"semver_satisfies": ["1.3.0", "~1.2.3"]}`, `false`},
+		"tilde major":              {`{"semver_satisfies": ["1.9.0", "~1"]}`, `true`},
+		"wildcard":                 {`{"semver_satisfies": ["1.2.9", "1.2.x"]}`, `true`},
+		"outside a wildcard":       {`{"semver_satisfies": ["1.3.0", "1.2.x"]}`, `false`},
+		"partial as a wildcard":    {`{"semver_satisfies": ["1.7.1", "1"]}`, `true`},
+		"any":                      {`{"semver_satisfies": ["3.4.5", "*"]}`, `true`},
+		"exact":                    {`{"semver_satisfies": ["1.2.3", "=1.2.3"]}`, `true`},
+		"not exact":                {`{"semver_satisfies": ["1.2.4", "1.2.3"]}`, `false`},
+		"greater than partial":     {`{"semver_satisfies": ["1.2.9", ">1.2"]}`, `false`},
+		"at most partial":          {`{"semver_satisfies": ["1.2.9", "<=1.2"]}`, `true`},
+		"alternatives":             {`{"semver_satisfies": ["2.1.4", "^1.4 || ~2.1.0"]}`, `true`},
+		"no alternative":           {`{"semver_satisfies": ["2.2.0", "^1.4 || ~2.1.0"]}`, `false`},
+		"invalid constraint":       {`{"semver_satisfies": ["1.0.0", ">=one"]}`, `null`},
+		"number after a wildcard":  {`{"semver_satisfies": ["1.0.0", "1.x.3"]}`, `null`},
+		"constraint not a string":  {`{"semver_satisfies": ["1.0.0", 1]}`, `null`},
+		"gated by version":         {`{"if": [{"semver_satisfies": [{"var": "client.version"}, ">=2.1"]}, "new", "old"]}`, `"new"`},
+		"satisfied by pre-release": {`{"semver_satisfies": ["2.0.0-beta", ">=2.0.0-alpha <2.0.0"]}`, `true`},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var result bytes.Buffer
+
+			err := Apply(strings.NewReader(scenario.Rule), strings.NewReader(`{"client": {"version": "2.10.1"}}`), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, result.String())
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package jsonlogic
+
+// setArgs reads the lists given to a set operator. Missing values are
+// empty lists and single values are lists of one element.
+func setArgs(values interface{}) [][]interface{} {
+	parsed := toSlice(values)
+
+	lists := make([][]interface{}, 0, len(parsed))
+	for _, value := range parsed {
+		switch {
+		case value == nil:
+			lists = append(lists, []interface{}{})
+		case isSlice(value):
+			lists = append(lists, value.([]interface{}))
+		default:
+			lists = append(lists, []interface{}{value})
+		}
+	}
+
+	return lists
+}
+
+// appendUnique adds value to set unless an equal value is already there
+func appendUnique(set []interface{}, value interface{}) []interface{} {
+	if indexOf(set, value) >= 0 {
+		return set
+	}
+
+	return append(set, value)
+}
+
+// intersection returns the values present in all the lists, in the order
+// they appear in the first one: {"intersection": [list, list...]}
+func intersection(values interface{}) interface{} {
+	lists := setArgs(values)
+
+	result := make([]interface{}, 0)
+	if len(lists) == 0 {
+		return result
+	}
+
+	for _, value := range lists[0] {
+		found := true
+		for _, list := range lists[1:] {
+			if indexOf(list, value) < 0 {
+				found = false
+
+				break
+			}
+		}
+
+		if found {
+			result = appendUnique(result, value)
+		}
+	}
+
+	return result
+}
+
+// union returns the values present in any of the lists, without repetitions
+func union(values interface{}) interface{} {
+	result := make([]interface{}, 0)
+
+	for _, list := range setArgs(values) {
+		for _, value := range list {
+			result = appendUnique(result, value)
+		}
+	}
+
+	return result
+}
+
+// difference returns the values of the first list that aren't present in
+// any of the others
+func difference(values interface{}) interface{} {
+	lists := setArgs(values)
+
+	result := make([]interface{}, 0)
+	if len(lists) == 0 {
+		return result
+	}
+
+	for _, value := range lists[0] {
+		found := false
+		for _, list := range lists[1:] {
+			if indexOf(list, value) >= 0 {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			result = appendUnique(result, value)
+		}
+	}
+
+	return result
+}
+
+// zip pairs the elements of the lists by their position, up to the end of
+// the shortest one: {"zip": [[1, 2, 3], ["a", "b"]]} is [[1, "a"], [2, "b"]]
+func zip(values interface{}) interface{} {
+	lists := setArgs(values)
+
+	result := make([]interface{}, 0)
+	if len(lists) == 0 {
+		return result
+	}
+
+	length := len(lists[0])
+	for _, list := range lists[1:] {
+		if len(list) < length {
+			length = len(list)
+		}
+	}
+
+	for i := 0; i < length; i++ {
+		tuple := make([]interface{}, len(lists))
+		for j, list := range lists {
+			tuple[j] = list[i]
+		}
+
+		result = append(result, tuple)
+	}
+
+	return result
+}
+
+// product combines every element of each list with every element of the
+// others: {"product": [[1, 2], ["a", "b"]]} is [[1, "a"], [1, "b"], [2,
+// "a"], [2, "b"]]
+func (ev *evaluator) product(values interface{}) interface{} {
+	lists := setArgs(values)
+
+	result := make([]interface{}, 0)
+	if len(lists) == 0 {
+		return result
+	}
+
+	// the size of the product is known before building anything
+	size := productSize(lists)
+	if size == 0 {
+		return result
+	}
+
+	ev.grow("product", size)
+
+	result = append(result, []interface{}{})
+	for _, list := range lists {
+		combined := make([]interface{}, 0, len(result)*len(list))
+		for _, tuple := range result {
+			for _, value := range list {
+				_tuple := make([]interface{}, 0, len(lists))
+				_tuple = append(_tuple, tuple.([]interface{})...)
+				combined = append(combined, append(_tuple, value))
+			}
+		}
+
+		result = combined
+	}
+
+	return result
+}
+
+// maxInt is the largest int
+const maxInt = int(^uint(0) >> 1)
+
+// productSize is the number of combinations of lists, the largest int
+// when there are more
+func productSize(lists [][]interface{}) int {
+	size := 1
+	for _, list := range lists {
+		if len(list) == 0 {
+			return 0
+		}
+
+		if size > maxInt/len(list) {
+			size = maxInt
+
+			continue
+		}
+
+		size *= len(list)
+	}
+
+	return size
+}
@@ -0,0 +1,95 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetOperators(t *testing.T) {
+	data := `{
+		"roles": ["editor", "viewer", "editor"],
+		"teams": [{"id": 1}, {"id": 2}],
+		"guest": null
+	}`
+
+	scenarios := map[string]struct {
+		Rule     string
+		Expected string
+	}{
+		"intersection":                 {`{"intersection": [{"var": "roles"}, ["admin", "editor"]]}`, `["editor"]`},
+		"intersection of many lists":   {`{"intersection": [[1, 2, 3], [2, 3], [3, 2, 1]]}`, `[2, 3]`},
+		"intersection of objects":      {`{"intersection": [{"var": "teams"}, [{"id": 2}, {"id": 3}]]}`, `[{"id": 2}]`},
+		"intersection does not coerce": {`{"intersection": [[1, 2], ["1", 2]]}`, `[2]`},
+		"user has any of the roles": {
+			`{">": [{"count": {"intersection": [{"var": "roles"}, ["admin", "editor"]]}}, 0]}`,
+			`true`,
+		},
+		"union":                   {`{"union": [{"var": "roles"}, ["admin", "viewer"]]}`, `["editor", "viewer", "admin"]`},
+		"union with missing data": {`{"union": [{"var": "guest"}, ["admin"]]}`, `["admin"]`},
+		"difference":              {`{"difference": [{"var": "roles"}, ["viewer"]]}`, `["editor"]`},
+		"difference of objects":   {`{"difference": [{"var": "teams"}, [{"id": 1}]]}`, `[{"id": 2}]`},
+		"no lists":                {`{"union": []}`, `[]`},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var result bytes.Buffer
+
+			err := Apply(strings.NewReader(scenario.Rule), strings.NewReader(data), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, result.String())
+		})
+	}
+}
+
+func TestCombinations(t *testing.T) {
+	data := `{
+		"expected": [1, 2, 3],
+		"actual": [1, 2, 4],
+		"sizes": ["S", "M"],
+		"colors": ["red", "blue"]
+	}`
+
+	scenarios := map[string]struct {
+		Rule     string
+		Expected string
+	}{
+		"zip":                      {`{"zip": [{"var": "expected"}, {"var": "actual"}]}`, `[[1, 1], [2, 2], [3, 4]]`},
+		"zip up to the shortest":   {`{"zip": [{"var": "expected"}, {"var": "sizes"}]}`, `[[1, "S"], [2, "M"]]`},
+		"zip three lists":          {`{"zip": [[1, 2], ["a", "b"], [true, false]]}`, `[[1, "a", true], [2, "b", false]]`},
+		"zip a single list":        {`{"zip": [[1, 2]]}`, `[[1], [2]]`},
+		"zip with missing data":    {`{"zip": [{"var": "expected"}, {"var": "missing"}]}`, `[]`},
+		"compare parallel lists":   {`{"all": [{"zip": [{"var": "expected"}, {"var": "actual"}]}, {"==": [{"var": ".0"}, {"var": ".1"}]}]}`, `false`},
+		"find differing positions": {`{"map": [{"filter": [{"zip": [{"var": "expected"}, {"var": "actual"}]}, {"!=": [{"var": ".0"}, {"var": ".1"}]}]}, {"var": ".1"}]}`, `[4]`},
+		"product": {
+			`{"product": [{"var": "sizes"}, {"var": "colors"}]}`,
+			`[["S", "red"], ["S", "blue"], ["M", "red"], ["M", "blue"]]`,
+		},
+		"product of three lists":       {`{"product": [[1, 2], ["a"], [true, false]]}`, `[[1, "a", true], [1, "a", false], [2, "a", true], [2, "a", false]]`},
+		"product with an empty list":   {`{"product": [{"var": "sizes"}, []]}`, `[]`},
+		"product with a single value":  {`{"product": [{"var": "sizes"}, "red"]}`, `[["S", "red"], ["M", "red"]]`},
+		"product of a single list":     {`{"product": [[1, 2]]}`, `[[1], [2]]`},
+		"combinations in a quantifier": {`{"some": [{"product": [{"var": "expected"}, {"var": "actual"}]}, {">": [{"var": ".0"}, {"var": ".1"}]}]}`, `true`},
+		"no lists":                     {`{"product": []}`, `[]`},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var result bytes.Buffer
+
+			err := Apply(strings.NewReader(scenario.Rule), strings.NewReader(data), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, result.String())
+		})
+	}
+}
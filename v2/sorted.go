@@ -0,0 +1,118 @@
+package jsonlogic
+
+import (
+	"sort"
+)
+
+// sortedBounds returns the lowest and the highest value an element of a
+// list given to in_sorted matches: the bounds of a range, or the value
+// itself for both
+func sortedBounds(element interface{}) (interface{}, interface{}, bool) {
+	if r, ok := element.([]interface{}); ok {
+		if len(r) != 2 {
+			return nil, nil, false
+		}
+
+		return r[0], r[1], valueOf(r[0]).isPrimitive() && valueOf(r[1]).isPrimitive() && compare(r[0], r[1]) <= 0
+	}
+
+	return element, element, valueOf(element).isPrimitive()
+}
+
+// inSorted tells if a value is in a list of values and [low, high] ranges
+// sorted as SortedRanges sorts them, searching it by bisection:
+// {"in_sorted": [{"var": "zip"}, ["1000", ["1100", "1199"], "2000"]]}.
+// Given "sort" as third argument, the list is sorted first.
+func inSorted(values []interface{}) bool {
+	if len(values) < 2 {
+		return false
+	}
+
+	list, ok := values[1].([]interface{})
+	if !ok {
+		return false
+	}
+
+	if len(values) > 2 && values[2] == "sort" {
+		list = SortedRanges(list)
+	}
+
+	key := values[0]
+	if !valueOf(key).isPrimitive() {
+		return false
+	}
+
+	i := sort.Search(len(list), func(i int) bool {
+		_, high, _ := sortedBounds(list[i])
+
+		return compare(high, key) >= 0
+	})
+	if i == len(list) {
+		return false
+	}
+
+	low, high, ok := sortedBounds(list[i])
+
+	return ok && compare(low, key) <= 0 && compare(key, high) <= 0
+}
+
+// sortedElement is an element of a list for in_sorted, with the bounds it
+// matches
+type sortedElement struct {
+	low, high interface{}
+}
+
+// SortedRanges returns a copy of a list of values and [low, high] ranges
+// ordered the way in_sorted expects them. in_sorted compares values the way
+// sort orders them: booleans first, then numbers by their values and
+// strings bytewise, so 9 is before 10 and numbers aren't found in lists of
+// strings. The bounds of ranges are inclusive. Values within ranges are
+// dropped and overlapping ranges are merged, so each value is matched by a
+// single element. Elements which are neither strings, numbers, booleans nor
+// ranges of them are dropped.
+func SortedRanges(list []interface{}) []interface{} {
+	elements := make([]sortedElement, 0, len(list))
+	for _, element := range list {
+		low, high, ok := sortedBounds(element)
+		if !ok {
+			continue
+		}
+
+		elements = append(elements, sortedElement{low: low, high: high})
+	}
+
+	sort.SliceStable(elements, func(i, j int) bool {
+		if c := compare(elements[i].low, elements[j].low); c != 0 {
+			return c < 0
+		}
+
+		return compare(elements[i].high, elements[j].high) < 0
+	})
+
+	merged := make([]sortedElement, 0, len(elements))
+	for _, element := range elements {
+		last := len(merged) - 1
+		if last >= 0 && compare(element.low, merged[last].high) <= 0 {
+			if compare(element.high, merged[last].high) > 0 {
+				merged[last].high = element.high
+			}
+
+			continue
+		}
+
+		merged = append(merged, element)
+	}
+
+	result := make([]interface{}, 0, len(merged))
+	for _, element := range merged {
+		if compare(element.low, element.high) == 0 {
+			result = append(result, element.low)
+
+			continue
+		}
+
+		result = append(result, []interface{}{element.low, element.high})
+	}
+
+	return result
+}
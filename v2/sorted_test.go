@@ -0,0 +1,119 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInSorted(t *testing.T) {
+	scenarios := map[string]struct {
+		Rule     string
+		Expected string
+	}{
+		"value":                 {`{"in_sorted": ["b", {"var": "sorted"}]}`, `true`},
+		"range":                 {`{"in_sorted": ["1150", {"var": "sorted"}]}`, `true`},
+		"range bound":           {`{"in_sorted": ["1199", {"var": "sorted"}]}`, `true`},
+		"missing":               {`{"in_sorted": ["1200", {"var": "sorted"}]}`, `false`},
+		"number among strings":  {`{"in_sorted": [1100, {"var": "sorted"}]}`, `false`},
+		"number":                {`{"in_sorted": [10, [1, 2, 10]]}`, `true`},
+		"missing number":        {`{"in_sorted": [3, [1, 2, 10]]}`, `false`},
+		"number in a range":     {`{"in_sorted": [150, {"var": "numbers"}]}`, `true`},
+		"integer and decimal":   {`{"in_sorted": [2.0, {"var": "numbers"}]}`, `true`},
+		"numbers sorted":        {`{"in_sorted": [10, [10, 9, 1], "sort"]}`, `true`},
+		"after the last":        {`{"in_sorted": ["z", {"var": "sorted"}]}`, `false`},
+		"unsorted":              {`{"in_sorted": [9, {"var": "unsorted"}]}`, `false`},
+		"sorted internally":     {`{"in_sorted": [9, {"var": "unsorted"}, "sort"]}`, `true`},
+		"range sorted":          {`{"in_sorted": [15, {"var": "unsorted"}, "sort"]}`, `true`},
+		"numeric order":         {`{"in_sorted": [2, [[1, 10]]]}`, `true`},
+		"not a list":            {`{"in_sorted": ["a", "abc"]}`, `false`},
+		"not a value":           {`{"in_sorted": [null, {"var": "sorted"}]}`, `false`},
+		"malformed range":       {`{"in_sorted": ["b", {"var": "malformed"}]}`, `false`},
+		"missing list":          {`{"in_sorted": ["b", {"var": "nothing"}]}`, `false`},
+		"empty list":            {`{"in_sorted": ["b", {"var": "empty"}]}`, `false`},
+		"empty list sorted":     {`{"in_sorted": ["b", {"var": "empty"}, "sort"]}`, `false`},
+		"malformed list sorted": {`{"in_sorted": ["b", {"var": "malformed"}, "sort"]}`, `false`},
+	}
+
+	data := `{
+		"sorted": ["1000", ["1100", "1199"], "2000", "a", "b"],
+		"unsorted": ["a", 9, [14, 16], 1],
+		"numbers": [2, 9, 10, [100, 200], 1000],
+		"malformed": [["a", "b", "c"]],
+		"empty": []
+	}`
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var result bytes.Buffer
+
+			err := Apply(strings.NewReader(scenario.Rule), strings.NewReader(data), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, result.String())
+		})
+	}
+}
+
+func TestSortedRanges(t *testing.T) {
+	scenarios := map[string]struct {
+		List     []interface{}
+		Expected []interface{}
+	}{
+		"empty": {
+			List:     []interface{}{},
+			Expected: []interface{}{},
+		},
+		"values": {
+			List:     []interface{}{"c", "a", "b"},
+			Expected: []interface{}{"a", "b", "c"},
+		},
+		"numbers by value": {
+			List:     []interface{}{10.0, "1", 9.0, int64(2)},
+			Expected: []interface{}{int64(2), 9.0, 10.0, "1"},
+		},
+		"numeric ranges": {
+			List:     []interface{}{[]interface{}{10.0, 20.0}, 9.0, []interface{}{15.0, 100.0}},
+			Expected: []interface{}{9.0, []interface{}{10.0, 100.0}},
+		},
+		"ranges": {
+			List:     []interface{}{[]interface{}{"m", "p"}, "a", []interface{}{"c", "f"}},
+			Expected: []interface{}{"a", []interface{}{"c", "f"}, []interface{}{"m", "p"}},
+		},
+		"overlapping ranges": {
+			List:     []interface{}{[]interface{}{"d", "h"}, []interface{}{"a", "e"}},
+			Expected: []interface{}{[]interface{}{"a", "h"}},
+		},
+		"nested ranges": {
+			List:     []interface{}{[]interface{}{"a", "z"}, []interface{}{"c", "d"}},
+			Expected: []interface{}{[]interface{}{"a", "z"}},
+		},
+		"touching ranges": {
+			List:     []interface{}{[]interface{}{"a", "c"}, []interface{}{"c", "e"}},
+			Expected: []interface{}{[]interface{}{"a", "e"}},
+		},
+		"values within ranges": {
+			List:     []interface{}{"b", []interface{}{"a", "c"}, "c"},
+			Expected: []interface{}{[]interface{}{"a", "c"}},
+		},
+		"duplicates": {
+			List:     []interface{}{"a", "a"},
+			Expected: []interface{}{"a"},
+		},
+		"invalid elements": {
+			List:     []interface{}{nil, map[string]interface{}{}, []interface{}{"z", "a"}, []interface{}{"a"}, "a"},
+			Expected: []interface{}{"a"},
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			assert.Equal(t, scenario.Expected, SortedRanges(scenario.List))
+		})
+	}
+}
@@ -0,0 +1,226 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// ApplyStream is like Apply, writing large lists as they are produced.
+// See Engine.ApplyStream.
+func ApplyStream(rule, data io.Reader, result io.Writer) error {
+	return defaultEngine.ApplyStream(rule, data, result)
+}
+
+// ApplyInterfaceStream is like ApplyStream, but works with already decoded
+// values. See Engine.ApplyInterfaceStream.
+func ApplyInterfaceStream(rule, data interface{}, result io.Writer) error {
+	return defaultEngine.ApplyInterfaceStream(rule, data, result)
+}
+
+// ApplyStream is like Apply, but when the rule is a filter or a map, the
+// elements of its result are written to result as they are produced
+// instead of being held in a list until the end, which bounds the memory
+// used by rules giving large lists. The output is the same as that of
+// Apply, except that output transformers get the elements one by one, and
+// that a failing evaluation leaves the elements already written in result.
+// Other rules, and the rules of engines with an audit log, a cache or
+// middlewares, are written as Apply writes them.
+func (e *Engine) ApplyStream(rule, data io.Reader, result io.Writer) error {
+	order := e.newKeyOrder()
+
+	_rule, _data, err := e.decode(rule, data, order)
+	if err != nil {
+		return err
+	}
+
+	return e.stream(order, _rule, _data, result)
+}
+
+// ApplyInterfaceStream is like ApplyStream, but works with already decoded
+// values, like ApplyInterface, so the lists of filter and map can be
+// DataSources: their elements are then read, evaluated and written one at
+// a time.
+func (e *Engine) ApplyInterfaceStream(rule, data interface{}, result io.Writer) error {
+	return e.stream(nil, rule, fromGoData(data), result)
+}
+
+// stream applies a decoded rule to decoded data, writing the elements of
+// the results of filter and map as they are produced
+func (e *Engine) stream(order keyOrder, rule, data interface{}, result io.Writer) error {
+	operator, values, ok := streamed(rule)
+	if !ok || e.audit != nil || e.cache != nil || len(e.middlewares) > 0 {
+		a := e.newArena()
+		defer a.release()
+
+		output, err := e.evaluateIn(context.Background(), order, a, rule, data)
+		if err != nil {
+			return err
+		}
+
+		output, err = e.prepare(order, output)
+		if err != nil {
+			return err
+		}
+
+		return e.write(result, output)
+	}
+
+	list := e.newListWriter(result)
+
+	// arenas are only reused once the evaluation ends, so the streamed
+	// lists don't use them to keep their memory bounded
+	ev := e.acquire()
+	defer release(ev)
+
+	err := ev.execute(rule, data, func() {
+		ev.stream(rule, operator, values, data, func(value interface{}) {
+			value, err := e.prepare(order, value)
+			if err == nil {
+				err = list.add(value)
+			}
+
+			if err != nil {
+				ev.fail(err)
+			}
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	return list.close()
+}
+
+// streamed returns the operator and the arguments of the rules whose
+// results can be streamed
+func streamed(rule interface{}) (string, interface{}, bool) {
+	expression, ok := rule.(map[string]interface{})
+	if !ok || len(expression) != 1 {
+		return "", nil, false
+	}
+
+	for operator, values := range expression {
+		if (operator == "filter" || operator == "map") && isSlice(values) && len(values.([]interface{})) >= 2 {
+			return operator, values, true
+		}
+	}
+
+	return "", nil, false
+}
+
+// stream evaluates a filter or a map like applyRule, giving the elements of
+// its result to emit instead of returning them
+func (ev *evaluator) stream(rule interface{}, operator string, values, data interface{}, emit func(value interface{})) {
+	if ev.engine.instrumentation != nil {
+		ev.engine.instrumentation.OperatorEvaluated(ev.ctx, operator)
+	}
+
+	if ev.budget != nil {
+		defer ev.enter(rule)()
+	}
+
+	ev.allow(operator)
+
+	count := 0
+	limited := func(value interface{}) {
+		count++
+		ev.grow(operator, count)
+
+		emit(value)
+	}
+
+	if operator == "filter" {
+		ev.filtered(values, data, limited)
+	} else {
+		ev.mapped(values, data, limited)
+	}
+}
+
+// listWriter writes a JSON list element by element, in the output format
+// of an engine
+type listWriter struct {
+	engine  *Engine
+	w       io.Writer
+	buffer  bytes.Buffer
+	encoder *json.Encoder
+	indent  bool
+	count   int
+	size    int
+}
+
+func (e *Engine) newListWriter(w io.Writer) *listWriter {
+	list := &listWriter{
+		engine: e,
+		w:      w,
+		indent: e.output.prefix != "" || e.output.indent != "",
+	}
+
+	// elements are nested in the list, and start on a new line when the
+	// output is indented
+	list.encoder = json.NewEncoder(&list.buffer)
+	list.encoder.SetIndent(e.output.prefix+e.output.indent, e.output.indent)
+	list.encoder.SetEscapeHTML(!e.output.keepHTML)
+
+	return list
+}
+
+// add writes an element of the list
+func (l *listWriter) add(value interface{}) error {
+	separator := ","
+	if l.count == 0 {
+		separator = "["
+	}
+
+	if l.indent {
+		separator += "\n" + l.engine.output.prefix + l.engine.output.indent
+	}
+
+	l.buffer.Reset()
+	l.buffer.WriteString(separator)
+
+	if err := l.encoder.Encode(value); err != nil {
+		return err
+	}
+
+	l.count++
+
+	return l.flush(bytes.TrimSuffix(l.buffer.Bytes(), []byte("\n")))
+}
+
+// close writes the end of the list
+func (l *listWriter) close() error {
+	end := "]"
+	if l.count == 0 {
+		end = "[]"
+	} else if l.indent {
+		end = "\n" + l.engine.output.prefix + "]"
+	}
+
+	if err := l.flush([]byte(end)); err != nil {
+		return err
+	}
+
+	if l.engine.output.noTrailingNewline {
+		return nil
+	}
+
+	_, err := l.w.Write([]byte("\n"))
+
+	return err
+}
+
+// flush writes part of the list, unless the list gets larger than the
+// results of the engine can be
+func (l *listWriter) flush(part []byte) error {
+	l.size += len(part)
+	if max := l.engine.maxResultSize; max > 0 && l.size > max {
+		return fmt.Errorf("%w: result of more than %d bytes", ErrLimitExceeded, max)
+	}
+
+	_, err := l.w.Write(part)
+
+	return err
+}
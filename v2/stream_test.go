@@ -0,0 +1,151 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyStream(t *testing.T) {
+	data := `{"items": [{"b": 1.234, "a": "<x>"}, {"b": 2, "a": "y"}, {"b": 3, "a": [1, {"z": null}]}], "empty": []}`
+
+	rules := map[string]string{
+		"filter":          `{"filter": [{"var": "items"}, {">": [{"var": ".b"}, 1]}]}`,
+		"map":             `{"map": [{"var": "items"}, {"var": ".a"}]}`,
+		"empty":           `{"filter": [{"var": "empty"}, true]}`,
+		"literal list":    `{"map": [[1, 2, 3], {"*": [{"var": ""}, 2]}]}`,
+		"not streamed":    `{"var": "items.0"}`,
+		"nested":          `{"map": [{"var": "items"}, {"map": [[1, 2], {"var": ""}]}]}`,
+		"not an operator": `[1, 2]`,
+	}
+
+	options := map[string][]Option{
+		"default":      nil,
+		"indent":       {WithIndent("> ", "  ")},
+		"prefix":       {WithIndent("-", "")},
+		"html":         {WithoutHTMLEscaping(), WithoutTrailingNewline()},
+		"key order":    {WithKeyOrder(), WithIndent("", "\t")},
+		"transformers": {WithOutputTransformers(RoundFloats(1), StripNulls())},
+	}
+
+	for name, rule := range rules {
+		for option, opts := range options {
+			t.Run(fmt.Sprintf("SCENARIO:%s with %s", name, option), func(t *testing.T) {
+				engine, err := NewEngine(opts...)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				var expected, result bytes.Buffer
+
+				err = engine.Apply(strings.NewReader(rule), strings.NewReader(data), &expected)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				err = engine.ApplyStream(strings.NewReader(rule), strings.NewReader(data), &result)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				assert.Equal(t, expected.String(), result.String())
+			})
+		}
+	}
+}
+
+// writeLog records what is written to it
+type writeLog struct {
+	bytes.Buffer
+	writes int
+}
+
+func (w *writeLog) Write(p []byte) (int, error) {
+	w.writes++
+
+	return w.Buffer.Write(p)
+}
+
+// streamedSource records how much of the output was written when each of
+// its elements was read
+type streamedSource struct {
+	output  *writeLog
+	written []int
+}
+
+func (s *streamedSource) Len() int {
+	return 3
+}
+
+func (s *streamedSource) Get(i int) (interface{}, error) {
+	s.written = append(s.written, s.output.Len())
+
+	return float64(i), nil
+}
+
+func TestApplyInterfaceStream(t *testing.T) {
+	var result writeLog
+
+	rows := &streamedSource{output: &result}
+
+	rule := map[string]interface{}{"map": []interface{}{
+		map[string]interface{}{"var": "rows"},
+		map[string]interface{}{"+": []interface{}{map[string]interface{}{"var": ""}, 1.0}},
+	}}
+
+	err := ApplyInterfaceStream(rule, map[string]interface{}{"rows": rows}, &result)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "[1,2,3]\n", result.String())
+	assert.Equal(t, []int{0, 2, 4}, rows.written)
+	assert.Equal(t, 5, result.writes)
+}
+
+func TestApplyStreamErrors(t *testing.T) {
+	scenarios := map[string]struct {
+		Options  []Option
+		Rule     string
+		Written  string
+		Expected error
+	}{
+		"list length": {
+			Options:  []Option{WithMaxListLength(2)},
+			Rule:     `{"map": [[1, 2, 3], {"var": ""}]}`,
+			Written:  `[1,2`,
+			Expected: ErrLimitExceeded,
+		},
+		"result size": {
+			Options:  []Option{WithMaxResultSize(6)},
+			Rule:     `{"map": [["aa", "bb", "cc"], {"var": ""}]}`,
+			Written:  `["aa"`,
+			Expected: ErrLimitExceeded,
+		},
+		"operator sets": {
+			Options:  []Option{WithOperatorSet("core-1")},
+			Rule:     `{"map": [[1], {"abs": -1}]}`,
+			Written:  ``,
+			Expected: ErrOperatorNotAllowed,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			engine, err := NewEngine(scenario.Options...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var result bytes.Buffer
+
+			err = engine.ApplyStream(strings.NewReader(scenario.Rule), strings.NewReader(`{}`), &result)
+			assert.True(t, errors.Is(err, scenario.Expected))
+			assert.Equal(t, scenario.Written, result.String())
+		})
+	}
+}
@@ -0,0 +1,331 @@
+package jsonlogic
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// fields caches, for every struct type, the index of its fields by the
+// name they have in JSON
+var fields sync.Map
+
+// property reads a property of data, which is either decoded JSON or Go
+// values: structs, through the names their fields have in JSON, maps and
+// slices. The value found is returned in the form of decoded JSON, except
+// for structs, which are read as they are needed.
+func property(data interface{}, name string) interface{} {
+	switch data := data.(type) {
+	case map[string]interface{}:
+		return fromGo(data[name])
+	case []interface{}:
+		i, ok := listIndex(name, len(data))
+		if !ok {
+			return nil
+		}
+
+		return fromGo(data[i])
+	case DataSource:
+		i, ok := listIndex(name, data.Len())
+		if !ok {
+			return nil
+		}
+
+		return element(data, i)
+	}
+
+	v := reflect.Indirect(reflect.ValueOf(data))
+
+	switch v.Kind() {
+	case reflect.Struct:
+		index, ok := structFields(v.Type())[name]
+		if !ok {
+			return nil
+		}
+
+		return fromGoData(v.FieldByIndex(index).Interface())
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return nil
+		}
+
+		value := v.MapIndex(reflect.ValueOf(name).Convert(v.Type().Key()))
+		if !value.IsValid() {
+			return nil
+		}
+
+		return fromGoData(value.Interface())
+	case reflect.Slice, reflect.Array:
+		i, ok := listIndex(name, v.Len())
+		if !ok {
+			return nil
+		}
+
+		return fromGoData(v.Index(i).Interface())
+	}
+
+	return nil
+}
+
+// listIndex reads the index of an element of a list of the given length,
+// negative ones counting from the end
+func listIndex(name string, length int) (int, bool) {
+	i, err := strconv.Atoi(name)
+	if err != nil {
+		return 0, false
+	}
+
+	if i < 0 {
+		i += length
+	}
+
+	return i, i >= 0 && i < length
+}
+
+// structFields returns the index of the fields of a struct type by their
+// name in JSON, following the rules of encoding/json for tags and
+// embedded structs
+func structFields(t reflect.Type) map[string][]int {
+	if cached, ok := fields.Load(t); ok {
+		return cached.(map[string][]int)
+	}
+
+	index := make(map[string][]int)
+	collectFields(t, nil, index)
+
+	fields.Store(t, index)
+
+	return index
+}
+
+func collectFields(t reflect.Type, parent []int, index map[string][]int) {
+	promoted := make([]reflect.StructField, 0)
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name := strings.Split(tag, ",")[0]
+
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			promoted = append(promoted, field)
+
+			continue
+		}
+
+		if field.PkgPath != "" {
+			// unexported
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		index[name] = append(append([]int{}, parent...), i)
+	}
+
+	// fields of the struct itself hide the promoted ones
+	for _, field := range promoted {
+		embedded := make(map[string][]int)
+		collectFields(field.Type, append(append([]int{}, parent...), field.Index...), embedded)
+
+		for name, i := range embedded {
+			if _, ok := index[name]; !ok {
+				index[name] = i
+			}
+		}
+	}
+}
+
+// structObject reads the fields of a struct, or of a pointer to one, as
+// the properties of an object, by the names they have in JSON, the way
+// var reads them, false for the other values
+func structObject(value interface{}) (map[string]interface{}, bool) {
+	if _, ok := value.(DataSource); ok || valueOf(value).kind != otherKind {
+		return nil, false
+	}
+
+	v := reflect.ValueOf(value)
+	if v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return nil, false
+	}
+
+	index := structFields(v.Type())
+
+	object := make(map[string]interface{}, len(index))
+	for name := range index {
+		object[name] = property(value, name)
+	}
+
+	return object, true
+}
+
+// fromGoData is fromGo for the data given as Go values, converting the
+// elements of its lists and the values of its objects too: the lists and
+// objects holding values which aren't decoded JSON are copied, the others
+// kept as they are.
+func fromGoData(value interface{}) interface{} {
+	converted, _ := convertGo(value)
+
+	return converted
+}
+
+// convertGo is fromGoData, telling if the value was converted
+func convertGo(value interface{}) (interface{}, bool) {
+	switch value := value.(type) {
+	case nil, bool, float64, int64, string, DataSource:
+		return value, false
+	case []interface{}:
+		var list []interface{}
+
+		for i, element := range value {
+			converted, ok := convertGo(element)
+			if ok && list == nil {
+				list = make([]interface{}, len(value))
+				copy(list, value)
+			}
+
+			if list != nil {
+				list[i] = converted
+			}
+		}
+
+		if list == nil {
+			return value, false
+		}
+
+		return list, true
+	case map[string]interface{}:
+		var object map[string]interface{}
+
+		for key, element := range value {
+			converted, ok := convertGo(element)
+			if !ok {
+				continue
+			}
+
+			if object == nil {
+				object = make(map[string]interface{}, len(value))
+				for k, v := range value {
+					object[k] = v
+				}
+			}
+
+			object[key] = converted
+		}
+
+		if object == nil {
+			return value, false
+		}
+
+		return object, true
+	}
+
+	return fromGo(value), true
+}
+
+// fromGo converts a Go value to the form of decoded JSON: float64 numbers,
+// []interface{} lists and map[string]interface{} objects, whose own
+// elements are left to fromGoData. Structs are kept, so only the fields
+// used by the rule are read; values with their own JSON encoding are
+// converted through it. int64 values are kept for the engines keeping
+// integers, see WithIntegers.
+func fromGo(value interface{}) interface{} {
+	switch value.(type) {
+	case nil, bool, float64, int64, string, []interface{}, map[string]interface{}:
+		return value
+	case DataSource:
+		// read as they are needed
+		return value
+	case json.Marshaler:
+		return fromJSON(value)
+	}
+
+	v := reflect.ValueOf(value)
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+
+		return fromGoData(v.Elem().Interface())
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.String:
+		return v.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil
+		}
+
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			// bytes are encoded as base64 strings
+			return fromJSON(value)
+		}
+
+		fallthrough
+	case reflect.Array:
+		list := make([]interface{}, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			list = append(list, fromGoData(v.Index(i).Interface()))
+		}
+
+		return list
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+
+		if v.Type().Key().Kind() != reflect.String {
+			return fromJSON(value)
+		}
+
+		object := make(map[string]interface{}, v.Len())
+		for _, key := range v.MapKeys() {
+			object[key.String()] = fromGoData(v.MapIndex(key).Interface())
+		}
+
+		return object
+	case reflect.Struct:
+		if _, ok := value.(interface{ MarshalText() ([]byte, error) }); ok {
+			return fromJSON(value)
+		}
+
+		return value
+	}
+
+	return nil
+}
+
+// fromJSON converts a value through its JSON encoding
+func fromJSON(value interface{}) interface{} {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		return nil
+	}
+
+	return decoded
+}
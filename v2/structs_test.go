@@ -0,0 +1,177 @@
+package jsonlogic
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testAddress struct {
+	City string `json:"city"`
+}
+
+type testAudit struct {
+	Created time.Time `json:"created"`
+}
+
+type testUser struct {
+	testAudit
+
+	Name     string            `json:"name"`
+	Age      int               `json:"age,omitempty"`
+	Admin    bool              `json:"is_admin"`
+	Address  *testAddress      `json:"address"`
+	Previous []testAddress     `json:"previous"`
+	Labels   map[string]string `json:"labels"`
+	Password string            `json:"-"`
+	Nickname string
+	internal string
+}
+
+func TestApplyInterfaceWithStructs(t *testing.T) {
+	user := &testUser{
+		testAudit: testAudit{Created: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)},
+		Name:      "Ana",
+		Age:       34,
+		Admin:     true,
+		Address:   &testAddress{City: "Lisbon"},
+		Previous:  []testAddress{{City: "Porto"}, {City: "Faro"}},
+		Labels:    map[string]string{"team": "core"},
+		Password:  "secret",
+		Nickname:  "Aninha",
+		internal:  "hidden",
+	}
+
+	scenarios := map[string]struct {
+		Rule     string
+		Expected interface{}
+	}{
+		"field with a tag": {
+			Rule:     `{"var": "name"}`,
+			Expected: "Ana",
+		},
+		"numbers are read as float64": {
+			Rule:     `{">=": [{"var": "age"}, 18]}`,
+			Expected: true,
+		},
+		"renamed field": {
+			Rule:     `{"var": "is_admin"}`,
+			Expected: true,
+		},
+		"field without a tag": {
+			Rule:     `{"var": "Nickname"}`,
+			Expected: "Aninha",
+		},
+		"ignored field": {
+			Rule:     `{"var": ["Password", "none"]}`,
+			Expected: "none",
+		},
+		"unexported field": {
+			Rule:     `{"var": ["internal", "none"]}`,
+			Expected: "none",
+		},
+		"nested struct through a pointer": {
+			Rule:     `{"var": "address.city"}`,
+			Expected: "Lisbon",
+		},
+		"list of structs": {
+			Rule:     `{"map": [{"var": "previous"}, {"var": "city"}]}`,
+			Expected: []interface{}{"Porto", "Faro"},
+		},
+		"element of a list": {
+			Rule:     `{"var": "previous.1.city"}`,
+			Expected: "Faro",
+		},
+		"map": {
+			Rule:     `{"var": "labels.team"}`,
+			Expected: "core",
+		},
+		"promoted field with its own encoding": {
+			Rule:     `{"var": "created"}`,
+			Expected: "2024-01-02T03:04:05Z",
+		},
+		"missing": {
+			Rule:     `{"missing": ["name", "email"]}`,
+			Expected: []interface{}{"email"},
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var rule interface{}
+			if err := json.NewDecoder(strings.NewReader(scenario.Rule)).Decode(&rule); err != nil {
+				t.Fatal(err)
+			}
+
+			result, err := ApplyInterface(rule, user)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, scenario.Expected, result)
+		})
+	}
+}
+
+type testItem struct {
+	ID    int     `json:"id"`
+	Price float32 `json:"price"`
+}
+
+func TestApplyInterfaceWithTypedData(t *testing.T) {
+	ints := []int{1, 2, 3}
+	floats := []float64{1.5, 2.5}
+	counts := map[string]int{"a": 1, "b": 2}
+	mixed := map[string]interface{}{"x": 1, "list": []int{1, 2, 3}, "items": []testItem{{ID: 1, Price: 10}, {ID: 2, Price: 30}}}
+	item := testItem{ID: 7, Price: 20}
+
+	scenarios := map[string]struct {
+		Rule     string
+		Data     interface{}
+		Expected interface{}
+	}{
+		"some of a typed slice":         {`{"some": [{"var": ""}, {">": [{"var": ""}, 2]}]}`, ints, true},
+		"all of a typed slice":          {`{"all": [{"var": ""}, {">": [{"var": ""}, 0]}]}`, ints, true},
+		"none of a typed slice":         {`{"none": [{"var": ""}, {">": [{"var": ""}, 3]}]}`, floats, true},
+		"map of a typed slice":          {`{"map": [{"var": ""}, {"*": [{"var": ""}, 2]}]}`, ints, []interface{}{2.0, 4.0, 6.0}},
+		"filter of a typed slice":       {`{"filter": [{"var": ""}, {">": [{"var": ""}, 2]}]}`, floats, []interface{}{2.5}},
+		"reduce of a typed slice":       {`{"reduce": [{"var": ""}, {"+": [{"var": "current"}, {"var": "accumulator"}]}, 0]}`, ints, 6.0},
+		"in a typed slice":              {`{"in": [2, {"var": ""}]}`, ints, true},
+		"count of a typed slice":        {`{"count": {"var": ""}}`, floats, 2.0},
+		"some of a typed map":           {`{"some": [{"var": ""}, {">": [{"var": ".value"}, 1]}]}`, counts, true},
+		"in a typed map":                {`{"in": ["b", {"var": ""}]}`, counts, true},
+		"values of a typed map":         {`{"values": {"var": ""}}`, counts, []interface{}{1.0, 2.0}},
+		"go number in an object":        {`{"+": [{"var": "x"}, 1]}`, mixed, 2.0},
+		"typed slice in an object":      {`{"all": [{"var": "list"}, {"<": [{"var": ""}, 4]}]}`, mixed, true},
+		"count of a typed slice inside": {`{"count": {"var": "list"}}`, mixed, 3.0},
+		"structs in an object":          {`{"filter": [{"var": "items"}, {">": [{"var": "price"}, 20]}]}`, mixed, []interface{}{testItem{ID: 2, Price: 30}}},
+		"keys of a struct":              {`{"keys": {"var": ""}}`, item, []interface{}{"id", "price"}},
+		"values of a struct":            {`{"values": {"var": ""}}`, item, []interface{}{7.0, 20.0}},
+		"has on a struct":               {`{"has": [{"var": ""}, "id"]}`, item, true},
+		"has on a struct pointer":       {`{"has": [{"var": ""}, "missing"]}`, &item, false},
+		"pick of a struct":              {`{"pick": [{"var": ""}, "id"]}`, item, map[string]interface{}{"id": 7.0}},
+		"in a struct":                   {`{"in": ["price", {"var": ""}]}`, item, true},
+		"some of a struct":              {`{"some": [{"var": ""}, {"==": [{"var": ".key"}, "id"]}]}`, item, true},
+		"map_obj of a struct":           {`{"map_obj": [{"var": ""}, {"*": [{"var": ".value"}, 2]}]}`, item, map[string]interface{}{"id": 14.0, "price": 40.0}},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var rule interface{}
+			if err := json.NewDecoder(strings.NewReader(scenario.Rule)).Decode(&rule); err != nil {
+				t.Fatal(err)
+			}
+
+			result, err := ApplyInterface(rule, scenario.Data)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, scenario.Expected, result)
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package jsonlogic
+
+import (
+	"strconv"
+	"strings"
+)
+
+// templatePart is a piece of a template: text written as is, or the
+// placeholder of a data path or of an argument
+type templatePart struct {
+	text        string
+	placeholder bool
+}
+
+// parseTemplate splits a template into its text and its placeholders,
+// written {path}. Braces are written {{ and }}. It fails on unbalanced and
+// empty placeholders.
+func parseTemplate(template string) ([]templatePart, bool) {
+	var parts []templatePart
+	var text strings.Builder
+
+	for i := 0; i < len(template); i++ {
+		c := template[i]
+
+		switch {
+		case c == '{' && strings.HasPrefix(template[i:], "{{"), c == '}' && strings.HasPrefix(template[i:], "}}"):
+			text.WriteByte(c)
+			i++
+		case c == '{':
+			end := strings.IndexAny(template[i+1:], "{}")
+			if end < 0 || template[i+1+end] != '}' {
+				return nil, false
+			}
+
+			name := strings.TrimSpace(template[i+1 : i+1+end])
+			if name == "" {
+				return nil, false
+			}
+
+			if text.Len() > 0 {
+				parts = append(parts, templatePart{text: text.String()})
+				text.Reset()
+			}
+
+			parts = append(parts, templatePart{text: name, placeholder: true})
+			i += end + 1
+		case c == '}':
+			return nil, false
+		default:
+			text.WriteByte(c)
+		}
+	}
+
+	if text.Len() > 0 {
+		parts = append(parts, templatePart{text: text.String()})
+	}
+
+	return parts, true
+}
+
+// argumentIndex returns the index of the argument of a placeholder made of
+// digits, counted from 1
+func argumentIndex(placeholder string) (int, bool) {
+	for _, c := range placeholder {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+	}
+
+	n, err := strconv.Atoi(placeholder)
+
+	return n, err == nil
+}
+
+// templatePaths returns the data paths of the placeholders of a template
+func templatePaths(template string) ([]string, bool) {
+	parts, ok := parseTemplate(template)
+	if !ok {
+		return nil, false
+	}
+
+	var paths []string
+	for _, part := range parts {
+		if _, ok := argumentIndex(part.text); part.placeholder && !ok {
+			paths = append(paths, part.text)
+		}
+	}
+
+	return paths, true
+}
+
+// template interpolates the placeholders of a string:
+// {"template": ["Order {id} exceeds limit {1}", {"var": "limits.order"}]}.
+// Placeholders made of digits stand for the arguments following the
+// template, counted from 1, and the others for paths of the data, read as
+// by var. Missing values are written as empty strings, and lists and
+// objects as JSON. It returns null when the template is invalid.
+func (ev *evaluator) template(values, data interface{}) interface{} {
+	parsed := toSlice(values)
+	if len(parsed) == 0 || !isString(parsed[0]) {
+		return nil
+	}
+
+	parts, ok := parseTemplate(parsed[0].(string))
+	if !ok {
+		return nil
+	}
+
+	var s strings.Builder
+	for _, part := range parts {
+		if !part.placeholder {
+			s.WriteString(part.text)
+
+			continue
+		}
+
+		var value interface{}
+		if i, ok := argumentIndex(part.text); ok {
+			if i >= 1 && i < len(parsed) {
+				value = parsed[i]
+			}
+		} else {
+			value = ev.variable(part.text, data)
+		}
+
+		s.WriteString(templateString(value))
+	}
+
+	return s.String()
+}
+
+// templateString writes a value in a template
+func templateString(value interface{}) string {
+	switch {
+	case value == nil:
+		return ""
+	case isBool(value):
+		return strconv.FormatBool(value.(bool))
+	case isString(value), isNumber(value):
+		return toString(value)
+	}
+
+	encoded, err := encodeCanonical(value, "")
+	if err != nil {
+		return ""
+	}
+
+	return string(encoded)
+}
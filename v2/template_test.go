@@ -0,0 +1,46 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplate(t *testing.T) {
+	data := `{"id": "A-12", "amount": 1500, "limit": 1000, "tags": ["vip"], "ok": true, "items": [{"name": "pen"}, {"name": "ink"}]}`
+
+	scenarios := map[string]struct {
+		Rule     string
+		Expected string
+	}{
+		"data placeholders":     {`{"template": ["Order {id} exceeds limit {limit}"]}`, `"Order A-12 exceeds limit 1000"`},
+		"single template":       {`{"template": "Order {id}"}`, `"Order A-12"`},
+		"argument placeholders": {`{"template": ["{id}: {1} over", {"-": [{"var": "amount"}, {"var": "limit"}]}]}`, `"A-12: 500 over"`},
+		"formatted argument":    {`{"template": ["{1} EUR", {"format_number": [{"var": "amount"}, 2]}]}`, `"1,500.00 EUR"`},
+		"missing values":        {`{"template": ["[{nope}] [{2}]", 1]}`, `"[] []"`},
+		"lists and booleans":    {`{"template": ["{tags} {ok}"]}`, `"[\"vip\"] true"`},
+		"escaped braces":        {`{"template": ["{{{id}}}"]}`, `"{A-12}"`},
+		"spaces in placeholder": {`{"template": ["{ id }"]}`, `"A-12"`},
+		"in an iteration":       {`{"map": [{"var": "items"}, {"template": ["item {name}"]}]}`, `["item pen", "item ink"]`},
+		"computed template":     {`{"template": [{"cat": ["{", "id", "}"]}]}`, `"A-12"`},
+		"unclosed placeholder":  {`{"template": ["{id"]}`, `null`},
+		"empty placeholder":     {`{"template": ["{}"]}`, `null`},
+		"not a string":          {`{"template": [1]}`, `null`},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var result bytes.Buffer
+
+			err := Apply(strings.NewReader(scenario.Rule), strings.NewReader(data), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, result.String())
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package jsonlogic
+
+import (
+	"io"
+)
+
+// Trace describes how an expression of a rule was evaluated
+type Trace struct {
+	// Operator and Arguments are the expression as written in the rule
+	Operator  string      `json:"operator"`
+	Arguments interface{} `json:"arguments"`
+
+	// Values are the evaluated arguments given to the operator. Operators
+	// evaluating their own arguments, like "filter" or "switch", have no
+	// values: how their arguments were evaluated is found in Children.
+	Values interface{} `json:"values,omitempty"`
+
+	Result   interface{} `json:"result"`
+	Children []*Trace    `json:"children,omitempty"`
+}
+
+// ApplyWithTrace is like Apply, but it also returns a trace of the
+// evaluation of every expression of the rule
+func ApplyWithTrace(rule, data io.Reader, result io.Writer) (*Trace, error) {
+	return defaultEngine.ApplyWithTrace(rule, data, result)
+}
+
+// ApplyWithTrace is like Apply, but it also returns a trace of the
+// evaluation of every expression of the rule. Rules that aren't objects
+// are returned as they are, without a trace.
+func (e *Engine) ApplyWithTrace(rule, data io.Reader, result io.Writer) (*Trace, error) {
+	order := e.newKeyOrder()
+
+	_rule, _data, err := e.decode(rule, data, order)
+	if err != nil {
+		return nil, err
+	}
+
+	ev := e.evaluator()
+	ev.tracing = true
+
+	output, err := ev.run(_rule, _data)
+	if err != nil {
+		return ev.root, err
+	}
+
+	output, err = e.prepare(order, output)
+	if err != nil {
+		return ev.root, err
+	}
+
+	return ev.root, e.write(result, output)
+}
+
+func (ev *evaluator) traceRule(rules, data interface{}) interface{} {
+	node := &Trace{}
+	for operator, values := range rules.(map[string]interface{}) {
+		node.Operator = operator
+		node.Arguments = values
+	}
+
+	parent := ev.current
+	if parent == nil {
+		ev.root = node
+	} else {
+		parent.Children = append(parent.Children, node)
+	}
+
+	ev.current = node
+	node.Result = ev.applyRule(rules, data)
+	ev.current = parent
+
+	return node.Result
+}
@@ -0,0 +1,82 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyWithTrace(t *testing.T) {
+	rule := strings.NewReader(`{
+		"and": [
+			{">=": [{"var": "age"}, 18]},
+			{"in": [{"var": "country"}, ["GB", "IE"]]}
+		]
+	}`)
+
+	data := strings.NewReader(`{"age": 21, "country": "FR"}`)
+
+	var result bytes.Buffer
+
+	trace, err := ApplyWithTrace(rule, data, &result)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.JSONEq(t, `false`, result.String())
+
+	assert.Equal(t, "and", trace.Operator)
+	assert.Equal(t, false, trace.Result)
+	assert.Equal(t, []interface{}{true, false}, trace.Values)
+	assert.Len(t, trace.Children, 2)
+
+	age := trace.Children[0]
+	assert.Equal(t, ">=", age.Operator)
+	assert.Equal(t, []interface{}{float64(21), float64(18)}, age.Values)
+	assert.Equal(t, true, age.Result)
+	assert.Len(t, age.Children, 1)
+	assert.Equal(t, "var", age.Children[0].Operator)
+	assert.Equal(t, "age", age.Children[0].Arguments)
+	assert.Equal(t, float64(21), age.Children[0].Result)
+
+	country := trace.Children[1]
+	assert.Equal(t, "in", country.Operator)
+	assert.Equal(t, []interface{}{"FR", []interface{}{"GB", "IE"}}, country.Values)
+	assert.Equal(t, false, country.Result)
+}
+
+func TestApplyWithTraceOfIterators(t *testing.T) {
+	rule := strings.NewReader(`{"filter": [{"var": "scores"}, {">": [{"var": ""}, 1]}]}`)
+	data := strings.NewReader(`{"scores": [1, 2]}`)
+
+	var result bytes.Buffer
+
+	trace, err := ApplyWithTrace(rule, data, &result)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.JSONEq(t, `[2]`, result.String())
+
+	assert.Equal(t, "filter", trace.Operator)
+	assert.Nil(t, trace.Values)
+
+	// the subject, then the condition for each element
+	assert.Len(t, trace.Children, 3)
+	assert.Equal(t, false, trace.Children[1].Result)
+	assert.Equal(t, true, trace.Children[2].Result)
+}
+
+func TestApplyWithTraceOfLiterals(t *testing.T) {
+	var result bytes.Buffer
+
+	trace, err := ApplyWithTrace(strings.NewReader(`[1, 2]`), strings.NewReader(`{}`), &result)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Nil(t, trace)
+	assert.JSONEq(t, `[1, 2]`, result.String())
+}
@@ -0,0 +1,266 @@
+package jsonlogic
+
+// The transformation operators return modified copies of objects, never
+// changing the data: only the objects and lists on the way to the changed
+// values are copied, the rest being shared with the original.
+
+// transformArgs reads the arguments of set, unset and rename: an object
+// and paths, every one followed by valuesPerPath values. The paths are
+// split as the paths of var.
+func (ev *evaluator) transformArgs(values interface{}, valuesPerPath int) (interface{}, [][]string, []interface{}, bool) {
+	parsed := toSlice(values)
+	if len(parsed) < 2 || (len(parsed)-1)%(valuesPerPath+1) != 0 {
+		return nil, nil, nil, false
+	}
+
+	var paths [][]string
+	var args []interface{}
+
+	for i := 1; i < len(parsed); i += valuesPerPath + 1 {
+		path, ok := parsed[i].(string)
+		if !ok {
+			return nil, nil, nil, false
+		}
+
+		parts, ok := splitPath(path, ev.engine.pathSyntax)
+		if !ok || len(parts) == 0 {
+			return nil, nil, nil, false
+		}
+
+		paths = append(paths, parts)
+		args = append(args, parsed[i+1:i+1+valuesPerPath]...)
+	}
+
+	return parsed[0], paths, args, true
+}
+
+// set returns a copy of an object with values at some paths:
+// {"set": [{"var": "user"}, "address.city", "Paris", "age", 42]}. Missing
+// objects on the way are created, and other values replaced by objects,
+// except lists, whose elements are reached by their index. Anything but an
+// object is returned as is, and invalid paths give null.
+func (ev *evaluator) set(values interface{}) interface{} {
+	object, paths, args, ok := ev.transformArgs(values, 1)
+	if !ok {
+		return nil
+	}
+
+	if !isMap(object) {
+		return object
+	}
+
+	for i, path := range paths {
+		object = setIn(object, path, args[i])
+	}
+
+	return object
+}
+
+// unset returns a copy of an object without the values at some paths:
+// {"unset": [{"var": "user"}, "password", "tokens.0"]}. Paths that aren't
+// found are ignored.
+func (ev *evaluator) unset(values interface{}) interface{} {
+	object, paths, _, ok := ev.transformArgs(values, 0)
+	if !ok {
+		return nil
+	}
+
+	if !isMap(object) {
+		return object
+	}
+
+	for _, path := range paths {
+		object, _ = unsetIn(object, path)
+	}
+
+	return object
+}
+
+// rename returns a copy of an object with values moved from a path to
+// another: {"rename": [{"var": "user"}, "name", "profile.name"]}. Paths
+// that aren't found are ignored.
+func (ev *evaluator) rename(values interface{}) interface{} {
+	object, paths, args, ok := ev.transformArgs(values, 1)
+	if !ok {
+		return nil
+	}
+
+	if !isMap(object) {
+		return object
+	}
+
+	for i, from := range paths {
+		to, ok := args[i].(string)
+		if !ok {
+			return nil
+		}
+
+		target, ok := splitPath(to, ev.engine.pathSyntax)
+		if !ok || len(target) == 0 {
+			return nil
+		}
+
+		value, found := getIn(object, from)
+		if !found {
+			continue
+		}
+
+		object, _ = unsetIn(object, from)
+		object = setIn(object, target, value)
+	}
+
+	return object
+}
+
+// mergeObjects merges objects deeply, the values of the last ones winning
+// over the values of the first ones, except objects, which are merged:
+// {"merge_objects": [{"var": "defaults"}, {"var": "settings"}]}. Arguments
+// that aren't objects are ignored.
+func mergeObjects(values interface{}) interface{} {
+	merged := make(map[string]interface{})
+
+	for _, value := range toSlice(values) {
+		if object, ok := value.(map[string]interface{}); ok {
+			merged = deepMerge(merged, object)
+		}
+	}
+
+	return merged
+}
+
+func deepMerge(a, b map[string]interface{}) map[string]interface{} {
+	merged := copyObject(a)
+
+	for key, value := range b {
+		current, isObject := merged[key].(map[string]interface{})
+		update, isUpdate := value.(map[string]interface{})
+
+		if isObject && isUpdate {
+			merged[key] = deepMerge(current, update)
+
+			continue
+		}
+
+		merged[key] = value
+	}
+
+	return merged
+}
+
+func copyObject(object map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(object)+1)
+	for key, value := range object {
+		copied[key] = value
+	}
+
+	return copied
+}
+
+// getIn returns the value at a path, and whether it was found
+func getIn(value interface{}, path []string) (interface{}, bool) {
+	for _, part := range path {
+		switch container := value.(type) {
+		case map[string]interface{}:
+			element, found := container[part]
+			if !found {
+				return nil, false
+			}
+
+			value = element
+		case []interface{}:
+			i, ok := listIndex(part, len(container))
+			if !ok {
+				return nil, false
+			}
+
+			value = container[i]
+		default:
+			return nil, false
+		}
+	}
+
+	return value, true
+}
+
+// setIn returns a copy of value with another value at a path. Lists are
+// left as they are when the path doesn't go through one of their elements.
+func setIn(value interface{}, path []string, v interface{}) interface{} {
+	if len(path) == 0 {
+		return v
+	}
+
+	switch container := value.(type) {
+	case []interface{}:
+		i, ok := listIndex(path[0], len(container))
+		if !ok {
+			return value
+		}
+
+		list := make([]interface{}, len(container))
+		copy(list, container)
+		list[i] = setIn(container[i], path[1:], v)
+
+		return list
+	case map[string]interface{}:
+		object := copyObject(container)
+		object[path[0]] = setIn(container[path[0]], path[1:], v)
+
+		return object
+	}
+
+	return map[string]interface{}{path[0]: setIn(nil, path[1:], v)}
+}
+
+// unsetIn returns a copy of value without the value at a path, and
+// whether it was found, value being returned as is otherwise
+func unsetIn(value interface{}, path []string) (interface{}, bool) {
+	switch container := value.(type) {
+	case map[string]interface{}:
+		element, found := container[path[0]]
+		if !found {
+			return value, false
+		}
+
+		if len(path) == 1 {
+			object := copyObject(container)
+			delete(object, path[0])
+
+			return object, true
+		}
+
+		updated, found := unsetIn(element, path[1:])
+		if !found {
+			return value, false
+		}
+
+		object := copyObject(container)
+		object[path[0]] = updated
+
+		return object, true
+	case []interface{}:
+		i, ok := listIndex(path[0], len(container))
+		if !ok {
+			return value, false
+		}
+
+		if len(path) == 1 {
+			list := make([]interface{}, 0, len(container)-1)
+			list = append(list, container[:i]...)
+
+			return append(list, container[i+1:]...), true
+		}
+
+		updated, found := unsetIn(container[i], path[1:])
+		if !found {
+			return value, false
+		}
+
+		list := make([]interface{}, len(container))
+		copy(list, container)
+		list[i] = updated
+
+		return list, true
+	}
+
+	return value, false
+}
@@ -0,0 +1,102 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransformations(t *testing.T) {
+	scenarios := map[string]struct {
+		Rule     string
+		Expected string
+	}{
+		"set":                      {`{"set": [{"var": "user"}, "age", 31]}`, `{"name": "Ada", "age": 31, "address": {"city": "London"}, "tags": ["a", "b"]}`},
+		"set nested":               {`{"set": [{"var": "user"}, "address.city", "Paris"]}`, `{"name": "Ada", "age": 30, "address": {"city": "Paris"}, "tags": ["a", "b"]}`},
+		"set creating objects":     {`{"set": [{"var": "user"}, "settings.theme.color", "dark"]}`, `{"name": "Ada", "age": 30, "address": {"city": "London"}, "tags": ["a", "b"], "settings": {"theme": {"color": "dark"}}}`},
+		"set replacing values":     {`{"set": [{"var": "user"}, "name.first", "Ada"]}`, `{"name": {"first": "Ada"}, "age": 30, "address": {"city": "London"}, "tags": ["a", "b"]}`},
+		"set in a list":            {`{"set": [{"var": "user"}, "tags.1", "c"]}`, `{"name": "Ada", "age": 30, "address": {"city": "London"}, "tags": ["a", "c"]}`},
+		"set the last element":     {`{"set": [{"var": "user"}, "tags.-1", "c"]}`, `{"name": "Ada", "age": 30, "address": {"city": "London"}, "tags": ["a", "c"]}`},
+		"set out of a list":        {`{"set": [{"var": "user"}, "tags.5", "c"]}`, `{"name": "Ada", "age": 30, "address": {"city": "London"}, "tags": ["a", "b"]}`},
+		"set several":              {`{"set": [{"var": "user"}, "age", 31, "name", "Grace"]}`, `{"name": "Grace", "age": 31, "address": {"city": "London"}, "tags": ["a", "b"]}`},
+		"set a pointer":            {`{"set": [{"var": "user"}, "/address/city", "Paris"]}`, `{"name": "Ada", "age": 30, "address": {"city": "Paris"}, "tags": ["a", "b"]}`},
+		"set an object":            {`{"set": [{}, "a", {"var": "user.address"}]}`, `{"a": {"city": "London"}}`},
+		"set without value":        {`{"set": [{"var": "user"}, "age"]}`, `null`},
+		"set an invalid path":      {`{"set": [{"var": "user"}, 1, 2]}`, `null`},
+		"set on a non object":      {`{"set": [{"var": "user.name"}, "age", 2]}`, `"Ada"`},
+		"unset":                    {`{"unset": [{"var": "user"}, "age", "tags"]}`, `{"name": "Ada", "address": {"city": "London"}}`},
+		"unset nested":             {`{"unset": [{"var": "user"}, "address.city"]}`, `{"name": "Ada", "age": 30, "address": {}, "tags": ["a", "b"]}`},
+		"unset in a list":          {`{"unset": [{"var": "user"}, "tags.0"]}`, `{"name": "Ada", "age": 30, "address": {"city": "London"}, "tags": ["b"]}`},
+		"unset missing":            {`{"unset": [{"var": "user"}, "address.zip", "phone"]}`, `{"name": "Ada", "age": 30, "address": {"city": "London"}, "tags": ["a", "b"]}`},
+		"unset without path":       {`{"unset": [{"var": "user"}]}`, `null`},
+		"rename":                   {`{"rename": [{"var": "user"}, "name", "full_name"]}`, `{"full_name": "Ada", "age": 30, "address": {"city": "London"}, "tags": ["a", "b"]}`},
+		"rename nested":            {`{"rename": [{"var": "user"}, "address.city", "city"]}`, `{"name": "Ada", "age": 30, "address": {}, "city": "London", "tags": ["a", "b"]}`},
+		"rename missing":           {`{"rename": [{"var": "user"}, "phone", "mobile"]}`, `{"name": "Ada", "age": 30, "address": {"city": "London"}, "tags": ["a", "b"]}`},
+		"rename to an invalid":     {`{"rename": [{"var": "user"}, "name", 1]}`, `null`},
+		"merge objects":            {`{"merge_objects": [{"var": "defaults"}, {"var": "settings"}]}`, `{"theme": {"color": "dark", "size": 12}, "lang": "fr", "beta": null}`},
+		"merge objects in order":   {`{"merge_objects": [{"var": "settings"}, {"var": "defaults"}]}`, `{"theme": {"color": "light", "size": 12}, "lang": "en", "beta": false}`},
+		"merge non objects":        {`{"merge_objects": [{"var": "defaults"}, 1, null, {"var": "user.tags"}]}`, `{"theme": {"color": "light", "size": 12}, "lang": "en", "beta": false}`},
+		"merge nothing":            {`{"merge_objects": []}`, `{}`},
+		"within an iteration":      {`{"map": [{"var": "items"}, {"set": [{"var": ""}, "total", {"*": [{"var": ".price"}, 2]}]}]}`, `[{"price": 1, "total": 2}, {"price": 3, "total": 6}]`},
+		"transformations combined": {`{"unset": [{"rename": [{"var": "user"}, "name", "profile.name"]}, "tags", "address"]}`, `{"age": 30, "profile": {"name": "Ada"}}`},
+	}
+
+	data := `{
+		"user": {"name": "Ada", "age": 30, "address": {"city": "London"}, "tags": ["a", "b"]},
+		"defaults": {"theme": {"color": "light", "size": 12}, "lang": "en", "beta": false},
+		"settings": {"theme": {"color": "dark"}, "lang": "fr", "beta": null},
+		"items": [{"price": 1}, {"price": 3}]
+	}`
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var result bytes.Buffer
+
+			err := Apply(strings.NewReader(scenario.Rule), strings.NewReader(data), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, result.String())
+		})
+	}
+}
+
+func TestTransformationsDontChangeTheData(t *testing.T) {
+	rules := []string{
+		`{"set": [{"var": "user"}, "address.city", "Paris", "tags.0", "z"]}`,
+		`{"unset": [{"var": "user"}, "address.city", "tags.0", "name"]}`,
+		`{"rename": [{"var": "user"}, "address.city", "city"]}`,
+		`{"merge_objects": [{"var": "user"}, {"var": "other"}]}`,
+	}
+
+	for _, rule := range rules {
+		var data interface{}
+		err := json.Unmarshal([]byte(`{"user": {"name": "Ada", "address": {"city": "London"}, "tags": ["a", "b"]}, "other": {"address": {"zip": "N1"}}}`), &data)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var decoded interface{}
+		err = json.Unmarshal([]byte(rule), &decoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = ApplyInterface(decoded, data)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		encoded, err := json.Marshal(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.JSONEq(t, `{"user": {"name": "Ada", "address": {"city": "London"}, "tags": ["a", "b"]}, "other": {"address": {"zip": "N1"}}}`, string(encoded), rule)
+	}
+}
@@ -0,0 +1,152 @@
+package jsonlogic
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+)
+
+// OutputTransformer changes the result of an evaluation before it is
+// written. Results may share objects and lists with the data and the rule,
+// which transformers must not modify: they return copies instead.
+type OutputTransformer func(result interface{}) (interface{}, error)
+
+// WithOutputTransformers makes the methods returning results, like Apply,
+// ApplyRaw, ApplyInterface, ApplyAll and the streaming ones, pass them
+// through transformers, in the given order, before writing them, rather
+// than having to decode and encode them again. ApplyBool, ApplyWithCoverage,
+// Decide, Score and Router, which read results to tell what they mean, use
+// them as rules give them. An error of a transformer fails the evaluation.
+// Objects changed by transformers have their keys sorted, even on engines
+// created WithKeyOrder.
+func WithOutputTransformers(transformers ...OutputTransformer) Option {
+	return func(e *Engine) error {
+		for _, transformer := range transformers {
+			if transformer == nil {
+				return fmt.Errorf("output transformer must not be nil")
+			}
+		}
+
+		e.transformers = append(e.transformers, transformers...)
+
+		return nil
+	}
+}
+
+// prepare passes a result through the output transformers of the engine,
+// and wraps its objects to be written with their keys in order
+func (e *Engine) prepare(order keyOrder, output interface{}) (interface{}, error) {
+	output, err := e.transform(output)
+	if err != nil {
+		return nil, err
+	}
+
+	return order.wrap(output), nil
+}
+
+// transform passes a result through the output transformers of the engine
+func (e *Engine) transform(output interface{}) (interface{}, error) {
+	for _, transformer := range e.transformers {
+		var err error
+
+		output, err = transformer(output)
+		if err != nil {
+			return nil, fmt.Errorf("error transforming output: %w", err)
+		}
+	}
+
+	return output, nil
+}
+
+// RoundFloats is an OutputTransformer rounding the numbers of results half
+// away from zero to a number of decimal places, as the round operator
+// does. Places are kept between 0 and 15.
+func RoundFloats(places int) OutputTransformer {
+	if places < 0 {
+		places = 0
+	} else if places > 15 {
+		places = 15
+	}
+
+	return func(result interface{}) (interface{}, error) {
+		return rewriteValues(result, func(_ string, value interface{}) (interface{}, bool) {
+			// integers are whole already, see WithIntegers
+			x, ok := value.(float64)
+			if !ok || math.IsNaN(x) || math.IsInf(x, 0) {
+				return value, true
+			}
+
+			return roundPlaces(x, int64(places)), true
+		}), nil
+	}
+}
+
+// StripNulls is an OutputTransformer removing the null properties of the
+// objects of results. Lists keep their null elements.
+func StripNulls() OutputTransformer {
+	return func(result interface{}) (interface{}, error) {
+		return rewriteValues(result, func(key string, value interface{}) (interface{}, bool) {
+			return value, key == "" || value != nil
+		}), nil
+	}
+}
+
+// RedactFields is an OutputTransformer replacing the values of the
+// properties of the objects of results whose name matches a pattern, at
+// any depth, with a replacement like "[redacted]"
+func RedactFields(pattern *regexp.Regexp, replacement interface{}) OutputTransformer {
+	return func(result interface{}) (interface{}, error) {
+		return rewriteValues(result, func(key string, value interface{}) (interface{}, bool) {
+			if key != "" && pattern.MatchString(key) {
+				return replacement, true
+			}
+
+			return value, true
+		}), nil
+	}
+}
+
+// rewriteValues returns a copy of a value whose objects and lists are
+// copied, replacing every value by what f returns for it, and leaving it
+// out of its object unless f keeps it. f gets the name of the property of
+// the values of objects, and an empty name for the other values. The
+// objects and lists it returns are rewritten in turn.
+func rewriteValues(value interface{}, f func(key string, value interface{}) (interface{}, bool)) interface{} {
+	rewrite := func(key string, value interface{}) (interface{}, bool) {
+		value, keep := f(key, value)
+		if !keep {
+			return nil, false
+		}
+
+		switch value.(type) {
+		case map[string]interface{}, []interface{}:
+			return rewriteValues(value, f), true
+		}
+
+		return value, true
+	}
+
+	switch value := value.(type) {
+	case map[string]interface{}:
+		object := make(map[string]interface{}, len(value))
+		for key, v := range value {
+			if v, keep := rewrite(key, v); keep {
+				object[key] = v
+			}
+		}
+
+		return object
+	case []interface{}:
+		list := make([]interface{}, 0, len(value))
+		for _, v := range value {
+			v, _ := rewrite("", v)
+			list = append(list, v)
+		}
+
+		return list
+	}
+
+	result, _ := f("", value)
+
+	return result
+}
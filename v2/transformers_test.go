@@ -0,0 +1,168 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutputTransformers(t *testing.T) {
+	data := `{"user": {"name": "Ana", "password": "hunter2", "score": 0.1234, "nickname": null}, "items": [1.005, null]}`
+
+	scenarios := map[string]struct {
+		Transformers []OutputTransformer
+		Rule         string
+		Expected     string
+	}{
+		"without transformers": {
+			Rule:     `{"var": "user.score"}`,
+			Expected: `0.1234`,
+		},
+		"round floats": {
+			Transformers: []OutputTransformer{RoundFloats(2)},
+			Rule:         `{"var": ""}`,
+			Expected:     `{"user": {"name": "Ana", "password": "hunter2", "score": 0.12, "nickname": null}, "items": [1.01, null]}`,
+		},
+		"strip nulls": {
+			Transformers: []OutputTransformer{StripNulls()},
+			Rule:         `{"var": ""}`,
+			Expected:     `{"user": {"name": "Ana", "password": "hunter2", "score": 0.1234}, "items": [1.005, null]}`,
+		},
+		"redact fields": {
+			Transformers: []OutputTransformer{RedactFields(regexp.MustCompile(`(?i)password|secret`), "[redacted]")},
+			Rule:         `{"var": "user"}`,
+			Expected:     `{"name": "Ana", "password": "[redacted]", "score": 0.1234, "nickname": null}`,
+		},
+		"in order": {
+			Transformers: []OutputTransformer{RedactFields(regexp.MustCompile(`^nickname$`), nil), StripNulls()},
+			Rule:         `{"var": "user"}`,
+			Expected:     `{"name": "Ana", "password": "hunter2", "score": 0.1234}`,
+		},
+		"scalar result": {
+			Transformers: []OutputTransformer{RoundFloats(0), StripNulls()},
+			Rule:         `{"*": [{"var": "user.score"}, 10]}`,
+			Expected:     `1`,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			engine, err := NewEngine(WithOutputTransformers(scenario.Transformers...))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var result bytes.Buffer
+
+			err = engine.Apply(strings.NewReader(scenario.Rule), strings.NewReader(data), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, result.String())
+
+			raw, err := engine.ApplyRaw(json.RawMessage(scenario.Rule), json.RawMessage(data))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, string(raw))
+
+			var rule, decoded interface{}
+			if err := json.Unmarshal([]byte(scenario.Rule), &rule); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := json.Unmarshal([]byte(data), &decoded); err != nil {
+				t.Fatal(err)
+			}
+
+			value, err := engine.ApplyInterface(rule, decoded)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			encoded, err := json.Marshal(value)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, string(encoded))
+
+			compiled, err := engine.Compile(strings.NewReader(scenario.Rule))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			results, err := engine.ApplyAll(map[string]*Rule{"rule": compiled}, []byte(data))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			encoded, err = json.Marshal(results["rule"])
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, string(encoded))
+		})
+	}
+}
+
+func TestOutputTransformersKeepTheData(t *testing.T) {
+	engine, err := NewEngine(WithOutputTransformers(StripNulls(), RoundFloats(0)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := map[string]interface{}{"a": 1.5, "b": nil}
+
+	output, err := engine.prepare(nil, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, map[string]interface{}{"a": float64(2)}, output)
+	assert.Equal(t, map[string]interface{}{"a": 1.5, "b": nil}, data)
+}
+
+func TestOutputTransformerErrors(t *testing.T) {
+	failure := errors.New("failure")
+
+	engine, err := NewEngine(WithOutputTransformers(func(interface{}) (interface{}, error) {
+		return nil, failure
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var result bytes.Buffer
+
+	err = engine.Apply(strings.NewReader(`1`), strings.NewReader(`{}`), &result)
+	assert.True(t, errors.Is(err, failure))
+	assert.Empty(t, result.String())
+
+	_, err = engine.ApplyInterface(1.0, nil)
+	assert.True(t, errors.Is(err, failure))
+
+	compiled, err := engine.Compile(strings.NewReader(`1`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = engine.ApplyAll(map[string]*Rule{"rule": compiled}, []byte(`{}`))
+	assert.True(t, errors.Is(err, failure))
+
+	matched, err := engine.ApplyBool(compiled, []byte(`{}`))
+	assert.NoError(t, err)
+	assert.True(t, matched)
+
+	_, err = NewEngine(WithOutputTransformers(nil))
+	assert.EqualError(t, err, "output transformer must not be nil")
+}
@@ -0,0 +1,49 @@
+package jsonlogic
+
+// argument evaluates the only argument of an operator, given either alone
+// or wrapped in a list. Looking at the rule rather than at the evaluated
+// values tells {"op": {"var": "list"}} and {"op": [1, 2]} apart.
+func (ev *evaluator) argument(values, data interface{}) interface{} {
+	if isSlice(values) {
+		parsed := values.([]interface{})
+		if len(parsed) == 0 {
+			return nil
+		}
+
+		return ev.parseValues(parsed[0], data)
+	}
+
+	return ev.parseValues(values, data)
+}
+
+// typeOf returns the JSON type of a value: "null", "boolean", "number",
+// "string", "array" or "object"
+func typeOf(value interface{}) string {
+	return kindNames[valueOf(value).kind]
+}
+
+// typeOperators maps the type testing operators to the type they test
+var typeOperators = map[string]string{
+	"is_null":   "null",
+	"is_bool":   "boolean",
+	"is_number": "number",
+	"is_string": "string",
+	"is_array":  "array",
+	"is_object": "object",
+}
+
+func isTypeOperator(operator string) bool {
+	_, ok := typeOperators[operator]
+
+	return ok || operator == "typeof"
+}
+
+func (ev *evaluator) inspectType(operator string, values, data interface{}) interface{} {
+	kind := typeOf(ev.argument(values, data))
+
+	if operator == "typeof" {
+		return kind
+	}
+
+	return kind == typeOperators[operator]
+}
@@ -0,0 +1,62 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypeOperators(t *testing.T) {
+	data := `{
+		"name": "Jane",
+		"age": 33,
+		"admin": false,
+		"tags": ["a", "b"],
+		"address": {"city": "Lisbon"},
+		"nickname": null
+	}`
+
+	scenarios := map[string]struct {
+		Rule     string
+		Expected string
+	}{
+		"typeof a string":         {`{"typeof": {"var": "name"}}`, `"string"`},
+		"typeof a number":         {`{"typeof": [{"var": "age"}]}`, `"number"`},
+		"typeof a boolean":        {`{"typeof": {"var": "admin"}}`, `"boolean"`},
+		"typeof an array":         {`{"typeof": {"var": "tags"}}`, `"array"`},
+		"typeof a literal array":  {`{"typeof": [[1, 2]]}`, `"array"`},
+		"typeof an object":        {`{"typeof": {"var": "address"}}`, `"object"`},
+		"typeof null":             {`{"typeof": {"var": "nickname"}}`, `"null"`},
+		"typeof a missing value":  {`{"typeof": {"var": "missing"}}`, `"null"`},
+		"typeof a computed value": {`{"typeof": {"+": [1, 2]}}`, `"number"`},
+		"is_null":                 {`{"is_null": {"var": "nickname"}}`, `true`},
+		"is_null with a value":    {`{"is_null": {"var": "admin"}}`, `false`},
+		"is_bool":                 {`{"is_bool": {"var": "admin"}}`, `true`},
+		"is_number":               {`{"is_number": {"var": "age"}}`, `true`},
+		"numeric strings":         {`{"is_number": "33"}`, `false`},
+		"is_string":               {`{"is_string": [{"var": "name"}]}`, `true`},
+		"is_array":                {`{"is_array": {"var": "tags"}}`, `true`},
+		"is_array with one value": {`{"is_array": ["a"]}`, `false`},
+		"is_object":               {`{"is_object": {"var": "address"}}`, `true`},
+		"branch on type": {
+			`{"if": [{"is_array": {"var": "tags"}}, {"count": {"var": "tags"}}, 0]}`,
+			`2`,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var result bytes.Buffer
+
+			err := Apply(strings.NewReader(scenario.Rule), strings.NewReader(data), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, result.String())
+		})
+	}
+}
@@ -29,6 +29,26 @@ func validateJsonLogic(rules interface{}) bool {
 				return false
 			}
 
+			if (operator == "match" || operator == "regex") && !isValidPattern(value) {
+				return false
+			}
+
+			if operator == "let" {
+				if _, _, ok := letArgs(value); !ok {
+					return false
+				}
+
+				value = letOperands(value)
+			}
+
+			if operator == "def" {
+				if _, _, ok := defArgs(value); !ok {
+					return false
+				}
+
+				value = defOperands(value)
+			}
+
 			return validateJsonLogic(value)
 		}
 
@@ -58,46 +78,124 @@ func validateJsonLogic(rules interface{}) bool {
 	return isPrimitive(rules)
 }
 
-func isOperator(op string) bool {
-	operators := []string{
-		"==",
-		"===",
-		"!=",
-		"!==",
-		">",
-		">=",
-		"<",
-		"<=",
-		"!",
-		"or",
-		"and",
-		"?:",
-		"in",
-		"in_sorted",
-		"cat",
-		"%",
-		"abs",
-		"max",
-		"min",
-		"+",
-		"-",
-		"*",
-		"/",
-		"substr",
-		"merge",
-		"if",
-		"!!",
-		"missing",
-		"missing_some",
-		"some",
-		"filter",
-		"map",
-		"reduce",
-		"all",
-		"none",
-		"set",
-	}
+// operators lists the operators supported by this package, except for
+// "var" which is handled on its own
+var operators = []string{
+	"==",
+	"===",
+	"!=",
+	"!==",
+	">",
+	">=",
+	"<",
+	"<=",
+	"!",
+	"or",
+	"and",
+	"?:",
+	"in",
+	"in_sorted",
+	"cat",
+	"%",
+	"abs",
+	"max",
+	"min",
+	"+",
+	"-",
+	"*",
+	"/",
+	"substr",
+	"merge",
+	"if",
+	"!!",
+	"missing",
+	"missing_some",
+	"template",
+	"let",
+	"def",
+	"call",
+	"some",
+	"filter",
+	"map",
+	"reduce",
+	"all",
+	"none",
+	"set",
+	"match",
+	"regex",
+	"now",
+	"date_before",
+	"date_after",
+	"date_between",
+	"date_add",
+	"date_diff",
+	"date_start_of",
+	"date_same",
+	"pow",
+	"sqrt",
+	"round",
+	"format_number",
+	"floor",
+	"ceil",
+	"sum",
+	"avg",
+	"count",
+	"median",
+	"sort",
+	"unique",
+	"reverse",
+	"slice",
+	"flatten",
+	"index_of",
+	"keys",
+	"values",
+	"pick",
+	"omit",
+	"has",
+	"intersection",
+	"union",
+	"difference",
+	"switch",
+	"typeof",
+	"is_null",
+	"is_bool",
+	"is_number",
+	"is_string",
+	"is_array",
+	"is_object",
+	"to_number",
+	"to_string",
+	"to_bool",
+	"log",
+	"rule",
+	"map_obj",
+	"filter_obj",
+	"groupby",
+	"zip",
+	"product",
+	"between",
+	"in_range",
+	"overlaps",
+	"contains_range",
+	"semver_gte",
+	"semver_lt",
+	"semver_satisfies",
+	"is_uuid",
+	"uuid_equals",
+	"is_ulid",
+	"ulid_time",
+	"ulid_compare",
+	"hash_sha256",
+	"hash_md5",
+	"hash_fnv",
+	"random",
+	"sample_pct",
+	"unset",
+	"rename",
+	"merge_objects",
+}
 
+func isOperator(op string) bool {
 	for _, operator := range operators {
 		if operator == op {
 			return true
@@ -0,0 +1,170 @@
+package jsonlogic
+
+import (
+	"strconv"
+)
+
+// kind is the JSON type of a value, in the order compare sorts them
+type kind uint8
+
+const (
+	nullKind kind = iota
+	boolKind
+	numberKind
+	stringKind
+	listKind
+	objectKind
+
+	// otherKind holds the Go values which aren't JSON, like structs, which
+	// are typed and ordered as objects
+	otherKind
+)
+
+var kindNames = [...]string{"null", "boolean", "number", "string", "array", "object", "object"}
+
+// value is a decoded value tagged with its kind, holding the rules of type
+// tests, truthiness, coercions and comparisons in one place: they switch
+// on the tag instead of reflecting on the value. Booleans and numbers are
+// held in n, strings in s, and lists, objects and the other Go values,
+// given back as they are by untagged, in ref.
+type value struct {
+	kind kind
+	n    float64
+	s    string
+	ref  interface{}
+}
+
+// valueOf tags a value. Only the types of decoded JSON are tagged with
+// their JSON kind, since that is what the operators read them as: the
+// other Go values, which fromGo converts on the way in, are otherKind.
+func valueOf(v interface{}) value {
+	switch t := v.(type) {
+	case nil:
+		return value{}
+	case bool:
+		return boolValue(t)
+	case float64:
+		return value{kind: numberKind, n: t}
+	case int64:
+		// the integers of engines keeping them, see WithIntegers
+		return value{kind: numberKind, n: float64(t), ref: v}
+	case string:
+		return value{kind: stringKind, s: t}
+	case []interface{}:
+		return value{kind: listKind, ref: v}
+	case map[string]interface{}:
+		return value{kind: objectKind, ref: v}
+	}
+
+	return value{kind: otherKind, ref: v}
+}
+
+func boolValue(b bool) value {
+	if b {
+		return value{kind: boolKind, n: 1}
+	}
+
+	return value{kind: boolKind}
+}
+
+// untagged returns the value as the operators and the API get it
+func (v value) untagged() interface{} {
+	if v.ref != nil {
+		return v.ref
+	}
+
+	switch v.kind {
+	case boolKind:
+		return v.n != 0
+	case numberKind:
+		return v.n
+	case stringKind:
+		return v.s
+	}
+
+	return nil
+}
+
+// rank orders the kinds of values, see compare
+func (v value) rank() int {
+	if v.kind == otherKind {
+		return int(objectKind)
+	}
+
+	return int(v.kind)
+}
+
+func (v value) isPrimitive() bool {
+	return v.kind == boolKind || v.kind == numberKind || v.kind == stringKind
+}
+
+// truthy tells if the value is true in a condition: false, 0, "", [], {}
+// and null are not
+func (v value) truthy() bool {
+	switch v.kind {
+	case boolKind, numberKind:
+		return v.n != 0
+	case stringKind:
+		return v.s != ""
+	case listKind, objectKind:
+		return v.len() > 0
+	}
+
+	return false
+}
+
+// len returns the number of elements of a list or an object
+func (v value) len() int {
+	switch ref := v.ref.(type) {
+	case []interface{}:
+		return len(ref)
+	case map[string]interface{}:
+		return len(ref)
+	}
+
+	return 0
+}
+
+// number reads the value as a number, telling if it is one or a string
+// holding one: the strings which aren't numbers and null are 0, booleans
+// 1 and 0, and lists and objects 0.
+func (v value) number() (float64, bool) {
+	switch v.kind {
+	case numberKind:
+		return v.n, true
+	case stringKind:
+		n, err := strconv.ParseFloat(v.s, 64)
+
+		return n, err == nil
+	case boolKind:
+		return v.n, false
+	}
+
+	return 0, false
+}
+
+// integer returns the int64 a number holds, false for the other numbers
+func (v value) integer() (int64, bool) {
+	i, ok := v.ref.(int64)
+
+	return i, ok
+}
+
+// text reads the value as a string, null being empty. Only numbers,
+// strings and null can be read as strings.
+func (v value) text() string {
+	switch v.kind {
+	case numberKind:
+		if i, ok := v.integer(); ok {
+			return strconv.FormatInt(i, 10)
+		}
+
+		return strconv.FormatFloat(v.n, 'f', -1, 64)
+	case stringKind:
+		return v.s
+	case nullKind:
+		return ""
+	}
+
+	return v.untagged().(string)
+}
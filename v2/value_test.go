@@ -0,0 +1,76 @@
+package jsonlogic
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type namedString string
+
+type namedList []interface{}
+
+func TestValueOf(t *testing.T) {
+	scenarios := map[string]struct {
+		Value  interface{}
+		Kind   string
+		Truthy bool
+		Rank   int
+	}{
+		"null":         {Value: nil, Kind: "null", Truthy: false, Rank: 0},
+		"false":        {Value: false, Kind: "boolean", Truthy: false, Rank: 1},
+		"true":         {Value: true, Kind: "boolean", Truthy: true, Rank: 1},
+		"zero":         {Value: 0.0, Kind: "number", Truthy: false, Rank: 2},
+		"number":       {Value: -1.5, Kind: "number", Truthy: true, Rank: 2},
+		"empty string": {Value: "", Kind: "string", Truthy: false, Rank: 3},
+		"string":       {Value: "0", Kind: "string", Truthy: true, Rank: 3},
+		"named string": {Value: namedString("a"), Kind: "object", Truthy: false, Rank: 5},
+		"empty list":   {Value: []interface{}{}, Kind: "array", Truthy: false, Rank: 4},
+		"named list":   {Value: namedList{1.0}, Kind: "object", Truthy: false, Rank: 5},
+		"typed list":   {Value: []int{1}, Kind: "object", Truthy: false, Rank: 5},
+		"typed map":    {Value: map[string]int{"a": 1}, Kind: "object", Truthy: false, Rank: 5},
+		"object":       {Value: map[string]interface{}{"a": 1.0}, Kind: "object", Truthy: true, Rank: 5},
+		"empty object": {Value: map[string]interface{}{}, Kind: "object", Truthy: false, Rank: 5},
+		"struct":       {Value: testRow{ID: 1}, Kind: "object", Truthy: false, Rank: 5},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			v := valueOf(scenario.Value)
+
+			assert.Equal(t, scenario.Kind, kindNames[v.kind])
+			assert.Equal(t, scenario.Truthy, v.truthy())
+			assert.Equal(t, scenario.Rank, v.rank())
+			assert.Equal(t, scenario.Value, v.untagged())
+		})
+	}
+}
+
+func TestValueCoercions(t *testing.T) {
+	for _, scenario := range []struct {
+		Value    interface{}
+		Expected float64
+		Number   bool
+	}{
+		{12.5, 12.5, true},
+		{"12.5", 12.5, true},
+		{"abc", 0, false},
+		{true, 1, false},
+		{nil, 0, false},
+		{[]interface{}{1.0}, 0, false},
+		{map[string]interface{}{}, 0, false},
+		{testRow{ID: 1}, 0, false},
+	} {
+		n, ok := valueOf(scenario.Value).number()
+		assert.Equal(t, scenario.Expected, n, "%v", scenario.Value)
+		assert.Equal(t, scenario.Number, ok, "%v", scenario.Value)
+	}
+
+	assert.Equal(t, "12.5", valueOf(12.5).text())
+	assert.Equal(t, "", valueOf(nil).text())
+
+	// untagged builds the values of the tags valueOf gives
+	assert.Equal(t, true, boolValue(true).untagged())
+	assert.Equal(t, 2.0, value{kind: numberKind, n: 2}.untagged())
+}
@@ -1,40 +1,61 @@
 package jsonlogic
 
 import (
-	"reflect"
 	"strings"
 )
 
-func solveVars(values, data interface{}) interface{} {
+// solveVars replaces the vars of the predicate of an iteration reading the
+// data around it by their values, unless the engine reads every path of
+// predicates from the element
+func (ev *evaluator) solveVars(values, data interface{}) interface{} {
+	if ev.engine.varConvention == PlainElement {
+		return values
+	}
+
 	if isMap(values) {
 		logic := map[string]interface{}{}
 
 		for key, value := range values.(map[string]interface{}) {
 			if key == "var" {
-				if value == "" || strings.HasPrefix(value.(string), ".") {
+				path, _ := varArgs(value)
+				if path == "" || strings.HasPrefix(path, ".") || isParentPath(path) || isPositionPath(path) {
+					logic["var"] = value
+					continue
+				}
+
+				if bound, _, ok := ev.bound(path); ok && bound == (unsolved{}) {
 					logic["var"] = value
 					continue
 				}
 
-				val := getVar(value, data)
+				val := ev.variable(value, data)
 				if val != nil {
 					return val
 				}
 
 				logic["var"] = value
+			} else if key == "let" {
+				logic[key] = ev.solveLet(value, data)
+			} else if key == "def" {
+				logic[key] = ev.solveDef(value, data)
 			} else {
-				logic[key] = solveVars(value, data)
+				logic[key] = ev.solveVars(value, data)
 			}
 		}
 
+		if ev.origins != nil {
+			ev.copied(values, logic)
+		}
+
 		return interface{}(logic)
 	}
 
 	if isSlice(values) {
-		logic := []interface{}{}
+		list := values.([]interface{})
+		logic := ev.arena.list(len(list))
 
-		for _, value := range values.([]interface{}) {
-			logic = append(logic, solveVars(value, data))
+		for _, value := range list {
+			logic = append(logic, ev.solveVars(value, data))
 		}
 
 		return logic
@@ -43,68 +64,64 @@ func solveVars(values, data interface{}) interface{} {
 	return values
 }
 
-func getVar(value, data interface{}) interface{} {
-	if value == nil {
-		return data
-	}
-
-	if isString(value) && toString(value) == "" {
-		return data
-	}
-
-	if isNumber(value) {
-		value = toString(value)
-	}
+// getVar looks up a path in data, returning the default of the var when
+// the path is missing. Indexes can be anywhere in the path, and count from
+// the end of the lists when they are negative: "items.-1.name".
+func getVar(value, data interface{}, syntax PathSyntax) interface{} {
+	return readVar(value, data, syntax, nil)
+}
 
+// readVar is getVar splitting the path into parts, which it doesn't keep
+func readVar(value, data interface{}, syntax PathSyntax, parts []string) interface{} {
 	var _default interface{}
 
 	if isSlice(value) { // syntax sugar
-		length := reflect.ValueOf(value).Len()
-
-		if length == 0 {
+		parsed := value.([]interface{})
+		if len(parsed) == 0 {
 			return data
 		}
 
-		if length == 2 {
-			_default = value.([]interface{})[1]
+		if len(parsed) > 1 {
+			_default = parsed[1]
 		}
 
-		value = value.([]interface{})[0].(string)
+		value = parsed[0]
 	}
 
-	if data == nil {
-		return _default
+	if value == nil {
+		return data
 	}
 
-	parts := strings.Split(value.(string), ".")
+	if isNumber(value) {
+		value = toString(value)
+	}
 
-	var _value interface{}
+	if !isString(value) {
+		return _default
+	}
 
-	for _, part := range parts {
-		if part == "" {
-			continue
-		}
+	parts, ok := appendPath(parts, value.(string), syntax)
+	if !ok {
+		return _default
+	}
 
-		if toNumber(part) > 0 {
-			_value = data.([]interface{})[int(toNumber(part))]
-		} else {
-			_value = data.(map[string]interface{})[part]
-		}
+	data, ok = lookup(data, parts)
+	if !ok {
+		return _default
+	}
 
-		if _value == nil {
-			return _default
-		}
+	return data
+}
 
-		if isPrimitive(_value) {
-			continue
+// lookup follows the parts of a path from data, telling if they all lead
+// somewhere
+func lookup(data interface{}, parts []string) (interface{}, bool) {
+	for _, part := range parts {
+		data = property(data, part)
+		if data == nil {
+			return nil, false
 		}
-
-		data = _value
-	}
-
-	if _value == nil {
-		return _default
 	}
 
-	return _value
+	return data, true
 }
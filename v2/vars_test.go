@@ -0,0 +1,75 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVarIndexes(t *testing.T) {
+	data := `{"items": [{"name": "a", "tags": ["x", "y"]}, {"name": "b", "tags": []}], "count": 2}`
+
+	scenarios := map[string]struct {
+		Rule     string
+		Expected string
+	}{
+		"index in a path":         {`{"var": "items.1.name"}`, `"b"`},
+		"nested indexes":          {`{"var": "items.0.tags.1"}`, `"y"`},
+		"last element":            {`{"var": "items.-1.name"}`, `"b"`},
+		"negative nested index":   {`{"var": "items.0.tags.-2"}`, `"x"`},
+		"out of range":            {`{"var": ["items.2.name", "none"]}`, `"none"`},
+		"negative out of range":   {`{"var": ["items.-3", "none"]}`, `"none"`},
+		"empty list":              {`{"var": ["items.1.tags.0", "none"]}`, `"none"`},
+		"path through a number":   {`{"var": ["count.items", "none"]}`, `"none"`},
+		"path through a string":   {`{"var": ["items.0.name.0", "none"]}`, `"none"`},
+		"default of a list":       {`{"var": ["items.0.tags.5", [1]]}`, `[1]`},
+		"missing with an index":   {`{"missing": ["items.-1", "items.5"]}`, `["items.5"]`},
+		"number path with a list": {`{"var": [0, "none"]}`, `"none"`},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var result bytes.Buffer
+
+			err := Apply(strings.NewReader(scenario.Rule), strings.NewReader(data), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.JSONEq(t, scenario.Expected, result.String())
+		})
+	}
+}
+
+func TestVarIndexesOfTopLevelLists(t *testing.T) {
+	scenarios := map[string]struct {
+		Rule     string
+		Expected interface{}
+	}{
+		"index":            {`{"var": 1}`, "b"},
+		"negative index":   {`{"var": -1}`, "c"},
+		"index in a list":  {`{"var": [0]}`, "a"},
+		"out of range":     {`{"var": [3, "none"]}`, "none"},
+		"negative default": {`{"var": [-4, "none"]}`, "none"},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var rule interface{}
+			if err := json.Unmarshal([]byte(scenario.Rule), &rule); err != nil {
+				t.Fatal(err)
+			}
+
+			result, err := ApplyInterface(rule, []string{"a", "b", "c"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, scenario.Expected, result)
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package jsonlogic
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// DecodeYAML reads a rule written in YAML, returning it in the form of
+// decoded JSON expected by ApplyInterface:
+//
+//	and:
+//	  - ">=": [{var: age}, 18]
+//	  - in: [{var: country}, [PT, BR]]
+//
+// Operators YAML gives a meaning to, like "!" or "==", must be quoted, as
+// well as strings YAML reads as booleans: yes, no, on, off, y and n.
+func DecodeYAML(rule io.Reader) (interface{}, error) {
+	if rule == nil {
+		return nil, fmt.Errorf("error Apply-ing nil rule")
+	}
+
+	content, err := ioutil.ReadAll(rule)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing rule: %w", err)
+	}
+
+	var tree interface{}
+
+	err = yaml.Unmarshal(content, &tree)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing rule: %w", err)
+	}
+
+	tree, err = fromYAML(tree)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing rule: %w", err)
+	}
+
+	return tree, nil
+}
+
+// fromYAML converts a value decoded from YAML to its JSON form: objects
+// keyed by strings and float64 numbers
+func fromYAML(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		object := make(map[string]interface{}, len(v))
+		for key, element := range v {
+			name, ok := yamlKey(key)
+			if !ok {
+				return nil, fmt.Errorf("unsupported key %v: keys must be strings", key)
+			}
+
+			converted, err := fromYAML(element)
+			if err != nil {
+				return nil, err
+			}
+
+			object[name] = converted
+		}
+
+		return object, nil
+	case []interface{}:
+		list := make([]interface{}, 0, len(v))
+		for _, element := range v {
+			converted, err := fromYAML(element)
+			if err != nil {
+				return nil, err
+			}
+
+			list = append(list, converted)
+		}
+
+		return list, nil
+	case int:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case uint64:
+		return float64(v), nil
+	case time.Time:
+		return v.Format(time.RFC3339Nano), nil
+	}
+
+	return value, nil
+}
+
+// yamlKey converts scalar keys, like the ones YAML reads as numbers or
+// booleans, to the string they would be in JSON
+func yamlKey(key interface{}) (string, bool) {
+	switch k := key.(type) {
+	case string:
+		return k, true
+	case int, int64, uint64, float64:
+		converted, _ := fromYAML(k)
+
+		return toString(converted), true
+	case bool:
+		return fmt.Sprint(k), true
+	case nil:
+		return "null", true
+	}
+
+	return "", false
+}
+
+// CompileYAML reads a rule written in YAML to be applied many times
+func CompileYAML(rule io.Reader) (*Rule, error) {
+	tree, err := DecodeYAML(rule)
+	if err != nil {
+		return nil, err
+	}
+
+	return compile(tree, false), nil
+}
+
+// ApplyYAML is like Apply, but reads the rule as YAML. See DecodeYAML.
+func ApplyYAML(rule, data io.Reader, result io.Writer) error {
+	return defaultEngine.ApplyYAML(rule, data, result)
+}
+
+// ApplyYAML is like Apply, but reads the rule as YAML. The data is still
+// read as JSON, and the result written as JSON. See DecodeYAML.
+func (e *Engine) ApplyYAML(rule, data io.Reader, result io.Writer) error {
+	_rule, err := DecodeYAML(rule)
+	if err != nil {
+		return err
+	}
+
+	if data == nil {
+		data = strings.NewReader("{}")
+	}
+
+	var _data interface{}
+
+	order := e.newKeyOrder()
+
+	err = e.readJSON(order, data, &_data)
+	if err != nil {
+		return fmt.Errorf("error parsing data %w", err)
+	}
+
+	a := e.newArena()
+	defer a.release()
+
+	output, err := e.evaluateIn(context.Background(), order, a, _rule, _data)
+	if err != nil {
+		return err
+	}
+
+	output, err = e.prepare(order, output)
+	if err != nil {
+		return err
+	}
+
+	return e.write(result, output)
+}
@@ -0,0 +1,134 @@
+package jsonlogic
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyYAML(t *testing.T) {
+	scenarios := map[string]struct {
+		rule     string
+		data     string
+		expected string
+	}{
+		"flow style": {
+			rule:     `{"==": [{var: name}, ana]}`,
+			data:     `{"name": "ana"}`,
+			expected: `true`,
+		},
+		"block style": {
+			rule: `
+and:
+  - ">=": [{var: age}, 18]
+  - in:
+      - var: country
+      - [PT, BR]
+`,
+			data:     `{"age": 20, "country": "PT"}`,
+			expected: `true`,
+		},
+		"integers are numbers": {
+			rule:     `{"+": [1, 2.5, {var: count}]}`,
+			data:     `{"count": 3}`,
+			expected: `6.5`,
+		},
+		"numeric keys": {
+			rule:     `{var: "items.1"}`,
+			data:     `{"items": [1, 2]}`,
+			expected: `2`,
+		},
+		"quoted negation": {
+			rule:     `{"!": [{var: flag}]}`,
+			data:     `{"flag": false}`,
+			expected: `true`,
+		},
+		"nil data": {
+			rule:     `{cat: [a, b]}`,
+			expected: `"ab"`,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			var data *strings.Reader
+			if scenario.data != "" {
+				data = strings.NewReader(scenario.data)
+			}
+
+			var result bytes.Buffer
+
+			var err error
+			if data == nil {
+				err = ApplyYAML(strings.NewReader(scenario.rule), nil, &result)
+			} else {
+				err = ApplyYAML(strings.NewReader(scenario.rule), data, &result)
+			}
+
+			assert.NoError(t, err)
+			assert.JSONEq(t, scenario.expected, result.String())
+		})
+	}
+}
+
+func TestDecodeYAMLMatchesJSON(t *testing.T) {
+	tree, err := DecodeYAML(strings.NewReader(`
+if:
+  - {"<": [{var: temp}, 0]}
+  - freezing
+  - {"<": [{var: temp}, 100]}
+  - liquid
+  - gas
+`))
+	assert.NoError(t, err)
+
+	expected, err := ApplyInterface(map[string]interface{}{
+		"if": []interface{}{
+			map[string]interface{}{"<": []interface{}{map[string]interface{}{"var": "temp"}, 0.0}},
+			"freezing",
+			map[string]interface{}{"<": []interface{}{map[string]interface{}{"var": "temp"}, 100.0}},
+			"liquid",
+			"gas",
+		},
+	}, map[string]interface{}{"temp": 50.0})
+	assert.NoError(t, err)
+
+	result, err := ApplyInterface(tree, map[string]interface{}{"temp": 50.0})
+	assert.NoError(t, err)
+	assert.Equal(t, expected, result)
+}
+
+func TestDecodeYAMLKeys(t *testing.T) {
+	tree, err := DecodeYAML(strings.NewReader(`{1: one, 2.5: two, true: "yes", ~: none}`))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"1": "one", "2.5": "two", "true": "yes", "null": "none"}, tree)
+}
+
+func TestCompileYAML(t *testing.T) {
+	rule, err := CompileYAML(strings.NewReader(`{">": [{var: user.age}, 17]}`))
+	assert.NoError(t, err)
+
+	result, err := ApplyBool(rule, []byte(`{"user": {"age": 18}}`))
+	assert.NoError(t, err)
+	assert.True(t, result)
+}
+
+func TestDecodeYAMLErrors(t *testing.T) {
+	scenarios := map[string]string{
+		"invalid yaml":   `{var: [}`,
+		"non scalar key": `{[a, b]: 1}`,
+	}
+
+	for name, rule := range scenarios {
+		t.Run(fmt.Sprintf("SCENARIO:%s", name), func(t *testing.T) {
+			_, err := DecodeYAML(strings.NewReader(rule))
+			assert.Error(t, err)
+		})
+	}
+
+	_, err := DecodeYAML(nil)
+	assert.Error(t, err)
+}